@@ -0,0 +1,278 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Package anchor exports app hash of every N blocks with signed header and validator set
+// from Tendermint RPC as compact proof file and/or to external endpoint, so that history
+// of chain can be anchored outside of it.
+//
+// App hash of state after block H is in header of block H+1, so anchor of height H is
+// exported once block H+1 is committed. Anchor can be verified independently by checking
+// signatures of commit of block H+1 with public keys of its validators.
+package anchor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const lastAnchorHeightFileName = "last_anchor_height"
+
+// Config is configuration of anchor exporter
+type Config struct {
+	TendermintAddr string
+	// Interval is number of blocks between anchors
+	Interval     int64
+	OutputDir    string
+	Endpoint     string
+	PollInterval time.Duration
+}
+
+// Anchor is app hash of state at height with signed header of next block which includes it
+type Anchor struct {
+	ChainID      string          `json:"chain_id"`
+	Height       int64           `json:"height,string"`
+	AppHash      string          `json:"app_hash"`
+	BlockHeight  int64           `json:"block_height,string"`
+	BlockTime    string          `json:"block_time"`
+	SignedHeader json.RawMessage `json:"signed_header"`
+	Validators   json.RawMessage `json:"validators"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+type exporter struct {
+	config Config
+	client *http.Client
+}
+
+// Run exports anchors until error. Last exported height is kept in output directory so
+// that exporter resumes after restart, otherwise it starts from latest block.
+func Run(config Config) error {
+	if config.Interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+	if config.OutputDir == "" && config.Endpoint == "" {
+		return fmt.Errorf("output directory or endpoint is required")
+	}
+	if config.OutputDir != "" {
+		err := os.MkdirAll(config.OutputDir, 0755)
+		if err != nil {
+			return err
+		}
+	}
+	e := &exporter{
+		config: config,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+	lastHeight, err := e.loadLastHeight()
+	if err != nil {
+		return err
+	}
+	for {
+		latestHeight, err := e.getLatestHeight()
+		if err != nil {
+			log.Printf("Error getting latest block height: %s", err.Error())
+			time.Sleep(config.PollInterval)
+			continue
+		}
+		if lastHeight == 0 {
+			// Start from latest height which can be anchored
+			lastHeight = ((latestHeight-1)/config.Interval - 1) * config.Interval
+			if lastHeight < 0 {
+				lastHeight = 0
+			}
+		}
+		for height := lastHeight + config.Interval; height+1 <= latestHeight; height += config.Interval {
+			err = e.export(height)
+			if err != nil {
+				log.Printf("Error exporting anchor of height %d: %s", height, err.Error())
+				break
+			}
+			lastHeight = height
+			err = e.saveLastHeight(lastHeight)
+			if err != nil {
+				return err
+			}
+		}
+		time.Sleep(config.PollInterval)
+	}
+}
+
+func (e *exporter) export(height int64) error {
+	blockHeight := strconv.FormatInt(height+1, 10)
+	commitResult, err := e.call("commit", url.Values{"height": {blockHeight}})
+	if err != nil {
+		return err
+	}
+	var commit struct {
+		SignedHeader json.RawMessage `json:"signed_header"`
+		Canonical    bool            `json:"canonical"`
+	}
+	err = json.Unmarshal(commitResult, &commit)
+	if err != nil {
+		return err
+	}
+	if !commit.Canonical {
+		return fmt.Errorf("commit of block %s is not canonical yet", blockHeight)
+	}
+	var signedHeader struct {
+		Header struct {
+			ChainID string `json:"chain_id"`
+			Height  int64  `json:"height,string"`
+			Time    string `json:"time"`
+			AppHash string `json:"app_hash"`
+		} `json:"header"`
+	}
+	err = json.Unmarshal(commit.SignedHeader, &signedHeader)
+	if err != nil {
+		return err
+	}
+	validatorsResult, err := e.call("validators", url.Values{"height": {blockHeight}})
+	if err != nil {
+		return err
+	}
+	var validators struct {
+		Validators json.RawMessage `json:"validators"`
+	}
+	err = json.Unmarshal(validatorsResult, &validators)
+	if err != nil {
+		return err
+	}
+	anchor := Anchor{
+		ChainID:      signedHeader.Header.ChainID,
+		Height:       height,
+		AppHash:      signedHeader.Header.AppHash,
+		BlockHeight:  signedHeader.Header.Height,
+		BlockTime:    signedHeader.Header.Time,
+		SignedHeader: commit.SignedHeader,
+		Validators:   validators.Validators,
+	}
+	anchorJSON, err := json.Marshal(anchor)
+	if err != nil {
+		return err
+	}
+	if e.config.Endpoint != "" {
+		err = e.publish(anchorJSON)
+		if err != nil {
+			return err
+		}
+	}
+	if e.config.OutputDir != "" {
+		fileName := filepath.Join(e.config.OutputDir, fmt.Sprintf("anchor_%d.json", height))
+		err = ioutil.WriteFile(fileName, anchorJSON, 0644)
+		if err != nil {
+			return err
+		}
+	}
+	log.Printf("Exported anchor of height %d, app hash: %s", height, anchor.AppHash)
+	return nil
+}
+
+func (e *exporter) publish(anchorJSON []byte) error {
+	resp, err := e.client.Post(e.config.Endpoint, "application/json", bytes.NewReader(anchorJSON))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+func (e *exporter) getLatestHeight() (int64, error) {
+	result, err := e.call("status", nil)
+	if err != nil {
+		return 0, err
+	}
+	var status struct {
+		SyncInfo struct {
+			LatestBlockHeight int64 `json:"latest_block_height,string"`
+		} `json:"sync_info"`
+	}
+	err = json.Unmarshal(result, &status)
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+func (e *exporter) call(method string, parameters url.Values) (json.RawMessage, error) {
+	URL, err := url.Parse(e.config.TendermintAddr)
+	if err != nil {
+		return nil, err
+	}
+	URL.Path = strings.TrimSuffix(URL.Path, "/") + "/" + method
+	URL.RawQuery = parameters.Encode()
+	resp, err := e.client.Get(URL.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var res rpcResponse
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("%s: %s", res.Error.Message, res.Error.Data)
+	}
+	return res.Result, nil
+}
+
+func (e *exporter) loadLastHeight() (int64, error) {
+	if e.config.OutputDir == "" {
+		return 0, nil
+	}
+	value, err := ioutil.ReadFile(filepath.Join(e.config.OutputDir, lastAnchorHeightFileName))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(value)), 10, 64)
+}
+
+func (e *exporter) saveLastHeight(height int64) error {
+	if e.config.OutputDir == "" {
+		return nil
+	}
+	return ioutil.WriteFile(filepath.Join(e.config.OutputDir, lastAnchorHeightFileName), []byte(strconv.FormatInt(height, 10)), 0644)
+}
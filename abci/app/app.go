@@ -23,15 +23,18 @@
 package app
 
 import (
-	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
 	"github.com/tendermint/tendermint/abci/types"
-	cmn "github.com/tendermint/tendermint/libs/common"
-	dbm "github.com/tendermint/tendermint/libs/db"
 
 	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/storage"
+	protoTm "github.com/ndidplatform/smart-contract/v4/protos/tendermint"
 	// appV2 "github.com/ndidplatform/smart-contract/v4/abci/app2/v2"
 )
 
@@ -47,16 +50,46 @@ func NewABCIApplicationInterface() *ABCIApplicationInterface {
 	var dbType = getEnv("ABCI_DB_TYPE", "goleveldb")
 	var dbDir = getEnv("ABCI_DB_DIR_PATH", "./DID")
 
-	if err := cmn.EnsureDir(dbDir, 0700); err != nil {
-		panic(fmt.Errorf("Could not create DB directory: %v", err.Error()))
+	db, err := storage.OpenDB(dbType, dbDir)
+	if err != nil {
+		panic(err)
 	}
-	name := "didDB"
-	db := dbm.NewDB(name, dbm.DBBackendType(dbType), dbDir)
 
-	return &ABCIApplicationInterface{
+	app := &ABCIApplicationInterface{
 		appV1: appV1.NewABCIApplication(logger, db),
 		// appV2: appV2.NewABCIApplication(logger, db),
 	}
+
+	// Non-consensus settings are read from config file on start and reloaded on SIGHUP
+	var configFilePath = getEnv("ABCI_CONFIG_FILE_PATH", "")
+	if configFilePath != "" {
+		config, err := appV1.LoadConfig(configFilePath)
+		if err != nil {
+			panic(err)
+		}
+		app.appV1.ApplyConfig(config)
+		go app.reloadConfigOnSignal(logger, configFilePath)
+	}
+
+	return app
+}
+
+// VerifyState checks state in DB against change journal of latest journalBlocks blocks
+func (app *ABCIApplicationInterface) VerifyState(journalBlocks int64) error {
+	_, err := app.appV1.VerifyState(journalBlocks)
+	return err
+}
+
+func (app *ABCIApplicationInterface) reloadConfigOnSignal(logger *logrus.Entry, configFilePath string) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	for range sigCh {
+		logger.Infof("Reload config from %s", configFilePath)
+		err := app.appV1.ReloadConfig(configFilePath)
+		if err != nil {
+			logger.Errorf("Could not reload config: %s", err.Error())
+		}
+	}
 }
 
 func (app *ABCIApplicationInterface) Info(req types.RequestInfo) types.ResponseInfo {
@@ -95,6 +128,19 @@ func (app *ABCIApplicationInterface) Query(reqQuery types.RequestQuery) types.Re
 	return app.appV1.Query(reqQuery)
 }
 
+// QueryMethod runs query method with params the same way as query sent via Tendermint.
+// Caller must not call it while app is processing other ABCI requests.
+func (app *ABCIApplicationInterface) QueryMethod(method string, params []byte) types.ResponseQuery {
+	var query protoTm.Query
+	query.Method = method
+	query.Params = string(params)
+	queryBytes, err := proto.Marshal(&query)
+	if err != nil {
+		return types.ResponseQuery{Code: code.MarshalError, Log: err.Error()}
+	}
+	return app.appV1.Query(types.RequestQuery{Data: queryBytes})
+}
+
 func (app *ABCIApplicationInterface) InitChain(req types.RequestInitChain) types.ResponseInitChain {
 	return app.appV1.InitChain(req)
 }
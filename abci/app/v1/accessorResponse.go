@@ -0,0 +1,126 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// checkResponseAccessor checks that accessor used to sign response is accessor of IdP
+// in reference group of the accessor and it is neither revoked nor deactivated
+func (app *ABCIApplication) checkResponseAccessor(accessorID string, idpID string) (uint32, string) {
+	accessorToRefCodeKey := accessorToRefCodeKeyPrefix + keySeparator + accessorID
+	refGroupCode, _ := app.state.Get([]byte(accessorToRefCodeKey), false)
+	if refGroupCode == nil {
+		return code.AccessorIDNotFound, "Accessor ID not found"
+	}
+	refGroupKey := refGroupCodeKeyPrefix + keySeparator + string(refGroupCode)
+	refGroupValue, _ := app.state.Get([]byte(refGroupKey), false)
+	if refGroupValue == nil {
+		return code.RefGroupNotFound, "Reference group not found"
+	}
+	var refGroup data.ReferenceGroup
+	err := proto.Unmarshal(refGroupValue, &refGroup)
+	if err != nil {
+		return code.UnmarshalError, err.Error()
+	}
+	for _, idp := range refGroup.Idps {
+		if idp.NodeId != idpID {
+			continue
+		}
+		for _, accessor := range idp.Accessors {
+			if accessor.AccessorId != accessorID {
+				continue
+			}
+			if accessor.Revoked {
+				return code.AccessorIsRevoked, "Accessor is revoked"
+			}
+			if !accessor.Active {
+				return code.AccessorIsNotActive, "Accessor is not active"
+			}
+			return code.OK, ""
+		}
+	}
+	return code.AccessorNotFoundInThisIdP, "Accessor not found in this IdP"
+}
+
+func getAccessorResponseKeyPrefix(accessorID string) string {
+	return accessorResponseKeyPrefix + keySeparator + accessorID + keySeparator
+}
+
+// saveAccessorResponse records that accessor is used to sign response of IdP to request
+func (app *ABCIApplication) saveAccessorResponse(accessorID string, requestID string, idpID string) error {
+	var accessorResponse data.AccessorResponse
+	accessorResponse.IdpId = idpID
+	accessorResponse.BlockHeight = app.state.CurrentBlockHeight
+	value, err := utils.ProtoDeterministicMarshal(&accessorResponse)
+	if err != nil {
+		return err
+	}
+	app.state.Set([]byte(getAccessorResponseKeyPrefix(accessorID)+requestID), value)
+	return nil
+}
+
+func (app *ABCIApplication) getAccessorResponseList(param string) types.ResponseQuery {
+	app.logger.Infof("GetAccessorResponseList, Parameter: %s", param)
+	var funcParam GetAccessorResponseListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if funcParam.AccessorID == "" {
+		return app.ReturnQueryWithCode(code.AccessorIDCannotBeEmpty, nil, "Accessor ID cannot be empty", app.state.Height)
+	}
+	var result GetAccessorResponseListResult
+	result.ResponseList = make([]AccessorResponse, 0)
+	prefix := getAccessorResponseKeyPrefix(funcParam.AccessorID)
+	app.state.IterateCommitted([]byte(prefix), func(key, value []byte) bool {
+		var accessorResponse data.AccessorResponse
+		err = proto.Unmarshal(value, &accessorResponse)
+		if err != nil {
+			return false
+		}
+		result.ResponseList = append(result.ResponseList, AccessorResponse{
+			RequestID:   strings.TrimPrefix(string(key), prefix),
+			IdpID:       accessorResponse.IdpId,
+			BlockHeight: accessorResponse.BlockHeight,
+		})
+		return true
+	})
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
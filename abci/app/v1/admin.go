@@ -0,0 +1,249 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// adminApprovalMethod is list of high-impact NDID methods which must be approved by
+// required number of NDID operators when admin approval policy is set
+var adminApprovalMethod = map[string]bool{
+	"SetValidator":           true,
+	"DisableNode":            true,
+	"SetLastBlock":           true,
+	"AddNodeToken":           true,
+	"SetNodeToken":           true,
+	"RegisterNodeBatch":      true,
+	"SetAdminApprovalPolicy": true,
+}
+
+func (app *ABCIApplication) getAdminApprovalPolicy(committedState bool) (data.AdminApprovalPolicy, error) {
+	var policy data.AdminApprovalPolicy
+	value, _ := app.state.Get(adminApprovalPolicyKeyBytes, committedState)
+	if value == nil {
+		return policy, nil
+	}
+	err := proto.Unmarshal(value, &policy)
+	return policy, err
+}
+
+// isAdminApprovalRequired checks whether method cannot be called directly by NDID
+// and must be proposed and approved by NDID operators instead
+func (app *ABCIApplication) isAdminApprovalRequired(method string, committedState bool) bool {
+	if !adminApprovalMethod[method] {
+		return false
+	}
+	policy, err := app.getAdminApprovalPolicy(committedState)
+	if err != nil {
+		return true
+	}
+	return policy.RequiredApprovalCount > 0
+}
+
+func (app *ABCIApplication) setAdminApprovalPolicy(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetAdminApprovalPolicy, Parameter: %s", param)
+	var funcParam AdminApprovalPolicyParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.RequiredApprovalCount < 0 || funcParam.RequiredApprovalCount > int64(len(funcParam.OperatorPublicKeyList)) {
+		return app.ReturnDeliverTxLog(code.InvalidAdminApprovalPolicy, "Required approval count must be between 0 and number of operator public keys", "")
+	}
+	operatorKeyHashes := make(map[string]bool)
+	for _, publicKey := range funcParam.OperatorPublicKeyList {
+		checkCode, checkLog := checkPubKey(publicKey)
+		if checkCode != code.OK {
+			return app.ReturnDeliverTxLog(checkCode, checkLog, "")
+		}
+		publicKeyHash := getPublicKeyHash(publicKey)
+		if operatorKeyHashes[publicKeyHash] {
+			return app.ReturnDeliverTxLog(code.DuplicatePublicKey, "Duplicate operator public key", "")
+		}
+		operatorKeyHashes[publicKeyHash] = true
+	}
+	var policy data.AdminApprovalPolicy
+	policy.OperatorPublicKeyList = funcParam.OperatorPublicKeyList
+	policy.RequiredApprovalCount = funcParam.RequiredApprovalCount
+	value, err := utils.ProtoDeterministicMarshal(&policy)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(adminApprovalPolicyKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getAdminApprovalPolicyQuery(param string) types.ResponseQuery {
+	app.logger.Infof("GetAdminApprovalPolicy, Parameter: %s", param)
+	policy, err := app.getAdminApprovalPolicy(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result AdminApprovalPolicyParam
+	result.OperatorPublicKeyList = append(make([]string, 0), policy.OperatorPublicKeyList...)
+	result.RequiredApprovalCount = policy.RequiredApprovalCount
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
+
+func (app *ABCIApplication) createAdminProposal(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("CreateAdminProposal, Parameter: %s", param)
+	var funcParam CreateAdminProposalParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if !app.isAdminApprovalRequired(funcParam.Method, false) {
+		return app.ReturnDeliverTxLog(code.MethodDoesNotRequireAdminApproval, "Method does not require admin approval", "")
+	}
+	key := adminProposalKeyPrefix + keySeparator + funcParam.ProposalID
+	if app.state.Has([]byte(key), false) {
+		return app.ReturnDeliverTxLog(code.DuplicateAdminProposalID, "Duplicate admin proposal ID", "")
+	}
+	var proposal data.AdminProposal
+	proposal.ProposalId = funcParam.ProposalID
+	proposal.Method = funcParam.Method
+	proposal.Param = string(funcParam.Params)
+	proposal.ApprovedOperatorKeyHashList = make([]string, 0)
+	proposal.CreationBlockHeight = app.state.CurrentBlockHeight
+	value, err := utils.ProtoDeterministicMarshal(&proposal)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set([]byte(key), value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+// approveAdminProposal records approval of NDID operator signed with operator key.
+// Operator signs proposal ID, method and param of proposal. Proposal is executed
+// in the same Tx as its last required approval.
+func (app *ABCIApplication) approveAdminProposal(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("ApproveAdminProposal, Parameter: %s", param)
+	var funcParam ApproveAdminProposalParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	key := adminProposalKeyPrefix + keySeparator + funcParam.ProposalID
+	value, _ := app.state.Get([]byte(key), false)
+	if value == nil {
+		return app.ReturnDeliverTxLog(code.AdminProposalNotFound, "Admin proposal not found", "")
+	}
+	var proposal data.AdminProposal
+	err = proto.Unmarshal(value, &proposal)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if proposal.Executed {
+		return app.ReturnDeliverTxLog(code.AdminProposalIsAlreadyExecuted, "Admin proposal is already executed", "")
+	}
+	policy, err := app.getAdminApprovalPolicy(false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	publicKeyHash := getPublicKeyHash(funcParam.OperatorPublicKey)
+	var operatorPublicKey string
+	for _, publicKey := range policy.OperatorPublicKeyList {
+		if getPublicKeyHash(publicKey) == publicKeyHash {
+			operatorPublicKey = publicKey
+			break
+		}
+	}
+	if operatorPublicKey == "" {
+		return app.ReturnDeliverTxLog(code.NotAdminOperator, "Public key is not NDID operator key", "")
+	}
+	for _, approvedKeyHash := range proposal.ApprovedOperatorKeyHashList {
+		if approvedKeyHash == publicKeyHash {
+			return app.ReturnDeliverTxLog(code.OperatorAlreadyApprovedAdminProposal, "Operator already approved this admin proposal", "")
+		}
+	}
+	verified, err := verifySignature(proposal.Method+proposal.Param, []byte(proposal.ProposalId), funcParam.Signature, operatorPublicKey, "ApproveAdminProposal")
+	if err != nil || !verified {
+		return app.ReturnDeliverTxLog(code.VerifySignatureError, "Invalid operator signature", "")
+	}
+	proposal.ApprovedOperatorKeyHashList = append(proposal.ApprovedOperatorKeyHashList, publicKeyHash)
+	if int64(len(proposal.ApprovedOperatorKeyHashList)) >= policy.RequiredApprovalCount {
+		app.logger.Infof("Execute admin proposal: %s, Method: %s", proposal.ProposalId, proposal.Method)
+		result := app.callDeliverTxOrSchedule(proposal.Method, proposal.Param, nodeID)
+		proposal.Executed = true
+		proposal.ExecutionBlockHeight = app.state.CurrentBlockHeight
+		proposal.ResultCode = result.Code
+		proposal.ResultLog = result.Log
+	}
+	value, err = utils.ProtoDeterministicMarshal(&proposal)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set([]byte(key), value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getPendingAdminProposalList(param string) types.ResponseQuery {
+	app.logger.Infof("GetPendingAdminProposalList, Parameter: %s", param)
+	policy, err := app.getAdminApprovalPolicy(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetPendingAdminProposalListResult
+	result.ProposalList = make([]AdminProposal, 0)
+	prefix := []byte(adminProposalKeyPrefix + keySeparator)
+	app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		var proposal data.AdminProposal
+		err = proto.Unmarshal(value, &proposal)
+		if err != nil {
+			return false
+		}
+		if proposal.Executed {
+			return true
+		}
+		var row AdminProposal
+		row.ProposalID = strings.TrimPrefix(string(key), string(prefix))
+		row.Method = proposal.Method
+		row.Params = json.RawMessage(proposal.Param)
+		row.ApprovalCount = int64(len(proposal.ApprovedOperatorKeyHashList))
+		row.RequiredApprovalCount = policy.RequiredApprovalCount
+		row.CreationBlockHeight = proposal.CreationBlockHeight
+		result.ProposalList = append(result.ProposalList, row)
+		return true
+	})
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,180 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// AnalyticsResult is aggregate of requests in state. It contains no node ID,
+// request ID, identity or message, only counts.
+type AnalyticsResult struct {
+	// RequestCountByHour is number of requests by creation hour (unix time of start of hour).
+	// Requests created before block time is kept are not counted.
+	RequestCountByHour map[int64]int64
+	// ResponseCountByIAL is number of IdP responses by IAL
+	ResponseCountByIAL map[float64]int64
+	// ASResponseCountByLatency is number of AS data signatures by blocks from request creation
+	ASResponseCountByLatency map[int64]int64
+}
+
+// ExportAnalytics walks requests and data signatures in db (latest version) and aggregates them
+func ExportAnalytics(db dbm.DB) (result AnalyticsResult, err error) {
+	result.RequestCountByHour = make(map[int64]int64)
+	result.ResponseCountByIAL = make(map[float64]int64)
+	result.ASResponseCountByLatency = make(map[int64]int64)
+	// App is used only for reading request sub-records from committed state
+	app := &ABCIApplication{state: NewAppState(db)}
+	prefix := requestKeyPrefix + keySeparator
+	itr := db.Iterator([]byte(prefix), []byte(requestKeyPrefix+"}"))
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		key := string(itr.Key())
+		if !strings.HasSuffix(key, "|versions") {
+			continue
+		}
+		var keyVersions data.KeyVersions
+		err = proto.Unmarshal(itr.Value(), &keyVersions)
+		if err != nil {
+			return result, fmt.Errorf("Error unmarshaling versions %s: %v", key, err)
+		}
+		if len(keyVersions.Versions) == 0 {
+			continue
+		}
+		requestID := strings.TrimSuffix(strings.TrimPrefix(key, prefix), "|versions")
+		latestVersion := keyVersions.Versions[len(keyVersions.Versions)-1]
+		requestValue := db.Get([]byte(prefix + requestID + keySeparator + strconv.FormatInt(latestVersion, 10)))
+		if requestValue == nil {
+			continue
+		}
+		var request data.Request
+		err = proto.Unmarshal(requestValue, &request)
+		if err != nil {
+			return result, fmt.Errorf("Error unmarshaling request: %v", err)
+		}
+		err = app.loadRequestSubRecords(&request, 0, true)
+		if err != nil {
+			return result, fmt.Errorf("Error unmarshaling request: %v", err)
+		}
+		if request.CreationBlockTime > 0 {
+			hour := request.CreationBlockTime - request.CreationBlockTime%3600
+			result.RequestCountByHour[hour]++
+		}
+		for _, response := range request.ResponseList {
+			result.ResponseCountByIAL[response.Ial]++
+		}
+		for _, dataRequest := range request.DataRequestList {
+			for _, asID := range dataRequest.AnsweredAsIdList {
+				signDataValue := db.Get([]byte(dataSignatureKeyPrefix + keySeparator + asID + keySeparator + dataRequest.ServiceId + keySeparator + requestID))
+				if signDataValue == nil {
+					continue
+				}
+				var dataSignature data.DataSignature
+				err = proto.Unmarshal(signDataValue, &dataSignature)
+				if err != nil {
+					return result, fmt.Errorf("Error unmarshaling data signature: %v", err)
+				}
+				result.ASResponseCountByLatency[dataSignature.BlockHeight-request.CreationBlockHeight]++
+			}
+		}
+	}
+	return result, nil
+}
+
+// WriteCSV writes request_volume_by_hour.csv, ial_distribution.csv and
+// as_response_latency.csv to dir
+func (result AnalyticsResult) WriteCSV(dir string) error {
+	hours := make([]int64, 0, len(result.RequestCountByHour))
+	for hour := range result.RequestCountByHour {
+		hours = append(hours, hour)
+	}
+	sort.Slice(hours, func(i, j int) bool { return hours[i] < hours[j] })
+	rows := [][]string{{"hour", "request_count"}}
+	for _, hour := range hours {
+		rows = append(rows, []string{
+			time.Unix(hour, 0).UTC().Format(time.RFC3339),
+			strconv.FormatInt(result.RequestCountByHour[hour], 10),
+		})
+	}
+	err := writeCSVFile(filepath.Join(dir, "request_volume_by_hour.csv"), rows)
+	if err != nil {
+		return err
+	}
+
+	ials := make([]float64, 0, len(result.ResponseCountByIAL))
+	for ial := range result.ResponseCountByIAL {
+		ials = append(ials, ial)
+	}
+	sort.Float64s(ials)
+	rows = [][]string{{"ial", "response_count"}}
+	for _, ial := range ials {
+		rows = append(rows, []string{
+			strconv.FormatFloat(ial, 'f', -1, 64),
+			strconv.FormatInt(result.ResponseCountByIAL[ial], 10),
+		})
+	}
+	err = writeCSVFile(filepath.Join(dir, "ial_distribution.csv"), rows)
+	if err != nil {
+		return err
+	}
+
+	latencies := make([]int64, 0, len(result.ASResponseCountByLatency))
+	for latency := range result.ASResponseCountByLatency {
+		latencies = append(latencies, latency)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	rows = [][]string{{"latency_block", "as_response_count"}}
+	for _, latency := range latencies {
+		rows = append(rows, []string{
+			strconv.FormatInt(latency, 10),
+			strconv.FormatInt(result.ASResponseCountByLatency[latency], 10),
+		})
+	}
+	return writeCSVFile(filepath.Join(dir, "as_response_latency.csv"), rows)
+}
+
+func writeCSVFile(path string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := csv.NewWriter(file)
+	err = writer.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+	return file.Sync()
+}
@@ -23,10 +23,14 @@
 package app
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"os"
 	"runtime"
+	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,6 +40,8 @@ import (
 	dbm "github.com/tendermint/tendermint/libs/db"
 
 	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/storage"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
 	"github.com/ndidplatform/smart-contract/v4/abci/version"
 	protoTm "github.com/ndidplatform/smart-contract/v4/protos/tendermint"
 )
@@ -44,6 +50,7 @@ type ABCIApplication struct {
 	types.BaseApplication
 	AppProtocolVersion  uint64
 	CurrentChain        string
+	CurrentBlockTime    time.Time
 	Version             string
 	checkTxNonceState   map[string][]byte
 	deliverTxNonceState map[string][]byte
@@ -51,8 +58,50 @@ type ABCIApplication struct {
 	state               AppState
 	valUpdates          map[string]types.ValidatorUpdate
 	verifiedSignatures  map[string]string
+	recentTxs           map[string]int64
+	queryCache          *queryCache
+	queryLimiter        *queryLimiter
+	usedQueryNonces     map[string]bool
+	pruner              *statePruner
+	backup              *stateBackup
+	handlerBudget       *handlerBudget
+	crashReportDir      string
+	storeQueryEnabled   bool
+	// usedQueryNonceList is ring of keys of usedQueryNonces in order of use
+	usedQueryNonceList      []string
+	nextUsedQueryNonceIndex int
+	// queryAccessLogEnabled enables access log and latency summaries of queries
+	queryAccessLogEnabled bool
+	// catchingUpBlockTimeLag is how far time of latest block can be behind local time
+	// before node is considered catching up and rejects new Txs in CheckTx. 0 to disable.
+	catchingUpBlockTimeLag time.Duration
+	// compressionMinSize is min size of query result value compressed for gzip query path
+	compressionMinSize int
+	// deliverTxEvents is events emitted while delivering current Tx in addition to its result
+	deliverTxEvents []types.Event
+	// invariantCheckMode is "alert" or "halt" to check invariants at commit, empty to disable
+	invariantCheckMode     string
+	invariantCheckInterval int64
+	// queryInconsistencyCount is number of missing or corrupt records found by queries
+	// since last invariant check. It is updated by queries, so it is accessed atomically.
+	queryInconsistencyCount int64
+	// pendingConfig is reloaded config to be applied at next commit
+	pendingConfig chan *Config
+	// blockSeed is seed of deterministic pseudo-random ordering in current block
+	blockSeed []byte
+	// deliverTxHash and deliverTxIndex are hash and index in current block of Tx being delivered
+	deliverTxHash  []byte
+	deliverTxIndex int64
+	// blockWriteLimit is max number of state keys written in current block by Txs, 0 for no limit
+	blockWriteLimit int64
+	// beginBlockWriteCount is number of state keys written in current block before its first Tx
+	beginBlockWriteCount int64
 }
 
+// recentTxsCacheBlocks is number of blocks that hash of Tx accepted by CheckTx is kept
+// for rejecting identical Tx without parsing and verifying it again
+const recentTxsCacheBlocks = 10
+
 func NewABCIApplication(logger *logrus.Entry, db dbm.DB) *ABCIApplication {
 	defer func() {
 		if r := recover(); r != nil {
@@ -61,20 +110,82 @@ func NewABCIApplication(logger *logrus.Entry, db dbm.DB) *ABCIApplication {
 		}
 	}()
 
+	// State of each network (environment) is kept under its own key prefix when DB is shared
+	networkNamespace := getEnv("ABCI_NETWORK_NAMESPACE", "")
+	err := storage.RegisterNetworkNamespace(db, networkNamespace)
+	if err != nil {
+		panic(err)
+	}
+	sharedDB := db
+	db, err = storage.NamespaceDB(db, networkNamespace)
+	if err != nil {
+		panic(err)
+	}
+	// Backup copies state as stored so values of encrypted keys stay encrypted in backup
+	storedDB := db
+	db, err = storage.EncryptedDB(db, getEnv("ABCI_STORAGE_ENCRYPTION_KEY_FILE", ""), strings.Split(getEnv("ABCI_STORAGE_ENCRYPTION_KEY_PREFIXES", ""), ","))
+	if err != nil {
+		panic(err)
+	}
 	appState := NewAppState(db)
+	if appState.Height > 0 && appState.NetworkNamespace != networkNamespace {
+		panic(fmt.Errorf("State is of network namespace %q, not %q", appState.NetworkNamespace, networkNamespace))
+	}
+	appState.NetworkNamespace = networkNamespace
+
+	invariantCheckInterval, err := strconv.ParseInt(getEnv("ABCI_INVARIANT_CHECK_INTERVAL", "1"), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	pruneKeepBlocks, err := strconv.ParseInt(getEnv("ABCI_PRUNE_KEEP_BLOCKS", "0"), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	backupInterval, err := strconv.ParseInt(getEnv("ABCI_BACKUP_INTERVAL", "0"), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	backupRetention, err := strconv.Atoi(getEnv("ABCI_BACKUP_RETENTION", "0"))
+	if err != nil {
+		panic(err)
+	}
+	catchingUpBlockTimeLag, err := strconv.ParseInt(getEnv("ABCI_CATCHING_UP_BLOCK_TIME_LAG", "0"), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	stateProfileDumpInterval, err := strconv.ParseInt(getEnv("ABCI_STATE_PROFILE_DUMP_INTERVAL", "0"), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	appState.profiler = newStateProfiler(getEnv("ABCI_STATE_PROFILE_ENABLED", "false") == "true", stateProfileDumpInterval, logger)
 
 	ABCIVersion := version.Version
 	ABCIProtocolVersion := version.AppProtocolVersion
-	logger.Infof("Start ABCI app version: %s", ABCIVersion)
+	logger.Infof("Start ABCI app version: %s, state schema version: %d, network namespace: %q", ABCIVersion, appState.SchemaVersion, networkNamespace)
 	return &ABCIApplication{
-		AppProtocolVersion:  ABCIProtocolVersion,
-		Version:             ABCIVersion,
-		checkTxNonceState:   make(map[string][]byte),
-		deliverTxNonceState: make(map[string][]byte),
-		logger:              logger,
-		state:               appState,
-		valUpdates:          make(map[string]types.ValidatorUpdate),
-		verifiedSignatures:  make(map[string]string),
+		AppProtocolVersion:     ABCIProtocolVersion,
+		Version:                ABCIVersion,
+		checkTxNonceState:      make(map[string][]byte),
+		deliverTxNonceState:    make(map[string][]byte),
+		logger:                 logger,
+		state:                  appState,
+		valUpdates:             make(map[string]types.ValidatorUpdate),
+		verifiedSignatures:     make(map[string]string),
+		recentTxs:              make(map[string]int64),
+		queryCache:             newQueryCache(defaultQueryCacheSize),
+		queryLimiter:           newQueryLimiter(),
+		usedQueryNonces:        make(map[string]bool),
+		pruner:                 newStatePruner(db, logger, pruneKeepBlocks, appState.PrunedHeight),
+		backup:                 newStateBackup(sharedDB, networkNamespace, storedDB, logger, backupInterval, backupRetention, getEnv("ABCI_BACKUP_DIR", "")),
+		handlerBudget:          newHandlerBudget(),
+		crashReportDir:         getEnv("ABCI_CRASH_REPORT_DIR", ""),
+		compressionMinSize:     defaultQueryCompressMinSize,
+		storeQueryEnabled:      getEnv("ABCI_STORE_QUERY_ENABLED", "false") == "true",
+		queryAccessLogEnabled:  getEnv("ABCI_QUERY_ACCESS_LOG_ENABLED", "false") == "true",
+		catchingUpBlockTimeLag: time.Duration(catchingUpBlockTimeLag) * time.Second,
+		invariantCheckMode:     getEnv("ABCI_INVARIANT_CHECK", ""),
+		invariantCheckInterval: invariantCheckInterval,
+		pendingConfig:          make(chan *Config, 1),
 	}
 }
 
@@ -84,6 +195,15 @@ func (app *ABCIApplication) Info(req types.RequestInfo) (resInfo types.ResponseI
 	res.LastBlockHeight = app.state.Height
 	res.LastBlockAppHash = app.state.AppHash
 	res.AppVersion = app.AppProtocolVersion
+	var infoData InfoData
+	infoData.KeyCount = app.state.KeyCount
+	infoData.ByteSize = app.state.ByteSize
+	infoData.StateSchemaVersion = app.state.SchemaVersion
+	infoData.NetworkNamespace = app.state.NetworkNamespace
+	infoDataJSON, err := json.Marshal(infoData)
+	if err == nil {
+		res.Data = string(infoDataJSON)
+	}
 	return res
 }
 
@@ -103,17 +223,28 @@ func (app *ABCIApplication) BeginBlock(req types.RequestBeginBlock) types.Respon
 	app.logger.Infof("BeginBlock: %d, Chain ID: %s", req.Header.Height, req.Header.ChainID)
 	app.state.CurrentBlockHeight = req.Header.Height
 	app.CurrentChain = req.Header.ChainID
+	app.CurrentBlockTime = req.Header.Time
+	app.state.profiler.setMethod("BeginBlock")
+	app.setBlockSeed()
+	app.deliverTxIndex = -1
+	app.blockWriteLimit = app.getBlockWriteLimitFromStateDB(true)
 	// reset valset changes
 	app.valUpdates = make(map[string]types.ValidatorUpdate, 0)
-	return types.ResponseBeginBlock{}
+	events := app.recordValidatorAccountability(req)
+	events = append(events, app.executeDueGovernanceActions()...)
+	app.beginBlockWriteCount = app.state.UncommittedKeyCount()
+	return types.ResponseBeginBlock{Events: events}
 }
 
 // Update the validator set
 func (app *ABCIApplication) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
 	app.logger.Infof("EndBlock: %d", req.Height)
+	app.state.profiler.setMethod("EndBlock")
+	app.runEndBlockHooks(req.Height)
+	app.state.profiler.dumpAtEndBlock(req.Height)
 	valUpdates := make([]types.ValidatorUpdate, 0)
-	for _, newValidator := range app.valUpdates {
-		valUpdates = append(valUpdates, newValidator)
+	for _, key := range utils.SortedKeys(app.valUpdates) {
+		valUpdates = append(valUpdates, app.valUpdates[key])
 	}
 	return types.ResponseEndBlock{ValidatorUpdates: valUpdates}
 }
@@ -123,14 +254,19 @@ func (app *ABCIApplication) DeliverTx(req types.RequestDeliverTx) (res types.Res
 	defer func() {
 		if r := recover(); r != nil {
 			app.logger.Errorf("Recovered in %s, %s", r, identifyPanic())
+			app.recordPanic("DeliverTx", req.Tx, r, debug.Stack())
 			res = app.ReturnDeliverTxLog(code.UnknownError, "Unknown error", "")
 		}
 	}()
 
-	var txObj protoTm.Tx
-	err := proto.Unmarshal(req.Tx, &txObj)
+	app.deliverTxHash = hash(req.Tx)
+	app.deliverTxIndex++
+
+	txObj, err := parseTx(req.Tx)
 	if err != nil {
 		app.logger.Error(err.Error())
+		go recordDeliverTxFailMetrics("")
+		return app.ReturnDeliverTxLog(code.InvalidTransactionFormat, "Invalid transaction format", "")
 	}
 
 	method := txObj.Method
@@ -139,6 +275,11 @@ func (app *ABCIApplication) DeliverTx(req types.RequestDeliverTx) (res types.Res
 	signature := txObj.Signature
 	nodeID := txObj.NodeId
 
+	defer func() {
+		app.state.recordTxActivity(method, nodeID, param, app.CurrentChain, res.Code)
+	}()
+	app.state.profiler.setMethod("DeliverTx:" + method)
+
 	go recordDeliverTxMetrics(method)
 
 	startTime := time.Now()
@@ -161,6 +302,11 @@ func (app *ABCIApplication) DeliverTx(req types.RequestDeliverTx) (res types.Res
 		return app.ReturnDeliverTxLog(code.MethodCanNotBeEmpty, "method can not be empty", "")
 	}
 
+	if !IsMethod[method] {
+		go recordDeliverTxFailMetrics(method)
+		return app.returnUnknownMethodDeliverTx(method)
+	}
+
 	// Check signature
 	publicKey, retCode, retLog := app.getNodePublicKeyForSignatureVerification(method, param, nodeID, false)
 	if retCode != code.OK {
@@ -193,7 +339,10 @@ func (app *ABCIApplication) DeliverTx(req types.RequestDeliverTx) (res types.Res
 		}
 	}
 
+	handlerStartTime := time.Now()
 	result := app.DeliverTxRouter(method, param, nonce, signature, nodeID)
+	app.checkHandlerBudget("DeliverTx", method, param, time.Since(handlerStartTime))
+	addDeprecationEvent(&result, method, nodeID)
 	app.logger.Infof(
 		`DeliverTx response: {"code":%d,"log":"%s","attributes":[{"key":"%s","value":"%s"}]}`,
 		result.Code,
@@ -206,19 +355,46 @@ func (app *ABCIApplication) DeliverTx(req types.RequestDeliverTx) (res types.Res
 	return result
 }
 
+// isCatchingUp returns whether time of latest block is too far behind local time.
+// Block time is unknown until first block after start, node is not considered
+// catching up in this case.
+func (app *ABCIApplication) isCatchingUp() bool {
+	if app.catchingUpBlockTimeLag <= 0 || app.CurrentBlockTime.IsZero() {
+		return false
+	}
+	return time.Since(app.CurrentBlockTime) > app.catchingUpBlockTimeLag
+}
+
 func (app *ABCIApplication) CheckTx(req types.RequestCheckTx) (res types.ResponseCheckTx) {
 	// Recover when panic
 	defer func() {
 		if r := recover(); r != nil {
 			app.logger.Errorf("Recovered in %s, %s", r, identifyPanic())
+			app.recordPanic("CheckTx", req.Tx, r, debug.Stack())
 			res = ReturnCheckTx(code.UnknownError, "Unknown error")
 		}
 	}()
 
-	var txObj protoTm.Tx
-	err := proto.Unmarshal(req.Tx, &txObj)
+	// Reject new Tx while node is replaying or syncing blocks so client can send it to
+	// other node instead of waiting for Tx which will not be confirmed soon
+	if req.Type != types.CheckTxType_Recheck && app.isCatchingUp() {
+		go recordCheckTxFailMetrics("")
+		return ReturnCheckTx(code.NodeCatchingUp, "Node is catching up")
+	}
+
+	// Check Tx which is identical to recently accepted one (e.g. client retry broadcasting to multiple nodes)
+	txHash := string(hash(req.Tx))
+	if req.Type != types.CheckTxType_Recheck {
+		if _, exist := app.recentTxs[txHash]; exist {
+			return ReturnCheckTx(code.DuplicateTransaction, "Duplicate transaction")
+		}
+	}
+
+	txObj, err := parseTx(req.Tx)
 	if err != nil {
 		app.logger.Error(err.Error())
+		go recordCheckTxFailMetrics("")
+		return ReturnCheckTx(code.InvalidTransactionFormat, "Invalid transaction format")
 	}
 
 	method := txObj.Method
@@ -226,6 +402,17 @@ func (app *ABCIApplication) CheckTx(req types.RequestCheckTx) (res types.Respons
 	nonce := txObj.Nonce
 	signature := txObj.Signature
 	nodeID := txObj.NodeId
+	app.state.profiler.setMethod("CheckTx:" + method)
+
+	// Recheck is done to Txs remaining in mempool after each block is committed.
+	// Nonce of these Txs is already in checkTx state and their signature was verified.
+	recheck := req.Type == types.CheckTxType_Recheck
+	defer func() {
+		// Tx failed recheck is removed from mempool
+		if recheck && res.Code != code.OK {
+			delete(app.checkTxNonceState, string(nonce))
+		}
+	}()
 
 	go recordCheckTxMetrics(method)
 
@@ -245,18 +432,24 @@ func (app *ABCIApplication) CheckTx(req types.RequestCheckTx) (res types.Respons
 	}
 
 	// Check duplicate nonce in checkTx state
-	nonceStr := string(nonce)
-	_, exist := app.checkTxNonceState[nonceStr]
-	if !exist {
-		app.checkTxNonceState[nonceStr] = []byte(nil)
-	} else {
-		res.Code = code.DuplicateNonce
-		res.Log = "Duplicate nonce"
-		go recordCheckTxFailMetrics(method)
-		return res
+	if !recheck {
+		nonceStr := string(nonce)
+		_, exist := app.checkTxNonceState[nonceStr]
+		if !exist {
+			app.checkTxNonceState[nonceStr] = []byte(nil)
+		} else {
+			res.Code = code.DuplicateNonce
+			res.Log = "Duplicate nonce"
+			go recordCheckTxFailMetrics(method)
+			return res
+		}
 	}
 
-	app.logger.Infof("CheckTx: %s, NodeID: %s", method, nodeID)
+	if recheck {
+		app.logger.Infof("RecheckTx: %s, NodeID: %s", method, nodeID)
+	} else {
+		app.logger.Infof("CheckTx: %s, NodeID: %s", method, nodeID)
+	}
 
 	if method == "" || param == "" || nonce == nil || signature == nil || nodeID == "" {
 		res.Code = code.InvalidTransactionFormat
@@ -267,10 +460,8 @@ func (app *ABCIApplication) CheckTx(req types.RequestCheckTx) (res types.Respons
 
 	// Check has function in system
 	if !IsMethod[method] {
-		res.Code = code.UnknownMethod
-		res.Log = "Unknown method name"
 		go recordCheckTxFailMetrics(method)
-		return res
+		return returnUnknownMethodCheckTx(method)
 	}
 
 	// Check signature
@@ -279,22 +470,36 @@ func (app *ABCIApplication) CheckTx(req types.RequestCheckTx) (res types.Respons
 		return ReturnCheckTx(retCode, retLog)
 	}
 
-	isVerified, err := verifySignature(param, nonce, signature, publicKey, method)
-	if err != nil {
-		go recordCheckTxFailMetrics(method)
-		return ReturnCheckTx(code.VerifySignatureError, err.Error())
-	}
-	if !isVerified {
-		go recordCheckTxFailMetrics(method)
-		return ReturnCheckTx(code.VerifySignatureError, "Invalid Tx signature")
-	}
+	// Signature needs to be verified again on recheck only if node key has been changed
 	verifiedSignatureKey := string(signature) + "|" + nodeID
-	app.verifiedSignatures[verifiedSignatureKey] = publicKey
+	verifiedSigNodePubKey, verifiedSigResultExist := app.verifiedSignatures[verifiedSignatureKey]
+	if !recheck || !verifiedSigResultExist || verifiedSigNodePubKey != publicKey {
+		isVerified, err := verifySignature(param, nonce, signature, publicKey, method)
+		if err != nil {
+			delete(app.verifiedSignatures, verifiedSignatureKey)
+			go recordCheckTxFailMetrics(method)
+			return ReturnCheckTx(code.VerifySignatureError, err.Error())
+		}
+		if !isVerified {
+			delete(app.verifiedSignatures, verifiedSignatureKey)
+			go recordCheckTxFailMetrics(method)
+			return ReturnCheckTx(code.VerifySignatureError, "Invalid Tx signature")
+		}
+		app.verifiedSignatures[verifiedSignatureKey] = publicKey
+	}
 
 	result := app.CheckTxRouter(method, param, nonce, signature, nodeID, true)
+	// Evict Tx to request which has been closed or timed out
+	if result.Code == code.OK && IsRequestMethod[method] {
+		result = app.checkRequestIsNotFinished(method, param)
+	}
 	if result.Code != code.OK {
 		delete(app.verifiedSignatures, verifiedSignatureKey)
 		go recordCheckTxFailMetrics(method)
+		return result
+	}
+	if !recheck {
+		app.recentTxs[txHash] = app.state.Height
 	}
 	return result
 }
@@ -308,7 +513,9 @@ func (app *ABCIApplication) Commit() types.ResponseCommit {
 	startTime := time.Now()
 	app.logger.Infof("Commit")
 
-	app.state.Save()
+	app.queryCache.invalidate(app.state.UncommittedKeyPrefixes())
+	go recordBlockStateWriteMetrics(app.state.UncommittedKeyCount())
+	app.pruner.saveState(&app.state)
 	app.state.Height = app.state.Height + 1
 	dbSaveDuration := time.Since(startTime)
 	go recordDBSaveDurationMetrics(dbSaveDuration)
@@ -318,11 +525,21 @@ func (app *ABCIApplication) Commit() types.ResponseCommit {
 	}
 	app.deliverTxNonceState = make(map[string][]byte)
 
+	for key, height := range app.recentTxs {
+		if app.state.Height-height > recentTxsCacheBlocks {
+			delete(app.recentTxs, key)
+		}
+	}
+
 	appHashStartTime := time.Now()
 	// Calculate app hash
 	if len(app.state.HashData) > 0 {
-		app.state.HashData = append(app.state.AppHash, app.state.HashData...)
-		app.state.AppHash = hash(app.state.HashData)
+		appHashSchemeConfig, err := app.getAppHashSchemeConfig(true)
+		if err != nil {
+			panic(err)
+		}
+		appHashScheme := getAppHashScheme(appHashSchemeConfig, app.state.Height)
+		app.state.AppHash = computeAppHash(appHashScheme, app.state.AppHash, app.state.HashData, app.state.Height)
 	}
 	appHash := app.state.AppHash
 	appHashDuration := time.Since(appHashStartTime)
@@ -333,6 +550,14 @@ func (app *ABCIApplication) Commit() types.ResponseCommit {
 	// Save state
 	app.state.SaveMetadata()
 
+	app.checkInvariantsAtCommit()
+
+	app.applyPendingConfig()
+
+	app.pruner.notifyCommit(app.state.Height)
+
+	app.backup.notifyCommit(app.state.Height)
+
 	duration := time.Since(startTime)
 	go recordCommitDurationMetrics(duration)
 	return types.ResponseCommit{Data: appHash}
@@ -343,14 +568,35 @@ func (app *ABCIApplication) Query(reqQuery types.RequestQuery) (res types.Respon
 	defer func() {
 		if r := recover(); r != nil {
 			app.logger.Errorf("Recovered in %s, %s", r, identifyPanic())
-			res = app.ReturnQuery(nil, "Unknown error", app.state.Height)
+			app.recordPanic("Query", reqQuery.Data, r, debug.Stack())
+			res = app.ReturnQueryWithCode(code.UnknownError, nil, "Unknown error", app.state.Height)
 		}
 	}()
 
+	if reqQuery.Path == storeQueryPath {
+		return app.queryStore(reqQuery)
+	}
+
+	if reqQuery.Path == stateProfileQueryPath {
+		return app.queryStateProfile()
+	}
+
+	if reqQuery.Path == gzipQueryPath {
+		defer func() {
+			res = app.compressQueryResult(res)
+		}()
+	}
+
+	queryData, err := utils.DecodeEnvelope(reqQuery.Data)
+	if err != nil {
+		app.logger.Error(err.Error())
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, "Invalid query format", app.state.Height)
+	}
 	var query protoTm.Query
-	err := proto.Unmarshal(reqQuery.Data, &query)
+	err = proto.Unmarshal(queryData, &query)
 	if err != nil {
 		app.logger.Error(err.Error())
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, "Invalid query format", app.state.Height)
 	}
 
 	method := query.Method
@@ -364,16 +610,63 @@ func (app *ABCIApplication) Query(reqQuery types.RequestQuery) (res types.Respon
 	}()
 
 	app.logger.Infof("Query: %s", method)
+	defer func() {
+		addDeprecationWarning(&res, method)
+	}()
+	cacheHit := false
+	if app.queryAccessLogEnabled {
+		defer func() {
+			app.logQueryAccess(method, param, res, time.Since(startTime), cacheHit)
+		}()
+	}
+
+	if method == "" {
+		return app.ReturnQueryWithCode(code.UnknownMethod, nil, "method can't be empty", app.state.Height)
+	}
+	if !IsQueryMethod[method] {
+		return app.returnUnknownQueryMethod(method)
+	}
+
+	if !app.queryLimiter.allow(method) {
+		return app.ReturnQueryWithCode(code.QueryRateLimitExceeded, nil, "Query rate limit exceeded", app.state.Height)
+	}
+	if !app.queryLimiter.acquire() {
+		return app.ReturnQueryWithCode(code.TooManyConcurrentQueries, nil, "Too many concurrent queries", app.state.Height)
+	}
+	defer app.queryLimiter.release()
+
+	if reqQuery.Height > 0 && app.pruner.isHeightPruned(reqQuery.Height) {
+		return app.ReturnQueryWithCode(code.HeightPruned, nil, "Height is pruned", app.state.Height)
+	}
+
+	// Result of query at latest height is cached by requested height 0
+	// since it is still valid after commit if keys read by the query are not changed.
+	// Signed query is never served from cache so that its nonce is always checked.
+	cachedResult, exist := types.ResponseQuery{}, false
+	if method != "SignedQuery" {
+		cachedResult, exist = app.queryCache.get(method, param, reqQuery.Height)
+	}
+	if exist {
+		app.logger.Debugf("Found cached query result")
+		cacheHit = true
+		cachedResult.Info = app.getQueryInfo()
+		cachedResult.Height = app.state.Height
+		return cachedResult
+	}
 
 	height := reqQuery.Height
 	if height == 0 {
 		height = app.state.Height
 	}
 
-	if method == "" {
-		return app.ReturnQuery(nil, "method can't be empty", app.state.Height)
-	}
-	return app.QueryRouter(method, param, height)
+	app.state.StartRecordingReads()
+	app.state.profiler.setMethod("Query:" + method)
+	handlerStartTime := time.Now()
+	result := app.QueryRouter(method, param, height)
+	app.checkHandlerBudget("Query", method, param, time.Since(handlerStartTime))
+	keyPrefixes := app.state.StopRecordingReads()
+	app.queryCache.set(method, param, reqQuery.Height, result, keyPrefixes)
+	return result
 }
 
 func getEnv(key, defaultValue string) string {
@@ -384,6 +677,40 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// parseTx decodes Tx envelope for both CheckTx and DeliverTx. Tx must be in canonical
+// encoding (the same bytes as deterministic marshal of decoded Tx) without unknown fields
+// so that Tx accepted by CheckTx can't be altered into different bytes with the same content.
+// Nonce must be in canonical format and signature must not be empty.
+// Tx may be wrapped in base64url envelope (see utils.DecodeEnvelope).
+func parseTx(tx []byte) (txObj protoTm.Tx, err error) {
+	tx, err = utils.DecodeEnvelope(tx)
+	if err != nil {
+		return txObj, err
+	}
+	err = proto.Unmarshal(tx, &txObj)
+	if err != nil {
+		return txObj, err
+	}
+	if len(txObj.XXX_unrecognized) > 0 {
+		return txObj, fmt.Errorf("Transaction has unknown fields")
+	}
+	canonicalTx, err := utils.ProtoDeterministicMarshal(&txObj)
+	if err != nil {
+		return txObj, err
+	}
+	if !bytes.Equal(tx, canonicalTx) {
+		return txObj, fmt.Errorf("Transaction is not canonically encoded")
+	}
+	err = utils.ValidateNonce(string(txObj.Nonce))
+	if err != nil {
+		return txObj, err
+	}
+	if len(txObj.Signature) == 0 {
+		return txObj, fmt.Errorf("Signature can't be empty")
+	}
+	return txObj, nil
+}
+
 func identifyPanic() string {
 	var name, file string
 	var line int
@@ -0,0 +1,160 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/abci/version"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// Versions of app hash scheme. Scheme of block is the version with latest activation height
+// not greater than block height, legacy scheme when no version is activated.
+const (
+	// appHashSchemeLegacy hashes previous app hash followed by changes of block
+	appHashSchemeLegacy int64 = 1
+	// appHashSchemeDomainSeparated prefixes hashed material of legacy scheme with domain tag,
+	// scheme version, state schema version and block height
+	appHashSchemeDomainSeparated int64 = 2
+
+	latestAppHashScheme = appHashSchemeDomainSeparated
+)
+
+// appHashDomain separates app hash from other hashes (e.g. block seed)
+const appHashDomain = "ndid-app-hash"
+
+// computeAppHash returns app hash of block at height with changes of block in hashData
+func computeAppHash(scheme int64, previousAppHash []byte, hashData []byte, height int64) []byte {
+	if scheme == appHashSchemeLegacy {
+		return hash(append(append([]byte(nil), previousAppHash...), hashData...))
+	}
+	header := make([]byte, 24)
+	binary.BigEndian.PutUint64(header[0:8], uint64(scheme))
+	binary.BigEndian.PutUint64(header[8:16], uint64(version.StateSchemaVersion))
+	binary.BigEndian.PutUint64(header[16:24], uint64(height))
+	hasher := sha256.New()
+	hasher.Write([]byte(appHashDomain))
+	hasher.Write([]byte{0})
+	hasher.Write(header)
+	hasher.Write(previousAppHash)
+	hasher.Write(hashData)
+	return hasher.Sum(nil)
+}
+
+func (app *ABCIApplication) getAppHashSchemeConfig(committedState bool) (data.AppHashSchemeConfig, error) {
+	var config data.AppHashSchemeConfig
+	value, _ := app.state.Get(appHashSchemeConfigKeyBytes, committedState)
+	if value == nil {
+		return config, nil
+	}
+	err := proto.Unmarshal(value, &config)
+	return config, err
+}
+
+// getAppHashScheme returns app hash scheme version of block at height
+func getAppHashScheme(config data.AppHashSchemeConfig, height int64) int64 {
+	scheme := appHashSchemeLegacy
+	for _, activation := range config.ActivationList {
+		if activation.ActivationHeight <= height {
+			scheme = activation.Version
+		}
+	}
+	return scheme
+}
+
+// setAppHashScheme schedules app hash scheme version to be used from activation height.
+// Activation height must be later than current block and every scheduled activation so that
+// every node switches scheme at the same block and scheme of any height is known from state.
+func (app *ABCIApplication) setAppHashScheme(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetAppHashScheme, Parameter: %s", param)
+	var funcParam SetAppHashSchemeParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.Version < appHashSchemeLegacy || funcParam.Version > latestAppHashScheme {
+		return app.ReturnDeliverTxLog(code.InvalidAppHashSchemeVersion, "Unknown app hash scheme version", "")
+	}
+	if funcParam.ActivationHeight <= app.state.CurrentBlockHeight {
+		return app.ReturnDeliverTxLog(code.InvalidActivationHeight, "Activation height must be greater than current block height", "")
+	}
+	config, err := app.getAppHashSchemeConfig(false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	activationCount := len(config.ActivationList)
+	if activationCount > 0 && funcParam.ActivationHeight <= config.ActivationList[activationCount-1].ActivationHeight {
+		return app.ReturnDeliverTxLog(code.InvalidActivationHeight, "Activation height must be greater than activation height of scheduled app hash scheme", "")
+	}
+	config.ActivationList = append(config.ActivationList, &data.AppHashSchemeActivation{
+		Version:          funcParam.Version,
+		ActivationHeight: funcParam.ActivationHeight,
+	})
+	value, err := utils.ProtoDeterministicMarshal(&config)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(appHashSchemeConfigKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+// getAppHashSchemeQuery returns app hash scheme version of block at height
+// (latest committed height when not given) and every scheduled activation
+func (app *ABCIApplication) getAppHashSchemeQuery(param string) types.ResponseQuery {
+	app.logger.Infof("GetAppHashScheme, Parameter: %s", param)
+	var funcParam GetAppHashSchemeParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	config, err := app.getAppHashSchemeConfig(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetAppHashSchemeResult
+	result.Height = funcParam.Height
+	if result.Height <= 0 {
+		result.Height = app.state.Height
+	}
+	result.Version = getAppHashScheme(config, result.Height)
+	result.ActivationList = make([]AppHashSchemeActivation, 0, len(config.ActivationList))
+	for _, activation := range config.ActivationList {
+		result.ActivationList = append(result.ActivationList, AppHashSchemeActivation{
+			Version:          activation.Version,
+			ActivationHeight: activation.ActivationHeight,
+		})
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
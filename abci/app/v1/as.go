@@ -30,6 +30,7 @@ import (
 
 	"github.com/ndidplatform/smart-contract/v4/abci/code"
 	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/client"
 	"github.com/ndidplatform/smart-contract/v4/protos/data"
 )
 
@@ -51,15 +52,15 @@ func (app *ABCIApplication) signData(param string, nodeID string) types.Response
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
-
-	// Check IsClosed
-	if request.Closed {
-		return app.ReturnDeliverTxLog(code.RequestIsClosed, "Request is closed", "")
+	err = app.loadRequestSubRecords(&request, 0, false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
 
-	// Check IsTimedOut
-	if request.TimedOut {
-		return app.ReturnDeliverTxLog(code.RequestIsTimedOut, "Request is timed out", "")
+	// Check request flow state
+	errCode, errLog := checkRequestFlowTransition(&request, "SignData")
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
 	}
 
 	// Check Service ID
@@ -158,8 +159,42 @@ func (app *ABCIApplication) signData(param string, nodeID string) types.Response
 		}
 	}
 
+	// Check data schema version used by AS
+	dataSchemaVersion := service.DataSchemaVersion
+	if signData.DataSchemaVersion != "" && signData.DataSchemaVersion != service.DataSchemaVersion {
+		dataSchemaKey := dataSchemaKeyPrefix + keySeparator + signData.ServiceID + keySeparator + signData.DataSchemaVersion
+		if !app.state.Has([]byte(dataSchemaKey), false) {
+			return app.ReturnDeliverTxLog(code.DataSchemaVersionNotFound, "Data schema version not found", "")
+		}
+		dataSchemaVersion = signData.DataSchemaVersion
+	}
+
+	// Check signature is well-formed for RSA key of AS, RP verifies it against data
+	publicKey, err := client.ParsePublicKey(app.getPublicKeyFromNodeID(nodeID, false))
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.InvalidKeyFormat, err.Error(), "")
+	}
+	_, err = client.DecodeDataSignature(signData.Signature, publicKey)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.InvalidDataSignature, err.Error(), "")
+	}
+
+	// Data signature is stored by AS, service and request, never overwrite stored one
 	signDataKey := dataSignatureKeyPrefix + keySeparator + nodeID + keySeparator + signData.ServiceID + keySeparator + signData.RequestID
-	signDataValue := signData.Signature
+	if app.state.Has([]byte(signDataKey), false) {
+		return app.ReturnDeliverTxLog(code.DataSignatureAlreadyExisted, "Data signature of this AS for this request and service is already existed", "")
+	}
+	var dataSignature data.DataSignature
+	dataSignature.Signature = signData.Signature
+	dataSignature.DataSchemaVersion = dataSchemaVersion
+	dataSignature.BlockHeight = app.state.CurrentBlockHeight
+	dataSignature.BlockTime = app.CurrentBlockTime.Unix()
+	dataSignature.DataHash = signData.DataHash
+	dataSignature.DataContentType = signData.DataContentType
+	signDataValue, err := utils.ProtoDeterministicMarshal(&dataSignature)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
 
 	// Update answered_as_id_list in request
 	for index, dataRequest := range request.DataRequestList {
@@ -167,14 +202,25 @@ func (app *ABCIApplication) signData(param string, nodeID string) types.Response
 			request.DataRequestList[index].AnsweredAsIdList = append(dataRequest.AnsweredAsIdList, nodeID)
 		}
 	}
-
-	requestJSON, err = utils.ProtoDeterministicMarshal(&request)
+	err = app.saveDataRequestStatus(&request, signData.ServiceID)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.emitRequestStatusEvent(&request, requestStatusDataSigned, nodeID, signData.ServiceID)
+	err = app.closeRequestIfCompleted(&request)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
 
-	app.state.SetVersioned([]byte(requestKey), []byte(requestJSON))
 	app.state.Set([]byte(signDataKey), []byte(signDataValue))
+	err = app.increaseStatistics("SignData", signData.ServiceID)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	err = app.increaseServiceUsage(signData.ServiceID, nodeID)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
 	return app.ReturnDeliverTxLog(code.OK, "success", signData.RequestID)
 }
 
@@ -241,6 +287,7 @@ func (app *ABCIApplication) registerServiceDestination(param string, nodeID stri
 	newService.MinIal = funcParam.MinIal
 	newService.Active = true
 	newService.SupportedNamespaceList = funcParam.SupportedNamespaceList
+	newService.SupportedDataUrlTypeList = funcParam.SupportedDataURLTypeList
 	services.Services = append(services.Services, &newService)
 
 	provideServiceJSON, err := utils.ProtoDeterministicMarshal(&services)
@@ -272,6 +319,7 @@ func (app *ABCIApplication) registerServiceDestination(param string, nodeID stri
 		newNode.MinAal = funcParam.MinAal
 		newNode.ServiceId = funcParam.ServiceID
 		newNode.SupportedNamespaceList = funcParam.SupportedNamespaceList
+		newNode.SupportedDataUrlTypeList = funcParam.SupportedDataURLTypeList
 		newNode.Active = true
 		nodes.Node = append(nodes.Node, &newNode)
 		value, err := utils.ProtoDeterministicMarshal(&nodes)
@@ -287,6 +335,7 @@ func (app *ABCIApplication) registerServiceDestination(param string, nodeID stri
 		newNode.MinAal = funcParam.MinAal
 		newNode.ServiceId = funcParam.ServiceID
 		newNode.SupportedNamespaceList = funcParam.SupportedNamespaceList
+		newNode.SupportedDataUrlTypeList = funcParam.SupportedDataURLTypeList
 		newNode.Active = true
 		nodes.Node = append(nodes.Node, &newNode)
 		value, err := utils.ProtoDeterministicMarshal(&nodes)
@@ -345,6 +394,9 @@ func (app *ABCIApplication) updateServiceDestination(param string, nodeID string
 			if len(funcParam.SupportedNamespaceList) > 0 {
 				nodes.Node[index].SupportedNamespaceList = funcParam.SupportedNamespaceList
 			}
+			if len(funcParam.SupportedDataURLTypeList) > 0 {
+				nodes.Node[index].SupportedDataUrlTypeList = funcParam.SupportedDataURLTypeList
+			}
 			break
 		}
 	}
@@ -370,6 +422,9 @@ func (app *ABCIApplication) updateServiceDestination(param string, nodeID string
 			if len(funcParam.SupportedNamespaceList) > 0 {
 				services.Services[index].SupportedNamespaceList = funcParam.SupportedNamespaceList
 			}
+			if len(funcParam.SupportedDataURLTypeList) > 0 {
+				services.Services[index].SupportedDataUrlTypeList = funcParam.SupportedDataURLTypeList
+			}
 			break
 		}
 	}
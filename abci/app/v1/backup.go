@@ -0,0 +1,240 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/storage"
+)
+
+const (
+	// backupDirPrefix is prefix of directory name of backup followed by its height
+	backupDirPrefix = "backup_"
+	// backupBatchSize is max number of keys written to backup in one DB batch
+	backupBatchSize = 10000
+)
+
+// stateBackup takes backup of app state every interval blocks to directory "backup_<height>"
+// in backup directory and deletes old backups except latest retention backups. Backup is
+// goleveldb DB which can be used as src_db_dir of restore. With goleveldb, backup is copied
+// in background from DB snapshot taken right after Commit. With other DB backends, which
+// have no snapshot, backup is copied in Commit so block execution is paused while copying.
+type stateBackup struct {
+	// db is whole DB and prefix is prefix of keys of app state in it (network namespace)
+	db       dbm.DB
+	prefix   []byte
+	stateDB  dbm.DB
+	logger   *logrus.Entry
+	mutex    sync.Mutex
+	interval int64
+	// retention is number of latest backups kept, 0 to keep all backups
+	retention int
+	dir       string
+	running   int32
+}
+
+func newStateBackup(db dbm.DB, networkNamespace string, stateDB dbm.DB, logger *logrus.Entry, interval int64, retention int, dir string) *stateBackup {
+	return &stateBackup{
+		db:        db,
+		prefix:    storage.NetworkNamespaceStatePrefix(networkNamespace),
+		stateDB:   stateDB,
+		logger:    logger,
+		interval:  interval,
+		retention: retention,
+		dir:       dir,
+	}
+}
+
+func (backup *stateBackup) setInterval(interval int64) {
+	backup.mutex.Lock()
+	defer backup.mutex.Unlock()
+	backup.interval = interval
+}
+
+func (backup *stateBackup) setRetention(retention int) {
+	backup.mutex.Lock()
+	defer backup.mutex.Unlock()
+	backup.retention = retention
+}
+
+func (backup *stateBackup) setDir(dir string) {
+	backup.mutex.Lock()
+	defer backup.mutex.Unlock()
+	backup.dir = dir
+}
+
+func (backup *stateBackup) getSettings() (interval int64, retention int, dir string) {
+	backup.mutex.Lock()
+	defer backup.mutex.Unlock()
+	return backup.interval, backup.retention, backup.dir
+}
+
+// notifyCommit takes backup of state of height if it is due. It must be called
+// after state and metadata are saved and before next block is executed.
+func (backup *stateBackup) notifyCommit(height int64) {
+	interval, retention, dir := backup.getSettings()
+	if interval <= 0 || dir == "" || height%interval != 0 {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&backup.running, 0, 1) {
+		backup.logger.Warnf("Skipped backup at height %d, previous backup is still running", height)
+		return
+	}
+	if goLevelDB, ok := backup.db.(*dbm.GoLevelDB); ok {
+		snapshot, err := goLevelDB.DB().GetSnapshot()
+		if err == nil {
+			go func() {
+				defer atomic.StoreInt32(&backup.running, 0)
+				defer snapshot.Release()
+				var keyRange *util.Range
+				if len(backup.prefix) > 0 {
+					keyRange = util.BytesPrefix(backup.prefix)
+				}
+				backup.write(height, dir, retention, func(fn func(key, value []byte)) error {
+					itr := snapshot.NewIterator(keyRange, nil)
+					defer itr.Release()
+					for itr.Next() {
+						fn(itr.Key()[len(backup.prefix):], itr.Value())
+					}
+					return itr.Error()
+				})
+			}()
+			return
+		}
+		backup.logger.Errorf("Error getting DB snapshot for backup, copy backup without snapshot: %s", err.Error())
+	}
+	defer atomic.StoreInt32(&backup.running, 0)
+	backup.write(height, dir, retention, func(fn func(key, value []byte)) error {
+		itr := backup.stateDB.Iterator(nil, nil)
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			fn(itr.Key(), itr.Value())
+		}
+		return nil
+	})
+}
+
+// write copies keys given by iterate to new backup of height and deletes old backups.
+// Backup is copied to temporary directory and renamed when it is done
+// so that incomplete backup is never used.
+func (backup *stateBackup) write(height int64, dir string, retention int, iterate func(fn func(key, value []byte)) error) {
+	startTime := time.Now()
+	path := filepath.Join(dir, backupDirPrefix+strconv.FormatInt(height, 10))
+	tmpPath := path + ".tmp"
+	err := os.RemoveAll(tmpPath)
+	if err != nil {
+		backup.logger.Errorf("Error removing incomplete backup %s: %s", tmpPath, err.Error())
+		return
+	}
+	backupDB, err := storage.OpenDB(string(dbm.GoLevelDBBackend), tmpPath)
+	if err != nil {
+		backup.logger.Errorf("Error creating backup %s: %s", tmpPath, err.Error())
+		return
+	}
+	batch := backupDB.NewBatch()
+	var count int
+	err = iterate(func(key, value []byte) {
+		batch.Set(key, value)
+		count++
+		if count%backupBatchSize == 0 {
+			batch.Write()
+			batch.Close()
+			batch = backupDB.NewBatch()
+		}
+	})
+	batch.WriteSync()
+	batch.Close()
+	if err == nil {
+		RecomputeStateStats(backupDB)
+	}
+	backupDB.Close()
+	if err != nil {
+		backup.logger.Errorf("Error copying backup at height %d: %s", height, err.Error())
+		return
+	}
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		backup.logger.Errorf("Error renaming backup %s: %s", tmpPath, err.Error())
+		return
+	}
+	duration := time.Since(startTime)
+	backup.logger.Infof("Backup at height %d (%d keys) is written to %s in %s", height, count, path, duration)
+	recordBackupMetrics(height, duration)
+	backup.rotate(dir, retention)
+}
+
+// rotate deletes backups in dir except latest retention backups
+func (backup *stateBackup) rotate(dir string, retention int) {
+	if retention <= 0 {
+		return
+	}
+	heights, err := listBackupHeights(dir)
+	if err != nil {
+		backup.logger.Errorf("Error reading backup directory %s: %s", dir, err.Error())
+		return
+	}
+	for index := retention; index < len(heights); index++ {
+		path := filepath.Join(dir, fmt.Sprintf("%s%d", backupDirPrefix, heights[index]))
+		err = os.RemoveAll(path)
+		if err != nil {
+			backup.logger.Errorf("Error deleting old backup %s: %s", path, err.Error())
+			continue
+		}
+		backup.logger.Infof("Deleted old backup %s", path)
+	}
+}
+
+// listBackupHeights returns heights of complete backups in dir, latest first
+func listBackupHeights(dir string) ([]int64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	heights := make([]int64, 0, len(files))
+	for _, file := range files {
+		if !file.IsDir() || !strings.HasPrefix(file.Name(), backupDirPrefix) {
+			continue
+		}
+		height, err := strconv.ParseInt(strings.TrimPrefix(file.Name(), backupDirPrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	return heights, nil
+}
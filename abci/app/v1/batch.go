@@ -0,0 +1,131 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+// isNotAllowedInBatchMethod is list of methods which cannot be sub-Tx of Batch.
+// Batch Tx is signed with node key so methods which require other key cannot be in batch.
+var isNotAllowedInBatchMethod = map[string]bool{
+	"InitNDID": true,
+	"Batch":    true,
+}
+
+func getBatchParam(param string) (BatchParam, uint32, string) {
+	var funcParam BatchParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return funcParam, code.UnmarshalError, err.Error()
+	}
+	if len(funcParam.TxList) == 0 {
+		return funcParam, code.BatchTxListCannotBeEmpty, "Batch Tx list cannot be empty"
+	}
+	for _, tx := range funcParam.TxList {
+		if !IsMethod[tx.Method] {
+			return funcParam, code.UnknownMethod, unknownMethodLog(suggestMethod(tx.Method, IsMethod))
+		}
+		if isNotAllowedInBatchMethod[tx.Method] || IsMasterKeyMethod[tx.Method] {
+			return funcParam, code.MethodIsNotAllowedInBatch, "Method is not allowed in batch"
+		}
+	}
+	return funcParam, code.OK, ""
+}
+
+// checkBatch checks every sub-Tx of Batch as if it was sent by itself
+func (app *ABCIApplication) checkBatch(param string, nonce []byte, signature []byte, nodeID string, committedState bool) types.ResponseCheckTx {
+	funcParam, retCode, retLog := getBatchParam(param)
+	if retCode != code.OK {
+		return ReturnCheckTx(retCode, retLog)
+	}
+	for _, tx := range funcParam.TxList {
+		result := app.CheckTxRouter(tx.Method, string(tx.Params), nonce, signature, nodeID, committedState)
+		if result.Code != code.OK {
+			return result
+		}
+	}
+	return ReturnCheckTx(code.OK, "")
+}
+
+// getBatchTokenPrice returns sum of token price of every sub-Tx of Batch
+func (app *ABCIApplication) getBatchTokenPrice(param string, nodeID string, committedState bool) float64 {
+	var funcParam BatchParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.getTokenPriceByFunc("Batch", committedState)
+	}
+	var price float64
+	for _, tx := range funcParam.TxList {
+		price += app.getTxTokenPrice(tx.Method, string(tx.Params), nodeID, committedState)
+	}
+	return price
+}
+
+func (app *ABCIApplication) batch(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("Batch, Parameter: %s", param)
+	funcParam, retCode, retLog := getBatchParam(param)
+	if retCode != code.OK {
+		return app.ReturnDeliverTxLog(retCode, retLog, "")
+	}
+	snapshot := app.state.Snapshot()
+	valUpdates := app.copyValUpdates()
+	eventCount := len(app.deliverTxEvents)
+	for index, tx := range funcParam.TxList {
+		checkTxResult := app.CheckTxRouter(tx.Method, string(tx.Params), nil, nil, nodeID, false)
+		var result types.ResponseDeliverTx
+		if checkTxResult.Code != code.OK {
+			result = app.ReturnDeliverTxLog(checkTxResult.Code, checkTxResult.Log, "")
+		} else if quotaCode, quotaLog := app.checkNodeQuota(tx.Method, nodeID); quotaCode != code.OK {
+			result = app.ReturnDeliverTxLog(quotaCode, quotaLog, "")
+		} else {
+			result = app.callDeliverTxOrSchedule(tx.Method, string(tx.Params), nodeID)
+			if result.Code == code.OK {
+				app.increaseNodeQuotaUsage(tx.Method, nodeID)
+			}
+		}
+		if result.Code != code.OK {
+			app.state.RevertToSnapshot(snapshot)
+			app.valUpdates = valUpdates
+			// Drop events of reverted sub-Txs
+			app.deliverTxEvents = app.deliverTxEvents[:eventCount]
+			// Add index and method of failed sub-Tx
+			var attributes []cmn.KVPair
+			var attribute cmn.KVPair
+			attribute.Key = []byte("batch_index")
+			attribute.Value = []byte(strconv.Itoa(index))
+			attributes = append(attributes, attribute)
+			attribute.Key = []byte("batch_method")
+			attribute.Value = []byte(tx.Method)
+			attributes = append(attributes, attribute)
+			return app.ReturnDeliverTxLogWithAttributes(result.Code, result.Log, attributes)
+		}
+	}
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
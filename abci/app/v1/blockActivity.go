@@ -0,0 +1,125 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+func getBlockActivityKey(height int64) []byte {
+	return []byte(blockActivityKeyPrefix + keySeparator + strconv.FormatInt(height, 10))
+}
+
+// recordTxActivity records method, result code, request ID in parameter and salted hash of
+// parameter of Tx delivered in current block. It is saved with state at commit and is not
+// part of app hash.
+func (appState *AppState) recordTxActivity(method string, nodeID string, param string, chainID string, resultCode uint32) {
+	var requestIDParam struct {
+		RequestID string `json:"request_id"`
+	}
+	// Request ID is left empty when parameter has none or is invalid
+	json.Unmarshal([]byte(param), &requestIDParam)
+	appState.txActivity = append(appState.txActivity, &data.TxActivity{
+		Method:    method,
+		NodeId:    nodeID,
+		Code:      resultCode,
+		RequestId: requestIDParam.RequestID,
+		ParamHash: utils.CanonicalParamHash(chainID, param),
+	})
+}
+
+// getBlockActivity returns Txs delivered in committed block at given height with their
+// result code and request IDs changed in the block (from change journal)
+func (app *ABCIApplication) getBlockActivity(param string) types.ResponseQuery {
+	app.logger.Infof("GetBlockActivity, Parameter: %s", param)
+	var funcParam GetBlockActivityParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if funcParam.Height <= 0 || funcParam.Height > app.state.Height {
+		return app.ReturnQueryWithCode(code.ResultNotFound, nil, "not found", app.state.Height)
+	}
+	if app.pruner.isHeightPruned(funcParam.Height) {
+		return app.ReturnQueryWithCode(code.HeightPruned, nil, "Height is pruned", app.state.Height)
+	}
+	var result GetBlockActivityResult
+	result.Height = funcParam.Height
+	result.TxList = make([]TxActivity, 0)
+	result.RequestIDList = make([]string, 0)
+	// No activity means no Tx is delivered in the block
+	activityValue, _ := app.state.Get(getBlockActivityKey(funcParam.Height), true)
+	if activityValue != nil {
+		var activity data.BlockActivity
+		err = proto.Unmarshal(activityValue, &activity)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+		for _, tx := range activity.TxList {
+			result.TxList = append(result.TxList, TxActivity{
+				Method:    tx.Method,
+				NodeID:    tx.NodeId,
+				Code:      tx.Code,
+				RequestID: tx.RequestId,
+				ParamHash: tx.ParamHash,
+			})
+		}
+	}
+	// Requests changed by Txs or at end of block (e.g. timed out) are found in change journal
+	journalValue, _ := app.state.Get(getChangeJournalKey(funcParam.Height), true)
+	if journalValue != nil {
+		var journal data.ChangeJournal
+		err = proto.Unmarshal(journalValue, &journal)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+		requestPrefix := requestKeyPrefix + keySeparator
+		requestIDs := make(map[string]bool)
+		for _, change := range journal.Changes {
+			if !strings.HasPrefix(change.Key, requestPrefix) {
+				continue
+			}
+			requestID := strings.SplitN(strings.TrimPrefix(change.Key, requestPrefix), keySeparator, 2)[0]
+			requestIDs[requestID] = true
+		}
+		for requestID := range requestIDs {
+			result.RequestIDList = append(result.RequestIDList, requestID)
+		}
+		sort.Strings(result.RequestIDList)
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,81 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+func (app *ABCIApplication) getBlockWriteLimitFromStateDB(committedState bool) int64 {
+	value, _ := app.state.Get(blockWriteLimitKeyBytes, committedState)
+	if value == nil {
+		return 0
+	}
+	var limit data.BlockWriteLimit
+	err := proto.Unmarshal(value, &limit)
+	if err != nil {
+		return 0
+	}
+	return limit.MaxWriteCount
+}
+
+// setBlockWriteLimit sets max number of state keys which Txs of a block may write in total
+// so that Commit latency stays bounded. Tx which writes keys over remaining budget of block
+// fails and its changes are discarded. New limit is applied from next block, 0 for no limit.
+func (app *ABCIApplication) setBlockWriteLimit(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetBlockWriteLimit, Parameter: %s", param)
+	var funcParam BlockWriteLimitParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.MaxWriteCount < 0 {
+		return app.ReturnDeliverTxLog(code.ThresholdMustBeGreaterOrEqualToZero, "Max write count must be greater than or equal to zero", "")
+	}
+	var limit data.BlockWriteLimit
+	limit.MaxWriteCount = funcParam.MaxWriteCount
+	value, err := utils.ProtoDeterministicMarshal(&limit)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(blockWriteLimitKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getBlockWriteLimit(param string) types.ResponseQuery {
+	app.logger.Infof("GetBlockWriteLimit, Parameter: %s", param)
+	var result BlockWriteLimitParam
+	result.MaxWriteCount = app.getBlockWriteLimitFromStateDB(true)
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,126 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+)
+
+// defaultBudgetBreakerThreshold is default number of consecutive executions of handler
+// over its time budget which opens its circuit breaker
+const defaultBudgetBreakerThreshold = 3
+
+type handlerBreaker struct {
+	overrunCount int
+	open         bool
+}
+
+// handlerBudget keeps execution time budget (milliseconds) of DeliverTx and query handlers
+// by method where "*" is budget of each method without its own budget. Circuit breaker of
+// handler is opened when it exceeds budget for threshold consecutive times and closed
+// when it runs within budget again. Open breaker only logs and is reported in metrics,
+// handler is still executed so result of block is the same on every node. Zero values
+// mean no budget.
+type handlerBudget struct {
+	mutex     sync.Mutex
+	budgets   map[string]int64
+	threshold int
+	breakers  map[string]*handlerBreaker
+}
+
+func newHandlerBudget() *handlerBudget {
+	return &handlerBudget{
+		budgets:   make(map[string]int64),
+		threshold: defaultBudgetBreakerThreshold,
+		breakers:  make(map[string]*handlerBreaker),
+	}
+}
+
+// setBudgets replaces time budgets (milliseconds) by method
+func (budget *handlerBudget) setBudgets(budgets map[string]int64) {
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	budget.budgets = budgets
+	for key := range budget.breakers {
+		recordHandlerBreakerMetrics(key, false)
+	}
+	budget.breakers = make(map[string]*handlerBreaker)
+}
+
+func (budget *handlerBudget) setThreshold(threshold int) {
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	budget.threshold = threshold
+}
+
+// observe records execution duration of handler of method called by call (DeliverTx or Query).
+// It returns budget and whether breaker is opened or closed by this execution.
+func (budget *handlerBudget) observe(call string, method string, duration time.Duration) (limit time.Duration, opened bool, closed bool) {
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	budgetMs, exist := budget.budgets[method]
+	if !exist {
+		budgetMs = budget.budgets[queryRateLimitAllMethods]
+	}
+	if budgetMs <= 0 {
+		return 0, false, false
+	}
+	limit = time.Duration(budgetMs) * time.Millisecond
+	key := call + "|" + method
+	breaker, exist := budget.breakers[key]
+	if !exist {
+		breaker = &handlerBreaker{}
+		budget.breakers[key] = breaker
+	}
+	if duration <= limit {
+		breaker.overrunCount = 0
+		if breaker.open {
+			breaker.open = false
+			recordHandlerBreakerMetrics(key, false)
+			return limit, false, true
+		}
+		return limit, false, false
+	}
+	recordHandlerBudgetExceededMetrics(call, method)
+	breaker.overrunCount++
+	if !breaker.open && breaker.overrunCount >= budget.threshold {
+		breaker.open = true
+		recordHandlerBreakerMetrics(key, true)
+		return limit, true, false
+	}
+	return limit, false, false
+}
+
+// checkHandlerBudget logs when circuit breaker of handler is opened or closed
+func (app *ABCIApplication) checkHandlerBudget(call string, method string, param string, duration time.Duration) {
+	limit, opened, closed := app.handlerBudget.observe(call, method, duration)
+	if opened {
+		app.logger.Errorf("Circuit breaker of %s %s is open: exceeded time budget %s consecutively, last duration: %s, height: %d, parameter hash: %s",
+			call, method, limit, duration, app.state.CurrentBlockHeight, utils.CanonicalParamHash(app.CurrentChain, param))
+	} else if closed {
+		app.logger.Infof("Circuit breaker of %s %s is closed: duration %s is within time budget %s", call, method, duration, limit)
+	}
+}
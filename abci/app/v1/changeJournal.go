@@ -0,0 +1,84 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+const (
+	keyChangeOperationSet    = "set"
+	keyChangeOperationDelete = "delete"
+)
+
+// getChangesAtHeight returns keys changed in committed block at given height
+// so that indexer can sync changes incrementally
+func (app *ABCIApplication) getChangesAtHeight(param string) types.ResponseQuery {
+	app.logger.Infof("GetChangesAtHeight, Parameter: %s", param)
+	var funcParam GetChangesAtHeightParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if funcParam.Height <= 0 || funcParam.Height > app.state.Height {
+		return app.ReturnQueryWithCode(code.ResultNotFound, nil, "not found", app.state.Height)
+	}
+	if app.pruner.isHeightPruned(funcParam.Height) {
+		return app.ReturnQueryWithCode(code.HeightPruned, nil, "Height is pruned", app.state.Height)
+	}
+	var result GetChangesAtHeightResult
+	result.Height = funcParam.Height
+	result.Changes = make([]KeyChange, 0)
+	journalValue, _ := app.state.Get(getChangeJournalKey(funcParam.Height), true)
+	// No journal means no key is changed in the block
+	if journalValue != nil {
+		var journal data.ChangeJournal
+		err = proto.Unmarshal(journalValue, &journal)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+		for _, change := range journal.Changes {
+			var keyChange KeyChange
+			keyChange.Key = change.Key
+			if change.Deleted {
+				keyChange.Operation = keyChangeOperationDelete
+			} else {
+				keyChange.Operation = keyChangeOperationSet
+				keyChange.ValueHash = hex.EncodeToString(change.ValueHash)
+			}
+			result.Changes = append(result.Changes, keyChange)
+		}
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
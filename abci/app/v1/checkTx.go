@@ -28,7 +28,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/x509"
-	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
@@ -39,14 +38,17 @@ import (
 	"github.com/tendermint/tendermint/abci/types"
 
 	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/client"
 	"github.com/ndidplatform/smart-contract/v4/protos/data"
 )
 
 var IsMethod = map[string]bool{
 	"InitNDID":                         true,
 	"RegisterNode":                     true,
+	"RegisterNodeBatch":                true,
 	"AddNodeToken":                     true,
 	"ReduceNodeToken":                  true,
+	"RefundToken":                      true,
 	"SetNodeToken":                     true,
 	"SetPriceFunc":                     true,
 	"AddNamespace":                     true,
@@ -73,7 +75,9 @@ var IsMethod = map[string]bool{
 	"UpdateServiceDestination":         true,
 	"CreateRequest":                    true,
 	"SetMqAddresses":                   true,
+	"SetSupportedFeatureList":          true,
 	"UpdateNode":                       true,
+	"SetNodeContact":                   true,
 	"CloseRequest":                     true,
 	"TimeOutRequest":                   true,
 	"SetDataReceived":                  true,
@@ -86,15 +90,47 @@ var IsMethod = map[string]bool{
 	"RemoveNodeFromProxyNode":          true,
 	"RevokeAccessor":                   true,
 	"SetInitData":                      true,
+	"SetChainHistoryInfo":              true,
 	"EndInit":                          true,
 	"SetLastBlock":                     true,
 	"RevokeIdentityAssociation":        true,
 	"UpdateIdentityModeList":           true,
+	"UpgradeIdentityMode":              true,
 	"AddIdentity":                      true,
 	"SetAllowedModeList":               true,
 	"UpdateNamespace":                  true,
 	"SetAllowedMinIalForRegisterIdentityAtFirstIdp": true,
 	"RevokeAndAddAccessor":                          true,
+	"RegisterIdentityAndCreateIdpResponse":          true,
+	"AnchorConsentReceipt":                          true,
+	"Batch":                                         true,
+	"SetNodeQuota":                                  true,
+	"SetMaxRequestTimeoutExtension":                 true,
+	"SetRequestListSizeLimit":                       true,
+	"SetRandomnessBeaconConfig":                     true,
+	"SetAppHashScheme":                              true,
+	"SetValidatorNode":                              true,
+	"SetRequestEscrowPrice":                         true,
+	"SetLowTokenThreshold":                          true,
+	"SetAdminApprovalPolicy":                        true,
+	"CreateAdminProposal":                           true,
+	"ApproveAdminProposal":                          true,
+	"SetGovernanceActionDelay":                      true,
+	"CancelGovernanceAction":                        true,
+	"SetMethodPaused":                               true,
+	"SetValidatorPowerPolicy":                       true,
+	"SetValidatorMissThreshold":                     true,
+	"SetNodeIDAlias":                                true,
+	"RebuildIndexes":                                true,
+	"SetBlockWriteLimit":                            true,
+	"SetRequestMessageHashCheckHeight":              true,
+	"SetRequestPriorityClassList":                   true,
+	"SetQueryVisibility":                            true,
+	"SetDataRetentionPolicy":                        true,
+	"SetEndBlockHookEnabled":                        true,
+	"AddNodeRole":                                   true,
+	"RemoveNodeRole":                                true,
+	"ExtendRequestTimeout":                          true,
 }
 
 func (app *ABCIApplication) checkTxInitNDID(param string, nodeID string) types.ResponseCheckTx {
@@ -116,6 +152,7 @@ func (app *ABCIApplication) checkTxSetMqAddresses(param string, nodeID string) t
 	}
 	if string(node.Role) != "RP" &&
 		string(node.Role) != "IdP" &&
+		string(node.Role) != "IdPAgent" &&
 		string(node.Role) != "AS" &&
 		string(node.Role) != "Proxy" {
 		return ReturnCheckTx(code.NoPermissionForSetMqAddresses, "This node does not have permission to set MQ addresses")
@@ -123,6 +160,24 @@ func (app *ABCIApplication) checkTxSetMqAddresses(param string, nodeID string) t
 	return ReturnCheckTx(code.OK, "")
 }
 
+func (app *ABCIApplication) checkTxSetSupportedFeatureList(param string, nodeID string) types.ResponseCheckTx {
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
+	value, _ := app.state.Get([]byte(nodeDetailKey), true)
+	var node data.NodeDetail
+	err := proto.Unmarshal(value, &node)
+	if err != nil {
+		return ReturnCheckTx(code.UnmarshalError, err.Error())
+	}
+	if string(node.Role) != "RP" &&
+		string(node.Role) != "IdP" &&
+		string(node.Role) != "IdPAgent" &&
+		string(node.Role) != "AS" &&
+		string(node.Role) != "Proxy" {
+		return ReturnCheckTx(code.NoPermissionForSetSupportedFeatureList, "This node does not have permission to set supported feature list")
+	}
+	return ReturnCheckTx(code.OK, "")
+}
+
 func (app *ABCIApplication) checkNDID(param string, nodeID string, committedState bool) bool {
 	nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
 	value, _ := app.state.Get([]byte(nodeDetailKey), committedState)
@@ -145,7 +200,21 @@ func (app *ABCIApplication) checkIdP(param string, nodeID string) bool {
 	if err != nil {
 		return false
 	}
-	if node.Role != "IdP" {
+	if !hasRole(&node, "IdP") {
+		return false
+	}
+	return true
+}
+
+func (app *ABCIApplication) checkIdPorIdPAgent(param string, nodeID string) bool {
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
+	value, _ := app.state.Get([]byte(nodeDetailKey), true)
+	var node data.NodeDetail
+	err := proto.Unmarshal(value, &node)
+	if err != nil {
+		return false
+	}
+	if !hasRole(&node, "IdP") && node.Role != "IdPAgent" {
 		return false
 	}
 	return true
@@ -159,7 +228,7 @@ func (app *ABCIApplication) checkAS(param string, nodeID string) bool {
 	if err != nil {
 		return false
 	}
-	if node.Role != "AS" {
+	if !hasRole(&node, "AS") {
 		return false
 	}
 	return true
@@ -173,7 +242,7 @@ func (app *ABCIApplication) checkIdPorRP(param string, nodeID string) bool {
 	if err != nil {
 		return false
 	}
-	if node.Role != "IdP" && node.Role != "RP" {
+	if !hasRole(&node, "IdP") && !hasRole(&node, "RP") {
 		return false
 	}
 	return true
@@ -195,6 +264,14 @@ func (app *ABCIApplication) checkIsIDP(param string, nodeID string) types.Respon
 	return ReturnCheckTx(code.OK, "")
 }
 
+func (app *ABCIApplication) checkIsIdPorIdPAgent(param string, nodeID string) types.ResponseCheckTx {
+	ok := app.checkIdPorIdPAgent(param, nodeID)
+	if ok == false {
+		return ReturnCheckTx(code.NoPermissionForCallIdPOrIdPAgentMethod, "This node does not have permission to call IdP or IdP agent method")
+	}
+	return ReturnCheckTx(code.OK, "")
+}
+
 func (app *ABCIApplication) checkIsAS(param string, nodeID string) types.ResponseCheckTx {
 	ok := app.checkAS(param, nodeID)
 	if ok == false {
@@ -243,9 +320,7 @@ func verifySignature(param string, nonce []byte, signature []byte, publicKey str
 	if err != nil {
 		return false, err
 	}
-	tempPSSmessage := append([]byte(method), []byte(param)...)
-	tempPSSmessage = append(tempPSSmessage, []byte(nonce)...)
-	PSSmessage := []byte(base64.StdEncoding.EncodeToString(tempPSSmessage))
+	PSSmessage := client.SigningPayload(method, []byte(param), string(nonce))
 	newhash := crypto.SHA256
 	pssh := newhash.New()
 	pssh.Write(PSSmessage)
@@ -273,7 +348,7 @@ func (app *ABCIApplication) getNodePublicKeyForSignatureVerification(method stri
 		if publicKey == "" {
 			return publicKey, code.CannotGetPublicKeyFromParam, "Can not get public key from parameter"
 		}
-	} else if method == "UpdateNode" {
+	} else if IsMasterKeyMethod[method] {
 		publicKey = app.getMasterPublicKeyFromNodeID(nodeID, committedState)
 		if publicKey == "" {
 			return publicKey, code.CannotGetMasterPublicKeyFromNodeID, "Can not get master public key from node ID"
@@ -388,6 +463,24 @@ func checkNodePubKeys(param string) (returnCode uint32, log string) {
 	return code.OK, ""
 }
 
+func (app *ABCIApplication) checkNodePubKeysNotRevoked(param string, committedState bool) (returnCode uint32, log string) {
+	var keys struct {
+		MasterPublicKey string `json:"master_public_key"`
+		PublicKey       string `json:"public_key"`
+	}
+	err := json.Unmarshal([]byte(param), &keys)
+	if err != nil {
+		return code.UnmarshalError, err.Error()
+	}
+	if keys.MasterPublicKey != "" && app.isRevokedPublicKey(keys.MasterPublicKey, committedState) {
+		return code.PublicKeyIsRevoked, "Master public key has been revoked"
+	}
+	if keys.PublicKey != "" && app.isRevokedPublicKey(keys.PublicKey, committedState) {
+		return code.PublicKeyIsRevoked, "Public key has been revoked"
+	}
+	return code.OK, ""
+}
+
 func checkAccessorPubKey(param string) (returnCode uint32, log string) {
 	var key struct {
 		AccessorPublicKey string `json:"accessor_public_key"`
@@ -404,13 +497,59 @@ func checkAccessorPubKey(param string) (returnCode uint32, log string) {
 }
 
 var IsCheckOwnerRequestMethod = map[string]bool{
-	"CloseRequest":    true,
-	"TimeOutRequest":  true,
-	"SetDataReceived": true,
+	"CloseRequest":         true,
+	"TimeOutRequest":       true,
+	"SetDataReceived":      true,
+	"ExtendRequestTimeout": true,
 }
 
 var IsMasterKeyMethod = map[string]bool{
-	"UpdateNode": true,
+	"UpdateNode":     true,
+	"SetNodeContact": true,
+}
+
+// IsRequestMethod is list of methods which cannot be done to closed or timed out request
+var IsRequestMethod = map[string]bool{
+	"CreateIdpResponse":                    true,
+	"SignData":                             true,
+	"CloseRequest":                         true,
+	"TimeOutRequest":                       true,
+	"SetDataReceived":                      true,
+	"RegisterIdentityAndCreateIdpResponse": true,
+	"ExtendRequestTimeout":                 true,
+}
+
+// checkRequestIsNotFinished checks that request in Tx param is neither closed nor timed out in committed state
+// so that Tx which is going to fail in DeliverTx is not kept in mempool
+func (app *ABCIApplication) checkRequestIsNotFinished(method string, param string) types.ResponseCheckTx {
+	var requestID string
+	if method == "RegisterIdentityAndCreateIdpResponse" {
+		var funcParam RegisterIdentityAndCreateIdpResponseParam
+		err := json.Unmarshal([]byte(param), &funcParam)
+		if err != nil {
+			return ReturnCheckTx(code.UnmarshalError, err.Error())
+		}
+		requestID = funcParam.CreateIdpResponse.RequestID
+	} else {
+		var funcParam RequestIDParam
+		err := json.Unmarshal([]byte(param), &funcParam)
+		if err != nil {
+			return ReturnCheckTx(code.UnmarshalError, err.Error())
+		}
+		requestID = funcParam.RequestID
+	}
+	requestKey := requestKeyPrefix + keySeparator + requestID
+	requestValue, _ := app.state.GetVersioned([]byte(requestKey), 0, true)
+	if requestValue == nil {
+		return ReturnCheckTx(code.RequestIDNotFound, "Request ID not found")
+	}
+	var request data.Request
+	err := proto.Unmarshal([]byte(requestValue), &request)
+	if err != nil {
+		return ReturnCheckTx(code.UnmarshalError, err.Error())
+	}
+	errCode, errLog := checkRequestFlowTransition(&request, method)
+	return ReturnCheckTx(errCode, errLog)
 }
 
 func (app *ABCIApplication) checkCanCreateTx(committedState bool) types.ResponseCheckTx {
@@ -456,7 +595,8 @@ func (app *ABCIApplication) checkLastBlock(committedState bool) types.ResponseCh
 func (app *ABCIApplication) CheckTxRouter(method string, param string, nonce []byte, signature []byte, nodeID string, committedState bool) types.ResponseCheckTx {
 
 	// ---- Check current block <= last block ----
-	if method != "SetLastBlock" {
+	// Admin proposal Txs are allowed so proposal of SetLastBlock can enable chain again
+	if method != "SetLastBlock" && method != "CreateAdminProposal" && method != "ApproveAdminProposal" {
 		result := app.checkLastBlock(committedState)
 		if result.Code != code.OK {
 			return result
@@ -467,6 +607,13 @@ func (app *ABCIApplication) CheckTxRouter(method string, param string, nonce []b
 	if method == "SetInitData" {
 		return app.checkCanSetInitData(committedState)
 	}
+	if method == "SetChainHistoryInfo" {
+		result := app.checkCanSetInitData(committedState)
+		if result.Code != code.OK {
+			return result
+		}
+		return app.checkIsNDID(param, nodeID)
+	}
 
 	// ---- Check is in init state ----
 	if method != "InitNDID" && method != "EndInit" {
@@ -476,12 +623,26 @@ func (app *ABCIApplication) CheckTxRouter(method string, param string, nonce []b
 		}
 	}
 
+	// ---- Check method is not paused by NDID ----
+	if app.isMethodPaused(method, committedState) {
+		return ReturnCheckTx(code.MethodIsPaused, "Method is paused by NDID")
+	}
+
+	// ---- Check method is not restricted to admin proposal ----
+	if app.isAdminApprovalRequired(method, committedState) {
+		return ReturnCheckTx(code.MethodRequiresAdminApproval, "Method requires approval of NDID operators. Please create admin proposal.")
+	}
+
 	// Check pub key
 	if method == "InitNDID" || method == "RegisterNode" || method == "UpdateNode" {
 		checkCode, log := checkNodePubKeys(param)
 		if checkCode != code.OK {
 			return ReturnCheckTx(checkCode, log)
 		}
+		checkCode, log = app.checkNodePubKeysNotRevoked(param, committedState)
+		if checkCode != code.OK {
+			return ReturnCheckTx(checkCode, log)
+		}
 	} else if method == "RegisterAccessor" || method == "AddAccessor" {
 		checkCode, log := checkAccessorPubKey(param)
 		if checkCode != code.OK {
@@ -531,7 +692,9 @@ func (app *ABCIApplication) CheckTxRouter(method string, param string, nonce []b
 	var result types.ResponseCheckTx
 
 	// special case checkIsOwnerRequest
-	if IsCheckOwnerRequestMethod[method] {
+	if method == "Batch" {
+		result = app.checkBatch(param, nonce, signature, nodeID, committedState)
+	} else if IsCheckOwnerRequestMethod[method] {
 		result = app.checkIsOwnerRequest(param, nodeID, committedState)
 	} else if IsMasterKeyMethod[method] {
 		// If verifyResult is true, return true
@@ -542,7 +705,8 @@ func (app *ABCIApplication) CheckTxRouter(method string, param string, nonce []b
 	// check token for create Tx
 	if result.Code == code.OK {
 		if !app.checkNDID(param, nodeID, committedState) && method != "InitNDID" {
-			needToken := app.getTokenPriceByFunc(method, committedState)
+			needToken := app.getTxTokenPrice(method, param, nodeID, committedState)
+			needToken += app.getTxEscrowAmount(method, param, nodeID, committedState)
 			nodeToken, err := app.getToken(nodeID, committedState)
 			if err != nil {
 				result.Code = code.TokenAccountNotFound
@@ -562,8 +726,10 @@ func (app *ABCIApplication) callCheckTx(name string, param string, nodeID string
 	case "InitNDID":
 		return app.checkTxInitNDID(param, nodeID)
 	case "RegisterNode",
+		"RegisterNodeBatch",
 		"AddNodeToken",
 		"ReduceNodeToken",
+		"RefundToken",
 		"SetNodeToken",
 		"SetPriceFunc",
 		"AddNamespace",
@@ -585,36 +751,69 @@ func (app *ABCIApplication) callCheckTx(name string, param string, nodeID string
 		"UpdateNodeProxyNode",
 		"RemoveNodeFromProxyNode",
 		"SetInitData",
+		"SetChainHistoryInfo",
 		"EndInit",
 		"SetLastBlock",
 		"SetAllowedModeList",
 		"UpdateNamespace",
-		"SetAllowedMinIalForRegisterIdentityAtFirstIdp":
+		"SetAllowedMinIalForRegisterIdentityAtFirstIdp",
+		"SetNodeQuota",
+		"SetMaxRequestTimeoutExtension",
+		"SetRequestListSizeLimit",
+		"SetRandomnessBeaconConfig",
+		"SetAppHashScheme",
+		"SetValidatorNode",
+		"SetRequestEscrowPrice",
+		"SetLowTokenThreshold",
+		"SetAdminApprovalPolicy",
+		"CreateAdminProposal",
+		"ApproveAdminProposal",
+		"SetGovernanceActionDelay",
+		"CancelGovernanceAction",
+		"SetMethodPaused",
+		"SetValidatorPowerPolicy",
+		"SetValidatorMissThreshold",
+		"SetNodeIDAlias",
+		"RebuildIndexes",
+		"SetBlockWriteLimit",
+		"SetRequestMessageHashCheckHeight",
+		"SetRequestPriorityClassList",
+		"SetQueryVisibility",
+		"SetDataRetentionPolicy",
+		"SetEndBlockHookEnabled",
+		"AddNodeRole",
+		"RemoveNodeRole":
 		return app.checkIsNDID(param, nodeID)
 	case "RegisterIdentity",
 		"AddAccessor",
-		"CreateIdpResponse",
 		"RegisterAccessor",
 		"UpdateIdentity",
 		"ClearRegisterIdentityTimeout",
 		"RevokeAccessor",
 		"RevokeIdentityAssociation",
 		"UpdateIdentityModeList",
+		"UpgradeIdentityMode",
 		"AddIdentity",
-		"RevokeAndAddAccessor":
+		"RevokeAndAddAccessor",
+		"RegisterIdentityAndCreateIdpResponse":
 		return app.checkIsIDP(param, nodeID)
+	case "CreateIdpResponse":
+		return app.checkIsIdPorIdPAgent(param, nodeID)
 	case "SignData",
 		"RegisterServiceDestination",
 		"UpdateServiceDestination",
 		"DisableServiceDestination",
 		"EnableServiceDestination":
 		return app.checkIsAS(param, nodeID)
-	case "CreateRequest":
+	case "CreateRequest",
+		"AnchorConsentReceipt":
 		return app.checkIsRPorIdP(param, nodeID)
 	case "SetMqAddresses":
 		return app.checkTxSetMqAddresses(param, nodeID)
+	case "SetSupportedFeatureList":
+		return app.checkTxSetSupportedFeatureList(param, nodeID)
 	default:
-		return types.ResponseCheckTx{Code: code.UnknownMethod, Log: "Unknown method name"}
+		return returnUnknownMethodCheckTx(name)
 	}
 }
 
@@ -23,13 +23,23 @@
 package app
 
 import (
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/tendermint/tendermint/abci/types"
 
 	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/keys"
 	"github.com/ndidplatform/smart-contract/v4/abci/utils"
 	"github.com/ndidplatform/smart-contract/v4/protos/data"
 )
@@ -42,34 +52,83 @@ var modeFunctionMap = map[string]bool{
 	"RevokeIdentityAssociation": true,
 	"UpdateIdentityModeList":    true,
 	"RevokeAndAddAccessor":      true,
+	"UpgradeIdentityMode":       true,
 }
 
 var (
-	masterNDIDKeyBytes   = []byte("MasterNDID")
-	initStateKeyBytes    = []byte("InitState")
-	lastBlockKeyBytes    = []byte("lastBlock")
-	idpListKeyBytes      = []byte("IdPList")
-	allNamespaceKeyBytes = []byte("AllNamespace")
+	masterNDIDKeyBytes   = []byte(keys.MasterNDIDKey)
+	initStateKeyBytes    = []byte(keys.InitStateKey)
+	lastBlockKeyBytes    = []byte(keys.LastBlockKey)
+	idpListKeyBytes      = []byte(keys.IdPListKey)
+	allNamespaceKeyBytes = []byte(keys.AllNamespaceKey)
+
+	maxRequestTimeoutExtensionKeyBytes = []byte(keys.MaxRequestTimeoutExtensionKey)
+	requestEscrowPriceKeyBytes         = []byte(keys.RequestEscrowPriceKey)
+	lowTokenThresholdKeyBytes          = []byte(keys.LowTokenThresholdKey)
+	adminApprovalPolicyKeyBytes        = []byte(keys.AdminApprovalPolicyKey)
+	governanceActionDelayKeyBytes      = []byte(keys.GovernanceActionDelayKey)
+	validatorPowerPolicyKeyBytes       = []byte(keys.ValidatorPowerPolicyKey)
+	validatorMissThresholdKeyBytes     = []byte(keys.ValidatorMissThresholdKey)
+	blockWriteLimitKeyBytes            = []byte(keys.BlockWriteLimitKey)
+	requestHashCheckHeightKeyBytes     = []byte(keys.RequestMessageHashCheckHeightKey)
+	requestPriorityClassListKeyBytes   = []byte(keys.RequestPriorityClassListKey)
+	dataRetentionPolicyKeyBytes        = []byte(keys.DataRetentionPolicyKey)
+	previousChainListKeyBytes          = []byte(keys.PreviousChainListKey)
+	endBlockHookFlagListKeyBytes       = []byte(keys.EndBlockHookFlagListKey)
+	requestListSizeLimitKeyBytes       = []byte(keys.RequestListSizeLimitKey)
+	randomnessBeaconConfigKeyBytes     = []byte(keys.RandomnessBeaconConfigKey)
+	appHashSchemeConfigKeyBytes        = []byte(keys.AppHashSchemeConfigKey)
 )
 
 const (
-	keySeparator                = "|"
-	nodeIDKeyPrefix             = "NodeID"
-	behindProxyNodeKeyPrefix    = "BehindProxyNode"
-	tokenKeyPrefix              = "Token"
-	tokenPriceFuncKeyPrefix     = "TokenPriceFunc"
-	serviceKeyPrefix            = "Service"
-	serviceDestinationKeyPrefix = "ServiceDestination"
-	approvedServiceKeyPrefix    = "ApproveKey"
-	providedServicesKeyPrefix   = "ProvideService"
-	refGroupCodeKeyPrefix       = "RefGroupCode"
-	identityToRefCodeKeyPrefix  = "identityToRefCodeKey"
-	accessorToRefCodeKeyPrefix  = "accessorToRefCodeKey"
-	allowedModeListKeyPrefix    = "AllowedModeList"
-	requestKeyPrefix            = "Request"
-	dataSignatureKeyPrefix      = "SignData"
+	keySeparator                = keys.Separator
+	nodeIDKeyPrefix             = keys.NodeIDPrefix
+	behindProxyNodeKeyPrefix    = keys.BehindProxyNodePrefix
+	tokenKeyPrefix              = keys.TokenPrefix
+	tokenPriceFuncKeyPrefix     = keys.TokenPriceFuncPrefix
+	serviceKeyPrefix            = keys.ServicePrefix
+	serviceDestinationKeyPrefix = keys.ServiceDestinationPrefix
+	approvedServiceKeyPrefix    = keys.ApprovedServicePrefix
+	providedServicesKeyPrefix   = keys.ProvidedServicesPrefix
+	refGroupCodeKeyPrefix       = keys.RefGroupCodePrefix
+	identityToRefCodeKeyPrefix  = keys.IdentityToRefCodePrefix
+	accessorToRefCodeKeyPrefix  = keys.AccessorToRefCodePrefix
+	allowedModeListKeyPrefix    = keys.AllowedModeListPrefix
+	requestKeyPrefix            = keys.RequestPrefix
+	dataSignatureKeyPrefix      = keys.DataSignaturePrefix
+	idpAgentListKeyPrefix       = keys.IdPAgentListPrefix
+	nodeKeyKeyPrefix            = keys.NodeKeyPrefix
+	revokedPublicKeyKeyPrefix   = keys.RevokedPublicKeyPrefix
+	dataSchemaKeyPrefix         = keys.DataSchemaPrefix
+	consentReceiptKeyPrefix     = keys.ConsentReceiptPrefix
+	statisticsKeyPrefix         = keys.StatisticsPrefix
+	nodeQuotaKeyPrefix          = keys.NodeQuotaPrefix
+	nodeQuotaUsageKeyPrefix     = keys.NodeQuotaUsagePrefix
+	validatorNodeKeyPrefix      = keys.ValidatorNodePrefix
+	tokenLedgerKeyPrefix        = keys.TokenLedgerPrefix
+	adminProposalKeyPrefix      = keys.AdminProposalPrefix
+	governanceActionKeyPrefix   = keys.GovernanceActionPrefix
+	pausedMethodKeyPrefix       = keys.PausedMethodPrefix
+	changeJournalKeyPrefix      = keys.ChangeJournalPrefix
+	blockActivityKeyPrefix      = keys.BlockActivityPrefix
+	misbehaviorKeyPrefix        = keys.ValidatorMisbehaviorPrefix
+	pendingValidatorKeyPrefix   = keys.PendingValidatorUpdatePrefix
+	serviceUsageKeyPrefix       = keys.ServiceUsagePrefix
+	openRequestCountKeyPrefix   = keys.OpenRequestCountPrefix
+	queryVisibilityKeyPrefix    = keys.QueryVisibilityPrefix
+	requestResponseKeyPrefix    = keys.RequestResponsePrefix
+	responseCountKeyPrefix      = keys.RequestResponseCountPrefix
+	dataRequestStatusKeyPrefix  = keys.RequestDataStatusPrefix
+	requestSummaryKeyPrefix     = keys.RequestSummaryPrefix
+	requestSettlementKeyPrefix  = keys.RequestSettlementPrefix
+	accessorResponseKeyPrefix   = keys.AccessorResponsePrefix
+	nodeContactKeyPrefix        = keys.NodeContactPrefix
+	nodeIDAliasKeyPrefix        = keys.NodeIDAliasPrefix
 )
 
+// statisticsMonthFormat is format of month (in UTC) which statistics are kept by
+const statisticsMonthFormat = "2006-01"
+
 func (app *ABCIApplication) setMqAddresses(param string, nodeID string) types.ResponseDeliverTx {
 	app.logger.Infof("SetMqAddresses, Parameter: %s", param)
 	var funcParam SetMqAddressesParam
@@ -89,10 +148,47 @@ func (app *ABCIApplication) setMqAddresses(param string, nodeID string) types.Re
 		var msq data.MQ
 		msq.Ip = address.IP
 		msq.Port = address.Port
+		msq.Priority = address.Priority
+		// Address is active unless node sets it inactive
+		msq.Inactive = address.Active != nil && !*address.Active
 		msqAddress = append(msqAddress, &msq)
 	}
 	nodeDetail.Mq = msqAddress
 
+	app.setNodeDetailLastUpdate(&nodeDetail)
+	nodeDetailByte, err := utils.ProtoDeterministicMarshal(&nodeDetail)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set([]byte(nodeDetailKey), []byte(nodeDetailByte))
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) setSupportedFeatureList(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetSupportedFeatureList, Parameter: %s", param)
+	var funcParam SetSupportedFeatureListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	// Feature names must be non-empty and unique
+	featureSet := make(map[string]bool)
+	for _, feature := range funcParam.SupportedFeatureList {
+		if feature == "" || featureSet[feature] {
+			return app.ReturnDeliverTxLog(code.InvalidSupportedFeatureList, "Supported feature list must contain unique non-empty feature names", "")
+		}
+		featureSet[feature] = true
+	}
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
+	value, _ := app.state.Get([]byte(nodeDetailKey), false)
+	var nodeDetail data.NodeDetail
+	err = proto.Unmarshal(value, &nodeDetail)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	nodeDetail.SupportedFeatureList = funcParam.SupportedFeatureList
+
+	app.setNodeDetailLastUpdate(&nodeDetail)
 	nodeDetailByte, err := utils.ProtoDeterministicMarshal(&nodeDetail)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
@@ -101,12 +197,59 @@ func (app *ABCIApplication) setMqAddresses(param string, nodeID string) types.Re
 	return app.ReturnDeliverTxLog(code.OK, "success", "")
 }
 
+// getMqAddressList returns MQ addresses of node ordered for failover:
+// active addresses first, then by priority (lower value first), then in order set by node
+func getMqAddressList(mqList []*data.MQ) []MsqAddress {
+	var result []MsqAddress
+	for _, mq := range mqList {
+		var msq MsqAddress
+		msq.IP = mq.Ip
+		msq.Port = mq.Port
+		msq.Priority = mq.Priority
+		active := !mq.Inactive
+		msq.Active = &active
+		result = append(result, msq)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if *result[i].Active != *result[j].Active {
+			return *result[i].Active
+		}
+		return result[i].Priority < result[j].Priority
+	})
+	return result
+}
+
 func (app *ABCIApplication) getNodeMasterPublicKey(param string) types.ResponseQuery {
 	app.logger.Infof("GetNodeMasterPublicKey, Parameter: %s", param)
 	var funcParam GetNodeMasterPublicKeyParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if funcParam.Height > 0 && app.pruner.isHeightPruned(funcParam.Height) {
+		return app.ReturnQueryWithCode(code.HeightPruned, nil, "Height is pruned", app.state.Height)
+	}
+	if funcParam.Height > 0 {
+		var res GetNodeMasterPublicKeyResult
+		nodeKey, err := app.getNodeKeyAtHeight(funcParam.NodeID, funcParam.Height)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+		if nodeKey == nil {
+			valueJSON, err := json.Marshal(res)
+			if err != nil {
+				return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+			}
+			return app.ReturnQueryWithCode(code.ResultNotFound, valueJSON, "not found", app.state.Height)
+		}
+		res.MasterPublicKey = nodeKey.MasterPublicKey
+		res.Algorithm = getPublicKeyAlgorithm(nodeKey.MasterPublicKey)
+		res.Version = nodeKey.BlockHeight
+		valueJSON, err := json.Marshal(res)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+		}
+		return app.ReturnQuery(valueJSON, "success", app.state.Height)
 	}
 	key := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
 	value, _ := app.state.Get([]byte(key), true)
@@ -114,19 +257,21 @@ func (app *ABCIApplication) getNodeMasterPublicKey(param string) types.ResponseQ
 	if value == nil {
 		valueJSON, err := json.Marshal(res)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(valueJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, valueJSON, "not found", app.state.Height)
 	}
 	var nodeDetail data.NodeDetail
 	err = proto.Unmarshal(value, &nodeDetail)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	res.MasterPublicKey = nodeDetail.MasterPublicKey
+	res.Algorithm = getPublicKeyAlgorithm(nodeDetail.MasterPublicKey)
+	res.Version = app.getNodeKeyVersion(funcParam.NodeID)
 	valueJSON, err := json.Marshal(res)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(valueJSON, "success", app.state.Height)
 
@@ -137,7 +282,32 @@ func (app *ABCIApplication) getNodePublicKey(param string) types.ResponseQuery {
 	var funcParam GetNodePublicKeyParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if funcParam.Height > 0 && app.pruner.isHeightPruned(funcParam.Height) {
+		return app.ReturnQueryWithCode(code.HeightPruned, nil, "Height is pruned", app.state.Height)
+	}
+	if funcParam.Height > 0 {
+		var res GetNodePublicKeyResult
+		nodeKey, err := app.getNodeKeyAtHeight(funcParam.NodeID, funcParam.Height)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+		if nodeKey == nil {
+			valueJSON, err := json.Marshal(res)
+			if err != nil {
+				return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+			}
+			return app.ReturnQueryWithCode(code.ResultNotFound, valueJSON, "not found", app.state.Height)
+		}
+		res.PublicKey = nodeKey.PublicKey
+		res.Algorithm = getPublicKeyAlgorithm(nodeKey.PublicKey)
+		res.Version = nodeKey.BlockHeight
+		valueJSON, err := json.Marshal(res)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+		}
+		return app.ReturnQuery(valueJSON, "success", app.state.Height)
 	}
 	key := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
 	value, _ := app.state.Get([]byte(key), true)
@@ -145,23 +315,291 @@ func (app *ABCIApplication) getNodePublicKey(param string) types.ResponseQuery {
 	if value == nil {
 		valueJSON, err := json.Marshal(res)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(valueJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, valueJSON, "not found", app.state.Height)
 	}
 	var nodeDetail data.NodeDetail
 	err = proto.Unmarshal(value, &nodeDetail)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	res.PublicKey = nodeDetail.PublicKey
+	res.Algorithm = getPublicKeyAlgorithm(nodeDetail.PublicKey)
+	res.Version = app.getNodeKeyVersion(funcParam.NodeID)
 	valueJSON, err := json.Marshal(res)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(valueJSON, "success", app.state.Height)
 }
 
+// setNodeKey keeps a version of node's public key and master public key at current block height
+// so that signatures made with previous keys can still be verified
+func (app *ABCIApplication) setNodeKey(nodeID string, nodeDetail *data.NodeDetail) error {
+	err := app.seedNodeKey(nodeID)
+	if err != nil {
+		return err
+	}
+	var nodeKey data.NodeKey
+	nodeKey.PublicKey = nodeDetail.PublicKey
+	nodeKey.MasterPublicKey = nodeDetail.MasterPublicKey
+	nodeKey.BlockHeight = app.state.CurrentBlockHeight
+	nodeKeyValue, err := utils.ProtoDeterministicMarshal(&nodeKey)
+	if err != nil {
+		return err
+	}
+	nodeKeyKey := nodeKeyKeyPrefix + keySeparator + nodeID
+	app.state.SetVersioned([]byte(nodeKeyKey), []byte(nodeKeyValue))
+	return nil
+}
+
+// seedNodeKey keeps keys in saved node detail as first version at node's creation height
+// for node registered before key history was introduced, so that queries at height
+// before its first key rotation return keys which were active at that height
+func (app *ABCIApplication) seedNodeKey(nodeID string) error {
+	nodeKeyKey := nodeKeyKeyPrefix + keySeparator + nodeID
+	if app.state.HasVersioned([]byte(nodeKeyKey), false) {
+		return nil
+	}
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
+	nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), false)
+	if nodeDetailValue == nil {
+		return nil
+	}
+	var nodeDetail data.NodeDetail
+	err := proto.Unmarshal(nodeDetailValue, &nodeDetail)
+	if err != nil {
+		return err
+	}
+	if nodeDetail.CreationBlockHeight >= app.state.CurrentBlockHeight {
+		return nil
+	}
+	var nodeKey data.NodeKey
+	nodeKey.PublicKey = nodeDetail.PublicKey
+	nodeKey.MasterPublicKey = nodeDetail.MasterPublicKey
+	nodeKey.BlockHeight = nodeDetail.CreationBlockHeight
+	nodeKeyValue, err := utils.ProtoDeterministicMarshal(&nodeKey)
+	if err != nil {
+		return err
+	}
+	app.state.SetVersionedAtHeight([]byte(nodeKeyKey), nodeKeyValue, nodeDetail.CreationBlockHeight)
+	return nil
+}
+
+// setNodeDetailCreation stamps node detail with height and time of block which node is registered in
+func (app *ABCIApplication) setNodeDetailCreation(nodeDetail *data.NodeDetail) {
+	nodeDetail.CreationBlockHeight = app.state.CurrentBlockHeight
+	nodeDetail.CreationBlockTime = app.CurrentBlockTime.Unix()
+}
+
+// setNodeDetailLastUpdate stamps node detail with height and time of block which saves it
+func (app *ABCIApplication) setNodeDetailLastUpdate(nodeDetail *data.NodeDetail) {
+	nodeDetail.LastUpdateBlockHeight = app.state.CurrentBlockHeight
+	nodeDetail.LastUpdateBlockTime = app.CurrentBlockTime.Unix()
+}
+
+// getNodeRecordTimestamps returns heights and times of blocks which node detail is created and last saved in,
+// they are zero for node detail saved before they were recorded
+func getNodeRecordTimestamps(nodeDetail *data.NodeDetail) *NodeRecordTimestamps {
+	return &NodeRecordTimestamps{
+		CreationBlockHeight:   nodeDetail.CreationBlockHeight,
+		CreationBlockTime:     nodeDetail.CreationBlockTime,
+		LastUpdateBlockHeight: nodeDetail.LastUpdateBlockHeight,
+		LastUpdateBlockTime:   nodeDetail.LastUpdateBlockTime,
+	}
+}
+
+// increaseStatistics increases counter of given method in statistics of the month of current block.
+// serviceID is used only for SignData.
+func (app *ABCIApplication) increaseStatistics(method string, serviceID string) error {
+	statisticsKey := statisticsKeyPrefix + keySeparator + app.CurrentBlockTime.UTC().Format(statisticsMonthFormat)
+	statisticsValue, _ := app.state.Get([]byte(statisticsKey), false)
+	var statistics data.Statistics
+	if statisticsValue != nil {
+		err := proto.Unmarshal(statisticsValue, &statistics)
+		if err != nil {
+			return err
+		}
+	}
+	switch method {
+	case "CreateRequest":
+		statistics.RequestCreatedCount++
+	case "CloseRequest":
+		statistics.RequestClosedCount++
+	case "TimeOutRequest":
+		statistics.RequestTimedOutCount++
+	case "SignData":
+		found := false
+		for _, serviceStatistics := range statistics.ServiceStatisticsList {
+			if serviceStatistics.ServiceId == serviceID {
+				serviceStatistics.SignDataCount++
+				found = true
+				break
+			}
+		}
+		if !found {
+			var serviceStatistics data.ServiceStatistics
+			serviceStatistics.ServiceId = serviceID
+			serviceStatistics.SignDataCount = 1
+			statistics.ServiceStatisticsList = append(statistics.ServiceStatisticsList, &serviceStatistics)
+		}
+	}
+	statisticsValue, err := utils.ProtoDeterministicMarshal(&statistics)
+	if err != nil {
+		return err
+	}
+	app.state.Set([]byte(statisticsKey), []byte(statisticsValue))
+	return nil
+}
+
+// increaseServiceUsage increases all-time usage counters of service. asID is AS which signed data
+// for SignData and empty for request which includes the service.
+func (app *ABCIApplication) increaseServiceUsage(serviceID string, asID string) error {
+	serviceUsageKey := serviceUsageKeyPrefix + keySeparator + serviceID
+	serviceUsageValue, _ := app.state.Get([]byte(serviceUsageKey), false)
+	var serviceUsage data.ServiceUsage
+	if serviceUsageValue != nil {
+		err := proto.Unmarshal(serviceUsageValue, &serviceUsage)
+		if err != nil {
+			return err
+		}
+	}
+	if asID == "" {
+		serviceUsage.RequestCount++
+	} else {
+		serviceUsage.SignDataCount++
+		found := false
+		for _, existingAsID := range serviceUsage.AsIdList {
+			if existingAsID == asID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			serviceUsage.AsIdList = append(serviceUsage.AsIdList, asID)
+		}
+	}
+	serviceUsageValue, err := utils.ProtoDeterministicMarshal(&serviceUsage)
+	if err != nil {
+		return err
+	}
+	app.state.Set([]byte(serviceUsageKey), serviceUsageValue)
+	return nil
+}
+
+// getNodeKeyAtHeight returns node's keys which were active at given block height.
+// Nodes which have not changed their keys since key history was introduced have no history,
+// in that case current keys are returned. History of such node is seeded with its keys
+// at creation height on its first key rotation.
+func (app *ABCIApplication) getNodeKeyAtHeight(nodeID string, height int64) (*data.NodeKey, error) {
+	nodeKeyKey := nodeKeyKeyPrefix + keySeparator + nodeID
+	if !app.state.HasVersioned([]byte(nodeKeyKey), true) {
+		nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
+		nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
+		if nodeDetailValue == nil {
+			return nil, nil
+		}
+		var nodeDetail data.NodeDetail
+		err := proto.Unmarshal(nodeDetailValue, &nodeDetail)
+		if err != nil {
+			return nil, err
+		}
+		var nodeKey data.NodeKey
+		nodeKey.PublicKey = nodeDetail.PublicKey
+		nodeKey.MasterPublicKey = nodeDetail.MasterPublicKey
+		return &nodeKey, nil
+	}
+	nodeKeyValue, err := app.state.GetVersioned([]byte(nodeKeyKey), height, true)
+	if err != nil {
+		return nil, err
+	}
+	if nodeKeyValue == nil {
+		return nil, nil
+	}
+	var nodeKey data.NodeKey
+	err = proto.Unmarshal(nodeKeyValue, &nodeKey)
+	if err != nil {
+		return nil, err
+	}
+	// Node was not registered yet at given height
+	if nodeKey.BlockHeight > height {
+		return nil, nil
+	}
+	return &nodeKey, nil
+}
+
+// getNodeKeyVersion returns block height at which node's current keys were set.
+// Nodes which have not changed their keys since key history was introduced have version 0.
+func (app *ABCIApplication) getNodeKeyVersion(nodeID string) int64 {
+	nodeKey, err := app.getNodeKeyAtHeight(nodeID, app.state.Height)
+	if err != nil || nodeKey == nil {
+		return 0
+	}
+	return nodeKey.BlockHeight
+}
+
+// getPublicKeyAlgorithm returns algorithm name of PEM encoded public key
+// or empty string if the key cannot be parsed
+func getPublicKeyAlgorithm(publicKey string) string {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return ""
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return ""
+	}
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		return "RSA"
+	case *ecdsa.PublicKey:
+		return "ECDSA"
+	case *dsa.PublicKey:
+		return "DSA"
+	default:
+		return ""
+	}
+}
+
+// getPublicKeyHash returns hash of DER encoded public key so that the same key
+// in differently formatted PEM gets the same hash
+func getPublicKeyHash(publicKey string) string {
+	keyBytes := []byte(publicKey)
+	block, _ := pem.Decode([]byte(publicKey))
+	if block != nil {
+		keyBytes = block.Bytes
+	}
+	hash := sha256.Sum256(keyBytes)
+	return hex.EncodeToString(hash[:])
+}
+
+func (app *ABCIApplication) revokePublicKey(publicKey string, nodeID string) {
+	revokedPublicKeyKey := revokedPublicKeyKeyPrefix + keySeparator + getPublicKeyHash(publicKey)
+	app.state.Set([]byte(revokedPublicKeyKey), []byte(nodeID))
+}
+
+func (app *ABCIApplication) isRevokedPublicKey(publicKey string, committedState bool) bool {
+	revokedPublicKeyKey := revokedPublicKeyKeyPrefix + keySeparator + getPublicKeyHash(publicKey)
+	return app.state.Has([]byte(revokedPublicKeyKey), committedState)
+}
+
+func (app *ABCIApplication) checkRevokedPublicKey(param string) types.ResponseQuery {
+	app.logger.Infof("CheckRevokedPublicKey, Parameter: %s", param)
+	var funcParam CheckRevokedPublicKeyParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	var result CheckRevokedPublicKeyResult
+	result.Revoked = app.isRevokedPublicKey(funcParam.PublicKey, true)
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
 func (app *ABCIApplication) getNodeNameByNodeID(nodeID string) string {
 	key := nodeIDKeyPrefix + keySeparator + nodeID
 	value, _ := app.state.Get([]byte(key), true)
@@ -181,7 +619,7 @@ func (app *ABCIApplication) getIdpNodes(param string) types.ResponseQuery {
 	var funcParam GetIdpNodesParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var returnNodes GetIdpNodesResult
 	returnNodes.Node = make([]interface{}, 0)
@@ -191,7 +629,7 @@ func (app *ABCIApplication) getIdpNodes(param string) types.ResponseQuery {
 		if idpsValue != nil {
 			err := proto.Unmarshal(idpsValue, &idpsList)
 			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
+				return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 			}
 			for _, idp := range idpsList.NodeId {
 				nodeDetailKey := nodeIDKeyPrefix + keySeparator + idp
@@ -249,19 +687,19 @@ func (app *ABCIApplication) getIdpNodes(param string) types.ResponseQuery {
 			identityToRefCodeKey := identityToRefCodeKeyPrefix + keySeparator + funcParam.IdentityNamespace + keySeparator + funcParam.IdentityIdentifierHash
 			refGroupCodeFromDB, _ := app.state.Get([]byte(identityToRefCodeKey), true)
 			if refGroupCodeFromDB == nil {
-				return app.ReturnQuery(nil, "not found", app.state.Height)
+				return app.ReturnQueryWithCode(code.ResultNotFound, nil, "not found", app.state.Height)
 			}
 			refGroupCode = string(refGroupCodeFromDB)
 		}
 		refGroupKey := refGroupCodeKeyPrefix + keySeparator + string(refGroupCode)
 		refGroupValue, _ := app.state.Get([]byte(refGroupKey), true)
 		if refGroupValue == nil {
-			return app.ReturnQuery(nil, "not found", app.state.Height)
+			return app.ReturnQueryWithCode(code.ResultNotFound, nil, "not found", app.state.Height)
 		}
 		var refGroup data.ReferenceGroup
 		err := proto.Unmarshal(refGroupValue, &refGroup)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 		}
 		for _, idp := range refGroup.Idps {
 			nodeDetailKey := nodeIDKeyPrefix + keySeparator + idp.NodeId
@@ -335,10 +773,10 @@ func (app *ABCIApplication) getIdpNodes(param string) types.ResponseQuery {
 	}
 	value, err := json.Marshal(returnNodes)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	if len(returnNodes.Node) == 0 {
-		return app.ReturnQuery(value, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, value, "not found", app.state.Height)
 	}
 	return app.ReturnQuery(value, "success", app.state.Height)
 }
@@ -348,21 +786,11 @@ func (app *ABCIApplication) getAsNodesByServiceId(param string) types.ResponseQu
 	var funcParam GetAsNodesByServiceIdParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	key := serviceDestinationKeyPrefix + keySeparator + funcParam.ServiceID
 	value, _ := app.state.Get([]byte(key), true)
 
-	if value == nil {
-		var result GetAsNodesByServiceIdResult
-		result.Node = make([]ASNode, 0)
-		value, err := json.Marshal(result)
-		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
-		}
-		return app.ReturnQuery(value, "not found", app.state.Height)
-	}
-
 	// filter serive is active
 	serviceKey := serviceKeyPrefix + keySeparator + funcParam.ServiceID
 	serviceValue, _ := app.state.Get([]byte(serviceKey), true)
@@ -371,29 +799,39 @@ func (app *ABCIApplication) getAsNodesByServiceId(param string) types.ResponseQu
 		result.Node = make([]ASNode, 0)
 		value, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(value, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, value, "not found", app.state.Height)
 	}
 	var service data.ServiceDetail
 	err = proto.Unmarshal([]byte(serviceValue), &service)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	if service.Active == false {
 		var result GetAsNodesByServiceIdResult
 		result.Node = make([]ASNode, 0)
 		value, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+		}
+		return app.ReturnQueryWithCode(code.ServiceIsNotActive, value, "service is not active", app.state.Height)
+	}
+	// service exists but has no registered AS
+	if value == nil {
+		var result GetAsNodesByServiceIdResult
+		result.Node = make([]ASNode, 0)
+		value, err := json.Marshal(result)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(value, "service is not active", app.state.Height)
+		return app.ReturnQuery(value, "success", app.state.Height)
 	}
 
 	var storedData data.ServiceDesList
 	err = proto.Unmarshal([]byte(value), &storedData)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 
 	var result GetAsNodesByServiceIdWithNameResult
@@ -435,21 +873,23 @@ func (app *ABCIApplication) getAsNodesByServiceId(param string) types.ResponseQu
 		if !nodeDetail.Active {
 			continue
 		}
+		// filter node still has AS role
+		if !hasRole(&nodeDetail, "AS") {
+			continue
+		}
 		var newRow = ASNodeResult{
 			storedData.Node[index].NodeId,
 			nodeDetail.NodeName,
 			storedData.Node[index].MinIal,
 			storedData.Node[index].MinAal,
 			storedData.Node[index].SupportedNamespaceList,
+			append(make([]string, 0), storedData.Node[index].SupportedDataUrlTypeList...),
 		}
 		result.Node = append(result.Node, newRow)
 	}
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
-	}
-	if len(result.Node) == 0 {
-		return app.ReturnQuery(resultJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(resultJSON, "success", app.state.Height)
 }
@@ -459,32 +899,26 @@ func (app *ABCIApplication) getMqAddresses(param string) types.ResponseQuery {
 	var funcParam GetMqAddressesParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
 	value, _ := app.state.Get([]byte(nodeDetailKey), true)
 	var nodeDetail data.NodeDetail
 	err = proto.Unmarshal(value, &nodeDetail)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	if value == nil {
 		value = []byte("[]")
-		return app.ReturnQuery(value, "not found", app.state.Height)
-	}
-	var result GetMqAddressesResult
-	for _, msq := range nodeDetail.Mq {
-		var newRow MsqAddress
-		newRow.IP = msq.Ip
-		newRow.Port = msq.Port
-		result = append(result, newRow)
+		return app.ReturnQueryWithCode(code.ResultNotFound, value, "not found", app.state.Height)
 	}
+	result := GetMqAddressesResult(getMqAddressList(nodeDetail.Mq))
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	if len(result) == 0 {
-		return app.ReturnQuery(resultJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, resultJSON, "not found", app.state.Height)
 	}
 	return app.ReturnQuery(resultJSON, "success", app.state.Height)
 }
@@ -494,19 +928,19 @@ func (app *ABCIApplication) getRequest(param string, height int64) types.Respons
 	var funcParam GetRequestParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	key := requestKeyPrefix + keySeparator + funcParam.RequestID
 	value, _ := app.state.GetVersioned([]byte(key), height, true)
 
 	if value == nil {
 		valueJSON := []byte("{}")
-		return app.ReturnQuery(valueJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, valueJSON, "not found", app.state.Height)
 	}
 	var request data.Request
 	err = proto.Unmarshal([]byte(value), &request)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 
 	var res GetRequestResult
@@ -517,7 +951,7 @@ func (app *ABCIApplication) getRequest(param string, height int64) types.Respons
 
 	valueJSON, err := json.Marshal(res)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(valueJSON, "success", app.state.Height)
 }
@@ -527,14 +961,14 @@ func (app *ABCIApplication) getRequestDetail(param string, height int64, committ
 	var funcParam GetRequestParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 
 	key := requestKeyPrefix + keySeparator + funcParam.RequestID
 	value, _ := app.state.GetVersioned([]byte(key), height, committedState)
 	if value == nil {
 		valueJSON := []byte("{}")
-		return app.ReturnQuery(valueJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, valueJSON, "not found", app.state.Height)
 	}
 
 	var result GetRequestDetailResult
@@ -542,7 +976,11 @@ func (app *ABCIApplication) getRequestDetail(param string, height int64, committ
 	err = proto.Unmarshal([]byte(value), &request)
 	if err != nil {
 		value = []byte("")
-		return app.ReturnQuery(value, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, value, err.Error(), app.state.Height)
+	}
+	err = app.loadRequestSubRecords(&request, height, committedState)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, []byte(""), err.Error(), app.state.Height)
 	}
 
 	result.RequestID = request.RequestId
@@ -578,6 +1016,10 @@ func (app *ABCIApplication) getRequestDetail(param string, height int64, committ
 		newRow.Status = response.Status
 		newRow.Signature = response.Signature
 		newRow.IdpID = response.IdpId
+		newRow.AgentID = response.AgentId
+		newRow.AccessorID = response.AccessorId
+		newRow.BlockHeight = response.BlockHeight
+		newRow.BlockTime = response.BlockTime
 		if response.ValidIal != "" {
 			if response.ValidIal == "true" {
 				tValue := true
@@ -619,13 +1061,149 @@ func (app *ABCIApplication) getRequestDetail(param string, height int64, committ
 	// Set creation_block_height
 	result.CreationBlockHeight = request.CreationBlockHeight
 
+	// Set creation_block_time
+	result.CreationBlockTime = request.CreationBlockTime
+
+	// Set last_update_block_height and last_update_block_time
+	result.LastUpdateBlockHeight, result.LastUpdateBlockTime = getRequestLastUpdate(&request)
+
 	// Set creation_chain_id
 	result.CreationChainID = request.ChainId
 
+	// Set auto_close
+	result.AutoClose = request.AutoClose
+
+	// Set timeout_extension
+	result.TimeoutExtension = request.TimeoutExtension
+
+	// Set idp_response_timeout
+	result.IdPResponseTimeout = request.IdpResponseTimeout
+
+	// Set priority_class
+	result.PriorityClass = request.PriorityClass
+
+	// Set status and summary
+	summary := getRequestSummary(&request)
+	result.Status = summary.Status
+	result.Summary = newRequestSummaryResult(summary)
+
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
 		value = []byte("")
-		return app.ReturnQuery(value, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, value, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(resultJSON, "success", app.state.Height)
+}
+
+func newRequestSummaryResult(summary *data.RequestSummary) RequestSummary {
+	var result RequestSummary
+	result.AcceptCount = summary.AcceptCount
+	result.RejectCount = summary.RejectCount
+	result.ErrorCount = summary.ErrorCount
+	result.SignedDataCount = make(map[string]int64)
+	for _, serviceSigned := range summary.SignedServiceList {
+		result.SignedDataCount[serviceSigned.ServiceId] = serviceSigned.SignedCount
+	}
+	return result
+}
+
+// getRequestStatusQuery returns only status and summary of request for clients polling request
+func (app *ABCIApplication) getRequestStatusQuery(param string, height int64) types.ResponseQuery {
+	app.logger.Infof("GetRequestStatus, Parameter: %s", param)
+	var funcParam GetRequestParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	value, _ := app.state.GetVersioned(getRequestKey(funcParam.RequestID), height, true)
+	if value == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var request data.Request
+	err = proto.Unmarshal(value, &request)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	err = app.loadRequestSubRecords(&request, height, true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	summary := getRequestSummary(&request)
+	var result GetRequestStatusResult
+	result.RequestID = request.RequestId
+	result.Status = summary.Status
+	result.Summary = newRequestSummaryResult(summary)
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(resultJSON, "success", app.state.Height)
+}
+
+// getDataRequestProgress returns progress of data request of a service in request without
+// loading responses and other data requests of the request
+func (app *ABCIApplication) getDataRequestProgress(param string, height int64) types.ResponseQuery {
+	app.logger.Infof("GetDataRequestProgress, Parameter: %s", param)
+	var funcParam GetDataRequestProgressParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	value, _ := app.state.GetVersioned(getRequestKey(funcParam.RequestID), height, true)
+	if value == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var request data.Request
+	err = proto.Unmarshal(value, &request)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var dataRequest *data.DataRequest
+	for _, item := range request.DataRequestList {
+		if item.ServiceId == funcParam.ServiceID {
+			dataRequest = item
+			break
+		}
+	}
+	if dataRequest == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	if request.SubRecordsSplit {
+		dataRequest.AnsweredAsIdList = nil
+		dataRequest.ReceivedDataFromList = nil
+		statusValue, _ := app.state.GetVersioned(getDataRequestStatusKey(request.RequestId, dataRequest.ServiceId), height, true)
+		if statusValue != nil {
+			var status data.DataRequestStatus
+			err = proto.Unmarshal(statusValue, &status)
+			if err != nil {
+				return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+			}
+			dataRequest.AnsweredAsIdList = status.AnsweredAsIdList
+			dataRequest.ReceivedDataFromList = status.ReceivedDataFromList
+		}
+	}
+	var result GetDataRequestProgressResult
+	result.RequestID = request.RequestId
+	result.ServiceID = dataRequest.ServiceId
+	result.Count = dataRequest.MinAs
+	result.As = dataRequest.AsIdList
+	result.AnsweredAsIdList = dataRequest.AnsweredAsIdList
+	result.ReceivedDataFromList = dataRequest.ReceivedDataFromList
+	// Same condition as data request of completed request status
+	result.Completed = int64(len(dataRequest.AnsweredAsIdList)) >= dataRequest.MinAs
+	// make nil to array len 0
+	if result.As == nil {
+		result.As = make([]string, 0)
+	}
+	if result.AnsweredAsIdList == nil {
+		result.AnsweredAsIdList = make([]string, 0)
+	}
+	if result.ReceivedDataFromList == nil {
+		result.ReceivedDataFromList = make([]string, 0)
+	}
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(resultJSON, "success", app.state.Height)
 }
@@ -635,7 +1213,7 @@ func (app *ABCIApplication) getNamespaceList(param string) types.ResponseQuery {
 	value, _ := app.state.Get(allNamespaceKeyBytes, true)
 	if value == nil {
 		value = []byte("[]")
-		return app.ReturnQuery(value, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, value, "not found", app.state.Height)
 	}
 
 	result := make([]*data.Namespace, 0)
@@ -643,7 +1221,7 @@ func (app *ABCIApplication) getNamespaceList(param string) types.ResponseQuery {
 	var namespaces data.NamespaceList
 	err := proto.Unmarshal([]byte(value), &namespaces)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	for _, namespace := range namespaces.Namespaces {
 		if namespace.Active {
@@ -652,7 +1230,7 @@ func (app *ABCIApplication) getNamespaceList(param string) types.ResponseQuery {
 	}
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
@@ -662,22 +1240,22 @@ func (app *ABCIApplication) getServiceDetail(param string) types.ResponseQuery {
 	var funcParam GetServiceDetailParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	key := serviceKeyPrefix + keySeparator + funcParam.ServiceID
 	value, _ := app.state.Get([]byte(key), true)
 	if value == nil {
 		value = []byte("{}")
-		return app.ReturnQuery(value, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, value, "not found", app.state.Height)
 	}
 	var service data.ServiceDetail
 	err = proto.Unmarshal(value, &service)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	returnValue, err := json.Marshal(service)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
@@ -699,22 +1277,35 @@ func (app *ABCIApplication) updateNode(param string, nodeID string) types.Respon
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
-	// update MasterPublicKey
+	// update MasterPublicKey and revoke old one
 	if funcParam.MasterPublicKey != "" {
+		if getPublicKeyHash(funcParam.MasterPublicKey) != getPublicKeyHash(nodeDetail.MasterPublicKey) {
+			app.revokePublicKey(nodeDetail.MasterPublicKey, nodeID)
+		}
 		nodeDetail.MasterPublicKey = funcParam.MasterPublicKey
 	}
-	// update PublicKey
+	// update PublicKey and revoke old one
 	if funcParam.PublicKey != "" {
+		if getPublicKeyHash(funcParam.PublicKey) != getPublicKeyHash(nodeDetail.PublicKey) {
+			app.revokePublicKey(nodeDetail.PublicKey, nodeID)
+		}
 		nodeDetail.PublicKey = funcParam.PublicKey
 	}
 	// update SupportedRequestMessageDataUrlTypeList and Role of node ID is IdP
-	if funcParam.SupportedRequestMessageDataUrlTypeList != nil && string(app.getRoleFromNodeID(nodeID)) == "IdP" {
+	if funcParam.SupportedRequestMessageDataUrlTypeList != nil && app.nodeHasRole(nodeID, "IdP") {
 		nodeDetail.SupportedRequestMessageDataUrlTypeList = funcParam.SupportedRequestMessageDataUrlTypeList
 	}
+	app.setNodeDetailLastUpdate(&nodeDetail)
 	nodeDetailValue, err := utils.ProtoDeterministicMarshal(&nodeDetail)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
+	if funcParam.MasterPublicKey != "" || funcParam.PublicKey != "" {
+		err = app.setNodeKey(nodeID, &nodeDetail)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+		}
+	}
 	app.state.Set([]byte(key), []byte(nodeDetailValue))
 	return app.ReturnDeliverTxLog(code.OK, "success", "")
 }
@@ -724,15 +1315,15 @@ func (app *ABCIApplication) checkExistingIdentity(param string) types.ResponseQu
 	var funcParam CheckExistingIdentityParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var result CheckExistingIdentityResult
 	if funcParam.ReferenceGroupCode != "" && funcParam.IdentityNamespace != "" && funcParam.IdentityIdentifierHash != "" {
 		returnValue, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(returnValue, "Found reference group code and identity detail in parameter", app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, returnValue, "Found reference group code and identity detail in parameter", app.state.Height)
 	}
 	refGroupCode := ""
 	if funcParam.ReferenceGroupCode != "" {
@@ -743,7 +1334,7 @@ func (app *ABCIApplication) checkExistingIdentity(param string) types.ResponseQu
 		if refGroupCodeFromDB == nil {
 			returnValue, err := json.Marshal(result)
 			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
+				return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 			}
 			return app.ReturnQuery(returnValue, "success", app.state.Height)
 		}
@@ -754,7 +1345,7 @@ func (app *ABCIApplication) checkExistingIdentity(param string) types.ResponseQu
 	if refGroupValue == nil {
 		returnValue, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
 		return app.ReturnQuery(returnValue, "success", app.state.Height)
 	}
@@ -763,14 +1354,14 @@ func (app *ABCIApplication) checkExistingIdentity(param string) types.ResponseQu
 	if err != nil {
 		returnValue, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
 		return app.ReturnQuery(returnValue, "success", app.state.Height)
 	}
 	result.Exist = true
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
@@ -780,24 +1371,24 @@ func (app *ABCIApplication) getAccessorKey(param string) types.ResponseQuery {
 	var funcParam GetAccessorKeyParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var result GetAccessorKeyResult
 	result.AccessorPublicKey = ""
 	accessorToRefCodeKey := accessorToRefCodeKeyPrefix + keySeparator + funcParam.AccessorID
 	refGroupCodeFromDB, _ := app.state.Get([]byte(accessorToRefCodeKey), true)
 	if refGroupCodeFromDB == nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	refGroupKey := refGroupCodeKeyPrefix + keySeparator + string(refGroupCodeFromDB)
 	refGroupValue, _ := app.state.Get([]byte(refGroupKey), true)
 	if refGroupValue == nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	var refGroup data.ReferenceGroup
 	err = proto.Unmarshal(refGroupValue, &refGroup)
 	if err != nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	for _, idp := range refGroup.Idps {
 		for _, accessor := range idp.Accessors {
@@ -810,29 +1401,29 @@ func (app *ABCIApplication) getAccessorKey(param string) types.ResponseQuery {
 	}
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
 
 func (app *ABCIApplication) getServiceList(param string) types.ResponseQuery {
 	app.logger.Infof("GetServiceList, Parameter: %s", param)
-	key := "AllService"
+	key := keys.AllServiceKey
 	value, _ := app.state.Get([]byte(key), true)
 	if value == nil {
 		result := make([]ServiceDetail, 0)
 		value, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(value, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, value, "not found", app.state.Height)
 	}
 	result := make([]*data.ServiceDetail, 0)
 	// filter flag==true
 	var services data.ServiceDetailList
 	err := proto.Unmarshal([]byte(value), &services)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	for _, service := range services.Services {
 		if service.Active {
@@ -841,7 +1432,7 @@ func (app *ABCIApplication) getServiceList(param string) types.ResponseQuery {
 	}
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
@@ -865,24 +1456,24 @@ func (app *ABCIApplication) checkExistingAccessorID(param string) types.Response
 	var funcParam CheckExistingAccessorIDParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var result CheckExistingResult
 	result.Exist = false
 	accessorToRefCodeKey := accessorToRefCodeKeyPrefix + keySeparator + funcParam.AccessorID
 	refGroupCodeFromDB, _ := app.state.Get([]byte(accessorToRefCodeKey), true)
 	if refGroupCodeFromDB == nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	refGroupKey := refGroupCodeKeyPrefix + keySeparator + string(refGroupCodeFromDB)
 	refGroupValue, _ := app.state.Get([]byte(refGroupKey), true)
 	if refGroupValue == nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	var refGroup data.ReferenceGroup
 	err = proto.Unmarshal(refGroupValue, &refGroup)
 	if err != nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	for _, idp := range refGroup.Idps {
 		for _, accessor := range idp.Accessors {
@@ -894,7 +1485,7 @@ func (app *ABCIApplication) checkExistingAccessorID(param string) types.Response
 	}
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
@@ -904,18 +1495,18 @@ func (app *ABCIApplication) getNodeInfo(param string) types.ResponseQuery {
 	var funcParam GetNodeInfoParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 
 	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
 	nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
 	if nodeDetailValue == nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	var nodeDetail data.NodeDetail
 	err = proto.Unmarshal([]byte(nodeDetailValue), &nodeDetail)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 
 	// If node behind proxy
@@ -925,14 +1516,14 @@ func (app *ABCIApplication) getNodeInfo(param string) types.ResponseQuery {
 		proxyNodeDetailKey := nodeIDKeyPrefix + keySeparator + string(proxyNodeID)
 		proxyNodeDetailValue, _ := app.state.Get([]byte(proxyNodeDetailKey), true)
 		if proxyNodeDetailValue == nil {
-			return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+			return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 		}
 		var proxyNode data.NodeDetail
 		err = proto.Unmarshal([]byte(proxyNodeDetailValue), &proxyNode)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 		}
-		if nodeDetail.Role == "IdP" {
+		if hasRole(&nodeDetail, "IdP") {
 			var result GetNodeInfoResultIdPandASBehindProxy
 			result.PublicKey = nodeDetail.PublicKey
 			result.MasterPublicKey = nodeDetail.MasterPublicKey
@@ -945,19 +1536,17 @@ func (app *ABCIApplication) getNodeInfo(param string) types.ResponseQuery {
 			result.Proxy.NodeName = proxyNode.NodeName
 			result.Proxy.PublicKey = proxyNode.PublicKey
 			result.Proxy.MasterPublicKey = proxyNode.MasterPublicKey
-			if proxyNode.Mq != nil {
-				for _, mq := range proxyNode.Mq {
-					var msq MsqAddress
-					msq.IP = mq.Ip
-					msq.Port = mq.Port
-					result.Proxy.Mq = append(result.Proxy.Mq, msq)
-				}
-			}
+			result.Proxy.Mq = getMqAddressList(proxyNode.Mq)
 			result.Proxy.Config = nodeDetail.ProxyConfig
 			result.Active = nodeDetail.Active
+			result.SupportedFeatureList = append(make([]string, 0), nodeDetail.SupportedFeatureList...)
+			result.AdditionalRoleList = nodeDetail.AdditionalRoleList
+			if funcParam.IncludeRecordTimestamps {
+				result.RecordTimestamps = getNodeRecordTimestamps(&nodeDetail)
+			}
 			value, err := json.Marshal(result)
 			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
+				return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 			}
 			return app.ReturnQuery(value, "success", app.state.Height)
 		}
@@ -970,23 +1559,42 @@ func (app *ABCIApplication) getNodeInfo(param string) types.ResponseQuery {
 		result.Proxy.NodeName = proxyNode.NodeName
 		result.Proxy.PublicKey = proxyNode.PublicKey
 		result.Proxy.MasterPublicKey = proxyNode.MasterPublicKey
-		if proxyNode.Mq != nil {
-			for _, mq := range proxyNode.Mq {
-				var msq MsqAddress
-				msq.IP = mq.Ip
-				msq.Port = mq.Port
-				result.Proxy.Mq = append(result.Proxy.Mq, msq)
-			}
-		}
+		result.Proxy.Mq = getMqAddressList(proxyNode.Mq)
 		result.Proxy.Config = nodeDetail.ProxyConfig
 		result.Active = nodeDetail.Active
+		result.SupportedFeatureList = append(make([]string, 0), nodeDetail.SupportedFeatureList...)
+		result.AdditionalRoleList = nodeDetail.AdditionalRoleList
+		if funcParam.IncludeRecordTimestamps {
+			result.RecordTimestamps = getNodeRecordTimestamps(&nodeDetail)
+		}
+		value, err := json.Marshal(result)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+		}
+		return app.ReturnQuery(value, "success", app.state.Height)
+	}
+	if nodeDetail.Role == "IdPAgent" {
+		var result GetNodeInfoIdPAgentResult
+		result.PublicKey = nodeDetail.PublicKey
+		result.MasterPublicKey = nodeDetail.MasterPublicKey
+		result.NodeName = nodeDetail.NodeName
+		result.Role = nodeDetail.Role
+		result.MaxIal = nodeDetail.MaxIal
+		result.MaxAal = nodeDetail.MaxAal
+		result.ParentIdPID = nodeDetail.ParentIdpId
+		result.Mq = getMqAddressList(nodeDetail.Mq)
+		result.Active = nodeDetail.Active
+		result.SupportedFeatureList = append(make([]string, 0), nodeDetail.SupportedFeatureList...)
+		if funcParam.IncludeRecordTimestamps {
+			result.RecordTimestamps = getNodeRecordTimestamps(&nodeDetail)
+		}
 		value, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
 		return app.ReturnQuery(value, "success", app.state.Height)
 	}
-	if nodeDetail.Role == "IdP" {
+	if hasRole(&nodeDetail, "IdP") {
 		var result GetNodeInfoIdPResult
 		result.PublicKey = nodeDetail.PublicKey
 		result.MasterPublicKey = nodeDetail.MasterPublicKey
@@ -995,18 +1603,16 @@ func (app *ABCIApplication) getNodeInfo(param string) types.ResponseQuery {
 		result.MaxIal = nodeDetail.MaxIal
 		result.MaxAal = nodeDetail.MaxAal
 		result.SupportedRequestMessageDataUrlTypeList = append(make([]string, 0), nodeDetail.SupportedRequestMessageDataUrlTypeList...)
-		if nodeDetail.Mq != nil {
-			for _, mq := range nodeDetail.Mq {
-				var msq MsqAddress
-				msq.IP = mq.Ip
-				msq.Port = mq.Port
-				result.Mq = append(result.Mq, msq)
-			}
-		}
+		result.Mq = getMqAddressList(nodeDetail.Mq)
 		result.Active = nodeDetail.Active
+		result.SupportedFeatureList = append(make([]string, 0), nodeDetail.SupportedFeatureList...)
+		result.AdditionalRoleList = nodeDetail.AdditionalRoleList
+		if funcParam.IncludeRecordTimestamps {
+			result.RecordTimestamps = getNodeRecordTimestamps(&nodeDetail)
+		}
 		value, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
 		return app.ReturnQuery(value, "success", app.state.Height)
 	}
@@ -1015,18 +1621,16 @@ func (app *ABCIApplication) getNodeInfo(param string) types.ResponseQuery {
 	result.MasterPublicKey = nodeDetail.MasterPublicKey
 	result.NodeName = nodeDetail.NodeName
 	result.Role = nodeDetail.Role
-	if nodeDetail.Mq != nil {
-		for _, mq := range nodeDetail.Mq {
-			var msq MsqAddress
-			msq.IP = mq.Ip
-			msq.Port = mq.Port
-			result.Mq = append(result.Mq, msq)
-		}
-	}
+	result.Mq = getMqAddressList(nodeDetail.Mq)
 	result.Active = nodeDetail.Active
+	result.SupportedFeatureList = append(make([]string, 0), nodeDetail.SupportedFeatureList...)
+	result.AdditionalRoleList = nodeDetail.AdditionalRoleList
+	if funcParam.IncludeRecordTimestamps {
+		result.RecordTimestamps = getNodeRecordTimestamps(&nodeDetail)
+	}
 	value, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(value, "success", app.state.Height)
 }
@@ -1036,15 +1640,15 @@ func (app *ABCIApplication) getIdentityInfo(param string) types.ResponseQuery {
 	var funcParam GetIdentityInfoParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var result GetIdentityInfoResult
 	if funcParam.ReferenceGroupCode != "" && funcParam.IdentityNamespace != "" && funcParam.IdentityIdentifierHash != "" {
 		returnValue, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(returnValue, "Found reference group code and identity detail in parameter", app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, returnValue, "Found reference group code and identity detail in parameter", app.state.Height)
 	}
 	refGroupCode := ""
 	if funcParam.ReferenceGroupCode != "" {
@@ -1055,9 +1659,9 @@ func (app *ABCIApplication) getIdentityInfo(param string) types.ResponseQuery {
 		if refGroupCodeFromDB == nil {
 			returnValue, err := json.Marshal(result)
 			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
+				return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 			}
-			return app.ReturnQuery(returnValue, "Reference group not found", app.state.Height)
+			return app.ReturnQueryWithCode(code.RefGroupNotFound, returnValue, "Reference group not found", app.state.Height)
 		}
 		refGroupCode = string(refGroupCodeFromDB)
 	}
@@ -1066,18 +1670,18 @@ func (app *ABCIApplication) getIdentityInfo(param string) types.ResponseQuery {
 	if refGroupValue == nil {
 		returnValue, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(returnValue, "Reference group not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.RefGroupNotFound, returnValue, "Reference group not found", app.state.Height)
 	}
 	var refGroup data.ReferenceGroup
 	err = proto.Unmarshal(refGroupValue, &refGroup)
 	if err != nil {
 		returnValue, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(returnValue, "Reference group not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.RefGroupNotFound, returnValue, "Reference group not found", app.state.Height)
 	}
 	for _, idp := range refGroup.Idps {
 		if funcParam.NodeID == idp.NodeId && idp.Active {
@@ -1088,29 +1692,256 @@ func (app *ABCIApplication) getIdentityInfo(param string) types.ResponseQuery {
 	}
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	if result.Ial <= 0.0 {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
 
-func (app *ABCIApplication) getDataSignature(param string) types.ResponseQuery {
-	app.logger.Infof("GetDataSignature, Parameter: %s", param)
-	var funcParam GetDataSignatureParam
+// getIdentityModeList returns modes which identity is registered in with every active IdP
+// so that RP can choose mode of request before creating it
+func (app *ABCIApplication) getIdentityModeList(param string) types.ResponseQuery {
+	app.logger.Infof("GetIdentityModeList, Parameter: %s", param)
+	var funcParam GetIdentityModeListParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
-	signDataKey := dataSignatureKeyPrefix + keySeparator + funcParam.NodeID + keySeparator + funcParam.ServiceID + keySeparator + funcParam.RequestID
-	signDataValue, _ := app.state.Get([]byte(signDataKey), true)
-	if signDataValue == nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+	if funcParam.ReferenceGroupCode != "" && funcParam.IdentityNamespace != "" && funcParam.IdentityIdentifierHash != "" {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, "Found reference group code and identity detail in parameter", app.state.Height)
+	}
+	refGroupCode := funcParam.ReferenceGroupCode
+	if refGroupCode == "" {
+		identityToRefCodeKey := identityToRefCodeKeyPrefix + keySeparator + funcParam.IdentityNamespace + keySeparator + funcParam.IdentityIdentifierHash
+		refGroupCodeFromDB, _ := app.state.Get([]byte(identityToRefCodeKey), true)
+		if refGroupCodeFromDB == nil {
+			return app.ReturnQueryWithCode(code.RefGroupNotFound, []byte("{}"), "Reference group not found", app.state.Height)
+		}
+		refGroupCode = string(refGroupCodeFromDB)
+	}
+	refGroupKey := refGroupCodeKeyPrefix + keySeparator + refGroupCode
+	refGroupValue, _ := app.state.Get([]byte(refGroupKey), true)
+	if refGroupValue == nil {
+		return app.ReturnQueryWithCode(code.RefGroupNotFound, []byte("{}"), "Reference group not found", app.state.Height)
+	}
+	var refGroup data.ReferenceGroup
+	err = proto.Unmarshal(refGroupValue, &refGroup)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetIdentityModeListResult
+	result.ModeList = make([]int32, 0)
+	result.IdPList = make([]IdentityModeListOfIdP, 0)
+	modes := make(map[int32]bool)
+	for _, idp := range refGroup.Idps {
+		if !idp.Active {
+			continue
+		}
+		result.IdPList = append(result.IdPList, IdentityModeListOfIdP{
+			NodeID:   idp.NodeId,
+			ModeList: idp.Mode,
+		})
+		for _, mode := range idp.Mode {
+			if !modes[mode] {
+				modes[mode] = true
+				result.ModeList = append(result.ModeList, mode)
+			}
+		}
+	}
+	sort.Slice(result.ModeList, func(i, j int) bool { return result.ModeList[i] < result.ModeList[j] })
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+func (app *ABCIApplication) getDataSignature(param string) types.ResponseQuery {
+	app.logger.Infof("GetDataSignature, Parameter: %s", param)
+	var funcParam GetDataSignatureParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	signDataKey := dataSignatureKeyPrefix + keySeparator + funcParam.NodeID + keySeparator + funcParam.ServiceID + keySeparator + funcParam.RequestID
+	signDataValue, _ := app.state.Get([]byte(signDataKey), true)
+	if signDataValue == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	dataSignature := getDataSignatureFromValue(signDataValue)
+	var result GetDataSignatureResult
+	result.Signature = dataSignature.Signature
+	result.DataSchemaVersion = dataSignature.DataSchemaVersion
+	result.BlockHeight = dataSignature.BlockHeight
+	result.DataHash = dataSignature.DataHash
+	result.DataContentType = dataSignature.DataContentType
+	result.BlockTime = dataSignature.BlockTime
+	returnValue, err := json.Marshal(result)
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+// getDataSignatureFromValue parses stored data signature.
+// Data signatures stored before data schema version was recorded are plain signature strings.
+func getDataSignatureFromValue(value []byte) data.DataSignature {
+	var dataSignature data.DataSignature
+	err := proto.Unmarshal(value, &dataSignature)
+	if err != nil || dataSignature.Signature == "" {
+		dataSignature = data.DataSignature{}
+		dataSignature.Signature = string(value)
+	}
+	return dataSignature
+}
+
+func (app *ABCIApplication) getDataSchema(param string) types.ResponseQuery {
+	app.logger.Infof("GetDataSchema, Parameter: %s", param)
+	var funcParam GetDataSchemaParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	serviceKey := serviceKeyPrefix + keySeparator + funcParam.ServiceID
+	serviceValue, _ := app.state.Get([]byte(serviceKey), true)
+	if serviceValue == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var service data.ServiceDetail
+	err = proto.Unmarshal(serviceValue, &service)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetDataSchemaResult
+	result.ServiceID = funcParam.ServiceID
+	if funcParam.DataSchemaVersion == "" || funcParam.DataSchemaVersion == service.DataSchemaVersion {
+		result.DataSchema = service.DataSchema
+		result.DataSchemaVersion = service.DataSchemaVersion
+	} else {
+		dataSchemaKey := dataSchemaKeyPrefix + keySeparator + funcParam.ServiceID + keySeparator + funcParam.DataSchemaVersion
+		dataSchemaValue, _ := app.state.Get([]byte(dataSchemaKey), true)
+		if dataSchemaValue == nil {
+			return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+		}
+		result.DataSchema = string(dataSchemaValue)
+		result.DataSchemaVersion = funcParam.DataSchemaVersion
+	}
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+func (app *ABCIApplication) getConsentReceiptList(param string) types.ResponseQuery {
+	app.logger.Infof("GetConsentReceiptList, Parameter: %s", param)
+	var funcParam GetConsentReceiptListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	var result GetConsentReceiptListResult
+	result.ConsentReceiptList = make([]ConsentReceipt, 0)
+	consentReceiptKey := consentReceiptKeyPrefix + keySeparator + funcParam.RequestID
+	consentReceiptValue, _ := app.state.Get([]byte(consentReceiptKey), true)
+	if consentReceiptValue == nil {
+		returnValue, err := json.Marshal(result)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+		}
+		return app.ReturnQueryWithCode(code.ResultNotFound, returnValue, "not found", app.state.Height)
+	}
+	var consentReceipts data.ConsentReceiptList
+	err = proto.Unmarshal(consentReceiptValue, &consentReceipts)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	for _, consentReceipt := range consentReceipts.ConsentReceipts {
+		var newRow ConsentReceipt
+		newRow.NodeID = consentReceipt.NodeId
+		newRow.ConsentReceiptHash = consentReceipt.ConsentReceiptHash
+		newRow.BlockHeight = consentReceipt.BlockHeight
+		result.ConsentReceiptList = append(result.ConsentReceiptList, newRow)
+	}
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+func (app *ABCIApplication) getStatistics(param string) types.ResponseQuery {
+	app.logger.Infof("GetStatistics, Parameter: %s", param)
+	var funcParam GetStatisticsParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	var result GetStatisticsResult
+	result.Month = funcParam.Month
+	result.ServiceStatisticsList = make([]ServiceStatistics, 0)
+	_, err = time.Parse(statisticsMonthFormat, funcParam.Month)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	statisticsKey := statisticsKeyPrefix + keySeparator + funcParam.Month
+	statisticsValue, _ := app.state.Get([]byte(statisticsKey), true)
+	if statisticsValue == nil {
+		returnValue, err := json.Marshal(result)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+		}
+		return app.ReturnQueryWithCode(code.ResultNotFound, returnValue, "not found", app.state.Height)
+	}
+	var statistics data.Statistics
+	err = proto.Unmarshal(statisticsValue, &statistics)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	result.RequestCreatedCount = statistics.RequestCreatedCount
+	result.RequestClosedCount = statistics.RequestClosedCount
+	result.RequestTimedOutCount = statistics.RequestTimedOutCount
+	for _, serviceStatistics := range statistics.ServiceStatisticsList {
+		var newRow ServiceStatistics
+		newRow.ServiceID = serviceStatistics.ServiceId
+		newRow.SignDataCount = serviceStatistics.SignDataCount
+		result.ServiceStatisticsList = append(result.ServiceStatisticsList, newRow)
+	}
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+func (app *ABCIApplication) getServiceStatistics(param string) types.ResponseQuery {
+	app.logger.Infof("GetServiceStatistics, Parameter: %s", param)
+	var funcParam GetServiceStatisticsParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	serviceKey := serviceKeyPrefix + keySeparator + funcParam.ServiceID
+	if !app.state.Has([]byte(serviceKey), true) {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var serviceUsage data.ServiceUsage
+	serviceUsageKey := serviceUsageKeyPrefix + keySeparator + funcParam.ServiceID
+	serviceUsageValue, _ := app.state.Get([]byte(serviceUsageKey), true)
+	if serviceUsageValue != nil {
+		err = proto.Unmarshal(serviceUsageValue, &serviceUsage)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+	}
+	var result GetServiceStatisticsResult
+	result.ServiceID = funcParam.ServiceID
+	result.RequestCount = serviceUsage.RequestCount
+	result.SignDataCount = serviceUsage.SignDataCount
+	result.AsCount = int64(len(serviceUsage.AsIdList))
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
-	var result GetDataSignatureResult
-	result.Signature = string(signDataValue)
-	returnValue, err := json.Marshal(result)
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
 
@@ -1119,7 +1950,7 @@ func (app *ABCIApplication) getServicesByAsID(param string) types.ResponseQuery
 	var funcParam GetServicesByAsIDParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var result GetServicesByAsIDResult
 	result.Services = make([]Service, 0)
@@ -1128,28 +1959,32 @@ func (app *ABCIApplication) getServicesByAsID(param string) types.ResponseQuery
 	if provideServiceValue == nil {
 		resultJSON, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(resultJSON, "not found", app.state.Height)
+		// AS exists but provides no service
+		if app.state.Has([]byte(nodeIDKeyPrefix+keySeparator+funcParam.AsID), true) {
+			return app.ReturnQuery(resultJSON, "success", app.state.Height)
+		}
+		return app.ReturnQueryWithCode(code.ResultNotFound, resultJSON, "not found", app.state.Height)
 	}
 	var services data.ServiceList
 	err = proto.Unmarshal([]byte(provideServiceValue), &services)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.AsID
 	nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
 	if nodeDetailValue == nil {
 		resultJSON, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(resultJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, resultJSON, "not found", app.state.Height)
 	}
 	var nodeDetail data.NodeDetail
 	err = proto.Unmarshal([]byte(nodeDetailValue), &nodeDetail)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	for index, provideService := range services.Services {
 		serviceKey := serviceKeyPrefix + keySeparator + provideService.ServiceId
@@ -1160,7 +1995,7 @@ func (app *ABCIApplication) getServicesByAsID(param string) types.ResponseQuery
 		var service data.ServiceDetail
 		err = proto.Unmarshal([]byte(serviceValue), &service)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 		}
 		if nodeDetail.Active && service.Active {
 			// Set suspended from NDID
@@ -1181,15 +2016,13 @@ func (app *ABCIApplication) getServicesByAsID(param string) types.ResponseQuery
 			newRow.ServiceID = services.Services[index].ServiceId
 			newRow.Suspended = services.Services[index].Suspended
 			newRow.SupportedNamespaceList = services.Services[index].SupportedNamespaceList
+			newRow.SupportedDataURLTypeList = append(make([]string, 0), services.Services[index].SupportedDataUrlTypeList...)
 			result.Services = append(result.Services, newRow)
 		}
 	}
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
-	}
-	if len(result.Services) == 0 {
-		return app.ReturnQuery(resultJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(resultJSON, "success", app.state.Height)
 }
@@ -1199,7 +2032,7 @@ func (app *ABCIApplication) getIdpNodesInfo(param string) types.ResponseQuery {
 	var funcParam GetIdpNodesParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var returnNodes GetIdpNodesInfoResult
 	returnNodes.Node = make([]interface{}, 0)
@@ -1209,17 +2042,19 @@ func (app *ABCIApplication) getIdpNodesInfo(param string) types.ResponseQuery {
 		if idpsValue != nil {
 			err := proto.Unmarshal(idpsValue, &idpsList)
 			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
+				return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 			}
 			for _, idp := range idpsList.NodeId {
 				nodeDetailKey := nodeIDKeyPrefix + keySeparator + idp
 				nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
 				if nodeDetailValue == nil {
+					returnNodes.ErrorList = append(returnNodes.ErrorList, app.newQueryItemError("GetIdpNodesInfo", idp, "node detail not found"))
 					continue
 				}
 				var nodeDetail data.NodeDetail
 				err := proto.Unmarshal(nodeDetailValue, &nodeDetail)
 				if err != nil {
+					returnNodes.ErrorList = append(returnNodes.ErrorList, app.newQueryItemError("GetIdpNodesInfo", idp, "node detail: "+err.Error()))
 					continue
 				}
 				// check node is active
@@ -1257,12 +2092,14 @@ func (app *ABCIApplication) getIdpNodesInfo(param string) types.ResponseQuery {
 					proxyNodeDetailKey := nodeIDKeyPrefix + keySeparator + string(proxyNodeID)
 					proxyNodeDetailValue, _ := app.state.Get([]byte(proxyNodeDetailKey), true)
 					if proxyNodeDetailValue == nil {
-						return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+						returnNodes.ErrorList = append(returnNodes.ErrorList, app.newQueryItemError("GetIdpNodesInfo", idp, "proxy node detail not found"))
+						continue
 					}
 					var proxyNode data.NodeDetail
 					err = proto.Unmarshal([]byte(proxyNodeDetailValue), &proxyNode)
 					if err != nil {
-						return app.ReturnQuery(nil, err.Error(), app.state.Height)
+						returnNodes.ErrorList = append(returnNodes.ErrorList, app.newQueryItemError("GetIdpNodesInfo", idp, "proxy node detail: "+err.Error()))
+						continue
 					}
 					// Check proxy node is active
 					if !proxyNode.Active {
@@ -1277,24 +2114,11 @@ func (app *ABCIApplication) getIdpNodesInfo(param string) types.ResponseQuery {
 					msqDesNode.SupportedRequestMessageDataUrlTypeList = append(make([]string, 0), nodeDetail.SupportedRequestMessageDataUrlTypeList...)
 					msqDesNode.Proxy.NodeID = string(proxyNodeID)
 					msqDesNode.Proxy.PublicKey = proxyNode.PublicKey
-					if proxyNode.Mq != nil {
-						for _, mq := range proxyNode.Mq {
-							var msq MsqAddress
-							msq.IP = mq.Ip
-							msq.Port = mq.Port
-							msqDesNode.Proxy.Mq = append(msqDesNode.Proxy.Mq, msq)
-						}
-					}
+					msqDesNode.Proxy.Mq = getMqAddressList(proxyNode.Mq)
 					msqDesNode.Proxy.Config = nodeDetail.ProxyConfig
 					returnNodes.Node = append(returnNodes.Node, msqDesNode)
 				} else {
-					var msq []MsqAddress
-					for _, mq := range nodeDetail.Mq {
-						var msqAddress MsqAddress
-						msqAddress.IP = mq.Ip
-						msqAddress.Port = mq.Port
-						msq = append(msq, msqAddress)
-					}
+					msq := getMqAddressList(nodeDetail.Mq)
 					var msqDesNode IdpNode
 					msqDesNode.NodeID = idp
 					msqDesNode.Name = nodeDetail.NodeName
@@ -1315,29 +2139,31 @@ func (app *ABCIApplication) getIdpNodesInfo(param string) types.ResponseQuery {
 			identityToRefCodeKey := identityToRefCodeKeyPrefix + keySeparator + funcParam.IdentityNamespace + keySeparator + funcParam.IdentityIdentifierHash
 			refGroupCodeFromDB, _ := app.state.Get([]byte(identityToRefCodeKey), true)
 			if refGroupCodeFromDB == nil {
-				return app.ReturnQuery(nil, "not found", app.state.Height)
+				return app.ReturnQueryWithCode(code.ResultNotFound, nil, "not found", app.state.Height)
 			}
 			refGroupCode = string(refGroupCodeFromDB)
 		}
 		refGroupKey := refGroupCodeKeyPrefix + keySeparator + string(refGroupCode)
 		refGroupValue, _ := app.state.Get([]byte(refGroupKey), true)
 		if refGroupValue == nil {
-			return app.ReturnQuery(nil, "not found", app.state.Height)
+			return app.ReturnQueryWithCode(code.ResultNotFound, nil, "not found", app.state.Height)
 		}
 		var refGroup data.ReferenceGroup
 		err := proto.Unmarshal(refGroupValue, &refGroup)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 		}
 		for _, idp := range refGroup.Idps {
 			nodeDetailKey := nodeIDKeyPrefix + keySeparator + idp.NodeId
 			nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
 			if nodeDetailValue == nil {
+				returnNodes.ErrorList = append(returnNodes.ErrorList, app.newQueryItemError("GetIdpNodesInfo", idp.NodeId, "node detail not found"))
 				continue
 			}
 			var nodeDetail data.NodeDetail
 			err := proto.Unmarshal(nodeDetailValue, &nodeDetail)
 			if err != nil {
+				returnNodes.ErrorList = append(returnNodes.ErrorList, app.newQueryItemError("GetIdpNodesInfo", idp.NodeId, "node detail: "+err.Error()))
 				continue
 			}
 			// check node is active
@@ -1395,12 +2221,14 @@ func (app *ABCIApplication) getIdpNodesInfo(param string) types.ResponseQuery {
 				proxyNodeDetailKey := nodeIDKeyPrefix + keySeparator + string(proxyNodeID)
 				proxyNodeDetailValue, _ := app.state.Get([]byte(proxyNodeDetailKey), true)
 				if proxyNodeDetailValue == nil {
-					return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+					returnNodes.ErrorList = append(returnNodes.ErrorList, app.newQueryItemError("GetIdpNodesInfo", idp.NodeId, "proxy node detail not found"))
+					continue
 				}
 				var proxyNode data.NodeDetail
 				err = proto.Unmarshal([]byte(proxyNodeDetailValue), &proxyNode)
 				if err != nil {
-					return app.ReturnQuery(nil, err.Error(), app.state.Height)
+					returnNodes.ErrorList = append(returnNodes.ErrorList, app.newQueryItemError("GetIdpNodesInfo", idp.NodeId, "proxy node detail: "+err.Error()))
+					continue
 				}
 				// Check proxy node is active
 				if !proxyNode.Active {
@@ -1415,25 +2243,12 @@ func (app *ABCIApplication) getIdpNodesInfo(param string) types.ResponseQuery {
 				msqDesNode.SupportedRequestMessageDataUrlTypeList = append(make([]string, 0), nodeDetail.SupportedRequestMessageDataUrlTypeList...)
 				msqDesNode.Proxy.NodeID = string(proxyNodeID)
 				msqDesNode.Proxy.PublicKey = proxyNode.PublicKey
-				if proxyNode.Mq != nil {
-					for _, mq := range proxyNode.Mq {
-						var msq MsqAddress
-						msq.IP = mq.Ip
-						msq.Port = mq.Port
-						msqDesNode.Proxy.Mq = append(msqDesNode.Proxy.Mq, msq)
-					}
-				}
+				msqDesNode.Proxy.Mq = getMqAddressList(proxyNode.Mq)
 				msqDesNode.Proxy.Config = nodeDetail.ProxyConfig
 				msqDesNode.ModeList = idp.Mode
 				returnNodes.Node = append(returnNodes.Node, msqDesNode)
 			} else {
-				var msq []MsqAddress
-				for _, mq := range nodeDetail.Mq {
-					var msqAddress MsqAddress
-					msqAddress.IP = mq.Ip
-					msqAddress.Port = mq.Port
-					msq = append(msq, msqAddress)
-				}
+				msq := getMqAddressList(nodeDetail.Mq)
 				var msqDesNode IdpNodeWithModeList
 				msqDesNode.NodeID = idp.NodeId
 				msqDesNode.Name = nodeDetail.NodeName
@@ -1450,10 +2265,10 @@ func (app *ABCIApplication) getIdpNodesInfo(param string) types.ResponseQuery {
 	}
 	value, err := json.Marshal(returnNodes)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	if len(returnNodes.Node) == 0 {
-		return app.ReturnQuery(value, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, value, "not found", app.state.Height)
 	}
 	return app.ReturnQuery(value, "success", app.state.Height)
 }
@@ -1463,19 +2278,10 @@ func (app *ABCIApplication) getAsNodesInfoByServiceId(param string) types.Respon
 	var funcParam GetAsNodesByServiceIdParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	key := serviceDestinationKeyPrefix + keySeparator + funcParam.ServiceID
 	value, _ := app.state.Get([]byte(key), true)
-	if value == nil {
-		var result GetAsNodesInfoByServiceIdResult
-		result.Node = make([]interface{}, 0)
-		value, err := json.Marshal(result)
-		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
-		}
-		return app.ReturnQuery(value, "not found", app.state.Height)
-	}
 	// filter serive is active
 	serviceKey := serviceKeyPrefix + keySeparator + funcParam.ServiceID
 	serviceValue, _ := app.state.Get([]byte(serviceKey), true)
@@ -1484,28 +2290,38 @@ func (app *ABCIApplication) getAsNodesInfoByServiceId(param string) types.Respon
 		result.Node = make([]ASNode, 0)
 		value, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(value, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, value, "not found", app.state.Height)
 	}
 	var service data.ServiceDetail
 	err = proto.Unmarshal([]byte(serviceValue), &service)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	if service.Active == false {
 		var result GetAsNodesByServiceIdResult
 		result.Node = make([]ASNode, 0)
 		value, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 		}
-		return app.ReturnQuery(value, "service is not active", app.state.Height)
+		return app.ReturnQueryWithCode(code.ServiceIsNotActive, value, "service is not active", app.state.Height)
+	}
+	// service exists but has no registered AS
+	if value == nil {
+		var result GetAsNodesInfoByServiceIdResult
+		result.Node = make([]interface{}, 0)
+		value, err := json.Marshal(result)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+		}
+		return app.ReturnQuery(value, "success", app.state.Height)
 	}
 	var storedData data.ServiceDesList
 	err = proto.Unmarshal([]byte(value), &storedData)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	// Make mapping
 	mapNodeIDList := map[string]bool{}
@@ -1534,6 +2350,7 @@ func (app *ABCIApplication) getAsNodesInfoByServiceId(param string) types.Respon
 		var approveService data.ApproveService
 		err = proto.Unmarshal([]byte(approveServiceJSON), &approveService)
 		if err != nil {
+			result.ErrorList = append(result.ErrorList, app.newQueryItemError("GetAsNodesInfoByServiceId", storedData.Node[index].NodeId, "approved service: "+err.Error()))
 			continue
 		}
 		if !approveService.Active {
@@ -1542,17 +2359,23 @@ func (app *ABCIApplication) getAsNodesInfoByServiceId(param string) types.Respon
 		nodeDetailKey := nodeIDKeyPrefix + keySeparator + storedData.Node[index].NodeId
 		nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
 		if nodeDetailValue == nil {
+			result.ErrorList = append(result.ErrorList, app.newQueryItemError("GetAsNodesInfoByServiceId", storedData.Node[index].NodeId, "node detail not found"))
 			continue
 		}
 		var nodeDetail data.NodeDetail
 		err := proto.Unmarshal(nodeDetailValue, &nodeDetail)
 		if err != nil {
+			result.ErrorList = append(result.ErrorList, app.newQueryItemError("GetAsNodesInfoByServiceId", storedData.Node[index].NodeId, "node detail: "+err.Error()))
 			continue
 		}
 		// filter node is active
 		if !nodeDetail.Active {
 			continue
 		}
+		// filter node still has AS role
+		if !hasRole(&nodeDetail, "AS") {
+			continue
+		}
 		// If node is behind proxy
 		if nodeDetail.ProxyNodeId != "" {
 			proxyNodeID := nodeDetail.ProxyNodeId
@@ -1560,12 +2383,14 @@ func (app *ABCIApplication) getAsNodesInfoByServiceId(param string) types.Respon
 			proxyNodeDetailKey := nodeIDKeyPrefix + keySeparator + string(proxyNodeID)
 			proxyNodeDetailValue, _ := app.state.Get([]byte(proxyNodeDetailKey), true)
 			if proxyNodeDetailValue == nil {
-				return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+				result.ErrorList = append(result.ErrorList, app.newQueryItemError("GetAsNodesInfoByServiceId", storedData.Node[index].NodeId, "proxy node detail not found"))
+				continue
 			}
 			var proxyNode data.NodeDetail
 			err = proto.Unmarshal([]byte(proxyNodeDetailValue), &proxyNode)
 			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
+				result.ErrorList = append(result.ErrorList, app.newQueryItemError("GetAsNodesInfoByServiceId", storedData.Node[index].NodeId, "proxy node detail: "+err.Error()))
+				continue
 			}
 			// Check proxy node is active
 			if !proxyNode.Active {
@@ -1578,26 +2403,14 @@ func (app *ABCIApplication) getAsNodesInfoByServiceId(param string) types.Respon
 			as.MinAal = storedData.Node[index].MinAal
 			as.PublicKey = nodeDetail.PublicKey
 			as.SupportedNamespaceList = storedData.Node[index].SupportedNamespaceList
+			as.SupportedDataURLTypeList = append(make([]string, 0), storedData.Node[index].SupportedDataUrlTypeList...)
 			as.Proxy.NodeID = string(proxyNodeID)
 			as.Proxy.PublicKey = proxyNode.PublicKey
-			if proxyNode.Mq != nil {
-				for _, mq := range proxyNode.Mq {
-					var msq MsqAddress
-					msq.IP = mq.Ip
-					msq.Port = mq.Port
-					as.Proxy.Mq = append(as.Proxy.Mq, msq)
-				}
-			}
+			as.Proxy.Mq = getMqAddressList(proxyNode.Mq)
 			as.Proxy.Config = nodeDetail.ProxyConfig
 			result.Node = append(result.Node, as)
 		} else {
-			var msqAddress []MsqAddress
-			for _, mq := range nodeDetail.Mq {
-				var msq MsqAddress
-				msq.IP = mq.Ip
-				msq.Port = mq.Port
-				msqAddress = append(msqAddress, msq)
-			}
+			msqAddress := getMqAddressList(nodeDetail.Mq)
 			var newRow = ASWithMqNode{
 				storedData.Node[index].NodeId,
 				nodeDetail.NodeName,
@@ -1606,13 +2419,14 @@ func (app *ABCIApplication) getAsNodesInfoByServiceId(param string) types.Respon
 				nodeDetail.PublicKey,
 				msqAddress,
 				storedData.Node[index].SupportedNamespaceList,
+				append(make([]string, 0), storedData.Node[index].SupportedDataUrlTypeList...),
 			}
 			result.Node = append(result.Node, newRow)
 		}
 	}
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(resultJSON, "success", app.state.Height)
 }
@@ -1622,34 +2436,40 @@ func (app *ABCIApplication) getNodesBehindProxyNode(param string) types.Response
 	var funcParam GetNodesBehindProxyNodeParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var result GetNodesBehindProxyNodeResult
 	result.Nodes = make([]interface{}, 0)
-	behindProxyNodeKey := "BehindProxyNode" + keySeparator + funcParam.ProxyNodeID
+	behindProxyNodeKey := behindProxyNodeKeyPrefix + keySeparator + funcParam.ProxyNodeID
 	behindProxyNodeValue, _ := app.state.Get([]byte(behindProxyNodeKey), true)
 	if behindProxyNodeValue == nil {
 		resultJSON, err := json.Marshal(result)
 		if err != nil {
-			return app.ReturnQuery(nil, err.Error(), app.state.Height)
+			return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+		}
+		// proxy node exists but has no node behind it
+		if app.state.Has([]byte(nodeIDKeyPrefix+keySeparator+funcParam.ProxyNodeID), true) {
+			return app.ReturnQuery(resultJSON, "success", app.state.Height)
 		}
-		return app.ReturnQuery(resultJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, resultJSON, "not found", app.state.Height)
 	}
 	var nodes data.BehindNodeList
 	nodes.Nodes = make([]string, 0)
 	err = proto.Unmarshal([]byte(behindProxyNodeValue), &nodes)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 	}
 	for _, node := range nodes.Nodes {
 		nodeDetailKey := nodeIDKeyPrefix + keySeparator + node
 		nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
 		if nodeDetailValue == nil {
+			result.ErrorList = append(result.ErrorList, app.newQueryItemError("GetNodesBehindProxyNode", node, "node detail not found"))
 			continue
 		}
 		var nodeDetail data.NodeDetail
 		err := proto.Unmarshal([]byte(nodeDetailValue), &nodeDetail)
 		if err != nil {
+			result.ErrorList = append(result.ErrorList, app.newQueryItemError("GetNodesBehindProxyNode", node, "node detail: "+err.Error()))
 			continue
 		}
 
@@ -1658,7 +2478,7 @@ func (app *ABCIApplication) getNodesBehindProxyNode(param string) types.Response
 			continue
 		}
 
-		if nodeDetail.Role == "IdP" {
+		if hasRole(&nodeDetail, "IdP") {
 			var row IdPBehindProxy
 			row.NodeID = node
 			row.NodeName = nodeDetail.NodeName
@@ -1684,125 +2504,89 @@ func (app *ABCIApplication) getNodesBehindProxyNode(param string) types.Response
 	}
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
-	}
-	if len(result.Nodes) == 0 {
-		return app.ReturnQuery(resultJSON, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(resultJSON, "success", app.state.Height)
 }
 
+// nodeIDListKeyByRole is key of node ID list of each role which GetNodeIDList reads.
+// Proxy nodes have no list of their own and are filtered from list of all nodes.
+var nodeIDListKeyByRole = map[string]string{
+	"rp":    keys.RPListKey,
+	"idp":   string(idpListKeyBytes),
+	"as":    keys.ASListKey,
+	"proxy": keys.AllListKey,
+	"":      keys.AllListKey,
+}
+
+const (
+	nodeStatusActive   = "active"
+	nodeStatusDisabled = "disabled"
+	nodeStatusAll      = "all"
+)
+
 func (app *ABCIApplication) getNodeIDList(param string) types.ResponseQuery {
 	app.logger.Infof("GetNodeIDList, Parameter: %s", param)
 	var funcParam GetNodeIDListParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	role := strings.ToLower(funcParam.Role)
+	listKey, validRole := nodeIDListKeyByRole[role]
+	if !validRole {
+		// Unknown role is treated as all nodes as before
+		listKey = nodeIDListKeyByRole[""]
+	}
+	status := strings.ToLower(funcParam.Status)
+	if status == "" {
+		status = nodeStatusActive
+	}
+	if status != nodeStatusActive && status != nodeStatusDisabled && status != nodeStatusAll {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, "Invalid status", app.state.Height)
+	}
+	if funcParam.Offset < 0 || funcParam.Limit < 0 {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, "Offset and limit must not be negative", app.state.Height)
 	}
 	var result GetNodeIDListResult
 	result.NodeIDList = make([]string, 0)
-	if strings.ToLower(funcParam.Role) == "rp" {
-		var rpsList data.RPList
-		rpsKey := "rpList"
-		rpsValue, _ := app.state.Get([]byte(rpsKey), true)
-		if rpsValue != nil {
-			err := proto.Unmarshal(rpsValue, &rpsList)
-			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
-			}
-			for _, nodeID := range rpsList.NodeId {
-				nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
-				nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
-				if nodeDetailValue != nil {
-					var nodeDetail data.NodeDetail
-					err := proto.Unmarshal([]byte(nodeDetailValue), &nodeDetail)
-					if err != nil {
-						continue
-					}
-					if nodeDetail.Active {
-						result.NodeIDList = append(result.NodeIDList, nodeID)
-					}
-				}
-			}
+	var nodeIDList data.AllList
+	nodeIDListValue, _ := app.state.Get([]byte(listKey), true)
+	if nodeIDListValue != nil {
+		err := proto.Unmarshal(nodeIDListValue, &nodeIDList)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
 		}
-	} else if strings.ToLower(funcParam.Role) == "idp" {
-		var idpsList data.IdPList
-		idpsValue, _ := app.state.Get(idpListKeyBytes, true)
-		if idpsValue != nil {
-			err := proto.Unmarshal(idpsValue, &idpsList)
-			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
-			}
-			for _, nodeID := range idpsList.NodeId {
-				nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
-				nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
-				if nodeDetailValue != nil {
-					var nodeDetail data.NodeDetail
-					err := proto.Unmarshal([]byte(nodeDetailValue), &nodeDetail)
-					if err != nil {
-						continue
-					}
-					if nodeDetail.Active {
-						result.NodeIDList = append(result.NodeIDList, nodeID)
-					}
-				}
-			}
+	}
+	for _, nodeID := range nodeIDList.NodeId {
+		nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
+		nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
+		if nodeDetailValue == nil {
+			continue
 		}
-	} else if strings.ToLower(funcParam.Role) == "as" {
-		var asList data.ASList
-		asKey := "asList"
-		asValue, _ := app.state.Get([]byte(asKey), true)
-		if asValue != nil {
-			err := proto.Unmarshal(asValue, &asList)
-			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
-			}
-			for _, nodeID := range asList.NodeId {
-				nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
-				nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
-				if nodeDetailValue != nil {
-					var nodeDetail data.NodeDetail
-					err := proto.Unmarshal([]byte(nodeDetailValue), &nodeDetail)
-					if err != nil {
-						continue
-					}
-					if nodeDetail.Active {
-						result.NodeIDList = append(result.NodeIDList, nodeID)
-					}
-				}
-			}
+		var nodeDetail data.NodeDetail
+		err := proto.Unmarshal([]byte(nodeDetailValue), &nodeDetail)
+		if err != nil {
+			continue
 		}
-	} else {
-		var allList data.AllList
-		allKey := "allList"
-		allValue, _ := app.state.Get([]byte(allKey), true)
-		if allValue != nil {
-			err := proto.Unmarshal(allValue, &allList)
-			if err != nil {
-				return app.ReturnQuery(nil, err.Error(), app.state.Height)
-			}
-			for _, nodeID := range allList.NodeId {
-				nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
-				nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), true)
-				if nodeDetailValue != nil {
-					var nodeDetail data.NodeDetail
-					err := proto.Unmarshal([]byte(nodeDetailValue), &nodeDetail)
-					if err != nil {
-						continue
-					}
-					if nodeDetail.Active {
-						result.NodeIDList = append(result.NodeIDList, nodeID)
-					}
-				}
-			}
+		if role == "proxy" && nodeDetail.Role != "Proxy" {
+			continue
+		}
+		if (status == nodeStatusActive && !nodeDetail.Active) || (status == nodeStatusDisabled && nodeDetail.Active) {
+			continue
+		}
+		result.Total++
+		if result.Total <= funcParam.Offset || (funcParam.Limit > 0 && result.Total > funcParam.Offset+funcParam.Limit) {
+			continue
 		}
+		result.NodeIDList = append(result.NodeIDList, nodeID)
 	}
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
-	if len(result.NodeIDList) == 0 {
-		return app.ReturnQuery(resultJSON, "not found", app.state.Height)
+	if result.Total == 0 {
+		return app.ReturnQueryWithCode(code.ResultNotFound, resultJSON, "not found", app.state.Height)
 	}
 	return app.ReturnQuery(resultJSON, "success", app.state.Height)
 }
@@ -1812,24 +2596,24 @@ func (app *ABCIApplication) getAccessorOwner(param string) types.ResponseQuery {
 	var funcParam GetAccessorOwnerParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var result GetAccessorOwnerResult
 	result.NodeID = ""
 	accessorToRefCodeKey := accessorToRefCodeKeyPrefix + keySeparator + funcParam.AccessorID
 	refGroupCodeFromDB, _ := app.state.Get([]byte(accessorToRefCodeKey), true)
 	if refGroupCodeFromDB == nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	refGroupKey := refGroupCodeKeyPrefix + keySeparator + string(refGroupCodeFromDB)
 	refGroupValue, _ := app.state.Get([]byte(refGroupKey), true)
 	if refGroupValue == nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	var refGroup data.ReferenceGroup
 	err = proto.Unmarshal(refGroupValue, &refGroup)
 	if err != nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	for _, idp := range refGroup.Idps {
 		for _, accessor := range idp.Accessors {
@@ -1841,7 +2625,7 @@ func (app *ABCIApplication) getAccessorOwner(param string) types.ResponseQuery {
 	}
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
@@ -1856,18 +2640,47 @@ func (app *ABCIApplication) isInitEnded(param string) types.ResponseQuery {
 	}
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
 
 func (app *ABCIApplication) getChainHistory(param string) types.ResponseQuery {
 	app.logger.Infof("GetChainHistory, Parameter: %s", param)
-	chainHistoryInfoKey := "ChainHistoryInfo"
+	chainHistoryInfoKey := keys.ChainHistoryInfoKey
 	value, _ := app.state.Get([]byte(chainHistoryInfoKey), true)
 	return app.ReturnQuery(value, "success", app.state.Height)
 }
 
+func (app *ABCIApplication) getChainHistoryInfo(param string) types.ResponseQuery {
+	app.logger.Infof("GetChainHistoryInfo, Parameter: %s", param)
+	var previousChainList data.PreviousChainList
+	value, _ := app.state.Get(previousChainListKeyBytes, true)
+	if value != nil {
+		err := proto.Unmarshal(value, &previousChainList)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+	}
+	var result GetChainHistoryInfoResult
+	result.CurrentChainID = app.CurrentChain
+	result.PreviousChainList = make([]PreviousChain, 0, len(previousChainList.ChainList))
+	for _, previousChain := range previousChainList.ChainList {
+		result.PreviousChainList = append(result.PreviousChainList, PreviousChain{
+			ChainID:             previousChain.ChainId,
+			FinalBlockHeight:    previousChain.FinalBlockHeight,
+			FinalAppHash:        previousChain.FinalAppHash,
+			RecordedChainID:     previousChain.RecordedChainId,
+			RecordedBlockHeight: previousChain.RecordedBlockHeight,
+		})
+	}
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
 func contains(a string, list []string) bool {
 	for _, b := range list {
 		if b == a {
@@ -1891,7 +2704,7 @@ func (app *ABCIApplication) GetReferenceGroupCode(param string) types.ResponseQu
 	var funcParam GetReferenceGroupCodeParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	identityToRefCodeKey := identityToRefCodeKeyPrefix + keySeparator + funcParam.IdentityNamespace + keySeparator + funcParam.IdentityIdentifierHash
 	refGroupCodeFromDB, _ := app.state.Get([]byte(identityToRefCodeKey), true)
@@ -1902,10 +2715,10 @@ func (app *ABCIApplication) GetReferenceGroupCode(param string) types.ResponseQu
 	result.ReferenceGroupCode = string(refGroupCodeFromDB)
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	if string(refGroupCodeFromDB) == "" {
-		return app.ReturnQuery(returnValue, "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, returnValue, "not found", app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
@@ -1915,7 +2728,7 @@ func (app *ABCIApplication) GetReferenceGroupCodeByAccessorID(param string) type
 	var funcParam GetReferenceGroupCodeByAccessorIDParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	accessorToRefCodeKey := accessorToRefCodeKeyPrefix + keySeparator + funcParam.AccessorID
 	refGroupCodeFromDB, _ := app.state.Get([]byte(accessorToRefCodeKey), true)
@@ -1926,7 +2739,115 @@ func (app *ABCIApplication) GetReferenceGroupCodeByAccessorID(param string) type
 	result.ReferenceGroupCode = string(refGroupCodeFromDB)
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+// getReferenceGroupIdPList returns IdPs associated with reference group with their mode list
+func (app *ABCIApplication) getReferenceGroupIdPList(param string) types.ResponseQuery {
+	app.logger.Infof("GetReferenceGroupIdPList, Parameter: %s", param)
+	var funcParam GetReferenceGroupIdPListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if funcParam.ReferenceGroupCode != "" && funcParam.IdentityNamespace != "" && funcParam.IdentityIdentifierHash != "" {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, "Found reference group code and identity detail in parameter", app.state.Height)
+	}
+	refGroupCode := funcParam.ReferenceGroupCode
+	if refGroupCode == "" {
+		identityToRefCodeKey := identityToRefCodeKeyPrefix + keySeparator + funcParam.IdentityNamespace + keySeparator + funcParam.IdentityIdentifierHash
+		refGroupCodeFromDB, _ := app.state.Get([]byte(identityToRefCodeKey), true)
+		if refGroupCodeFromDB == nil {
+			return app.ReturnQueryWithCode(code.RefGroupNotFound, nil, "Reference group not found", app.state.Height)
+		}
+		refGroupCode = string(refGroupCodeFromDB)
+	}
+	refGroupKey := refGroupCodeKeyPrefix + keySeparator + refGroupCode
+	refGroupValue, _ := app.state.Get([]byte(refGroupKey), true)
+	if refGroupValue == nil {
+		return app.ReturnQueryWithCode(code.RefGroupNotFound, nil, "Reference group not found", app.state.Height)
+	}
+	var refGroup data.ReferenceGroup
+	err = proto.Unmarshal(refGroupValue, &refGroup)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetReferenceGroupIdPListResult
+	result.ReferenceGroupCode = refGroupCode
+	result.IdPList = make([]IdPInReferenceGroup, 0, len(refGroup.Idps))
+	for _, idp := range refGroup.Idps {
+		var row IdPInReferenceGroup
+		row.NodeID = idp.NodeId
+		row.ModeList = idp.Mode
+		if row.ModeList == nil {
+			row.ModeList = make([]int32, 0)
+		}
+		row.Ial = idp.Ial
+		row.Active = idp.Active
+		result.IdPList = append(result.IdPList, row)
+	}
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+// getAccessorsInAccessorGroup returns every accessor (including revoked) of IdPs in reference group
+// found by reference group code or one of its accessor ID, optionally only of given IdP
+func (app *ABCIApplication) getAccessorsInAccessorGroup(param string) types.ResponseQuery {
+	app.logger.Infof("GetAccessorsInAccessorGroup, Parameter: %s", param)
+	var funcParam GetAccessorsInAccessorGroupParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if funcParam.ReferenceGroupCode != "" && funcParam.AccessorID != "" {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, "Found reference group code and accessor ID in parameter", app.state.Height)
+	}
+	refGroupCode := funcParam.ReferenceGroupCode
+	if refGroupCode == "" {
+		accessorToRefCodeKey := accessorToRefCodeKeyPrefix + keySeparator + funcParam.AccessorID
+		refGroupCodeFromDB, _ := app.state.Get([]byte(accessorToRefCodeKey), true)
+		if refGroupCodeFromDB == nil {
+			return app.ReturnQueryWithCode(code.RefGroupNotFound, nil, "Reference group not found", app.state.Height)
+		}
+		refGroupCode = string(refGroupCodeFromDB)
+	}
+	refGroupKey := refGroupCodeKeyPrefix + keySeparator + refGroupCode
+	refGroupValue, _ := app.state.Get([]byte(refGroupKey), true)
+	if refGroupValue == nil {
+		return app.ReturnQueryWithCode(code.RefGroupNotFound, nil, "Reference group not found", app.state.Height)
+	}
+	var refGroup data.ReferenceGroup
+	err = proto.Unmarshal(refGroupValue, &refGroup)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetAccessorsInAccessorGroupResult
+	result.ReferenceGroupCode = refGroupCode
+	result.AccessorList = make([]AccessorInAccessorGroup, 0)
+	for _, idp := range refGroup.Idps {
+		if funcParam.IdpID != "" && idp.NodeId != funcParam.IdpID {
+			continue
+		}
+		for _, accessor := range idp.Accessors {
+			var row AccessorInAccessorGroup
+			row.AccessorID = accessor.AccessorId
+			row.AccessorType = accessor.AccessorType
+			row.AccessorPublicKey = accessor.AccessorPublicKey
+			row.IdpID = idp.NodeId
+			row.Active = accessor.Active
+			row.Revoked = accessor.Revoked
+			row.CreationBlockHeight = accessor.CreationBlockHeight
+			result.AccessorList = append(result.AccessorList, row)
+		}
+	}
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
@@ -1936,19 +2857,19 @@ func (app *ABCIApplication) GetAllowedModeList(param string) types.ResponseQuery
 	var funcParam GetAllowedModeListParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	var result GetAllowedModeListResult
 	result.AllowedModeList = app.GetAllowedModeFromStateDB(funcParam.Purpose, true)
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
 
 func (app *ABCIApplication) GetAllowedModeFromStateDB(purpose string, committedState bool) (result []int32) {
-	allowedModeKey := "AllowedModeList" + keySeparator + purpose
+	allowedModeKey := allowedModeListKeyPrefix + keySeparator + purpose
 	var allowedModeList data.AllowedModeList
 	allowedModeValue, _ := app.state.Get([]byte(allowedModeKey), committedState)
 	if allowedModeValue == nil {
@@ -2010,19 +2931,80 @@ func (app *ABCIApplication) GetNamespaceAllowedIdentifierCountMap(committedState
 	return result
 }
 
+func (app *ABCIApplication) GetNamespaceAllowedActiveIdentifierCountMap(committedState bool) (result map[string]int) {
+	result = make(map[string]int, 0)
+	allNamespaceValue, _ := app.state.Get(allNamespaceKeyBytes, committedState)
+	if allNamespaceValue == nil {
+		return result
+	}
+	var namespaces data.NamespaceList
+	err := proto.Unmarshal([]byte(allNamespaceValue), &namespaces)
+	if err != nil {
+		return result
+	}
+	for _, namespace := range namespaces.Namespaces {
+		if namespace.Active {
+			if namespace.AllowedActiveIdentifierCountInReferenceGroup == -1 {
+				result[namespace.Namespace] = 0
+			} else {
+				result[namespace.Namespace] = int(namespace.AllowedActiveIdentifierCountInReferenceGroup)
+			}
+		}
+	}
+	return result
+}
+
+// GetNamespaceAllowedModeListMap returns allowed mode list of each namespace.
+// Namespace without allowed mode list is not in result and allows all modes.
+func (app *ABCIApplication) GetNamespaceAllowedModeListMap(committedState bool) (result map[string][]int32) {
+	result = make(map[string][]int32, 0)
+	allNamespaceValue, _ := app.state.Get(allNamespaceKeyBytes, committedState)
+	if allNamespaceValue == nil {
+		return result
+	}
+	var namespaces data.NamespaceList
+	err := proto.Unmarshal([]byte(allNamespaceValue), &namespaces)
+	if err != nil {
+		return result
+	}
+	for _, namespace := range namespaces.Namespaces {
+		if namespace.Active && len(namespace.AllowedModeList) > 0 {
+			result[namespace.Namespace] = namespace.AllowedModeList
+		}
+	}
+	return result
+}
+
+// isModeListAllowedInNamespaces checks every mode in mode list is allowed by every given namespace
+func (app *ABCIApplication) isModeListAllowedInNamespaces(modeList []int32, namespaces map[string]int) bool {
+	allowedModeList := app.GetNamespaceAllowedModeListMap(false)
+	for _, namespace := range utils.SortedKeys(namespaces) {
+		allowedMode, exist := allowedModeList[namespace]
+		if !exist {
+			continue
+		}
+		for _, mode := range modeList {
+			if !containsInt32(mode, allowedMode) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func (app *ABCIApplication) GetAllowedMinIalForRegisterIdentityAtFirstIdp(param string) types.ResponseQuery {
 	app.logger.Infof("GetAllowedMinIalForRegisterIdentityAtFirstIdp, Parameter: %s", param)
 	var result GetAllowedMinIalForRegisterIdentityAtFirstIdpResult
 	result.MinIal = app.GetAllowedMinIalForRegisterIdentityAtFirstIdpFromStateDB(true)
 	returnValue, err := json.Marshal(result)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(returnValue, "success", app.state.Height)
 }
 
 func (app *ABCIApplication) GetAllowedMinIalForRegisterIdentityAtFirstIdpFromStateDB(committedState bool) float64 {
-	allowedMinIalKey := "AllowedMinIalForRegisterIdentityAtFirstIdp"
+	allowedMinIalKey := keys.AllowedMinIalForRegisterIdentityAtFirstIdpKey
 	var allowedMinIal data.AllowedMinIalForRegisterIdentityAtFirstIdp
 	allowedMinIalValue, _ := app.state.Get([]byte(allowedMinIalKey), committedState)
 	if allowedMinIalValue == nil {
@@ -2034,3 +3016,34 @@ func (app *ABCIApplication) GetAllowedMinIalForRegisterIdentityAtFirstIdpFromSta
 	}
 	return allowedMinIal.MinIal
 }
+
+func (app *ABCIApplication) getIdPAgentList(param string) types.ResponseQuery {
+	app.logger.Infof("GetIdPAgentList, Parameter: %s", param)
+	var funcParam GetIdPAgentListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	var result GetNodeIDListResult
+	result.NodeIDList = make([]string, 0)
+	agentListKey := idpAgentListKeyPrefix + keySeparator + funcParam.ParentIdPID
+	agentListValue, _ := app.state.Get([]byte(agentListKey), true)
+	if agentListValue != nil {
+		var agentList data.IdPList
+		err := proto.Unmarshal(agentListValue, &agentList)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+		for _, nodeID := range agentList.NodeId {
+			if app.getActiveStatusByNodeID(nodeID, true) {
+				result.NodeIDList = append(result.NodeIDList, nodeID)
+			}
+		}
+	}
+	result.Total = int64(len(result.NodeIDList))
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,208 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Config is ABCI app settings which do not affect consensus.
+// Settings are read from JSON file on start and can be reloaded while running.
+// Omitted setting is left unchanged. Query rate limits are queries per second by method
+// where "*" is limit of each method without its own limit. Handler time budgets are
+// milliseconds by DeliverTx or query method in the same way.
+type Config struct {
+	LogLevel               *string            `json:"log_level"`
+	MetricsEnabled         *bool              `json:"metrics_enabled"`
+	QueryCacheSize         *int               `json:"query_cache_size"`
+	StoreQueryEnabled      *bool              `json:"store_query_enabled"`
+	QueryAccessLogEnabled  *bool              `json:"query_access_log_enabled"`
+	CatchingUpBlockTimeLag *int64             `json:"catching_up_block_time_lag"`
+	InvariantCheck         *string            `json:"invariant_check"`
+	InvariantCheckInterval *int64             `json:"invariant_check_interval"`
+	QueryRateLimits        map[string]float64 `json:"query_rate_limits"`
+	MaxConcurrentQueries   *int               `json:"max_concurrent_queries"`
+	QueryCompressMinSize   *int               `json:"query_compression_min_size"`
+	PruneKeepBlocks        *int64             `json:"prune_keep_blocks"`
+	PrunePaused            *bool              `json:"prune_paused"`
+	CrashReportDir         *string            `json:"crash_report_dir"`
+	BackupInterval         *int64             `json:"backup_interval"`
+	BackupRetention        *int               `json:"backup_retention"`
+	BackupDir              *string            `json:"backup_dir"`
+	HandlerTimeBudgets     map[string]int64   `json:"handler_time_budgets"`
+	BudgetBreakerThreshold *int               `json:"budget_breaker_threshold"`
+}
+
+// LoadConfig reads and validates config file
+func LoadConfig(path string) (*Config, error) {
+	configJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	err = json.Unmarshal(configJSON, &config)
+	if err != nil {
+		return nil, err
+	}
+	if config.LogLevel != nil {
+		_, err = logrus.ParseLevel(*config.LogLevel)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.QueryCacheSize != nil && *config.QueryCacheSize < 0 {
+		return nil, fmt.Errorf("query_cache_size must be greater or equal to 0")
+	}
+	if config.InvariantCheck != nil && *config.InvariantCheck != "" &&
+		*config.InvariantCheck != invariantCheckModeAlert && *config.InvariantCheck != invariantCheckModeHalt {
+		return nil, fmt.Errorf("invariant_check must be \"%s\", \"%s\" or empty", invariantCheckModeAlert, invariantCheckModeHalt)
+	}
+	if config.InvariantCheckInterval != nil && *config.InvariantCheckInterval <= 0 {
+		return nil, fmt.Errorf("invariant_check_interval must be greater than 0")
+	}
+	for method, rateLimit := range config.QueryRateLimits {
+		if rateLimit < 0 {
+			return nil, fmt.Errorf("query_rate_limits of %s must be greater or equal to 0", method)
+		}
+	}
+	if config.MaxConcurrentQueries != nil && *config.MaxConcurrentQueries < 0 {
+		return nil, fmt.Errorf("max_concurrent_queries must be greater or equal to 0")
+	}
+	if config.QueryCompressMinSize != nil && *config.QueryCompressMinSize < 0 {
+		return nil, fmt.Errorf("query_compression_min_size must be greater or equal to 0")
+	}
+	if config.PruneKeepBlocks != nil && *config.PruneKeepBlocks < 0 {
+		return nil, fmt.Errorf("prune_keep_blocks must be greater or equal to 0")
+	}
+	if config.BackupInterval != nil && *config.BackupInterval < 0 {
+		return nil, fmt.Errorf("backup_interval must be greater or equal to 0")
+	}
+	if config.BackupRetention != nil && *config.BackupRetention < 0 {
+		return nil, fmt.Errorf("backup_retention must be greater or equal to 0")
+	}
+	for method, budget := range config.HandlerTimeBudgets {
+		if budget < 0 {
+			return nil, fmt.Errorf("handler_time_budgets of %s must be greater or equal to 0", method)
+		}
+	}
+	if config.CatchingUpBlockTimeLag != nil && *config.CatchingUpBlockTimeLag < 0 {
+		return nil, fmt.Errorf("catching_up_block_time_lag must be greater or equal to 0")
+	}
+	if config.BudgetBreakerThreshold != nil && *config.BudgetBreakerThreshold <= 0 {
+		return nil, fmt.Errorf("budget_breaker_threshold must be greater than 0")
+	}
+	return &config, nil
+}
+
+// ApplyConfig applies config immediately. It must not be called while app is processing
+// ABCI requests, use ReloadConfig instead.
+func (app *ABCIApplication) ApplyConfig(config *Config) {
+	if config.LogLevel != nil {
+		level, _ := logrus.ParseLevel(*config.LogLevel)
+		logrus.SetLevel(level)
+	}
+	if config.MetricsEnabled != nil {
+		setMetricsEnabled(*config.MetricsEnabled)
+	}
+	if config.QueryCacheSize != nil {
+		app.queryCache.setMaxSize(*config.QueryCacheSize)
+	}
+	if config.StoreQueryEnabled != nil {
+		app.storeQueryEnabled = *config.StoreQueryEnabled
+	}
+	if config.QueryAccessLogEnabled != nil {
+		app.queryAccessLogEnabled = *config.QueryAccessLogEnabled
+	}
+	if config.CatchingUpBlockTimeLag != nil {
+		app.catchingUpBlockTimeLag = time.Duration(*config.CatchingUpBlockTimeLag) * time.Second
+	}
+	if config.InvariantCheck != nil {
+		app.invariantCheckMode = *config.InvariantCheck
+	}
+	if config.InvariantCheckInterval != nil {
+		app.invariantCheckInterval = *config.InvariantCheckInterval
+	}
+	if config.QueryRateLimits != nil {
+		app.queryLimiter.setRateLimits(config.QueryRateLimits)
+	}
+	if config.MaxConcurrentQueries != nil {
+		app.queryLimiter.setMaxConcurrent(*config.MaxConcurrentQueries)
+	}
+	if config.QueryCompressMinSize != nil {
+		app.compressionMinSize = *config.QueryCompressMinSize
+	}
+	if config.PruneKeepBlocks != nil {
+		app.pruner.setKeepBlocks(*config.PruneKeepBlocks)
+	}
+	if config.PrunePaused != nil {
+		app.pruner.setPaused(*config.PrunePaused)
+	}
+	if config.CrashReportDir != nil {
+		app.crashReportDir = *config.CrashReportDir
+	}
+	if config.BackupInterval != nil {
+		app.backup.setInterval(*config.BackupInterval)
+	}
+	if config.BackupRetention != nil {
+		app.backup.setRetention(*config.BackupRetention)
+	}
+	if config.BackupDir != nil {
+		app.backup.setDir(*config.BackupDir)
+	}
+	if config.HandlerTimeBudgets != nil {
+		app.handlerBudget.setBudgets(config.HandlerTimeBudgets)
+	}
+	if config.BudgetBreakerThreshold != nil {
+		app.handlerBudget.setThreshold(*config.BudgetBreakerThreshold)
+	}
+	app.logger.Infof("Config applied")
+}
+
+// ReloadConfig reads config file and applies it at next commit
+// so that settings are not changed while a block or query is being processed
+func (app *ABCIApplication) ReloadConfig(path string) error {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	// Replace config which is not applied yet
+	select {
+	case <-app.pendingConfig:
+	default:
+	}
+	app.pendingConfig <- config
+	return nil
+}
+
+func (app *ABCIApplication) applyPendingConfig() {
+	select {
+	case config := <-app.pendingConfig:
+		app.ApplyConfig(config)
+	default:
+	}
+}
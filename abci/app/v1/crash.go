@@ -0,0 +1,136 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	protoTm "github.com/ndidplatform/smart-contract/v4/protos/tendermint"
+)
+
+// CrashReport is written to crash report directory when panic in DeliverTx, CheckTx
+// or Query is recovered. Parameter is not included since it may contain personal data,
+// only its hash.
+type CrashReport struct {
+	Time      time.Time `json:"time"`
+	Call      string    `json:"call"`
+	Method    string    `json:"method"`
+	ParamHash string    `json:"param_hash"`
+	Height    int64     `json:"height"`
+	Panic     string    `json:"panic"`
+	Stack     string    `json:"stack"`
+	Config    Config    `json:"config"`
+}
+
+// recordPanic counts recovered panic and writes crash report of it when crash report
+// directory is set. call is ABCI call (DeliverTx, CheckTx or Query) and request is its
+// Tx or query data.
+func (app *ABCIApplication) recordPanic(call string, request []byte, r interface{}, stack []byte) {
+	var method, param string
+	if decoded, err := utils.DecodeEnvelope(request); err == nil {
+		request = decoded
+	}
+	if call == "Query" {
+		var query protoTm.Query
+		if proto.Unmarshal(request, &query) == nil {
+			method, param = query.Method, query.Params
+		}
+	} else {
+		var tx protoTm.Tx
+		if proto.Unmarshal(request, &tx) == nil {
+			method, param = tx.Method, tx.Params
+		}
+	}
+	go recordPanicMetrics(call, method)
+
+	if app.crashReportDir == "" {
+		return
+	}
+	report := CrashReport{
+		Time:      time.Now().UTC(),
+		Call:      call,
+		Method:    method,
+		ParamHash: utils.CanonicalParamHash(app.CurrentChain, param),
+		Height:    app.state.Height,
+		Panic:     fmt.Sprintf("%v", r),
+		Stack:     string(stack),
+		Config:    app.configSnapshot(),
+	}
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		app.logger.Errorf("Could not marshal crash report: %s", err.Error())
+		return
+	}
+	err = os.MkdirAll(app.crashReportDir, 0755)
+	if err != nil {
+		app.logger.Errorf("Could not create crash report directory: %s", err.Error())
+		return
+	}
+	fileName := fmt.Sprintf("crash-%s-%d-%s.json", report.Time.Format("20060102T150405.000000000"), report.Height, call)
+	err = ioutil.WriteFile(filepath.Join(app.crashReportDir, fileName), reportJSON, 0644)
+	if err != nil {
+		app.logger.Errorf("Could not write crash report: %s", err.Error())
+		return
+	}
+	app.logger.Errorf("Crash report is written to %s", filepath.Join(app.crashReportDir, fileName))
+}
+
+// configSnapshot returns settings currently applied to app
+func (app *ABCIApplication) configSnapshot() Config {
+	logLevel := logrus.GetLevel().String()
+	metricsEnabled := isMetricsEnabled()
+	queryCacheSize := app.queryCache.maxSize
+	storeQueryEnabled := app.storeQueryEnabled
+	queryAccessLogEnabled := app.queryAccessLogEnabled
+	catchingUpBlockTimeLag := int64(app.catchingUpBlockTimeLag / time.Second)
+	invariantCheck := app.invariantCheckMode
+	invariantCheckInterval := app.invariantCheckInterval
+	compressionMinSize := app.compressionMinSize
+	pruneKeepBlocks := atomic.LoadInt64(&app.pruner.keepBlocks)
+	prunePaused := app.pruner.isPaused()
+	crashReportDir := app.crashReportDir
+	return Config{
+		LogLevel:               &logLevel,
+		MetricsEnabled:         &metricsEnabled,
+		QueryCacheSize:         &queryCacheSize,
+		StoreQueryEnabled:      &storeQueryEnabled,
+		QueryAccessLogEnabled:  &queryAccessLogEnabled,
+		CatchingUpBlockTimeLag: &catchingUpBlockTimeLag,
+		InvariantCheck:         &invariantCheck,
+		InvariantCheckInterval: &invariantCheckInterval,
+		QueryCompressMinSize:   &compressionMinSize,
+		PruneKeepBlocks:        &pruneKeepBlocks,
+		PrunePaused:            &prunePaused,
+		CrashReportDir:         &crashReportDir,
+	}
+}
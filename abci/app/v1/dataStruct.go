@@ -22,6 +22,12 @@
 
 package app
 
+import (
+	"encoding/json"
+
+	"github.com/ndidplatform/smart-contract/v4/client"
+)
+
 type NodePublicKey struct {
 	NodeID    string `json:"node_id"`
 	PublicKey string `json:"public_key"`
@@ -29,18 +35,24 @@ type NodePublicKey struct {
 
 type GetNodePublicKeyParam struct {
 	NodeID string `json:"node_id"`
+	Height int64  `json:"height"`
 }
 
 type GetNodePublicKeyResult struct {
 	PublicKey string `json:"public_key"`
+	Algorithm string `json:"algorithm"`
+	Version   int64  `json:"version"`
 }
 
 type GetNodeMasterPublicKeyParam struct {
 	NodeID string `json:"node_id"`
+	Height int64  `json:"height"`
 }
 
 type GetNodeMasterPublicKeyResult struct {
 	MasterPublicKey string `json:"master_public_key"`
+	Algorithm       string `json:"algorithm"`
+	Version         int64  `json:"version"`
 }
 
 type Identity struct {
@@ -127,6 +139,7 @@ type DataRequest struct {
 	RequestParamsHash    string   `json:"request_params_hash"`
 	AnsweredAsIdList     []string `json:"answered_as_id_list"`
 	ReceivedDataFromList []string `json:"received_data_from_list"`
+	RequestParamsSalt    string   `json:"request_params_salt,omitempty"`
 }
 
 type CreateRequestParam struct {
@@ -138,8 +151,21 @@ type CreateRequestParam struct {
 	IdPIDList       []string      `json:"idp_id_list"`
 	DataRequestList []DataRequest `json:"data_request_list"`
 	MessageHash     string        `json:"request_message_hash"`
+	MessageSalt     string        `json:"request_message_salt"`
 	Purpose         string        `json:"purpose"`
 	Mode            int32         `json:"mode"`
+	AutoClose       bool          `json:"auto_close"`
+	// IdPResponseTimeout is seconds after request creation (block time) which IdPs can respond in
+	IdPResponseTimeout int64 `json:"idp_response_timeout"`
+	// IdentityTarget resolves idp_id_list from IdPs associated with the identity (mode 2 and 3 only)
+	IdentityTarget *RequestIdentityTarget `json:"identity_target"`
+	PriorityClass  string                 `json:"priority_class"`
+}
+
+type RequestIdentityTarget struct {
+	IdentityNamespace      string  `json:"identity_namespace"`
+	IdentityIdentifierHash string  `json:"identity_identifier_hash"`
+	MinIal                 float64 `json:"min_ial"`
 }
 
 type Response struct {
@@ -150,20 +176,49 @@ type Response struct {
 	IdpID          string  `json:"idp_id"`
 	ValidIal       *bool   `json:"valid_ial"`
 	ValidSignature *bool   `json:"valid_signature"`
+	AgentID        string  `json:"agent_id,omitempty"`
+	AccessorID     string  `json:"accessor_id,omitempty"`
+	BlockHeight    int64   `json:"block_height,omitempty"`
+	BlockTime      int64   `json:"block_time,omitempty"`
 }
 
 type CreateIdpResponseParam struct {
-	Aal       float64 `json:"aal"`
-	Ial       float64 `json:"ial"`
-	RequestID string  `json:"request_id"`
-	Signature string  `json:"signature"`
-	Status    string  `json:"status"`
+	Aal                float64 `json:"aal"`
+	Ial                float64 `json:"ial"`
+	RequestID          string  `json:"request_id"`
+	Signature          string  `json:"signature"`
+	Status             string  `json:"status"`
+	RequestMessageHash string  `json:"request_message_hash,omitempty"`
+	AccessorID         string  `json:"accessor_id,omitempty"`
 }
 
 type GetRequestParam struct {
 	RequestID string `json:"request_id"`
 }
 
+type GetRequestMessageProofResult struct {
+	RequestID           string                   `json:"request_id"`
+	RequesterNodeID     string                   `json:"requester_node_id"`
+	MessageHash         string                   `json:"request_message_hash"`
+	MessageSalt         string                   `json:"request_message_salt"`
+	DataRequestList     []DataRequestParamsProof `json:"data_request_list"`
+	ResponseList        []ResponseSignatureProof `json:"response_list"`
+	CreationBlockHeight int64                    `json:"creation_block_height"`
+	CreationBlockTime   int64                    `json:"creation_block_time"`
+}
+
+type DataRequestParamsProof struct {
+	ServiceID         string `json:"service_id"`
+	RequestParamsHash string `json:"request_params_hash"`
+	RequestParamsSalt string `json:"request_params_salt"`
+}
+
+type ResponseSignatureProof struct {
+	IdpID     string `json:"idp_id"`
+	Status    string `json:"status"`
+	Signature string `json:"signature"`
+}
+
 type GetRequestResult struct {
 	IsClosed    bool   `json:"closed"`
 	IsTimedOut  bool   `json:"timed_out"`
@@ -172,28 +227,68 @@ type GetRequestResult struct {
 }
 
 type GetRequestDetailResult struct {
-	RequestID           string        `json:"request_id"`
-	MinIdp              int           `json:"min_idp"`
-	MinAal              float64       `json:"min_aal"`
-	MinIal              float64       `json:"min_ial"`
-	Timeout             int           `json:"request_timeout"`
-	IdPIDList           []string      `json:"idp_id_list"`
-	DataRequestList     []DataRequest `json:"data_request_list"`
-	MessageHash         string        `json:"request_message_hash"`
-	Responses           []Response    `json:"response_list"`
-	IsClosed            bool          `json:"closed"`
-	IsTimedOut          bool          `json:"timed_out"`
-	Purpose             string        `json:"purpose"`
-	Mode                int32         `json:"mode"`
-	RequesterNodeID     string        `json:"requester_node_id"`
-	CreationBlockHeight int64         `json:"creation_block_height"`
-	CreationChainID     string        `json:"creation_chain_id"`
+	RequestID             string         `json:"request_id"`
+	MinIdp                int            `json:"min_idp"`
+	MinAal                float64        `json:"min_aal"`
+	MinIal                float64        `json:"min_ial"`
+	Timeout               int            `json:"request_timeout"`
+	IdPIDList             []string       `json:"idp_id_list"`
+	DataRequestList       []DataRequest  `json:"data_request_list"`
+	MessageHash           string         `json:"request_message_hash"`
+	Responses             []Response     `json:"response_list"`
+	IsClosed              bool           `json:"closed"`
+	IsTimedOut            bool           `json:"timed_out"`
+	Purpose               string         `json:"purpose"`
+	Mode                  int32          `json:"mode"`
+	RequesterNodeID       string         `json:"requester_node_id"`
+	CreationBlockHeight   int64          `json:"creation_block_height"`
+	CreationChainID       string         `json:"creation_chain_id"`
+	AutoClose             bool           `json:"auto_close"`
+	TimeoutExtension      int64          `json:"timeout_extension"`
+	IdPResponseTimeout    int64          `json:"idp_response_timeout"`
+	PriorityClass         string         `json:"priority_class"`
+	Status                string         `json:"status"`
+	Summary               RequestSummary `json:"summary"`
+	CreationBlockTime     int64          `json:"creation_block_time"`
+	LastUpdateBlockHeight int64          `json:"last_update_block_height"`
+	LastUpdateBlockTime   int64          `json:"last_update_block_time"`
+}
+
+type GetRequestStatusResult struct {
+	RequestID string         `json:"request_id"`
+	Status    string         `json:"status"`
+	Summary   RequestSummary `json:"summary"`
+}
+
+type GetDataRequestProgressParam struct {
+	RequestID string `json:"request_id"`
+	ServiceID string `json:"service_id"`
+}
+
+type GetDataRequestProgressResult struct {
+	RequestID            string   `json:"request_id"`
+	ServiceID            string   `json:"service_id"`
+	Count                int64    `json:"min_as"`
+	As                   []string `json:"as_id_list"`
+	AnsweredAsIdList     []string `json:"answered_as_id_list"`
+	ReceivedDataFromList []string `json:"received_data_from_list"`
+	Completed            bool     `json:"completed"`
+}
+
+type RequestSummary struct {
+	AcceptCount     int64            `json:"accept_count"`
+	RejectCount     int64            `json:"reject_count"`
+	ErrorCount      int64            `json:"error_count"`
+	SignedDataCount map[string]int64 `json:"signed_data_count"`
 }
 
 type SignDataParam struct {
-	ServiceID string `json:"service_id"`
-	RequestID string `json:"request_id"`
-	Signature string `json:"signature"`
+	ServiceID         string `json:"service_id"`
+	RequestID         string `json:"request_id"`
+	Signature         string `json:"signature"`
+	DataSchemaVersion string `json:"data_schema_version"`
+	DataHash          string `json:"data_hash"`
+	DataContentType   string `json:"data_content_type"`
 }
 
 type AddServiceParam struct {
@@ -208,10 +303,11 @@ type DisableServiceParam struct {
 }
 
 type RegisterServiceDestinationParam struct {
-	MinAal                 float64  `json:"min_aal"`
-	MinIal                 float64  `json:"min_ial"`
-	ServiceID              string   `json:"service_id"`
-	SupportedNamespaceList []string `json:"supported_namespace_list"`
+	MinAal                   float64  `json:"min_aal"`
+	MinIal                   float64  `json:"min_ial"`
+	ServiceID                string   `json:"service_id"`
+	SupportedNamespaceList   []string `json:"supported_namespace_list"`
+	SupportedDataURLTypeList []string `json:"supported_data_url_type_list"`
 }
 
 type GetServiceDetailParam struct {
@@ -237,22 +333,34 @@ type GetAsNodesByServiceIdResult struct {
 }
 
 type ASNodeResult struct {
-	ID                     string   `json:"node_id"`
-	Name                   string   `json:"node_name"`
-	MinIal                 float64  `json:"min_ial"`
-	MinAal                 float64  `json:"min_aal"`
-	SupportedNamespaceList []string `json:"supported_namespace_list"`
+	ID                       string   `json:"node_id"`
+	Name                     string   `json:"node_name"`
+	MinIal                   float64  `json:"min_ial"`
+	MinAal                   float64  `json:"min_aal"`
+	SupportedNamespaceList   []string `json:"supported_namespace_list"`
+	SupportedDataURLTypeList []string `json:"supported_data_url_type_list"`
 }
 
 type GetAsNodesByServiceIdWithNameResult struct {
 	Node []ASNodeResult `json:"node"`
 }
 
-type InitNDIDParam struct {
-	NodeID           string `json:"node_id"`
-	PublicKey        string `json:"public_key"`
-	MasterPublicKey  string `json:"master_public_key"`
-	ChainHistoryInfo string `json:"chain_history_info"`
+// Parameters of migration Txs are defined in client package for migrate tooling
+type InitNDIDParam = client.InitNDIDParam
+
+type SetChainHistoryInfoParam = client.SetChainHistoryInfoParam
+
+type PreviousChain struct {
+	ChainID             string `json:"chain_id"`
+	FinalBlockHeight    int64  `json:"final_block_height"`
+	FinalAppHash        string `json:"final_app_hash"`
+	RecordedChainID     string `json:"recorded_chain_id"`
+	RecordedBlockHeight int64  `json:"recorded_block_height"`
+}
+
+type GetChainHistoryInfoResult struct {
+	CurrentChainID    string          `json:"current_chain_id"`
+	PreviousChainList []PreviousChain `json:"previous_chain_list"`
 }
 
 type TransferNDIDParam struct {
@@ -267,6 +375,27 @@ type RegisterNode struct {
 	Role            string  `json:"role"`
 	MaxIal          float64 `json:"max_ial"`
 	MaxAal          float64 `json:"max_aal"`
+	ParentIdPID     string  `json:"parent_idp_id"`
+}
+
+type RegisterNodeBatchEntry struct {
+	RegisterNode
+	Token float64 `json:"token"`
+}
+
+type RegisterNodeBatchParam struct {
+	NodeList []RegisterNodeBatchEntry `json:"node_list"`
+}
+
+type RegisterNodeBatchEntryResult struct {
+	NodeID string `json:"node_id"`
+	Code   uint32 `json:"code"`
+	Log    string `json:"log"`
+}
+
+type RegisterNodeBatchResult struct {
+	RegisteredCount int                            `json:"registered_count"`
+	ResultList      []RegisterNodeBatchEntryResult `json:"result_list"`
 }
 
 type NodeDetail struct {
@@ -286,13 +415,19 @@ type SetMqAddressesParam struct {
 	Addresses []MsqAddress `json:"addresses"`
 }
 
+type SetSupportedFeatureListParam struct {
+	SupportedFeatureList []string `json:"supported_feature_list"`
+}
+
 type GetMqAddressesParam struct {
 	NodeID string `json:"node_id"`
 }
 
 type MsqAddress struct {
-	IP   string `json:"ip"`
-	Port int64  `json:"port"`
+	IP       string `json:"ip"`
+	Port     int64  `json:"port"`
+	Priority int64  `json:"priority"`
+	Active   *bool  `json:"active"`
 }
 
 type SetNodeTokenParam struct {
@@ -310,6 +445,12 @@ type ReduceNodeTokenParam struct {
 	Amount float64 `json:"amount"`
 }
 
+type RefundNodeTokenParam struct {
+	NodeID string  `json:"node_id"`
+	Amount float64 `json:"amount"`
+	Reason string  `json:"reason"`
+}
+
 type GetNodeTokenParam struct {
 	NodeID string `json:"node_id"`
 }
@@ -318,6 +459,31 @@ type GetNodeTokenResult struct {
 	Amount float64 `json:"amount"`
 }
 
+type LowTokenThresholdParam struct {
+	Threshold float64 `json:"threshold"`
+}
+
+type GetTokenLedgerParam struct {
+	NodeID string `json:"node_id"`
+	Offset int64  `json:"offset"`
+	Limit  int64  `json:"limit"`
+}
+
+type TokenLedgerEntry struct {
+	Index       int64   `json:"index"`
+	Type        string  `json:"type"`
+	Amount      float64 `json:"amount"`
+	Balance     float64 `json:"balance"`
+	BlockHeight int64   `json:"block_height"`
+	Reason      string  `json:"reason"`
+}
+
+type GetTokenLedgerResult struct {
+	Amount    float64            `json:"amount"`
+	Total     int64              `json:"total"`
+	EntryList []TokenLedgerEntry `json:"entry_list"`
+}
+
 type SetPriceFuncParam struct {
 	Func  string  `json:"func"`
 	Price float64 `json:"price"`
@@ -342,11 +508,12 @@ type RequestIDParam struct {
 }
 
 type Namespace struct {
-	Namespace                                    string `json:"namespace"`
-	Description                                  string `json:"description"`
-	Active                                       bool   `json:"active"`
-	AllowedIdentifierCountInReferenceGroup       int32  `json:"allowed_identifier_count_in_reference_group"`
-	AllowedActiveIdentifierCountInReferenceGroup int32  `json:"allowed_active_identifier_count_in_reference_group"`
+	Namespace                                    string  `json:"namespace"`
+	Description                                  string  `json:"description"`
+	Active                                       bool    `json:"active"`
+	AllowedIdentifierCountInReferenceGroup       int32   `json:"allowed_identifier_count_in_reference_group"`
+	AllowedActiveIdentifierCountInReferenceGroup int32   `json:"allowed_active_identifier_count_in_reference_group"`
+	AllowedModeList                              []int32 `json:"allowed_mode_list"`
 }
 
 type DisableNamespaceParam struct {
@@ -412,8 +579,83 @@ type GetAccessorKeyResult struct {
 }
 
 type SetValidatorParam struct {
+	PublicKey  string `json:"public_key"`
+	Power      int64  `json:"power"`
+	PowerClass string `json:"power_class"`
+	// ActivationHeight stages validator change to take effect at given block height
+	ActivationHeight int64 `json:"activation_height"`
+}
+
+type SetValidatorNodeParam struct {
+	PublicKey string `json:"public_key"`
+	NodeID    string `json:"node_id"`
+}
+
+type GetValidatorNodeParam struct {
 	PublicKey string `json:"public_key"`
-	Power     int64  `json:"power"`
+}
+
+type GetValidatorNodeListParam struct {
+	NodeID string `json:"node_id"`
+}
+
+type ValidatorNodeResult struct {
+	PublicKey                   string `json:"public_key"`
+	Address                     string `json:"address"`
+	NodeID                      string `json:"node_id"`
+	MissedBlockCount            int64  `json:"missed_block_count"`
+	ConsecutiveMissedBlockCount int64  `json:"consecutive_missed_block_count"`
+	ByzantineEvidenceCount      int64  `json:"byzantine_evidence_count"`
+}
+
+type ValidatorMissThresholdParam struct {
+	Threshold int64 `json:"threshold"`
+}
+
+// UnknownMethodDetail is detail of unknown method error with known method nearest to it
+type UnknownMethodDetail struct {
+	Method          string `json:"method"`
+	SuggestedMethod string `json:"suggested_method,omitempty"`
+}
+
+type BlockWriteLimitParam struct {
+	MaxWriteCount int64 `json:"max_write_count"`
+}
+
+type RequestMessageHashCheckHeightParam struct {
+	ActivationHeight int64 `json:"activation_height"`
+}
+
+type GetValidatorNodeListResult struct {
+	ValidatorList []ValidatorNodeResult `json:"validator_list"`
+}
+
+type PendingValidatorUpdateResult struct {
+	ActivationHeight int64  `json:"activation_height"`
+	PublicKey        string `json:"public_key"`
+	Power            int64  `json:"power"`
+}
+
+type GetPendingValidatorUpdateListResult struct {
+	ValidatorUpdateList []PendingValidatorUpdateResult `json:"validator_update_list"`
+}
+
+type GetValidatorMisbehaviorListParam struct {
+	NodeID string `json:"node_id"`
+}
+
+type ValidatorMisbehaviorResult struct {
+	Address        string `json:"address"`
+	PublicKey      string `json:"public_key"`
+	EvidenceType   string `json:"evidence_type"`
+	EvidenceHeight int64  `json:"evidence_height"`
+	RecordedHeight int64  `json:"recorded_height"`
+	Action         string `json:"action"`
+}
+
+type GetValidatorMisbehaviorListResult struct {
+	NodeID          string                       `json:"node_id"`
+	MisbehaviorList []ValidatorMisbehaviorResult `json:"misbehavior_list"`
 }
 
 type SetDataReceivedParam struct {
@@ -443,28 +685,56 @@ type CheckExistingResult struct {
 }
 
 type GetNodeInfoParam struct {
-	NodeID string `json:"node_id"`
+	NodeID                  string `json:"node_id"`
+	IncludeRecordTimestamps bool   `json:"include_record_timestamps"`
+}
+
+type NodeRecordTimestamps struct {
+	CreationBlockHeight   int64 `json:"creation_block_height"`
+	CreationBlockTime     int64 `json:"creation_block_time"`
+	LastUpdateBlockHeight int64 `json:"last_update_block_height"`
+	LastUpdateBlockTime   int64 `json:"last_update_block_time"`
 }
 
 type GetNodeInfoResult struct {
-	PublicKey       string       `json:"public_key"`
-	MasterPublicKey string       `json:"master_public_key"`
-	NodeName        string       `json:"node_name"`
-	Role            string       `json:"role"`
-	Mq              []MsqAddress `json:"mq"`
-	Active          bool         `json:"active"`
+	PublicKey            string                `json:"public_key"`
+	MasterPublicKey      string                `json:"master_public_key"`
+	NodeName             string                `json:"node_name"`
+	Role                 string                `json:"role"`
+	Mq                   []MsqAddress          `json:"mq"`
+	Active               bool                  `json:"active"`
+	SupportedFeatureList []string              `json:"supported_feature_list"`
+	AdditionalRoleList   []string              `json:"additional_role_list,omitempty"`
+	RecordTimestamps     *NodeRecordTimestamps `json:"record_timestamps,omitempty"`
 }
 
 type GetNodeInfoIdPResult struct {
-	PublicKey                              string       `json:"public_key"`
-	MasterPublicKey                        string       `json:"master_public_key"`
-	NodeName                               string       `json:"node_name"`
-	Role                                   string       `json:"role"`
-	MaxIal                                 float64      `json:"max_ial"`
-	MaxAal                                 float64      `json:"max_aal"`
-	SupportedRequestMessageDataUrlTypeList []string     `json:"supported_request_message_data_url_type_list"`
-	Mq                                     []MsqAddress `json:"mq"`
-	Active                                 bool         `json:"active"`
+	PublicKey                              string                `json:"public_key"`
+	MasterPublicKey                        string                `json:"master_public_key"`
+	NodeName                               string                `json:"node_name"`
+	Role                                   string                `json:"role"`
+	MaxIal                                 float64               `json:"max_ial"`
+	MaxAal                                 float64               `json:"max_aal"`
+	SupportedRequestMessageDataUrlTypeList []string              `json:"supported_request_message_data_url_type_list"`
+	Mq                                     []MsqAddress          `json:"mq"`
+	Active                                 bool                  `json:"active"`
+	SupportedFeatureList                   []string              `json:"supported_feature_list"`
+	AdditionalRoleList                     []string              `json:"additional_role_list,omitempty"`
+	RecordTimestamps                       *NodeRecordTimestamps `json:"record_timestamps,omitempty"`
+}
+
+type GetNodeInfoIdPAgentResult struct {
+	PublicKey            string                `json:"public_key"`
+	MasterPublicKey      string                `json:"master_public_key"`
+	NodeName             string                `json:"node_name"`
+	Role                 string                `json:"role"`
+	MaxIal               float64               `json:"max_ial"`
+	MaxAal               float64               `json:"max_aal"`
+	ParentIdPID          string                `json:"parent_idp_id"`
+	Mq                   []MsqAddress          `json:"mq"`
+	Active               bool                  `json:"active"`
+	SupportedFeatureList []string              `json:"supported_feature_list"`
+	RecordTimestamps     *NodeRecordTimestamps `json:"record_timestamps,omitempty"`
 }
 
 type GetIdentityInfoParam struct {
@@ -479,6 +749,34 @@ type GetIdentityInfoResult struct {
 	ModeList []int32 `json:"mode_list"`
 }
 
+type GetIdentityModeListParam struct {
+	ReferenceGroupCode     string `json:"reference_group_code"`
+	IdentityNamespace      string `json:"identity_namespace"`
+	IdentityIdentifierHash string `json:"identity_identifier_hash"`
+}
+
+type IdentityModeListOfIdP struct {
+	NodeID   string  `json:"node_id"`
+	ModeList []int32 `json:"mode_list"`
+}
+
+type GetIdentityModeListResult struct {
+	ModeList []int32                 `json:"mode_list"`
+	IdPList  []IdentityModeListOfIdP `json:"idp_list"`
+}
+
+type AddNodeRoleParam struct {
+	NodeID string  `json:"node_id"`
+	Role   string  `json:"role"`
+	MaxIal float64 `json:"max_ial"`
+	MaxAal float64 `json:"max_aal"`
+}
+
+type RemoveNodeRoleParam struct {
+	NodeID string `json:"node_id"`
+	Role   string `json:"role"`
+}
+
 type UpdateNodeByNDIDParam struct {
 	NodeID   string  `json:"node_id"`
 	MaxIal   float64 `json:"max_ial"`
@@ -516,14 +814,20 @@ type GetDataSignatureParam struct {
 }
 
 type GetDataSignatureResult struct {
-	Signature string `json:"signature"`
+	Signature         string `json:"signature"`
+	DataSchemaVersion string `json:"data_schema_version"`
+	BlockHeight       int64  `json:"block_height"`
+	DataHash          string `json:"data_hash"`
+	DataContentType   string `json:"data_content_type"`
+	BlockTime         int64  `json:"block_time"`
 }
 
 type UpdateServiceDestinationParam struct {
-	ServiceID              string   `json:"service_id"`
-	MinIal                 float64  `json:"min_ial"`
-	MinAal                 float64  `json:"min_aal"`
-	SupportedNamespaceList []string `json:"supported_namespace_list"`
+	ServiceID                string   `json:"service_id"`
+	MinIal                   float64  `json:"min_ial"`
+	MinAal                   float64  `json:"min_aal"`
+	SupportedNamespaceList   []string `json:"supported_namespace_list"`
+	SupportedDataURLTypeList []string `json:"supported_data_url_type_list"`
 }
 
 type UpdateServiceParam struct {
@@ -551,12 +855,13 @@ type DisableNodeParam struct {
 }
 
 type Service struct {
-	ServiceID              string   `json:"service_id"`
-	MinIal                 float64  `json:"min_ial"`
-	MinAal                 float64  `json:"min_aal"`
-	Active                 bool     `json:"active"`
-	Suspended              bool     `json:"suspended"`
-	SupportedNamespaceList []string `json:"supported_namespace_list"`
+	ServiceID                string   `json:"service_id"`
+	MinIal                   float64  `json:"min_ial"`
+	MinAal                   float64  `json:"min_aal"`
+	Active                   bool     `json:"active"`
+	Suspended                bool     `json:"suspended"`
+	SupportedNamespaceList   []string `json:"supported_namespace_list"`
+	SupportedDataURLTypeList []string `json:"supported_data_url_type_list"`
 }
 
 type GetServicesByAsIDParam struct {
@@ -588,8 +893,52 @@ type TimeOutBlockRegisterIdentity struct {
 	TimeOutBlock int64 `json:"time_out_block"`
 }
 
+type MaxRequestTimeoutExtensionParam struct {
+	MaxExtension int64 `json:"max_extension"`
+}
+
+type RandomnessBeaconConfigParam struct {
+	Enabled bool `json:"enabled"`
+}
+
+type SetAppHashSchemeParam struct {
+	Version          int64 `json:"version"`
+	ActivationHeight int64 `json:"activation_height"`
+}
+
+type GetAppHashSchemeParam struct {
+	Height int64 `json:"height"`
+}
+
+type AppHashSchemeActivation struct {
+	Version          int64 `json:"version"`
+	ActivationHeight int64 `json:"activation_height"`
+}
+
+type GetAppHashSchemeResult struct {
+	Height         int64                     `json:"height"`
+	Version        int64                     `json:"version"`
+	ActivationList []AppHashSchemeActivation `json:"activation_list"`
+}
+
+type RequestListSizeLimitParam struct {
+	MaxDataRequestCount int64 `json:"max_data_request_count"`
+	MaxIdPCount         int64 `json:"max_idp_count"`
+}
+
+type RequestEscrowPriceParam struct {
+	IdPResponsePrice float64 `json:"idp_response_price"`
+	ASDataPrice      float64 `json:"as_data_price"`
+}
+
+type ExtendRequestTimeoutParam struct {
+	RequestID string `json:"request_id"`
+	Extension int64  `json:"extension"`
+}
+
 type GetIdpNodesInfoResult struct {
-	Node []interface{} `json:"node"`
+	Node      []interface{}    `json:"node"`
+	ErrorList []QueryItemError `json:"error_list,omitempty"`
 }
 
 type IdpNode struct {
@@ -615,17 +964,19 @@ type IdpNodeWithModeList struct {
 }
 
 type ASWithMqNode struct {
-	ID                     string       `json:"node_id"`
-	Name                   string       `json:"name"`
-	MinIal                 float64      `json:"min_ial"`
-	MinAal                 float64      `json:"min_aal"`
-	PublicKey              string       `json:"public_key"`
-	Mq                     []MsqAddress `json:"mq"`
-	SupportedNamespaceList []string     `json:"supported_namespace_list"`
+	ID                       string       `json:"node_id"`
+	Name                     string       `json:"name"`
+	MinIal                   float64      `json:"min_ial"`
+	MinAal                   float64      `json:"min_aal"`
+	PublicKey                string       `json:"public_key"`
+	Mq                       []MsqAddress `json:"mq"`
+	SupportedNamespaceList   []string     `json:"supported_namespace_list"`
+	SupportedDataURLTypeList []string     `json:"supported_data_url_type_list"`
 }
 
 type GetAsNodesInfoByServiceIdResult struct {
-	Node []interface{} `json:"node"`
+	Node      []interface{}    `json:"node"`
+	ErrorList []QueryItemError `json:"error_list,omitempty"`
 }
 
 type AddNodeToProxyNodeParam struct {
@@ -647,7 +998,10 @@ type GetNodeInfoResultRPandASBehindProxy struct {
 		Mq              []MsqAddress `json:"mq"`
 		Config          string       `json:"config"`
 	} `json:"proxy"`
-	Active bool `json:"active"`
+	Active               bool                  `json:"active"`
+	SupportedFeatureList []string              `json:"supported_feature_list"`
+	AdditionalRoleList   []string              `json:"additional_role_list,omitempty"`
+	RecordTimestamps     *NodeRecordTimestamps `json:"record_timestamps,omitempty"`
 }
 
 type GetNodeInfoResultIdPandASBehindProxy struct {
@@ -666,7 +1020,10 @@ type GetNodeInfoResultIdPandASBehindProxy struct {
 		Mq              []MsqAddress `json:"mq"`
 		Config          string       `json:"config"`
 	} `json:"proxy"`
-	Active bool `json:"active"`
+	Active               bool                  `json:"active"`
+	SupportedFeatureList []string              `json:"supported_feature_list"`
+	AdditionalRoleList   []string              `json:"additional_role_list,omitempty"`
+	RecordTimestamps     *NodeRecordTimestamps `json:"record_timestamps,omitempty"`
 }
 
 type UpdateNodeProxyNodeParam struct {
@@ -711,13 +1068,14 @@ type IdpNodeBehindProxyWithModeList struct {
 }
 
 type ASWithMqNodeBehindProxy struct {
-	NodeID                 string   `json:"node_id"`
-	Name                   string   `json:"name"`
-	MinIal                 float64  `json:"min_ial"`
-	MinAal                 float64  `json:"min_aal"`
-	PublicKey              string   `json:"public_key"`
-	SupportedNamespaceList []string `json:"supported_namespace_list"`
-	Proxy                  struct {
+	NodeID                   string   `json:"node_id"`
+	Name                     string   `json:"name"`
+	MinIal                   float64  `json:"min_ial"`
+	MinAal                   float64  `json:"min_aal"`
+	PublicKey                string   `json:"public_key"`
+	SupportedNamespaceList   []string `json:"supported_namespace_list"`
+	SupportedDataURLTypeList []string `json:"supported_data_url_type_list"`
+	Proxy                    struct {
 		NodeID    string       `json:"node_id"`
 		PublicKey string       `json:"public_key"`
 		Mq        []MsqAddress `json:"mq"`
@@ -730,7 +1088,14 @@ type GetNodesBehindProxyNodeParam struct {
 }
 
 type GetNodesBehindProxyNodeResult struct {
-	Nodes []interface{} `json:"nodes"`
+	Nodes     []interface{}    `json:"nodes"`
+	ErrorList []QueryItemError `json:"error_list,omitempty"`
+}
+
+// QueryItemError marks item of query result which record is missing or corrupt
+type QueryItemError struct {
+	NodeID string `json:"node_id"`
+	Error  string `json:"error"`
 }
 
 type IdPBehindProxy struct {
@@ -760,22 +1125,38 @@ type Proxy struct {
 }
 
 type GetNodeIDListParam struct {
-	Role string `json:"role"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+	Offset int64  `json:"offset"`
+	Limit  int64  `json:"limit"`
 }
 
 type GetNodeIDListResult struct {
 	NodeIDList []string `json:"node_id_list"`
+	Total      int64    `json:"total"`
 }
 
 type GetMqAddressesResult []MsqAddress
 
 type GetAccessorsInAccessorGroupParam struct {
-	AccessorGroupID string `json:"accessor_group_id"`
-	IdpID           string `json:"idp_id"`
+	ReferenceGroupCode string `json:"reference_group_code"`
+	AccessorID         string `json:"accessor_id"`
+	IdpID              string `json:"idp_id"`
+}
+
+type AccessorInAccessorGroup struct {
+	AccessorID          string `json:"accessor_id"`
+	AccessorType        string `json:"accessor_type"`
+	AccessorPublicKey   string `json:"accessor_public_key"`
+	IdpID               string `json:"idp_id"`
+	Active              bool   `json:"active"`
+	Revoked             bool   `json:"revoked"`
+	CreationBlockHeight int64  `json:"creation_block_height"`
 }
 
 type GetAccessorsInAccessorGroupResult struct {
-	AccessorList []string `json:"accessor_list"`
+	ReferenceGroupCode string                    `json:"reference_group_code"`
+	AccessorList       []AccessorInAccessorGroup `json:"accessor_list"`
 }
 
 type RevokeAccessorParam struct {
@@ -791,20 +1172,13 @@ type GetAccessorOwnerResult struct {
 	NodeID string `json:"node_id"`
 }
 
-type KeyValue struct {
-	Key   []byte `json:"key"`
-	Value []byte `json:"value"`
-}
+type KeyValue = client.KeyValue
 
-type SetInitDataParam struct {
-	KVList []KeyValue `json:"kv_list"`
-}
+type SetInitDataParam = client.SetInitDataParam
 
-type EndInitParam struct{}
+type EndInitParam = client.EndInitParam
 
-type SetLastBlockParam struct {
-	BlockHeight int64 `json:"block_height"`
-}
+type SetLastBlockParam = client.SetLastBlockParam
 
 type IsInitEndedParam struct{}
 
@@ -825,6 +1199,24 @@ type GetReferenceGroupCodeByAccessorIDParam struct {
 	AccessorID string `json:"accessor_id"`
 }
 
+type GetReferenceGroupIdPListParam struct {
+	ReferenceGroupCode     string `json:"reference_group_code"`
+	IdentityNamespace      string `json:"identity_namespace"`
+	IdentityIdentifierHash string `json:"identity_identifier_hash"`
+}
+
+type IdPInReferenceGroup struct {
+	NodeID   string  `json:"node_id"`
+	ModeList []int32 `json:"mode_list"`
+	Ial      float64 `json:"ial"`
+	Active   bool    `json:"active"`
+}
+
+type GetReferenceGroupIdPListResult struct {
+	ReferenceGroupCode string                `json:"reference_group_code"`
+	IdPList            []IdPInReferenceGroup `json:"idp_list"`
+}
+
 type RevokeIdentityAssociationParam struct {
 	ReferenceGroupCode     string `json:"reference_group_code"`
 	IdentityNamespace      string `json:"identity_namespace"`
@@ -836,6 +1228,13 @@ type GetAllowedModeListResult struct {
 	AllowedModeList []int32 `json:"allowed_mode_list"`
 }
 
+type UpgradeIdentityModeParam struct {
+	ReferenceGroupCode     string `json:"reference_group_code"`
+	IdentityNamespace      string `json:"identity_namespace"`
+	IdentityIdentifierHash string `json:"identity_identifier_hash"`
+	RequestID              string `json:"request_id"`
+}
+
 type UpdateIdentityModeListParam struct {
 	ReferenceGroupCode     string  `json:"reference_group_code"`
 	IdentityNamespace      string  `json:"identity_namespace"`
@@ -862,10 +1261,11 @@ type GetAllowedMinIalForRegisterIdentityAtFirstIdpResult struct {
 }
 
 type UpdateNamespaceParam struct {
-	Namespace                                    string `json:"namespace"`
-	Description                                  string `json:"description"`
-	AllowedIdentifierCountInReferenceGroup       int32  `json:"allowed_identifier_count_in_reference_group"`
-	AllowedActiveIdentifierCountInReferenceGroup int32  `json:"allowed_active_identifier_count_in_reference_group"`
+	Namespace                                    string  `json:"namespace"`
+	Description                                  string  `json:"description"`
+	AllowedIdentifierCountInReferenceGroup       int32   `json:"allowed_identifier_count_in_reference_group"`
+	AllowedActiveIdentifierCountInReferenceGroup int32   `json:"allowed_active_identifier_count_in_reference_group"`
+	AllowedModeList                              []int32 `json:"allowed_mode_list"`
 }
 
 type RevokeAndAddAccessorParam struct {
@@ -875,3 +1275,385 @@ type RevokeAndAddAccessorParam struct {
 	AccessorType       string `json:"accessor_type"`
 	RequestID          string `json:"request_id"`
 }
+
+type GetIdPAgentListParam struct {
+	ParentIdPID string `json:"parent_idp_id"`
+}
+
+type RegisterIdentityAndCreateIdpResponseParam struct {
+	RegisterIdentity  RegisterIdentityParam  `json:"register_identity"`
+	CreateIdpResponse CreateIdpResponseParam `json:"create_idp_response"`
+}
+
+type CheckRevokedPublicKeyParam struct {
+	PublicKey string `json:"public_key"`
+}
+
+type CheckRevokedPublicKeyResult struct {
+	Revoked bool `json:"revoked"`
+}
+
+type GetDataSchemaParam struct {
+	ServiceID         string `json:"service_id"`
+	DataSchemaVersion string `json:"data_schema_version"`
+}
+
+type GetDataSchemaResult struct {
+	ServiceID         string `json:"service_id"`
+	DataSchema        string `json:"data_schema"`
+	DataSchemaVersion string `json:"data_schema_version"`
+}
+
+type AnchorConsentReceiptParam struct {
+	RequestID          string `json:"request_id"`
+	ConsentReceiptHash string `json:"consent_receipt_hash"`
+}
+
+type GetConsentReceiptListParam struct {
+	RequestID string `json:"request_id"`
+}
+
+type ConsentReceipt struct {
+	NodeID             string `json:"node_id"`
+	ConsentReceiptHash string `json:"consent_receipt_hash"`
+	BlockHeight        int64  `json:"block_height"`
+}
+
+type GetConsentReceiptListResult struct {
+	ConsentReceiptList []ConsentReceipt `json:"consent_receipt_list"`
+}
+
+type GetStatisticsParam struct {
+	Month string `json:"month"`
+}
+
+type ServiceStatistics struct {
+	ServiceID     string `json:"service_id"`
+	SignDataCount int64  `json:"sign_data_count"`
+}
+
+type GetStatisticsResult struct {
+	Month                 string              `json:"month"`
+	RequestCreatedCount   int64               `json:"request_created_count"`
+	RequestClosedCount    int64               `json:"request_closed_count"`
+	RequestTimedOutCount  int64               `json:"request_timed_out_count"`
+	ServiceStatisticsList []ServiceStatistics `json:"service_statistics_list"`
+}
+
+type GetServiceStatisticsParam struct {
+	ServiceID string `json:"service_id"`
+}
+
+type GetServiceStatisticsResult struct {
+	ServiceID     string `json:"service_id"`
+	RequestCount  int64  `json:"request_count"`
+	SignDataCount int64  `json:"sign_data_count"`
+	AsCount       int64  `json:"as_count"`
+}
+
+type BatchTx struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type BatchParam struct {
+	TxList []BatchTx `json:"tx_list"`
+}
+
+type SetNodeQuotaParam struct {
+	NodeID       string `json:"node_id"`
+	Method       string `json:"method"`
+	DailyLimit   int64  `json:"daily_limit"`
+	MonthlyLimit int64  `json:"monthly_limit"`
+}
+
+type GetNodeQuotaParam struct {
+	NodeID string `json:"node_id"`
+	Method string `json:"method"`
+}
+
+type GetNodeQuotaResult struct {
+	DailyLimit   int64 `json:"daily_limit"`
+	MonthlyLimit int64 `json:"monthly_limit"`
+	DailyUsage   int64 `json:"daily_usage"`
+	MonthlyUsage int64 `json:"monthly_usage"`
+}
+
+type SimulateTxParam struct {
+	NodeID string          `json:"node_id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type SimulateTxResult struct {
+	Code uint32  `json:"code"`
+	Log  string  `json:"log"`
+	Fee  float64 `json:"fee"`
+}
+
+type CheckInvariantsResult struct {
+	Valid      bool     `json:"valid"`
+	Violations []string `json:"violations"`
+}
+
+type AdminApprovalPolicyParam struct {
+	OperatorPublicKeyList []string `json:"operator_public_key_list"`
+	RequiredApprovalCount int64    `json:"required_approval_count"`
+}
+
+type CreateAdminProposalParam struct {
+	ProposalID string          `json:"proposal_id"`
+	Method     string          `json:"method"`
+	Params     json.RawMessage `json:"params"`
+}
+
+type ApproveAdminProposalParam struct {
+	ProposalID        string `json:"proposal_id"`
+	OperatorPublicKey string `json:"operator_public_key"`
+	Signature         []byte `json:"signature"`
+}
+
+type AdminProposal struct {
+	ProposalID            string          `json:"proposal_id"`
+	Method                string          `json:"method"`
+	Params                json.RawMessage `json:"params"`
+	ApprovalCount         int64           `json:"approval_count"`
+	RequiredApprovalCount int64           `json:"required_approval_count"`
+	CreationBlockHeight   int64           `json:"creation_block_height"`
+}
+
+type GetPendingAdminProposalListResult struct {
+	ProposalList []AdminProposal `json:"proposal_list"`
+}
+
+type GovernanceActionDelayParam struct {
+	DelayBlock int64 `json:"delay_block"`
+}
+
+type GovernanceActionIDParam struct {
+	ActionID string `json:"action_id"`
+}
+
+type GovernanceAction struct {
+	ActionID             string          `json:"action_id"`
+	Method               string          `json:"method"`
+	Params               json.RawMessage `json:"params"`
+	ScheduledBlockHeight int64           `json:"scheduled_block_height"`
+	EffectiveBlockHeight int64           `json:"effective_block_height"`
+}
+
+type GetPendingGovernanceActionListResult struct {
+	ActionList []GovernanceAction `json:"action_list"`
+}
+
+type SetMethodPausedParam struct {
+	Method string `json:"method"`
+	Paused bool   `json:"paused"`
+}
+
+type GetPausedMethodListResult struct {
+	MethodList []string `json:"method_list"`
+}
+
+type MultiQueryItem struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type MultiQueryParam struct {
+	Queries []MultiQueryItem `json:"queries"`
+}
+
+type MultiQueryItemResult struct {
+	Code  uint32          `json:"code"`
+	Log   string          `json:"log"`
+	Value json.RawMessage `json:"value"`
+}
+
+type MultiQueryResult struct {
+	Results []MultiQueryItemResult `json:"results"`
+}
+
+type GetChangesAtHeightParam struct {
+	Height int64 `json:"height"`
+}
+
+type KeyChange struct {
+	Key       string `json:"key"`
+	Operation string `json:"operation"`
+	ValueHash string `json:"value_hash,omitempty"`
+}
+
+type GetChangesAtHeightResult struct {
+	Height  int64       `json:"height"`
+	Changes []KeyChange `json:"changes"`
+}
+
+type GetBlockActivityParam struct {
+	Height int64 `json:"height"`
+}
+
+type TxActivity struct {
+	Method    string `json:"method"`
+	NodeID    string `json:"node_id"`
+	Code      uint32 `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+	ParamHash string `json:"param_hash,omitempty"`
+}
+
+type GetBlockActivityResult struct {
+	Height        int64        `json:"height"`
+	TxList        []TxActivity `json:"tx_list"`
+	RequestIDList []string     `json:"request_id_list"`
+}
+
+type SetNodeContactParam struct {
+	Email          string `json:"email"`
+	WebhookURLHash string `json:"webhook_url_hash"`
+}
+
+type GetNodeContactListParam struct {
+	NodeIDList []string `json:"node_id_list"`
+}
+
+type NodeContact struct {
+	NodeID             string `json:"node_id"`
+	Email              string `json:"email"`
+	WebhookURLHash     string `json:"webhook_url_hash"`
+	UpdatedBlockHeight int64  `json:"updated_block_height"`
+}
+
+type NodeIDAliasParam struct {
+	NodeID string `json:"node_id"`
+	Alias  string `json:"alias"`
+}
+
+type GetNodeIDAliasParam struct {
+	NodeID string `json:"node_id"`
+}
+
+type RebuildIndexesParam struct {
+	IndexList []string `json:"index_list"`
+}
+
+type GetNodeContactListResult struct {
+	ContactList []NodeContact `json:"contact_list"`
+}
+
+type InfoData struct {
+	KeyCount           int64  `json:"key_count"`
+	ByteSize           int64  `json:"byte_size"`
+	StateSchemaVersion int64  `json:"state_schema_version"`
+	NetworkNamespace   string `json:"network_namespace,omitempty"`
+}
+
+type ValidatorPowerClass struct {
+	Name  string `json:"name"`
+	Power int64  `json:"power"`
+}
+
+type ValidatorPowerPolicyParam struct {
+	Mode           string                `json:"mode"`
+	EqualPower     int64                 `json:"equal_power"`
+	PowerClassList []ValidatorPowerClass `json:"power_class_list"`
+}
+
+type RequestPriorityClass struct {
+	Name                string `json:"name"`
+	MaxOpenRequestCount int64  `json:"max_open_request_count"`
+	OpenRequestCount    *int64 `json:"open_request_count,omitempty"`
+}
+
+type SetRequestPriorityClassListParam struct {
+	PriorityClassList []RequestPriorityClass `json:"priority_class_list"`
+}
+
+type GetRequestPriorityClassListParam struct {
+	NodeID string `json:"node_id"`
+}
+
+type GetRequestPriorityClassListResult struct {
+	PriorityClassList []RequestPriorityClass `json:"priority_class_list"`
+}
+
+type SetQueryVisibilityParam struct {
+	Method          string   `json:"method"`
+	Public          bool     `json:"public"`
+	AllowedRoleList []string `json:"allowed_role_list"`
+}
+
+type SignedQueryParam struct {
+	NodeID    string `json:"node_id"`
+	Method    string `json:"method"`
+	Params    string `json:"params"`
+	Nonce     string `json:"nonce"`
+	Signature []byte `json:"signature"`
+}
+
+type QueryVisibility struct {
+	Method          string   `json:"method"`
+	AllowedRoleList []string `json:"allowed_role_list"`
+}
+
+type GetQueryVisibilityListResult struct {
+	QueryVisibilityList []QueryVisibility `json:"query_visibility_list"`
+}
+
+type DataRetentionRule struct {
+	KeyPrefix      string `json:"key_prefix"`
+	RetentionBlock int64  `json:"retention_block"`
+}
+
+type DataRetentionPolicyParam struct {
+	RuleList []DataRetentionRule `json:"rule_list"`
+}
+
+type SetEndBlockHookEnabledParam struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+type EndBlockHookInfo struct {
+	Name             string `json:"name"`
+	Namespace        string `json:"namespace"`
+	Enabled          bool   `json:"enabled"`
+	EnabledByDefault bool   `json:"enabled_by_default"`
+}
+
+type GetEndBlockHookListResult struct {
+	HookList []EndBlockHookInfo `json:"hook_list"`
+}
+
+type GetRequestSettlementParam struct {
+	RequestID string `json:"request_id"`
+}
+
+type SettlementEntry struct {
+	NodeID    string  `json:"node_id"`
+	Role      string  `json:"role"`
+	ServiceID string  `json:"service_id,omitempty"`
+	Amount    float64 `json:"amount"`
+	Valid     bool    `json:"valid"`
+}
+
+type GetRequestSettlementResult struct {
+	RequestID   string            `json:"request_id"`
+	Owner       string            `json:"owner"`
+	BlockHeight int64             `json:"block_height"`
+	EntryList   []SettlementEntry `json:"entry_list"`
+	Total       float64           `json:"total"`
+}
+
+type GetAccessorResponseListParam struct {
+	AccessorID string `json:"accessor_id"`
+}
+
+type AccessorResponse struct {
+	RequestID   string `json:"request_id"`
+	IdpID       string `json:"idp_id"`
+	BlockHeight int64  `json:"block_height"`
+}
+
+type GetAccessorResponseListResult struct {
+	ResponseList []AccessorResponse `json:"response_list"`
+}
@@ -89,6 +89,8 @@ func (app *ABCIApplication) ReturnDeliverTxLogWithAttributes(code uint32, log st
 
 // DeliverTxRouter is Pointer to function
 func (app *ABCIApplication) DeliverTxRouter(method string, param string, nonce []byte, signature []byte, nodeID string) types.ResponseDeliverTx {
+	app.deliverTxEvents = make([]types.Event, 0)
+
 	// ---- check authorization ----
 	checkTxResult := app.CheckTxRouter(method, param, nonce, signature, nodeID, false)
 	if checkTxResult.Code != code.OK {
@@ -98,22 +100,51 @@ func (app *ABCIApplication) DeliverTxRouter(method string, param string, nonce [
 		return app.ReturnDeliverTxLog(checkTxResult.Code, "Unauthorized", "")
 	}
 
-	result := app.callDeliverTx(method, param, nodeID)
+	// Changes of Tx are reverted when its fee can't be charged
+	// or they exceed remaining state write budget of block
+	app.state.BeginTxJournal()
+	valUpdates := app.copyValUpdates()
+
+	// ---- Check quota ----
+	var result types.ResponseDeliverTx
+	quotaCode, quotaLog := app.checkNodeQuota(method, nodeID)
+	if quotaCode != code.OK {
+		result = app.ReturnDeliverTxLog(quotaCode, quotaLog, "")
+	} else {
+		result = app.callDeliverTxOrSchedule(method, param, nodeID)
+		if result.Code == code.OK {
+			app.increaseNodeQuotaUsage(method, nodeID)
+		}
+	}
 	// ---- Burn token ----
-	if !app.checkNDID(param, nodeID, false) && !isNDIDMethod[method] {
-		needToken := app.getTokenPriceByFunc(method, false)
-		errCode, errLog := app.reduceToken(nodeID, needToken)
+	// Fee is finalized only when Tx succeeds so failed Tx does not burn token
+	if result.Code == code.OK && !app.checkNDID(param, nodeID, false) && !isNDIDMethod[method] {
+		needToken := app.getTxTokenPrice(method, param, nodeID, false)
+		errCode, errLog := app.reduceToken(nodeID, needToken, tokenLedgerEntryTypeCharge, method)
 		if errCode != code.OK {
-			result.Code = errCode
-			result.Log = errLog
+			// Handler may have spent token (e.g. escrow of requests in batch)
+			app.state.RevertTxJournal()
+			app.valUpdates = valUpdates
+			app.deliverTxEvents = make([]types.Event, 0)
+			result = app.ReturnDeliverTxLog(errCode, errLog, "")
 		}
 	}
 
+	if app.blockWriteLimit > 0 && app.state.UncommittedKeyCount()-app.beginBlockWriteCount > app.blockWriteLimit {
+		app.state.RevertTxJournal()
+		app.valUpdates = valUpdates
+		app.deliverTxEvents = make([]types.Event, 0)
+		go recordBlockWriteLimitExceededMetrics(method)
+		result = app.ReturnDeliverTxLog(code.BlockWriteLimitExceeded, "Tx exceeds remaining state write budget of block", "")
+	}
+	app.state.EndTxJournal()
+
 	// Set used nonce to stateDB
 	emptyValue := make([]byte, 0)
 	app.state.Set([]byte(nonce), emptyValue)
 	nonceStr := string(nonce)
 	app.deliverTxNonceState[nonceStr] = []byte(nil)
+	result.Events = append(result.Events, app.deliverTxEvents...)
 	return result
 }
 
@@ -123,6 +154,8 @@ func (app *ABCIApplication) callDeliverTx(name string, param string, nodeID stri
 		return app.initNDID(param, nodeID)
 	case "RegisterNode":
 		return app.registerNode(param, nodeID)
+	case "RegisterNodeBatch":
+		return app.registerNodeBatch(param, nodeID)
 	case "RegisterIdentity":
 		return app.registerIdentity(param, nodeID)
 	case "AddAccessor":
@@ -137,10 +170,14 @@ func (app *ABCIApplication) callDeliverTx(name string, param string, nodeID stri
 		return app.registerServiceDestination(param, nodeID)
 	case "SetMqAddresses":
 		return app.setMqAddresses(param, nodeID)
+	case "SetSupportedFeatureList":
+		return app.setSupportedFeatureList(param, nodeID)
 	case "AddNodeToken":
 		return app.addNodeToken(param, nodeID)
 	case "ReduceNodeToken":
 		return app.reduceNodeToken(param, nodeID)
+	case "RefundToken":
+		return app.refundToken(param, nodeID)
 	case "SetNodeToken":
 		return app.setNodeToken(param, nodeID)
 	case "SetPriceFunc":
@@ -153,6 +190,8 @@ func (app *ABCIApplication) callDeliverTx(name string, param string, nodeID stri
 		return app.addNamespace(param, nodeID)
 	case "UpdateNode":
 		return app.updateNode(param, nodeID)
+	case "SetNodeContact":
+		return app.setNodeContact(param, nodeID)
 	case "SetValidator":
 		return app.setValidator(param, nodeID)
 	case "AddService":
@@ -199,6 +238,8 @@ func (app *ABCIApplication) callDeliverTx(name string, param string, nodeID stri
 		return app.removeNodeFromProxyNode(param, nodeID)
 	case "SetInitData":
 		return app.SetInitData(param, nodeID)
+	case "SetChainHistoryInfo":
+		return app.setChainHistoryInfo(param, nodeID)
 	case "EndInit":
 		return app.EndInit(param, nodeID)
 	case "SetLastBlock":
@@ -207,6 +248,8 @@ func (app *ABCIApplication) callDeliverTx(name string, param string, nodeID stri
 		return app.revokeIdentityAssociation(param, nodeID)
 	case "RevokeAccessor":
 		return app.revokeAccessor(param, nodeID)
+	case "UpgradeIdentityMode":
+		return app.upgradeIdentityMode(param, nodeID)
 	case "UpdateIdentityModeList":
 		return app.updateIdentityModeList(param, nodeID)
 	case "AddIdentity":
@@ -219,7 +262,67 @@ func (app *ABCIApplication) callDeliverTx(name string, param string, nodeID stri
 		return app.SetAllowedMinIalForRegisterIdentityAtFirstIdp(param, nodeID)
 	case "RevokeAndAddAccessor":
 		return app.revokeAndAddAccessor(param, nodeID)
+	case "RegisterIdentityAndCreateIdpResponse":
+		return app.registerIdentityAndCreateIdpResponse(param, nodeID)
+	case "AnchorConsentReceipt":
+		return app.anchorConsentReceipt(param, nodeID)
+	case "Batch":
+		return app.batch(param, nodeID)
+	case "SetNodeQuota":
+		return app.setNodeQuota(param, nodeID)
+	case "SetMaxRequestTimeoutExtension":
+		return app.setMaxRequestTimeoutExtension(param, nodeID)
+	case "SetRequestListSizeLimit":
+		return app.setRequestListSizeLimit(param, nodeID)
+	case "SetRandomnessBeaconConfig":
+		return app.setRandomnessBeaconConfig(param, nodeID)
+	case "SetAppHashScheme":
+		return app.setAppHashScheme(param, nodeID)
+	case "SetValidatorNode":
+		return app.setValidatorNode(param, nodeID)
+	case "SetRequestEscrowPrice":
+		return app.setRequestEscrowPrice(param, nodeID)
+	case "SetLowTokenThreshold":
+		return app.setLowTokenThreshold(param, nodeID)
+	case "SetAdminApprovalPolicy":
+		return app.setAdminApprovalPolicy(param, nodeID)
+	case "CreateAdminProposal":
+		return app.createAdminProposal(param, nodeID)
+	case "ApproveAdminProposal":
+		return app.approveAdminProposal(param, nodeID)
+	case "SetGovernanceActionDelay":
+		return app.setGovernanceActionDelay(param, nodeID)
+	case "CancelGovernanceAction":
+		return app.cancelGovernanceAction(param, nodeID)
+	case "SetMethodPaused":
+		return app.setMethodPaused(param, nodeID)
+	case "SetValidatorPowerPolicy":
+		return app.setValidatorPowerPolicy(param, nodeID)
+	case "SetValidatorMissThreshold":
+		return app.setValidatorMissThreshold(param, nodeID)
+	case "SetNodeIDAlias":
+		return app.setNodeIDAlias(param, nodeID)
+	case "RebuildIndexes":
+		return app.rebuildIndexesTx(param, nodeID)
+	case "SetBlockWriteLimit":
+		return app.setBlockWriteLimit(param, nodeID)
+	case "SetRequestMessageHashCheckHeight":
+		return app.setRequestMessageHashCheckHeight(param, nodeID)
+	case "SetRequestPriorityClassList":
+		return app.setRequestPriorityClassList(param, nodeID)
+	case "SetQueryVisibility":
+		return app.setQueryVisibility(param, nodeID)
+	case "SetDataRetentionPolicy":
+		return app.setDataRetentionPolicy(param, nodeID)
+	case "SetEndBlockHookEnabled":
+		return app.setEndBlockHookEnabled(param, nodeID)
+	case "AddNodeRole":
+		return app.addNodeRole(param, nodeID)
+	case "RemoveNodeRole":
+		return app.removeNodeRole(param, nodeID)
+	case "ExtendRequestTimeout":
+		return app.extendRequestTimeout(param, nodeID)
 	default:
-		return types.ResponseDeliverTx{Code: code.UnknownMethod, Log: "Unknown method name"}
+		return app.returnUnknownMethodDeliverTx(name)
 	}
 }
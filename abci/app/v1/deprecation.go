@@ -0,0 +1,95 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+type methodDeprecation struct {
+	// Since is app version which method is deprecated in
+	Since       string
+	Replacement string
+}
+
+// deprecatedMethods is registry of deprecated Tx and query methods. Deprecated methods still
+// work but their results carry deprecation warning and calls are counted in
+// abci_deprecated_method_calls_total metric so that migration of clients can be tracked
+// before methods are removed. Add method here e.g.
+//
+//	"GetIdpNodes": {Since: "4.1.0", Replacement: "GetIdpNodesInfo"},
+var deprecatedMethods = map[string]methodDeprecation{}
+
+// DeprecationWarning is warning of deprecated method in DeliverTx event and query log
+type DeprecationWarning struct {
+	Method          string `json:"method"`
+	DeprecatedSince string `json:"deprecated_since"`
+	Replacement     string `json:"replacement"`
+}
+
+func getDeprecationWarning(method string) (DeprecationWarning, bool) {
+	deprecation, deprecated := deprecatedMethods[method]
+	if !deprecated {
+		return DeprecationWarning{}, false
+	}
+	return DeprecationWarning{
+		Method:          method,
+		DeprecatedSince: deprecation.Since,
+		Replacement:     deprecation.Replacement,
+	}, true
+}
+
+// addDeprecationEvent adds "did.deprecation" event to result of Tx of deprecated method
+func addDeprecationEvent(result *types.ResponseDeliverTx, method string, nodeID string) {
+	warning, deprecated := getDeprecationWarning(method)
+	if !deprecated {
+		return
+	}
+	go recordDeprecatedMethodMetrics("DeliverTx", method, nodeID)
+	result.Events = append(result.Events, types.Event{
+		Type: "did.deprecation",
+		Attributes: []cmn.KVPair{
+			{Key: []byte("method"), Value: []byte(warning.Method)},
+			{Key: []byte("deprecated_since"), Value: []byte(warning.DeprecatedSince)},
+			{Key: []byte("replacement"), Value: []byte(warning.Replacement)},
+		},
+	})
+}
+
+// addDeprecationWarning appends deprecation warning as JSON to log of query result of
+// deprecated method. Value of query result is not changed.
+func addDeprecationWarning(result *types.ResponseQuery, method string) {
+	warning, deprecated := getDeprecationWarning(method)
+	if !deprecated {
+		return
+	}
+	go recordDeprecatedMethodMetrics("Query", method, "")
+	warningJSON, err := json.Marshal(warning)
+	if err != nil {
+		return
+	}
+	result.Log = result.Log + "; deprecation: " + string(warningJSON)
+}
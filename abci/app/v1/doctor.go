@@ -0,0 +1,198 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/storage"
+	"github.com/ndidplatform/smart-contract/v4/abci/version"
+)
+
+const (
+	DoctorPass = "PASS"
+	DoctorFail = "FAIL"
+	DoctorSkip = "SKIP"
+)
+
+// DoctorCheck is result of one check of doctor with hint for fixing failure
+type DoctorCheck struct {
+	Name   string
+	Status string
+	Detail string
+	Hint   string
+}
+
+// RunDoctor runs checks of app state DB (node must be stopped) which operators do during
+// incidents: latest version is loadable, app hash is not empty, validators
+// are present and latest backup in backupDir (skipped when empty) is complete.
+// DB is not written.
+func RunDoctor(db dbm.DB, backupDir string) []DoctorCheck {
+	checks := make([]DoctorCheck, 0)
+	metadata, check := doctorCheckLatestVersion(db)
+	checks = append(checks, check)
+	if check.Status == DoctorFail {
+		return checks
+	}
+	checks = append(checks, doctorCheckAppHash(metadata))
+	checks = append(checks, doctorCheckValidators(db))
+	checks = append(checks, doctorCheckBackup(backupDir, metadata))
+	return checks
+}
+
+func doctorCheckLatestVersion(db dbm.DB) (AppStateMetadata, DoctorCheck) {
+	check := DoctorCheck{Name: "latest_version"}
+	var metadata AppStateMetadata
+	metadataBytes := db.Get(appStateMetadataKey)
+	if len(metadataBytes) == 0 {
+		check.Status = DoctorFail
+		check.Detail = "App state metadata not found"
+		check.Hint = "Check db_dir and network_namespace. Empty state is expected only before first block is committed."
+		return metadata, check
+	}
+	err := json.Unmarshal(metadataBytes, &metadata)
+	if err != nil {
+		check.Status = DoctorFail
+		check.Detail = fmt.Sprintf("Cannot decode app state metadata: %v", err)
+		check.Hint = "Restore DB from backup with \"migrate restore\"."
+		return metadata, check
+	}
+	if metadata.SchemaVersion > version.StateSchemaVersion {
+		check.Status = DoctorFail
+		check.Detail = fmt.Sprintf("State schema version %d is newer than version %d supported by ABCI app version %s", metadata.SchemaVersion, version.StateSchemaVersion, version.Version)
+		check.Hint = "Upgrade ABCI app to the version which wrote the state."
+		return metadata, check
+	}
+	// Change journal is written in the same batch as state changes before metadata is saved,
+	// journal of later height means that node stopped before metadata of the block was saved
+	var latestJournalHeight int64
+	appState := AppState{db: db}
+	appState.IterateCommitted([]byte(changeJournalKeyPrefix+keySeparator), func(key, value []byte) bool {
+		height, err := strconv.ParseInt(strings.TrimPrefix(string(key), changeJournalKeyPrefix+keySeparator), 10, 64)
+		if err == nil && height > latestJournalHeight {
+			latestJournalHeight = height
+		}
+		return true
+	})
+	if latestJournalHeight > metadata.Height {
+		check.Status = DoctorFail
+		check.Detail = fmt.Sprintf("State has changes of height %d but metadata is of height %d", latestJournalHeight, metadata.Height)
+		check.Hint = "Node stopped while committing. Restore DB from backup with \"migrate restore\" and let Tendermint replay blocks."
+		return metadata, check
+	}
+	check.Status = DoctorPass
+	check.Detail = fmt.Sprintf("Height %d, schema version %d", metadata.Height, metadata.SchemaVersion)
+	return metadata, check
+}
+
+// doctorCheckAppHash checks that app hash of committed height is kept in metadata. App hash chains
+// hashes of changes of every block since genesis, so it can't be recomputed from state and must be
+// compared with other nodes.
+func doctorCheckAppHash(metadata AppStateMetadata) DoctorCheck {
+	check := DoctorCheck{Name: "app_hash"}
+	if metadata.Height > 0 && len(metadata.AppHash) == 0 {
+		check.Status = DoctorFail
+		check.Detail = fmt.Sprintf("App hash of height %d is empty", metadata.Height)
+		check.Hint = "Restore DB from backup with \"migrate restore\"."
+		return check
+	}
+	check.Status = DoctorPass
+	check.Detail = fmt.Sprintf("App hash %X, compare it with other nodes (\"compare_state\") when state is suspected to diverge", metadata.AppHash)
+	return check
+}
+
+func doctorCheckValidators(db dbm.DB) DoctorCheck {
+	check := DoctorCheck{Name: "validators"}
+	validators, err := ExportGenesisValidators(db)
+	if err != nil {
+		check.Status = DoctorFail
+		check.Detail = err.Error()
+		check.Hint = "Replace validators with validators of genesis file with \"migrate import_genesis_validators\"."
+		return check
+	}
+	if len(validators) == 0 {
+		check.Status = DoctorFail
+		check.Detail = "No validator in state"
+		check.Hint = "Import validators of genesis file with \"migrate import_genesis_validators\"."
+		return check
+	}
+	check.Status = DoctorPass
+	check.Detail = fmt.Sprintf("%d validators", len(validators))
+	return check
+}
+
+// doctorCheckBackup checks that latest backup can be opened and its key count and size
+// agree with its metadata which is recomputed when backup is written
+func doctorCheckBackup(backupDir string, metadata AppStateMetadata) DoctorCheck {
+	check := DoctorCheck{Name: "backup"}
+	if backupDir == "" {
+		check.Status = DoctorSkip
+		check.Detail = "backup_dir is not set"
+		return check
+	}
+	heights, err := listBackupHeights(backupDir)
+	if err != nil || len(heights) == 0 {
+		check.Status = DoctorFail
+		check.Detail = fmt.Sprintf("No backup found in %s", backupDir)
+		check.Hint = "Set ABCI_BACKUP_INTERVAL and ABCI_BACKUP_DIR to take backups."
+		return check
+	}
+	path := filepath.Join(backupDir, backupDirPrefix+strconv.FormatInt(heights[0], 10))
+	backupDB, err := storage.OpenDB(string(dbm.GoLevelDBBackend), path)
+	if err != nil {
+		check.Status = DoctorFail
+		check.Detail = fmt.Sprintf("Cannot open backup %s: %v", path, err)
+		check.Hint = "Use previous backup."
+		return check
+	}
+	defer backupDB.Close()
+	backupMetadata, backupCheck := doctorCheckLatestVersion(backupDB)
+	if backupCheck.Status == DoctorFail {
+		check.Status = DoctorFail
+		check.Detail = fmt.Sprintf("Backup %s: %s", path, backupCheck.Detail)
+		check.Hint = "Use previous backup."
+		return check
+	}
+	keyCount, byteSize := countStateStats(backupDB)
+	if keyCount != backupMetadata.KeyCount || byteSize != backupMetadata.ByteSize {
+		check.Status = DoctorFail
+		check.Detail = fmt.Sprintf("Backup %s metadata has %d keys (%d bytes) but backup has %d keys (%d bytes)", path, backupMetadata.KeyCount, backupMetadata.ByteSize, keyCount, byteSize)
+		check.Hint = "Backup is incomplete. Use previous backup."
+		return check
+	}
+	if backupMetadata.Height > metadata.Height {
+		check.Status = DoctorFail
+		check.Detail = fmt.Sprintf("Backup %s is of height %d which is later than state height %d", path, backupMetadata.Height, metadata.Height)
+		check.Hint = "Check that backup_dir is backup directory of this node and network."
+		return check
+	}
+	check.Status = DoctorPass
+	check.Detail = fmt.Sprintf("Backup %s of height %d, %d keys", path, backupMetadata.Height, keyCount)
+	return check
+}
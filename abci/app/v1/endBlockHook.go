@@ -0,0 +1,185 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// EndBlockHookFunc is deterministic logic run at EndBlock of every block.
+// It must depend only on state and height so every node gets the same result.
+type EndBlockHookFunc func(app *ABCIApplication, height int64)
+
+type endBlockHook struct {
+	name             string
+	namespace        string
+	enabledByDefault bool
+	run              EndBlockHookFunc
+}
+
+// endBlockHooks is every registered hook ordered by name
+var endBlockHooks []*endBlockHook
+
+func init() {
+	RegisterEndBlockHook("validator", "activate_pending_updates", true, func(app *ABCIApplication, height int64) {
+		app.activatePendingValidatorUpdates(height)
+	})
+	RegisterEndBlockHook("retention", "sweep_expired_data", true, func(app *ABCIApplication, height int64) {
+		app.sweepExpiredData(height)
+	})
+}
+
+// RegisterEndBlockHook registers hook named "<namespace>.<name>" to be run at EndBlock.
+// Hooks are run ordered by name, not by order of registration, and each of them can be
+// enabled or disabled by NDID with SetEndBlockHookEnabled. It must be called before
+// the app is started (e.g. in init) and panics when name is invalid or already registered.
+func RegisterEndBlockHook(namespace string, name string, enabledByDefault bool, run EndBlockHookFunc) {
+	if namespace == "" || name == "" || strings.Contains(namespace, ".") || run == nil {
+		panic(fmt.Errorf("Invalid EndBlock hook %q in namespace %q", name, namespace))
+	}
+	fullName := namespace + "." + name
+	if getEndBlockHook(fullName) != nil {
+		panic(fmt.Errorf("EndBlock hook %q is already registered", fullName))
+	}
+	endBlockHooks = append(endBlockHooks, &endBlockHook{
+		name:             fullName,
+		namespace:        namespace,
+		enabledByDefault: enabledByDefault,
+		run:              run,
+	})
+	sort.Slice(endBlockHooks, func(i, j int) bool {
+		return endBlockHooks[i].name < endBlockHooks[j].name
+	})
+}
+
+func getEndBlockHook(name string) *endBlockHook {
+	for _, hook := range endBlockHooks {
+		if hook.name == name {
+			return hook
+		}
+	}
+	return nil
+}
+
+func (app *ABCIApplication) getEndBlockHookFlagList(committedState bool) (data.EndBlockHookFlagList, error) {
+	var flagList data.EndBlockHookFlagList
+	value, _ := app.state.Get(endBlockHookFlagListKeyBytes, committedState)
+	if value == nil {
+		return flagList, nil
+	}
+	err := proto.Unmarshal(value, &flagList)
+	return flagList, err
+}
+
+// isEndBlockHookEnabled returns flag set by NDID or default of hook when flag is not set
+func isEndBlockHookEnabled(hook *endBlockHook, flagList *data.EndBlockHookFlagList) bool {
+	for _, flag := range flagList.FlagList {
+		if flag.Name == hook.name {
+			return flag.Enabled
+		}
+	}
+	return hook.enabledByDefault
+}
+
+func (app *ABCIApplication) runEndBlockHooks(height int64) {
+	flagList, err := app.getEndBlockHookFlagList(false)
+	if err != nil {
+		app.logger.Errorf("Error unmarshaling EndBlock hook flag list: %s", err.Error())
+		return
+	}
+	for _, hook := range endBlockHooks {
+		if !isEndBlockHookEnabled(hook, &flagList) {
+			continue
+		}
+		hook.run(app, height)
+	}
+}
+
+func (app *ABCIApplication) setEndBlockHookEnabled(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetEndBlockHookEnabled, Parameter: %s", param)
+	var funcParam SetEndBlockHookEnabledParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if getEndBlockHook(funcParam.Name) == nil {
+		return app.ReturnDeliverTxLog(code.UnknownEndBlockHook, "Unknown EndBlock hook "+funcParam.Name, "")
+	}
+	flagList, err := app.getEndBlockHookFlagList(false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	found := false
+	for _, flag := range flagList.FlagList {
+		if flag.Name == funcParam.Name {
+			flag.Enabled = funcParam.Enabled
+			found = true
+			break
+		}
+	}
+	if !found {
+		flagList.FlagList = append(flagList.FlagList, &data.EndBlockHookFlag{
+			Name:    funcParam.Name,
+			Enabled: funcParam.Enabled,
+		})
+	}
+	value, err := utils.ProtoDeterministicMarshal(&flagList)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(endBlockHookFlagListKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getEndBlockHookList(param string) types.ResponseQuery {
+	app.logger.Infof("GetEndBlockHookList, Parameter: %s", param)
+	flagList, err := app.getEndBlockHookFlagList(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetEndBlockHookListResult
+	result.HookList = make([]EndBlockHookInfo, 0, len(endBlockHooks))
+	for _, hook := range endBlockHooks {
+		result.HookList = append(result.HookList, EndBlockHookInfo{
+			Name:             hook.name,
+			Namespace:        hook.namespace,
+			Enabled:          isEndBlockHookEnabled(hook, &flagList),
+			EnabledByDefault: hook.enabledByDefault,
+		})
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,174 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+func (app *ABCIApplication) getRequestEscrowPriceFromStateDB(committedState bool) (data.RequestEscrowPrice, error) {
+	var escrowPrice data.RequestEscrowPrice
+	value, _ := app.state.Get(requestEscrowPriceKeyBytes, committedState)
+	if value == nil {
+		return escrowPrice, nil
+	}
+	err := proto.Unmarshal(value, &escrowPrice)
+	return escrowPrice, err
+}
+
+func (app *ABCIApplication) setRequestEscrowPrice(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetRequestEscrowPrice, Parameter: %s", param)
+	var funcParam RequestEscrowPriceParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.IdPResponsePrice < 0 || funcParam.ASDataPrice < 0 {
+		return app.ReturnDeliverTxLog(code.EscrowPriceMustBeGreaterOrEqualToZero, "Escrow price must be greater than or equal to zero", "")
+	}
+	var escrowPrice data.RequestEscrowPrice
+	escrowPrice.IdpResponsePrice = funcParam.IdPResponsePrice
+	escrowPrice.AsDataPrice = funcParam.ASDataPrice
+	value, err := utils.ProtoDeterministicMarshal(&escrowPrice)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(requestEscrowPriceKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getRequestEscrowPrice(param string) types.ResponseQuery {
+	app.logger.Infof("GetRequestEscrowPrice, Parameter: %s", param)
+	escrowPrice, err := app.getRequestEscrowPriceFromStateDB(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result RequestEscrowPriceParam
+	result.IdPResponsePrice = escrowPrice.IdpResponsePrice
+	result.ASDataPrice = escrowPrice.AsDataPrice
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
+
+// getRequestEscrowAmount returns amount of token held when request is created:
+// price of every expected IdP response and every expected AS data
+func getRequestEscrowAmount(minIdp int64, minAsList []int64, escrowPrice data.RequestEscrowPrice) float64 {
+	amount := float64(minIdp) * escrowPrice.IdpResponsePrice
+	for _, minAs := range minAsList {
+		amount += float64(minAs) * escrowPrice.AsDataPrice
+	}
+	return amount
+}
+
+// getTxEscrowAmount returns amount of token held by Tx in addition to its fee.
+// Identity management requests are not charged the same way as their fee.
+func (app *ABCIApplication) getTxEscrowAmount(method string, param string, nodeID string, committedState bool) float64 {
+	if method != "CreateRequest" || app.isIdentityManagementRequestTx(method, param, nodeID, committedState) {
+		return 0
+	}
+	var funcParam CreateRequestParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return 0
+	}
+	escrowPrice, err := app.getRequestEscrowPriceFromStateDB(committedState)
+	if err != nil {
+		return 0
+	}
+	minAsList := make([]int64, 0)
+	for _, dataRequest := range funcParam.DataRequestList {
+		minAsList = append(minAsList, int64(dataRequest.Count))
+	}
+	return getRequestEscrowAmount(int64(funcParam.MinIdp), minAsList, escrowPrice)
+}
+
+// holdRequestEscrow takes escrow from requester and records escrow price in request
+// so the request is settled with the price at creation time
+func (app *ABCIApplication) holdRequestEscrow(request *data.Request) (uint32, string) {
+	if request.Purpose != "" {
+		return code.OK, ""
+	}
+	escrowPrice, err := app.getRequestEscrowPriceFromStateDB(false)
+	if err != nil {
+		return code.UnmarshalError, err.Error()
+	}
+	minAsList := make([]int64, 0)
+	for _, dataRequest := range request.DataRequestList {
+		minAsList = append(minAsList, dataRequest.MinAs)
+	}
+	amount := getRequestEscrowAmount(request.MinIdp, minAsList, escrowPrice)
+	if amount <= 0 {
+		return code.OK, ""
+	}
+	errCode, errLog := app.reduceToken(request.Owner, amount, tokenLedgerEntryTypeEscrow, "Escrow of request "+request.RequestId)
+	if errCode != code.OK {
+		return errCode, errLog
+	}
+	request.EscrowAmount = amount
+	request.EscrowIdpResponsePrice = escrowPrice.IdpResponsePrice
+	request.EscrowAsDataPrice = escrowPrice.AsDataPrice
+	return code.OK, ""
+}
+
+// settleRequestEscrow keeps price of IdP responses and AS data actually given to request
+// (up to min_idp and min_as) and refunds the rest of escrow to requester.
+// Fee attribution to each IdP and AS is recorded in request settlement.
+func (app *ABCIApplication) settleRequestEscrow(request *data.Request) error {
+	err := app.saveRequestSettlement(request)
+	if err != nil {
+		return err
+	}
+	if request.EscrowAmount <= 0 {
+		return nil
+	}
+	answeredIdp := int64(len(request.ResponseList))
+	if answeredIdp > request.MinIdp {
+		answeredIdp = request.MinIdp
+	}
+	answeredAsList := make([]int64, 0)
+	for _, dataRequest := range request.DataRequestList {
+		answeredAs := int64(len(dataRequest.AnsweredAsIdList))
+		if answeredAs > dataRequest.MinAs {
+			answeredAs = dataRequest.MinAs
+		}
+		answeredAsList = append(answeredAsList, answeredAs)
+	}
+	var escrowPrice data.RequestEscrowPrice
+	escrowPrice.IdpResponsePrice = request.EscrowIdpResponsePrice
+	escrowPrice.AsDataPrice = request.EscrowAsDataPrice
+	refund := request.EscrowAmount - getRequestEscrowAmount(answeredIdp, answeredAsList, escrowPrice)
+	if refund <= 0 {
+		return nil
+	}
+	return app.addToken(request.Owner, refund, tokenLedgerEntryTypeRefund, "Escrow refund of request "+request.RequestId)
+}
@@ -0,0 +1,114 @@
+//go:build gofuzz
+// +build gofuzz
+
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// Fuzz targets for go-fuzz (https://github.com/dvyukov/go-fuzz)
+//
+//	go-fuzz-build -func FuzzCheckTx github.com/ndidplatform/smart-contract/v4/abci/app/v1
+//	go-fuzz -bin app-fuzz.zip -workdir fuzz/CheckTx
+//
+// Targets must not panic on any input.
+// DeliverTx and query parameter targets run handlers without panic recovery.
+
+var fuzzApp = newFuzzApp()
+
+var fuzzMethods = getSortedMethods(IsMethod)
+
+var fuzzQueryMethods = getSortedMethods(IsQueryMethod)
+
+func newFuzzApp() *ABCIApplication {
+	logrus.SetLevel(logrus.PanicLevel)
+	logger := logrus.WithFields(logrus.Fields{"module": "abci-app-fuzz"})
+	return NewABCIApplication(logger, dbm.NewMemDB())
+}
+
+func getSortedMethods(methodMap map[string]bool) []string {
+	methods := make([]string, 0, len(methodMap))
+	for method := range methodMap {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// FuzzCheckTx fuzzes Tx envelope parser of CheckTx
+func FuzzCheckTx(data []byte) int {
+	res := fuzzApp.CheckTx(types.RequestCheckTx{Tx: data})
+	if res.IsOK() {
+		return 1
+	}
+	return 0
+}
+
+// FuzzDeliverTx fuzzes Tx envelope parser of DeliverTx
+func FuzzDeliverTx(data []byte) int {
+	res := fuzzApp.DeliverTx(types.RequestDeliverTx{Tx: data})
+	if res.IsOK() {
+		return 1
+	}
+	return 0
+}
+
+// FuzzQuery fuzzes query envelope parser
+func FuzzQuery(data []byte) int {
+	fuzzApp.Query(types.RequestQuery{Data: data})
+	return 0
+}
+
+// FuzzDeliverTxParam fuzzes parameter unmarshaling of DeliverTx handlers.
+// First byte of data selects method and the rest is parameter.
+func FuzzDeliverTxParam(data []byte) int {
+	if len(data) == 0 {
+		return -1
+	}
+	method := fuzzMethods[int(data[0])%len(fuzzMethods)]
+	snapshot := fuzzApp.state.Snapshot()
+	defer fuzzApp.state.RevertToSnapshot(snapshot)
+	res := fuzzApp.callDeliverTx(method, string(data[1:]), "fuzz")
+	if res.IsOK() {
+		return 1
+	}
+	return 0
+}
+
+// FuzzQueryParam fuzzes parameter unmarshaling of query handlers.
+// First byte of data selects method and the rest is parameter.
+func FuzzQueryParam(data []byte) int {
+	if len(data) == 0 {
+		return -1
+	}
+	method := fuzzQueryMethods[int(data[0])%len(fuzzQueryMethods)]
+	fuzzApp.callQuery(method, string(data[1:]), 0)
+	return 0
+}
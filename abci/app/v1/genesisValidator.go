@@ -0,0 +1,127 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// genesisPubKeyTypeEd25519 is type of ed25519 public key in Tendermint genesis file
+const genesisPubKeyTypeEd25519 = "tendermint/PubKeyEd25519"
+
+// validatorKeyEnd is end of range of validator keys with prefix "val:"
+var validatorKeyEnd = []byte("val;")
+
+// GenesisValidator is validator entry of Tendermint genesis file
+type GenesisValidator struct {
+	Address string        `json:"address"`
+	PubKey  GenesisPubKey `json:"pub_key"`
+	Power   string        `json:"power"`
+	Name    string        `json:"name"`
+}
+
+type GenesisPubKey struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// ExportGenesisValidators returns validators stored in app state ("val:" keys)
+// as Tendermint genesis validator entries ordered by key
+func ExportGenesisValidators(db dbm.DB) ([]GenesisValidator, error) {
+	validators := make([]GenesisValidator, 0)
+	itr := db.Iterator([]byte(ValidatorSetChangePrefix), validatorKeyEnd)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		var validator types.ValidatorUpdate
+		err := types.ReadMessage(bytes.NewBuffer(itr.Value()), &validator)
+		if err != nil {
+			return nil, fmt.Errorf("Error decoding validator %s: %v", itr.Key(), err)
+		}
+		if validator.PubKey.Type != "ed25519" {
+			return nil, fmt.Errorf("Unsupported public key type %s of validator %s", validator.PubKey.Type, itr.Key())
+		}
+		pubKey := validator.PubKey.Data
+		address := sha256.Sum256(pubKey)
+		validators = append(validators, GenesisValidator{
+			Address: strings.ToUpper(hex.EncodeToString(address[:20])),
+			PubKey: GenesisPubKey{
+				Type:  genesisPubKeyTypeEd25519,
+				Value: base64.StdEncoding.EncodeToString(pubKey),
+			},
+			Power: strconv.FormatInt(validator.Power, 10),
+		})
+	}
+	return validators, nil
+}
+
+// ImportGenesisValidators replaces validators stored in app state ("val:" keys) with
+// Tendermint genesis validator entries so that app state agrees with genesis file
+func ImportGenesisValidators(db dbm.DB, genesisValidators []GenesisValidator) error {
+	validators := make([]types.ValidatorUpdate, 0, len(genesisValidators))
+	for _, genesisValidator := range genesisValidators {
+		if genesisValidator.PubKey.Type != genesisPubKeyTypeEd25519 {
+			return fmt.Errorf("Unsupported public key type %s of validator %s", genesisValidator.PubKey.Type, genesisValidator.Address)
+		}
+		pubKey, err := base64.StdEncoding.DecodeString(genesisValidator.PubKey.Value)
+		if err != nil {
+			return fmt.Errorf("Invalid public key of validator %s: %v", genesisValidator.Address, err)
+		}
+		power, err := strconv.ParseInt(genesisValidator.Power, 10, 64)
+		if err != nil || power <= 0 {
+			return fmt.Errorf("Invalid power %q of validator %s", genesisValidator.Power, genesisValidator.Address)
+		}
+		var validator types.ValidatorUpdate
+		validator.PubKey = types.PubKey{Type: "ed25519", Data: pubKey}
+		validator.Power = power
+		validators = append(validators, validator)
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+	itr := db.Iterator([]byte(ValidatorSetChangePrefix), validatorKeyEnd)
+	for ; itr.Valid(); itr.Next() {
+		batch.Delete(itr.Key())
+	}
+	itr.Close()
+	for _, validator := range validators {
+		value := bytes.NewBuffer(make([]byte, 0))
+		err := types.WriteMessage(&validator, value)
+		if err != nil {
+			return fmt.Errorf("Error encoding validator: %v", err)
+		}
+		key := ValidatorSetChangePrefix + base64.StdEncoding.EncodeToString(validator.PubKey.Data)
+		batch.Set([]byte(key), value.Bytes())
+	}
+	batch.WriteSync()
+	RecomputeStateStats(db)
+	return nil
+}
@@ -0,0 +1,221 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// timeLockedMethod is list of destructive NDID methods which take effect
+// after governance action delay set by NDID
+var timeLockedMethod = map[string]bool{
+	"DisableNode":                     true,
+	"DisableService":                  true,
+	"DisableNamespace":                true,
+	"DisableServiceDestinationByNDID": true,
+}
+
+func getGovernanceActionKey(actionID string) string {
+	return governanceActionKeyPrefix + keySeparator + actionID
+}
+
+func (app *ABCIApplication) getGovernanceActionDelay(committedState bool) (data.GovernanceActionDelay, error) {
+	var delay data.GovernanceActionDelay
+	value, _ := app.state.Get(governanceActionDelayKeyBytes, committedState)
+	if value == nil {
+		return delay, nil
+	}
+	err := proto.Unmarshal(value, &delay)
+	return delay, err
+}
+
+func (app *ABCIApplication) setGovernanceActionDelay(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetGovernanceActionDelay, Parameter: %s", param)
+	var funcParam GovernanceActionDelayParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.DelayBlock < 0 {
+		return app.ReturnDeliverTxLog(code.DelayBlockMustBeGreaterOrEqualToZero, "Delay block must be greater than or equal to zero", "")
+	}
+	delay, err := app.getGovernanceActionDelay(false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	delay.DelayBlock = funcParam.DelayBlock
+	value, err := utils.ProtoDeterministicMarshal(&delay)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(governanceActionDelayKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getGovernanceActionDelayQuery(param string) types.ResponseQuery {
+	app.logger.Infof("GetGovernanceActionDelay, Parameter: %s", param)
+	delay, err := app.getGovernanceActionDelay(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GovernanceActionDelayParam
+	result.DelayBlock = delay.DelayBlock
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
+
+// callDeliverTxOrSchedule calls method now or, for time locked method when delay is set,
+// keeps it as pending governance action executed at begin of block after delay
+func (app *ABCIApplication) callDeliverTxOrSchedule(method string, param string, nodeID string) types.ResponseDeliverTx {
+	if !timeLockedMethod[method] {
+		return app.callDeliverTx(method, param, nodeID)
+	}
+	delay, err := app.getGovernanceActionDelay(false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if delay.DelayBlock <= 0 {
+		return app.callDeliverTx(method, param, nodeID)
+	}
+	actionID := fmt.Sprintf("%020d", delay.ActionCount)
+	delay.ActionCount++
+	var action data.GovernanceAction
+	action.Method = method
+	action.Param = param
+	action.NodeId = nodeID
+	action.ScheduledBlockHeight = app.state.CurrentBlockHeight
+	action.EffectiveBlockHeight = app.state.CurrentBlockHeight + delay.DelayBlock
+	actionValue, err := utils.ProtoDeterministicMarshal(&action)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	delayValue, err := utils.ProtoDeterministicMarshal(&delay)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set([]byte(getGovernanceActionKey(actionID)), actionValue)
+	app.state.Set(governanceActionDelayKeyBytes, delayValue)
+	app.logger.Infof("Schedule governance action: %s, Method: %s, Effective block height: %d", actionID, method, action.EffectiveBlockHeight)
+	return app.ReturnDeliverTxLog(code.OK, "success", actionID)
+}
+
+func (app *ABCIApplication) cancelGovernanceAction(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("CancelGovernanceAction, Parameter: %s", param)
+	var funcParam GovernanceActionIDParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	key := getGovernanceActionKey(funcParam.ActionID)
+	if !app.state.Has([]byte(key), false) {
+		return app.ReturnDeliverTxLog(code.GovernanceActionNotFound, "Governance action not found", "")
+	}
+	app.state.Delete([]byte(key))
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+// executeDueGovernanceActions executes pending governance actions which delay is over.
+// Actions of previous blocks are already committed at begin of block.
+func (app *ABCIApplication) executeDueGovernanceActions() []types.Event {
+	events := make([]types.Event, 0)
+	type dueAction struct {
+		actionID string
+		action   data.GovernanceAction
+	}
+	dueActions := make([]dueAction, 0)
+	prefix := []byte(governanceActionKeyPrefix + keySeparator)
+	app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		var action data.GovernanceAction
+		err := proto.Unmarshal(value, &action)
+		if err != nil {
+			app.logger.Errorf("Error unmarshaling governance action %s: %s", string(key), err.Error())
+			return true
+		}
+		if action.EffectiveBlockHeight <= app.state.CurrentBlockHeight {
+			dueActions = append(dueActions, dueAction{strings.TrimPrefix(string(key), string(prefix)), action})
+		}
+		return true
+	})
+	for _, due := range dueActions {
+		app.logger.Infof("Execute governance action: %s, Method: %s", due.actionID, due.action.Method)
+		result := app.callDeliverTx(due.action.Method, due.action.Param, due.action.NodeId)
+		if result.Code != code.OK {
+			app.logger.Errorf("Governance action %s failed: %s", due.actionID, result.Log)
+		}
+		app.state.Delete([]byte(getGovernanceActionKey(due.actionID)))
+		events = append(events, types.Event{
+			Type: "did.governance_action",
+			Attributes: []cmn.KVPair{
+				{Key: []byte("action_id"), Value: []byte(due.actionID)},
+				{Key: []byte("method"), Value: []byte(due.action.Method)},
+				{Key: []byte("code"), Value: []byte(strconv.FormatUint(uint64(result.Code), 10))},
+			},
+		})
+	}
+	return events
+}
+
+func (app *ABCIApplication) getPendingGovernanceActionList(param string) types.ResponseQuery {
+	app.logger.Infof("GetPendingGovernanceActionList, Parameter: %s", param)
+	var result GetPendingGovernanceActionListResult
+	result.ActionList = make([]GovernanceAction, 0)
+	var err error
+	prefix := []byte(governanceActionKeyPrefix + keySeparator)
+	app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		var action data.GovernanceAction
+		err = proto.Unmarshal(value, &action)
+		if err != nil {
+			return false
+		}
+		var row GovernanceAction
+		row.ActionID = strings.TrimPrefix(string(key), string(prefix))
+		row.Method = action.Method
+		row.Params = json.RawMessage(action.Param)
+		row.ScheduledBlockHeight = action.ScheduledBlockHeight
+		row.EffectiveBlockHeight = action.EffectiveBlockHeight
+		result.ActionList = append(result.ActionList, row)
+		return true
+	})
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
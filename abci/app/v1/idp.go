@@ -104,6 +104,7 @@ func (app *ABCIApplication) AddAccessor(param string, nodeID string) types.Respo
 	accessor.AccessorPublicKey = funcParam.AccessorPublicKey
 	accessor.Active = true
 	accessor.Owner = nodeID
+	accessor.CreationBlockHeight = app.state.CurrentBlockHeight
 	for _, idp := range refGroup.Idps {
 		if idp.NodeId == nodeID {
 			idp.Accessors = append(idp.Accessors, &accessor)
@@ -272,17 +273,30 @@ func (app *ABCIApplication) registerIdentity(param string, nodeID string) types.
 		namespaceCount[identity.Namespace] = namespaceCount[identity.Namespace] + 1
 	}
 	allowedIdentifierCount := app.GetNamespaceAllowedIdentifierCountMap(false)
-	for namespace, count := range namespaceCount {
+	for _, namespace := range utils.SortedKeys(namespaceCount) {
+		count := namespaceCount[namespace]
 		if count > allowedIdentifierCount[namespace] && allowedIdentifierCount[namespace] > 0 {
 			return app.ReturnDeliverTxLog(code.IdentifierCountIsGreaterThanAllowedIdentifierCount, "Identifier count is greater than allowed identifier count", "")
 		}
 	}
+	// Identity in reference group has no inactive state, so every identifier is counted as active
+	allowedActiveIdentifierCount := app.GetNamespaceAllowedActiveIdentifierCountMap(false)
+	for _, namespace := range utils.SortedKeys(namespaceCount) {
+		count := namespaceCount[namespace]
+		if count > allowedActiveIdentifierCount[namespace] && allowedActiveIdentifierCount[namespace] > 0 {
+			return app.ReturnDeliverTxLog(code.ActiveIdentifierCountIsGreaterThanAllowedCount, "Active identifier count is greater than allowed active identifier count", "")
+		}
+	}
+	if !app.isModeListAllowedInNamespaces(user.ModeList, namespaceCount) {
+		return app.ReturnDeliverTxLog(code.ModeIsNotAllowedForNamespace, "Mode is not allowed for namespace of identity", "")
+	}
 	var accessor data.Accessor
 	accessor.AccessorId = user.AccessorID
 	accessor.AccessorType = user.AccessorType
 	accessor.AccessorPublicKey = user.AccessorPublicKey
 	accessor.Active = true
 	accessor.Owner = nodeID
+	accessor.CreationBlockHeight = app.state.CurrentBlockHeight
 	var idp data.IdPInRefGroup
 	idp.NodeId = nodeID
 	idp.Mode = append(idp.Mode, user.ModeList...)
@@ -359,6 +373,10 @@ func (app *ABCIApplication) checkRequest(requestID string, purpose string, minId
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
+	err = app.loadRequestSubRecords(&request, app.state.Height, true)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
 	if request.Purpose != purpose {
 		return app.ReturnDeliverTxLog(code.InvalidPurpose, "Request has a invalid purpose", "")
 	}
@@ -399,6 +417,7 @@ func (app *ABCIApplication) increaseRequestUseCount(requestID string) types.Resp
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
 	request.UseCount = request.UseCount + 1
+	app.setRequestLastUpdate(&request)
 	requestProtobuf, err := utils.ProtoDeterministicMarshal(&request)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
@@ -421,6 +440,8 @@ func (app *ABCIApplication) createIdpResponse(param string, nodeID string) types
 	response.Status = funcParam.Status
 	response.Signature = funcParam.Signature
 	response.IdpId = nodeID
+	response.BlockHeight = app.state.CurrentBlockHeight
+	response.BlockTime = app.CurrentBlockTime.Unix()
 	value, _ := app.state.GetVersioned([]byte(key), 0, false)
 	if value == nil {
 		return app.ReturnDeliverTxLog(code.RequestIDNotFound, "Request ID not found", "")
@@ -430,6 +451,19 @@ func (app *ABCIApplication) createIdpResponse(param string, nodeID string) types
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
+	err = app.loadRequestSubRecords(&request, 0, false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	// Check request message hash which IdP computed from message received via MQ.
+	// It is required for requests created from activation height set by NDID.
+	checkHeight := app.getRequestMessageHashCheckHeightFromStateDB(false)
+	if checkHeight > 0 && request.CreationBlockHeight >= checkHeight && funcParam.RequestMessageHash == "" {
+		return app.ReturnDeliverTxLog(code.RequestMessageHashCannotBeEmpty, "Please input request message hash", "")
+	}
+	if funcParam.RequestMessageHash != "" && funcParam.RequestMessageHash != request.RequestMessageHash {
+		return app.ReturnDeliverTxLog(code.RequestMessageHashMismatch, "Request message hash mismatch", "")
+	}
 	// Check duplicate before add
 	chkDup := false
 	for _, oldResponse := range request.ResponseList {
@@ -457,6 +491,16 @@ func (app *ABCIApplication) createIdpResponse(param string, nodeID string) types
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
+	// If node is IdP agent, response is made on behalf of its parent IdP
+	idpID := nodeID
+	if nodeDetail.Role == "IdPAgent" {
+		idpID = nodeDetail.ParentIdpId
+		if !app.getActiveStatusByNodeID(idpID, false) {
+			return app.ReturnDeliverTxLog(code.ParentIdPIsNotActive, "Parent IdP is not active", "")
+		}
+		response.IdpId = idpID
+		response.AgentId = nodeID
+	}
 	if response.Aal > nodeDetail.MaxAal {
 		return app.ReturnDeliverTxLog(code.AALError, "Response's AAL is greater than max AAL", "")
 	}
@@ -467,18 +511,19 @@ func (app *ABCIApplication) createIdpResponse(param string, nodeID string) types
 	if int64(len(request.ResponseList)) >= request.MinIdp {
 		return app.ReturnDeliverTxLog(code.RequestIsCompleted, "Can't response a request that's complete response", "")
 	}
-	// Check IsClosed
-	if request.Closed {
-		return app.ReturnDeliverTxLog(code.RequestIsClosed, "Can't response a request that's closed", "")
+	// Check request flow state
+	errCode, errLog := checkRequestFlowTransition(&request, "CreateIdpResponse")
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
 	}
-	// Check IsTimedOut
-	if request.TimedOut {
-		return app.ReturnDeliverTxLog(code.RequestIsTimedOut, "Can't response a request that's timed out", "")
+	// Check IdP response deadline by time of current block
+	if request.IdpResponseTimeout > 0 && app.CurrentBlockTime.Unix() > request.CreationBlockTime+request.IdpResponseTimeout {
+		return app.ReturnDeliverTxLog(code.IdPResponseTimeoutPassed, "Can't response a request after IdP response timeout", "")
 	}
 	// Check nodeID is exist in idp_id_list
 	exist := false
-	for _, idpID := range request.IdpIdList {
-		if idpID == nodeID {
+	for _, id := range request.IdpIdList {
+		if id == idpID {
 			exist = true
 			break
 		}
@@ -489,12 +534,30 @@ func (app *ABCIApplication) createIdpResponse(param string, nodeID string) types
 	if chkDup == true {
 		return app.ReturnDeliverTxLog(code.DuplicateResponse, "Duplicate Response", "")
 	}
+	// Check accessor used to sign response of mode 3 request
+	if request.Mode == 3 && funcParam.AccessorID != "" {
+		errCode, errLog = app.checkResponseAccessor(funcParam.AccessorID, idpID)
+		if errCode != code.OK {
+			return app.ReturnDeliverTxLog(errCode, errLog, "")
+		}
+		response.AccessorId = funcParam.AccessorID
+	}
 	request.ResponseList = append(request.ResponseList, &response)
-	value, err = utils.ProtoDeterministicMarshal(&request)
+	err = app.addRequestResponse(&request)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	if response.AccessorId != "" {
+		err = app.saveAccessorResponse(response.AccessorId, request.RequestId, idpID)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+		}
+	}
+	app.emitRequestStatusEvent(&request, requestStatusResponseAdded, nodeID, "")
+	err = app.closeRequestIfCompleted(&request)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
-	app.state.SetVersioned([]byte(key), []byte(value))
 	return app.ReturnDeliverTxLog(code.OK, "success", funcParam.RequestID)
 }
 
@@ -760,6 +823,7 @@ func (app *ABCIApplication) revokeAccessor(param string, nodeID string) types.Re
 					// app.logger.Debugf("Acces:%s", args)
 					if accsesor.AccessorId == accsesorID {
 						refGroup.Idps[iIdP].Accessors[iAcc].Active = false
+						refGroup.Idps[iIdP].Accessors[iAcc].Revoked = true
 						break
 					}
 				}
@@ -864,6 +928,13 @@ func (app *ABCIApplication) updateIdentityModeList(param string, nodeID string)
 	if foundThisNodeID == false {
 		return app.ReturnDeliverTxLog(code.IdentityNotFoundInThisIdP, "Identity not found in this IdP", "")
 	}
+	namespaceCount := make(map[string]int)
+	for _, identity := range refGroup.Identities {
+		namespaceCount[identity.Namespace]++
+	}
+	if !app.isModeListAllowedInNamespaces(funcParam.ModeList, namespaceCount) {
+		return app.ReturnDeliverTxLog(code.ModeIsNotAllowedForNamespace, "Mode is not allowed for namespace of identity", "")
+	}
 	for index, idp := range refGroup.Idps {
 		if idp.NodeId == nodeID {
 			// Check new mode list is higher than current mode list
@@ -889,6 +960,93 @@ func (app *ABCIApplication) updateIdentityModeList(param string, nodeID string)
 	return app.ReturnDeliverTxLogWithAttributes(code.OK, "success", attributes)
 }
 
+// upgradeIdentityMode adds mode 3 to mode list of identity registered in mode 2 with the IdP.
+// User consent is a completed request with purpose "UpgradeIdentityMode" which can be used once.
+func (app *ABCIApplication) upgradeIdentityMode(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("UpgradeIdentityMode, Parameter: %s", param)
+	var funcParam UpgradeIdentityModeParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.ReferenceGroupCode != "" && funcParam.IdentityNamespace != "" && funcParam.IdentityIdentifierHash != "" {
+		return app.ReturnDeliverTxLog(code.GotRefGroupCodeAndIdentity, "Found reference group code and identity detail in parameter", "")
+	}
+	refGroupCode := ""
+	if funcParam.ReferenceGroupCode != "" {
+		refGroupCode = funcParam.ReferenceGroupCode
+	} else {
+		identityToRefCodeKey := identityToRefCodeKeyPrefix + keySeparator + funcParam.IdentityNamespace + keySeparator + funcParam.IdentityIdentifierHash
+		refGroupCodeFromDB, _ := app.state.Get([]byte(identityToRefCodeKey), false)
+		if refGroupCodeFromDB == nil {
+			return app.ReturnDeliverTxLog(code.RefGroupNotFound, "Reference group not found", "")
+		}
+		refGroupCode = string(refGroupCodeFromDB)
+	}
+	refGroupKey := refGroupCodeKeyPrefix + keySeparator + string(refGroupCode)
+	refGroupValue, _ := app.state.Get([]byte(refGroupKey), false)
+	if refGroupValue == nil {
+		return app.ReturnDeliverTxLog(code.RefGroupNotFound, "Reference group not found", "")
+	}
+	var refGroup data.ReferenceGroup
+	err = proto.Unmarshal(refGroupValue, &refGroup)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	idpIndex := -1
+	for index, idp := range refGroup.Idps {
+		if idp.NodeId == nodeID && idp.Active {
+			idpIndex = index
+			break
+		}
+	}
+	if idpIndex < 0 {
+		return app.ReturnDeliverTxLog(code.IdentityNotFoundInThisIdP, "Identity not found in this IdP", "")
+	}
+	mode2 := false
+	for _, mode := range refGroup.Idps[idpIndex].Mode {
+		if mode == 3 {
+			return app.ReturnDeliverTxLog(code.IdentityModeIsAlreadyUpgraded, "Identity is already in mode 3", "")
+		}
+		if mode == 2 {
+			mode2 = true
+		}
+	}
+	if !mode2 {
+		return app.ReturnDeliverTxLog(code.InvalidMode, "Identity is not in mode 2", "")
+	}
+	newModeList := append(append([]int32(nil), refGroup.Idps[idpIndex].Mode...), 3)
+	sort.Slice(newModeList, func(i, j int) bool { return newModeList[i] < newModeList[j] })
+	namespaceCount := make(map[string]int)
+	for _, identity := range refGroup.Identities {
+		namespaceCount[identity.Namespace]++
+	}
+	if !app.isModeListAllowedInNamespaces(newModeList, namespaceCount) {
+		return app.ReturnDeliverTxLog(code.ModeIsNotAllowedForNamespace, "Mode is not allowed for namespace of identity", "")
+	}
+	minIdp := 1
+	checkRequestResult := app.checkRequest(funcParam.RequestID, "UpgradeIdentityMode", minIdp)
+	if checkRequestResult.Code != code.OK {
+		return checkRequestResult
+	}
+	refGroup.Idps[idpIndex].Mode = newModeList
+	refGroupValue, err = utils.ProtoDeterministicMarshal(&refGroup)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	increaseRequestUseCountResult := app.increaseRequestUseCount(funcParam.RequestID)
+	if increaseRequestUseCountResult.Code != code.OK {
+		return increaseRequestUseCountResult
+	}
+	app.state.Set([]byte(refGroupKey), []byte(refGroupValue))
+	var attributes []cmn.KVPair
+	var attribute cmn.KVPair
+	attribute.Key = []byte("reference_group_code")
+	attribute.Value = []byte(refGroupCode)
+	attributes = append(attributes, attribute)
+	return app.ReturnDeliverTxLogWithAttributes(code.OK, "success", attributes)
+}
+
 func (app *ABCIApplication) addIdentity(param string, nodeID string) types.ResponseDeliverTx {
 	app.logger.Infof("AddIdentity, Parameter: %s", param)
 	var funcParam AddIdentityParam
@@ -969,11 +1127,30 @@ func (app *ABCIApplication) addIdentity(param string, nodeID string) types.Respo
 		namespaceCount[identity.Namespace] = namespaceCount[identity.Namespace] + 1
 	}
 	allowedIdentifierCount := app.GetNamespaceAllowedIdentifierCountMap(false)
-	for namespace, count := range namespaceCount {
+	for _, namespace := range utils.SortedKeys(namespaceCount) {
+		count := namespaceCount[namespace]
 		if count > allowedIdentifierCount[namespace] && allowedIdentifierCount[namespace] > 0 {
 			return app.ReturnDeliverTxLog(code.IdentifierCountIsGreaterThanAllowedIdentifierCount, "Identifier count is greater than allowed identifier count", "")
 		}
 	}
+	// Identity in reference group has no inactive state, so every identifier is counted as active
+	allowedActiveIdentifierCount := app.GetNamespaceAllowedActiveIdentifierCountMap(false)
+	for _, namespace := range utils.SortedKeys(namespaceCount) {
+		count := namespaceCount[namespace]
+		if count > allowedActiveIdentifierCount[namespace] && allowedActiveIdentifierCount[namespace] > 0 {
+			return app.ReturnDeliverTxLog(code.ActiveIdentifierCountIsGreaterThanAllowedCount, "Active identifier count is greater than allowed active identifier count", "")
+		}
+	}
+	// Mode of every IdP in reference group must be allowed for namespace of new identities
+	newNamespaceCount := make(map[string]int)
+	for _, identity := range user.NewIdentityList {
+		newNamespaceCount[identity.IdentityNamespace]++
+	}
+	for _, idp := range refGroup.Idps {
+		if idp.Active && !app.isModeListAllowedInNamespaces(idp.Mode, newNamespaceCount) {
+			return app.ReturnDeliverTxLog(code.ModeIsNotAllowedForNamespace, "Mode is not allowed for namespace of identity", "")
+		}
+	}
 	foundThisNodeID := false
 	mode3 := false
 	for _, idp := range refGroup.Idps {
@@ -1098,6 +1275,7 @@ func (app *ABCIApplication) revokeAndAddAccessor(param string, nodeID string) ty
 			for iAcc, accsesor := range idp.Accessors {
 				if accsesor.AccessorId == funcParam.RevokingAccessorID {
 					refGroup.Idps[iIdP].Accessors[iAcc].Active = false
+					refGroup.Idps[iIdP].Accessors[iAcc].Revoked = true
 					break
 				}
 			}
@@ -1137,6 +1315,7 @@ func (app *ABCIApplication) revokeAndAddAccessor(param string, nodeID string) ty
 	accessor.AccessorPublicKey = funcParam.AccessorPublicKey
 	accessor.Active = true
 	accessor.Owner = nodeID
+	accessor.CreationBlockHeight = app.state.CurrentBlockHeight
 	for _, idp := range refGroup.Idps {
 		if idp.NodeId == nodeID {
 			idp.Accessors = append(idp.Accessors, &accessor)
@@ -1175,3 +1354,42 @@ func MaxInt32(v []int32) int32 {
 	}
 	return m
 }
+
+// registerIdentityAndCreateIdpResponse registers identity and responds to request in one transaction.
+// If any of them fails, changes made by the other are discarded.
+func (app *ABCIApplication) registerIdentityAndCreateIdpResponse(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("RegisterIdentityAndCreateIdpResponse, Parameter: %s", param)
+	var funcParam RegisterIdentityAndCreateIdpResponseParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	registerIdentityParam, err := json.Marshal(funcParam.RegisterIdentity)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	createIdpResponseParam, err := json.Marshal(funcParam.CreateIdpResponse)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	snapshot := app.state.Snapshot()
+	registerIdentityResult := app.registerIdentity(string(registerIdentityParam), nodeID)
+	if registerIdentityResult.Code != code.OK {
+		app.state.RevertToSnapshot(snapshot)
+		return registerIdentityResult
+	}
+	createIdpResponseResult := app.createIdpResponse(string(createIdpResponseParam), nodeID)
+	if createIdpResponseResult.Code != code.OK {
+		app.state.RevertToSnapshot(snapshot)
+		return createIdpResponseResult
+	}
+	var attributes []cmn.KVPair
+	var attribute cmn.KVPair
+	attribute.Key = []byte("reference_group_code")
+	attribute.Value = []byte(funcParam.RegisterIdentity.ReferenceGroupCode)
+	attributes = append(attributes, attribute)
+	attribute.Key = []byte("request_id")
+	attribute.Value = []byte(funcParam.CreateIdpResponse.RequestID)
+	attributes = append(attributes, attribute)
+	return app.ReturnDeliverTxLogWithAttributes(code.OK, "success", attributes)
+}
@@ -0,0 +1,363 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// Secondary indexes which can be rebuilt from primary records
+const (
+	IndexRoleList           = "role_list"
+	IndexServiceDestination = "service_destination"
+	IndexOpenRequestCount   = "open_request_count"
+)
+
+var rebuildableIndexes = []string{IndexRoleList, IndexServiceDestination, IndexOpenRequestCount}
+
+const indexesRebuiltEventType = "did.indexes_rebuilt"
+
+// IndexDrift is secondary index key which value does not match primary records
+type IndexDrift struct {
+	Index  string `json:"index"`
+	Key    string `json:"key"`
+	Detail string `json:"detail"`
+}
+
+// rebuildIndexes rebuilds secondary indexes (all when indexes is empty) from primary records
+// in uncommitted state and sets rebuilt value of every index key which drifted:
+//   - role lists (IdP, RP, AS and all node ID lists) from node details
+//   - service destinations from services provided by AS nodes
+//   - open request counts of RP by priority class from requests
+//
+// Order of node IDs still in list is kept and missing node IDs are appended in node ID order.
+func (app *ABCIApplication) rebuildIndexes(indexes []string) (driftList []IndexDrift, err error) {
+	if len(indexes) == 0 {
+		indexes = rebuildableIndexes
+	}
+	driftList = make([]IndexDrift, 0)
+	for _, index := range indexes {
+		var drifts []IndexDrift
+		switch index {
+		case IndexRoleList:
+			drifts, err = app.rebuildRoleLists()
+		case IndexServiceDestination:
+			drifts, err = app.rebuildServiceDestinations()
+		case IndexOpenRequestCount:
+			drifts, err = app.rebuildOpenRequestCounts()
+		default:
+			return nil, fmt.Errorf("Unknown index %q", index)
+		}
+		if err != nil {
+			return nil, err
+		}
+		driftList = append(driftList, drifts...)
+	}
+	return driftList, nil
+}
+
+// mergeNodeIDList returns node IDs of list which are expected in list order followed by
+// expected node IDs missing from list in node ID order, and number of removed and added node IDs
+func mergeNodeIDList(list []string, expected map[string]bool) (merged []string, removed int, added int) {
+	merged = make([]string, 0, len(expected))
+	seen := make(map[string]bool)
+	for _, nodeID := range list {
+		if !expected[nodeID] || seen[nodeID] {
+			removed++
+			continue
+		}
+		seen[nodeID] = true
+		merged = append(merged, nodeID)
+	}
+	for _, nodeID := range utils.SortedKeys(expected) {
+		if !seen[nodeID] {
+			added++
+			merged = append(merged, nodeID)
+		}
+	}
+	return merged, removed, added
+}
+
+func (app *ABCIApplication) rebuildRoleLists() ([]IndexDrift, error) {
+	expected := make(map[string]map[string]bool)
+	for _, role := range []string{"", "idp", "rp", "as"} {
+		expected[role] = make(map[string]bool)
+	}
+	prefix := nodeIDKeyPrefix + keySeparator
+	for _, key := range app.state.Keys([]byte(prefix)) {
+		value, _ := app.state.Get([]byte(key), false)
+		var nodeDetail data.NodeDetail
+		err := proto.Unmarshal(value, &nodeDetail)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", key, err)
+		}
+		nodeID := strings.TrimPrefix(key, prefix)
+		expected[""][nodeID] = true
+		for _, role := range []string{"IdP", "RP", "AS"} {
+			if hasRole(&nodeDetail, role) {
+				expected[strings.ToLower(role)][nodeID] = true
+			}
+		}
+	}
+	driftList := make([]IndexDrift, 0)
+	for _, role := range []string{"", "idp", "rp", "as"} {
+		listKey := nodeIDListKeyByRole[role]
+		var nodeIDList data.AllList
+		value, _ := app.state.Get([]byte(listKey), false)
+		if value != nil {
+			err := proto.Unmarshal(value, &nodeIDList)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", listKey, err)
+			}
+		}
+		merged, removed, added := mergeNodeIDList(nodeIDList.NodeId, expected[role])
+		if removed == 0 && added == 0 {
+			continue
+		}
+		nodeIDList.NodeId = merged
+		value, err := utils.ProtoDeterministicMarshal(&nodeIDList)
+		if err != nil {
+			return nil, err
+		}
+		app.state.Set([]byte(listKey), value)
+		driftList = append(driftList, IndexDrift{
+			Index:  IndexRoleList,
+			Key:    listKey,
+			Detail: fmt.Sprintf("%d node IDs removed, %d node IDs added", removed, added),
+		})
+	}
+	return driftList, nil
+}
+
+func (app *ABCIApplication) rebuildServiceDestinations() ([]IndexDrift, error) {
+	// Services provided by AS node are primary records of service destinations
+	expected := make(map[string]map[string]*data.ASNode)
+	prefix := providedServicesKeyPrefix + keySeparator
+	for _, key := range app.state.Keys([]byte(prefix)) {
+		value, _ := app.state.Get([]byte(key), false)
+		var services data.ServiceList
+		err := proto.Unmarshal(value, &services)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", key, err)
+		}
+		nodeID := strings.TrimPrefix(key, prefix)
+		for _, service := range services.Services {
+			if expected[service.ServiceId] == nil {
+				expected[service.ServiceId] = make(map[string]*data.ASNode)
+			}
+			expected[service.ServiceId][nodeID] = &data.ASNode{
+				NodeId:                   nodeID,
+				MinIal:                   service.MinIal,
+				MinAal:                   service.MinAal,
+				ServiceId:                service.ServiceId,
+				SupportedNamespaceList:   service.SupportedNamespaceList,
+				Active:                   service.Active,
+				SupportedDataUrlTypeList: service.SupportedDataUrlTypeList,
+			}
+		}
+	}
+	serviceIDs := make(map[string]bool)
+	for serviceID := range expected {
+		serviceIDs[serviceID] = true
+	}
+	prefix = serviceDestinationKeyPrefix + keySeparator
+	for _, key := range app.state.Keys([]byte(prefix)) {
+		serviceIDs[strings.TrimPrefix(key, prefix)] = true
+	}
+	driftList := make([]IndexDrift, 0)
+	for _, serviceID := range utils.SortedKeys(serviceIDs) {
+		key := serviceDestinationKeyPrefix + keySeparator + serviceID
+		var nodes data.ServiceDesList
+		value, _ := app.state.Get([]byte(key), false)
+		if value != nil {
+			err := proto.Unmarshal(value, &nodes)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", key, err)
+			}
+		}
+		nodeIDList := make([]string, 0, len(nodes.Node))
+		existingNodes := make(map[string]*data.ASNode)
+		for _, node := range nodes.Node {
+			nodeIDList = append(nodeIDList, node.NodeId)
+			if _, exist := existingNodes[node.NodeId]; !exist {
+				existingNodes[node.NodeId] = node
+			}
+		}
+		expectedNodeIDs := make(map[string]bool)
+		for nodeID := range expected[serviceID] {
+			expectedNodeIDs[nodeID] = true
+		}
+		merged, removed, added := mergeNodeIDList(nodeIDList, expectedNodeIDs)
+		var rebuilt data.ServiceDesList
+		var updated int
+		for _, nodeID := range merged {
+			node := expected[serviceID][nodeID]
+			if existingNode, exist := existingNodes[nodeID]; exist && !proto.Equal(existingNode, node) {
+				updated++
+			}
+			rebuilt.Node = append(rebuilt.Node, node)
+		}
+		if proto.Equal(&nodes, &rebuilt) {
+			continue
+		}
+		if len(rebuilt.Node) == 0 {
+			app.state.Delete([]byte(key))
+		} else {
+			value, err := utils.ProtoDeterministicMarshal(&rebuilt)
+			if err != nil {
+				return nil, err
+			}
+			app.state.Set([]byte(key), value)
+		}
+		driftList = append(driftList, IndexDrift{
+			Index:  IndexServiceDestination,
+			Key:    key,
+			Detail: fmt.Sprintf("%d nodes removed, %d nodes added, %d nodes updated", removed, added, updated),
+		})
+	}
+	return driftList, nil
+}
+
+func (app *ABCIApplication) rebuildOpenRequestCounts() ([]IndexDrift, error) {
+	expected := make(map[string]int64)
+	prefix := requestKeyPrefix + keySeparator
+	for _, key := range app.state.Keys([]byte(prefix)) {
+		if !strings.HasSuffix(key, keySeparator+"versions") {
+			continue
+		}
+		requestKey := strings.TrimSuffix(key, keySeparator+"versions")
+		value, err := app.state.GetVersioned([]byte(requestKey), 0, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", requestKey, err)
+		}
+		if value == nil {
+			continue
+		}
+		var request data.Request
+		err = proto.Unmarshal(value, &request)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", requestKey, err)
+		}
+		if request.PriorityClass == "" || request.Closed || request.TimedOut {
+			continue
+		}
+		expected[getOpenRequestCountKey(request.Owner, request.PriorityClass)]++
+	}
+	countKeys := make(map[string]bool)
+	for key := range expected {
+		countKeys[key] = true
+	}
+	for _, key := range app.state.Keys([]byte(openRequestCountKeyPrefix + keySeparator)) {
+		countKeys[key] = true
+	}
+	driftList := make([]IndexDrift, 0)
+	for _, key := range utils.SortedKeys(countKeys) {
+		value, _ := app.state.Get([]byte(key), false)
+		var count int64
+		var err error
+		if value != nil {
+			count, err = strconv.ParseInt(string(value), 10, 64)
+		}
+		if err == nil && count == expected[key] {
+			continue
+		}
+		if expected[key] == 0 {
+			app.state.Delete([]byte(key))
+		} else {
+			app.state.Set([]byte(key), []byte(strconv.FormatInt(expected[key], 10)))
+		}
+		driftList = append(driftList, IndexDrift{
+			Index:  IndexOpenRequestCount,
+			Key:    key,
+			Detail: fmt.Sprintf("count %q, rebuilt count %d", string(value), expected[key]),
+		})
+	}
+	return driftList, nil
+}
+
+// rebuildIndexesTx rebuilds secondary indexes in consensus so that every node repairs
+// the same drift at the same height
+func (app *ABCIApplication) rebuildIndexesTx(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("RebuildIndexes, Parameter: %s", param)
+	var funcParam RebuildIndexesParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	for _, index := range funcParam.IndexList {
+		if !isRebuildableIndex(index) {
+			return app.ReturnDeliverTxLog(code.UnknownIndex, "Unknown index: "+index, "")
+		}
+	}
+	driftList, err := app.rebuildIndexes(funcParam.IndexList)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	attributes := []cmn.KVPair{
+		{Key: []byte("drift_count"), Value: []byte(strconv.Itoa(len(driftList)))},
+	}
+	for _, drift := range driftList {
+		app.logger.Warnf("Rebuilt index %s: %s", drift.Key, drift.Detail)
+		attributes = append(attributes, cmn.KVPair{Key: []byte("key"), Value: []byte(drift.Key)})
+	}
+	app.deliverTxEvents = append(app.deliverTxEvents, types.Event{
+		Type:       indexesRebuiltEventType,
+		Attributes: attributes,
+	})
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func isRebuildableIndex(index string) bool {
+	for _, rebuildableIndex := range rebuildableIndexes {
+		if index == rebuildableIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIndexes checks secondary indexes (all when indexes is empty) of app state DB (node must
+// be stopped) against primary records and returns index keys which drifted. DB is not written:
+// repair of a stopped node is not part of app hash and forks the node from the chain unless
+// every node repairs at the same height, RebuildIndexes Tx repairs indexes in consensus instead.
+func CheckIndexes(db dbm.DB, indexes []string) ([]IndexDrift, error) {
+	for _, index := range indexes {
+		if !isRebuildableIndex(index) {
+			return nil, fmt.Errorf("Unknown index %q, must be one of %s", index, strings.Join(rebuildableIndexes, ", "))
+		}
+	}
+	app := &ABCIApplication{state: NewAppState(db)}
+	return app.rebuildIndexes(indexes)
+}
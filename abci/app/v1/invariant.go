@@ -0,0 +1,237 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+const (
+	invariantCheckModeAlert = "alert"
+	invariantCheckModeHalt  = "halt"
+)
+
+// maxInvariantViolations is maximum number of violations reported by one check
+const maxInvariantViolations = 100
+
+type invariantChecker struct {
+	app        *ABCIApplication
+	nodeExists map[string]bool
+	violations []string
+}
+
+func (checker *invariantChecker) addViolation(format string, args ...interface{}) bool {
+	checker.violations = append(checker.violations, fmt.Sprintf(format, args...))
+	return len(checker.violations) < maxInvariantViolations
+}
+
+func (checker *invariantChecker) isNodeExist(nodeID string) bool {
+	exist, cached := checker.nodeExists[nodeID]
+	if !cached {
+		nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
+		exist = checker.app.state.Has([]byte(nodeDetailKey), true)
+		checker.nodeExists[nodeID] = exist
+	}
+	return exist
+}
+
+// checkInvariants verifies referential integrity of committed state and returns list of violations
+func (app *ABCIApplication) checkInvariants() []string {
+	checker := invariantChecker{
+		app:        app,
+		nodeExists: make(map[string]bool),
+	}
+	checker.checkServiceDestinations()
+	checker.checkRequests()
+	checker.checkTokens()
+	return checker.violations
+}
+
+// checkServiceDestinations checks that every node in service destination list exists
+func (checker *invariantChecker) checkServiceDestinations() {
+	prefix := []byte(serviceDestinationKeyPrefix + keySeparator)
+	checker.app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		var serviceDestinationList data.ServiceDesList
+		if err := proto.Unmarshal(value, &serviceDestinationList); err != nil {
+			return checker.addViolation("%s: %s", string(key), err.Error())
+		}
+		for _, node := range serviceDestinationList.Node {
+			if !checker.isNodeExist(node.NodeId) {
+				if !checker.addViolation("%s: node %s does not exist", string(key), node.NodeId) {
+					return false
+				}
+			}
+		}
+		return true
+	})
+}
+
+// checkRequests checks that owner, IdPs and ASes of latest version of every request exist
+// and that its summary matches its responses and answered AS lists
+func (checker *invariantChecker) checkRequests() {
+	prefix := []byte(requestKeyPrefix + keySeparator)
+	checker.app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		keyStr := string(key)
+		if !strings.HasSuffix(keyStr, keySeparator+"versions") {
+			return true
+		}
+		var keyVersions data.KeyVersions
+		if err := proto.Unmarshal(value, &keyVersions); err != nil {
+			return checker.addViolation("%s: %s", keyStr, err.Error())
+		}
+		if len(keyVersions.Versions) == 0 {
+			return true
+		}
+		requestKey := strings.TrimSuffix(keyStr, keySeparator+"versions")
+		latestVersion := keyVersions.Versions[len(keyVersions.Versions)-1]
+		requestKeyWithVersion := requestKey + keySeparator + strconv.FormatInt(latestVersion, 10)
+		requestValue, _ := checker.app.state.Get([]byte(requestKeyWithVersion), true)
+		if requestValue == nil {
+			return checker.addViolation("%s: version %d not found", requestKey, latestVersion)
+		}
+		var request data.Request
+		if err := proto.Unmarshal(requestValue, &request); err != nil {
+			return checker.addViolation("%s: %s", requestKey, err.Error())
+		}
+		if err := checker.app.loadRequestSubRecords(&request, 0, true); err != nil {
+			return checker.addViolation("%s: %s", requestKey, err.Error())
+		}
+		if request.Summary != nil && !proto.Equal(request.Summary, newRequestSummary(&request)) {
+			if !checker.addViolation("%s: summary does not match responses and answered AS lists", requestKey) {
+				return false
+			}
+		}
+		nodeIDs := []string{request.Owner}
+		nodeIDs = append(nodeIDs, request.IdpIdList...)
+		for _, response := range request.ResponseList {
+			nodeIDs = append(nodeIDs, response.IdpId)
+		}
+		for _, dataRequest := range request.DataRequestList {
+			nodeIDs = append(nodeIDs, dataRequest.AsIdList...)
+			nodeIDs = append(nodeIDs, dataRequest.AnsweredAsIdList...)
+		}
+		for _, nodeID := range nodeIDs {
+			if !checker.isNodeExist(nodeID) {
+				if !checker.addViolation("%s: node %s does not exist", requestKey, nodeID) {
+					return false
+				}
+			}
+		}
+		return true
+	})
+}
+
+// checkTokens checks that every token account belongs to existing node and is not negative
+func (checker *invariantChecker) checkTokens() {
+	prefix := []byte(tokenKeyPrefix + keySeparator)
+	checker.app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		var token data.Token
+		if err := proto.Unmarshal(value, &token); err != nil {
+			return checker.addViolation("%s: %s", string(key), err.Error())
+		}
+		nodeID := strings.TrimPrefix(string(key), string(prefix))
+		if !checker.isNodeExist(nodeID) {
+			if !checker.addViolation("%s: node %s does not exist", string(key), nodeID) {
+				return false
+			}
+		}
+		if token.Amount < 0 {
+			if !checker.addViolation("%s: amount %f is negative", string(key), token.Amount) {
+				return false
+			}
+		}
+		// Balance checkpoint must match balance after latest ledger entry
+		if token.LedgerEntryCount > 0 {
+			entryKey := getTokenLedgerEntryKey(nodeID, token.LedgerEntryCount-1)
+			entryValue, _ := checker.app.state.Get([]byte(entryKey), true)
+			var entry data.TokenLedgerEntry
+			if entryValue == nil || proto.Unmarshal(entryValue, &entry) != nil {
+				return checker.addViolation("%s: latest ledger entry not found", string(key))
+			}
+			if entry.Balance != token.Amount {
+				return checker.addViolation("%s: amount %f does not match ledger balance %f", string(key), token.Amount, entry.Balance)
+			}
+		}
+		return true
+	})
+}
+
+// newQueryItemError logs missing or corrupt record referenced by item of query result
+// and counts it so invariant check is run at next commit
+func (app *ABCIApplication) newQueryItemError(method string, nodeID string, message string) QueryItemError {
+	app.logger.Errorf("%s: inconsistent record of node %s: %s", method, nodeID, message)
+	atomic.AddInt64(&app.queryInconsistencyCount, 1)
+	go recordQueryInconsistencyMetrics(method)
+	return QueryItemError{
+		NodeID: nodeID,
+		Error:  message,
+	}
+}
+
+// checkInvariantsAtCommit runs invariant check every configured number of blocks, or at next commit
+// after queries found inconsistent records, when it is enabled.
+// Violations are logged as error and, in halt mode, stop the app.
+func (app *ABCIApplication) checkInvariantsAtCommit() {
+	if app.invariantCheckMode != invariantCheckModeAlert && app.invariantCheckMode != invariantCheckModeHalt {
+		return
+	}
+	queryInconsistencyFound := atomic.SwapInt64(&app.queryInconsistencyCount, 0) > 0
+	if !queryInconsistencyFound && (app.invariantCheckInterval <= 0 || app.state.Height%app.invariantCheckInterval != 0) {
+		return
+	}
+	violations := app.checkInvariants()
+	if len(violations) == 0 {
+		return
+	}
+	for _, violation := range violations {
+		app.logger.Errorf("Invariant violation at height %d: %s", app.state.Height, violation)
+	}
+	if app.invariantCheckMode == invariantCheckModeHalt {
+		panic(fmt.Errorf("%d invariant violation(s) found at height %d", len(violations), app.state.Height))
+	}
+}
+
+func (app *ABCIApplication) checkInvariantsQuery(param string) types.ResponseQuery {
+	app.logger.Infof("CheckInvariants, Parameter: %s", param)
+	var result CheckInvariantsResult
+	result.Violations = app.checkInvariants()
+	if result.Violations == nil {
+		result.Violations = make([]string, 0)
+	}
+	result.Valid = len(result.Violations) == 0
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
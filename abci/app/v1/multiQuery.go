@@ -0,0 +1,69 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+// maxMultiQueryCount is maximum number of queries in one MultiQuery
+const maxMultiQueryCount = 50
+
+// multiQuery runs each query against the same state height and returns results in the same order
+func (app *ABCIApplication) multiQuery(param string, height int64) types.ResponseQuery {
+	app.logger.Infof("MultiQuery, Parameter: %s", param)
+	var funcParam MultiQueryParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if len(funcParam.Queries) > maxMultiQueryCount {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, "Too many queries", app.state.Height)
+	}
+	var result MultiQueryResult
+	result.Results = make([]MultiQueryItemResult, 0, len(funcParam.Queries))
+	for _, query := range funcParam.Queries {
+		var itemResult types.ResponseQuery
+		if query.Method == "MultiQuery" {
+			itemResult = app.ReturnQueryWithCode(code.UnknownMethod, nil, "MultiQuery can't be nested", app.state.Height)
+		} else {
+			itemResult = app.QueryRouter(query.Method, string(query.Params), height)
+		}
+		var item MultiQueryItemResult
+		item.Code = itemResult.Code
+		item.Log = itemResult.Log
+		if json.Valid(itemResult.Value) {
+			item.Value = itemResult.Value
+		}
+		result.Results = append(result.Results, item)
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -23,6 +23,7 @@
 package app
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"strconv"
 	"strings"
@@ -31,6 +32,7 @@ import (
 	"github.com/tendermint/tendermint/abci/types"
 
 	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/keys"
 	"github.com/ndidplatform/smart-contract/v4/abci/utils"
 	"github.com/ndidplatform/smart-contract/v4/protos/data"
 )
@@ -38,8 +40,10 @@ import (
 var isNDIDMethod = map[string]bool{
 	"InitNDID":                         true,
 	"RegisterNode":                     true,
+	"RegisterNodeBatch":                true,
 	"AddNodeToken":                     true,
 	"ReduceNodeToken":                  true,
+	"RefundToken":                      true,
 	"SetNodeToken":                     true,
 	"SetPriceFunc":                     true,
 	"AddNamespace":                     true,
@@ -61,11 +65,38 @@ var isNDIDMethod = map[string]bool{
 	"UpdateNodeProxyNode":              true,
 	"RemoveNodeFromProxyNode":          true,
 	"SetInitData":                      true,
+	"SetChainHistoryInfo":              true,
 	"EndInit":                          true,
 	"SetLastBlock":                     true,
 	"SetAllowedModeList":               true,
 	"UpdateNamespace":                  true,
+	"SetRequestMessageHashCheckHeight": true,
 	"SetAllowedMinIalForRegisterIdentityAtFirstIdp": true,
+	"SetNodeQuota":                  true,
+	"SetMaxRequestTimeoutExtension": true,
+	"SetRequestListSizeLimit":       true,
+	"SetRandomnessBeaconConfig":     true,
+	"SetAppHashScheme":              true,
+	"SetValidatorNode":              true,
+	"SetRequestEscrowPrice":         true,
+	"SetLowTokenThreshold":          true,
+	"SetAdminApprovalPolicy":        true,
+	"CreateAdminProposal":           true,
+	"ApproveAdminProposal":          true,
+	"SetGovernanceActionDelay":      true,
+	"CancelGovernanceAction":        true,
+	"SetMethodPaused":               true,
+	"SetValidatorPowerPolicy":       true,
+	"SetValidatorMissThreshold":     true,
+	"SetNodeIDAlias":                true,
+	"RebuildIndexes":                true,
+	"SetBlockWriteLimit":            true,
+	"SetRequestPriorityClassList":   true,
+	"SetQueryVisibility":            true,
+	"SetDataRetentionPolicy":        true,
+	"SetEndBlockHookEnabled":        true,
+	"AddNodeRole":                   true,
+	"RemoveNodeRole":                true,
 }
 
 func (app *ABCIApplication) initNDID(param string, nodeID string) types.ResponseDeliverTx {
@@ -81,12 +112,18 @@ func (app *ABCIApplication) initNDID(param string, nodeID string) types.Response
 	nodeDetail.NodeName = "NDID"
 	nodeDetail.Role = "NDID"
 	nodeDetail.Active = true
+	app.setNodeDetailCreation(&nodeDetail)
+	app.setNodeDetailLastUpdate(&nodeDetail)
 	nodeDetailByte, err := utils.ProtoDeterministicMarshal(&nodeDetail)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
+	err = app.setNodeKey(funcParam.NodeID, &nodeDetail)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
 	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
-	chainHistoryInfoKey := "ChainHistoryInfo"
+	chainHistoryInfoKey := keys.ChainHistoryInfoKey
 	app.state.Set(masterNDIDKeyBytes, []byte(nodeID))
 	app.state.Set([]byte(nodeDetailKey), []byte(nodeDetailByte))
 	app.state.Set(initStateKeyBytes, []byte("true"))
@@ -104,13 +141,14 @@ func (app *ABCIApplication) registerNode(param string, nodeID string) types.Resp
 	key := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
 	// check Duplicate Node ID
 	chkExists, _ := app.state.Get([]byte(key), false)
-	if chkExists != nil {
+	if chkExists != nil || app.state.Has(getNodeIDAliasKey(funcParam.NodeID), false) {
 		return app.ReturnDeliverTxLog(code.DuplicateNodeID, "Duplicate Node ID", "")
 	}
 	// check role is valid
 	if !(funcParam.Role == "RP" ||
 		funcParam.Role == "IdP" ||
 		funcParam.Role == "AS" ||
+		funcParam.Role == "IdPAgent" ||
 		strings.ToLower(funcParam.Role) == "proxy") {
 		return app.ReturnDeliverTxLog(code.WrongRole, "Wrong Role", "")
 	}
@@ -124,12 +162,55 @@ func (app *ABCIApplication) registerNode(param string, nodeID string) types.Resp
 	nodeDetail.NodeName = funcParam.NodeName
 	nodeDetail.Role = funcParam.Role
 	nodeDetail.Active = true
+	app.setNodeDetailCreation(&nodeDetail)
 	// if node is IdP, set max_aal, min_ial and supported_request_message_type_list
 	if funcParam.Role == "IdP" {
 		nodeDetail.MaxAal = funcParam.MaxAal
 		nodeDetail.MaxIal = funcParam.MaxIal
 		nodeDetail.SupportedRequestMessageDataUrlTypeList = make([]string, 0)
 	}
+	// if node is IdP agent, parent IdP must exist and agent's max_ial, max_aal must not exceed parent's
+	if funcParam.Role == "IdPAgent" {
+		parentNodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.ParentIdPID
+		parentNodeDetailValue, _ := app.state.Get([]byte(parentNodeDetailKey), false)
+		if parentNodeDetailValue == nil {
+			return app.ReturnDeliverTxLog(code.ParentIdPNotFound, "Parent IdP not found", "")
+		}
+		var parentNodeDetail data.NodeDetail
+		err := proto.Unmarshal(parentNodeDetailValue, &parentNodeDetail)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+		}
+		if parentNodeDetail.Role != "IdP" {
+			return app.ReturnDeliverTxLog(code.ParentIdPNotFound, "Parent IdP not found", "")
+		}
+		if funcParam.MaxIal > parentNodeDetail.MaxIal {
+			return app.ReturnDeliverTxLog(code.IALError, "Max IAL must be less than or equals to parent IdP's max IAL", "")
+		}
+		if funcParam.MaxAal > parentNodeDetail.MaxAal {
+			return app.ReturnDeliverTxLog(code.AALError, "Max AAL must be less than or equals to parent IdP's max AAL", "")
+		}
+		nodeDetail.MaxAal = funcParam.MaxAal
+		nodeDetail.MaxIal = funcParam.MaxIal
+		nodeDetail.ParentIdpId = funcParam.ParentIdPID
+		nodeDetail.SupportedRequestMessageDataUrlTypeList = make([]string, 0)
+		// add node id to parent IdP's agent list
+		var agentList data.IdPList
+		agentListKey := idpAgentListKeyPrefix + keySeparator + funcParam.ParentIdPID
+		agentListValue, _ := app.state.Get([]byte(agentListKey), false)
+		if agentListValue != nil {
+			err := proto.Unmarshal(agentListValue, &agentList)
+			if err != nil {
+				return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+			}
+		}
+		agentList.NodeId = append(agentList.NodeId, funcParam.NodeID)
+		agentListByte, err := utils.ProtoDeterministicMarshal(&agentList)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+		}
+		app.state.Set([]byte(agentListKey), []byte(agentListByte))
+	}
 	// if node is IdP, add node id to IdPList
 	var idpsList data.IdPList
 	if funcParam.Role == "IdP" {
@@ -149,7 +230,7 @@ func (app *ABCIApplication) registerNode(param string, nodeID string) types.Resp
 	}
 	// if node is rp, add node id to rpList
 	var rpsList data.RPList
-	rpsKey := "rpList"
+	rpsKey := keys.RPListKey
 	if funcParam.Role == "RP" {
 		rpsValue, _ := app.state.Get([]byte(rpsKey), false)
 		if rpsValue != nil {
@@ -167,7 +248,7 @@ func (app *ABCIApplication) registerNode(param string, nodeID string) types.Resp
 	}
 	// if node is as, add node id to asList
 	var asList data.ASList
-	asKey := "asList"
+	asKey := keys.ASListKey
 	if funcParam.Role == "AS" {
 		asValue, _ := app.state.Get([]byte(asKey), false)
 		if asValue != nil {
@@ -184,7 +265,7 @@ func (app *ABCIApplication) registerNode(param string, nodeID string) types.Resp
 		app.state.Set([]byte(asKey), []byte(asListByte))
 	}
 	var allList data.AllList
-	allKey := "allList"
+	allKey := keys.AllListKey
 	allValue, _ := app.state.Get([]byte(allKey), false)
 	if allValue != nil {
 		err := proto.Unmarshal(allValue, &allList)
@@ -198,10 +279,15 @@ func (app *ABCIApplication) registerNode(param string, nodeID string) types.Resp
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
 	app.state.Set([]byte(allKey), []byte(allListByte))
+	app.setNodeDetailLastUpdate(&nodeDetail)
 	nodeDetailByte, err := utils.ProtoDeterministicMarshal(&nodeDetail)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
+	err = app.setNodeKey(funcParam.NodeID, &nodeDetail)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
 	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
 	app.state.Set([]byte(nodeDetailKey), []byte(nodeDetailByte))
 	app.createTokenAccount(funcParam.NodeID)
@@ -239,6 +325,7 @@ func (app *ABCIApplication) addNamespace(param string, nodeID string) types.Resp
 	if funcParam.AllowedActiveIdentifierCountInReferenceGroup != 0 {
 		newNamespace.AllowedActiveIdentifierCountInReferenceGroup = funcParam.AllowedActiveIdentifierCountInReferenceGroup
 	}
+	newNamespace.AllowedModeList = funcParam.AllowedModeList
 	// set active flag
 	newNamespace.Active = true
 	namespaces.Namespaces = append(namespaces.Namespaces, &newNamespace)
@@ -299,12 +386,16 @@ func (app *ABCIApplication) addService(param string, nodeID string) types.Respon
 	service.Active = true
 	service.DataSchema = funcParam.DataSchema
 	service.DataSchemaVersion = funcParam.DataSchemaVersion
+	if service.DataSchemaVersion != "" {
+		dataSchemaKey := dataSchemaKeyPrefix + keySeparator + service.ServiceId + keySeparator + service.DataSchemaVersion
+		app.state.Set([]byte(dataSchemaKey), []byte(service.DataSchema))
+	}
 	serviceJSON, err := utils.ProtoDeterministicMarshal(&service)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
 	// Add detail to service directory
-	allServiceKey := "AllService"
+	allServiceKey := keys.AllServiceKey
 	allServiceValue, _ := app.state.Get([]byte(allServiceKey), false)
 	var services data.ServiceDetailList
 	if allServiceValue != nil {
@@ -346,7 +437,7 @@ func (app *ABCIApplication) disableService(param string, nodeID string) types.Re
 		return app.ReturnDeliverTxLog(code.ServiceIDNotFound, "Service ID not found", "")
 	}
 	// Delete detail in service directory
-	allServiceKey := "AllService"
+	allServiceKey := keys.AllServiceKey
 	allServiceValue, _ := app.state.Get([]byte(allServiceKey), false)
 	var services data.ServiceDetailList
 	if allServiceValue == nil {
@@ -405,7 +496,7 @@ func (app *ABCIApplication) updateNodeByNDID(param string, nodeID string) types.
 		node.NodeName = funcParam.NodeName
 	}
 	// If node is IdP then update max_ial, max_aal
-	if node.Role == "IdP" {
+	if hasRole(&node, "IdP") {
 		if funcParam.MaxIal > 0 {
 			node.MaxIal = funcParam.MaxIal
 		}
@@ -413,6 +504,7 @@ func (app *ABCIApplication) updateNodeByNDID(param string, nodeID string) types.
 			node.MaxAal = funcParam.MaxAal
 		}
 	}
+	app.setNodeDetailLastUpdate(&node)
 	nodeDetailJSON, err := utils.ProtoDeterministicMarshal(&node)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
@@ -448,8 +540,17 @@ func (app *ABCIApplication) updateService(param string, nodeID string) types.Res
 	if funcParam.DataSchemaVersion != "" {
 		service.DataSchemaVersion = funcParam.DataSchemaVersion
 	}
+	// Keep schema of each version, registered schema version cannot be changed
+	if service.DataSchemaVersion != "" && (funcParam.DataSchema != "" || funcParam.DataSchemaVersion != "") {
+		dataSchemaKey := dataSchemaKeyPrefix + keySeparator + service.ServiceId + keySeparator + service.DataSchemaVersion
+		dataSchemaValue, _ := app.state.Get([]byte(dataSchemaKey), false)
+		if dataSchemaValue != nil && string(dataSchemaValue) != service.DataSchema {
+			return app.ReturnDeliverTxLog(code.DataSchemaVersionAlreadyExisted, "Data schema version is already existed with different data schema", "")
+		}
+		app.state.Set([]byte(dataSchemaKey), []byte(service.DataSchema))
+	}
 	// Update detail in service directory
-	allServiceKey := "AllService"
+	allServiceKey := keys.AllServiceKey
 	allServiceValue, _ := app.state.Get([]byte(allServiceKey), false)
 	var services data.ServiceDetailList
 	if allServiceValue != nil {
@@ -499,7 +600,7 @@ func (app *ABCIApplication) registerServiceDestinationByNDID(param string, nodeI
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
 	// Check role is AS
-	if nodeDetail.Role != "AS" {
+	if !hasRole(&nodeDetail, "AS") {
 		return app.ReturnDeliverTxLog(code.RoleIsNotAS, "Role of node ID is not AS", "")
 	}
 	// Check Service ID
@@ -542,6 +643,7 @@ func (app *ABCIApplication) disableNode(param string, nodeID string) types.Respo
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
 	nodeDetail.Active = false
+	app.setNodeDetailLastUpdate(&nodeDetail)
 	nodeDetailValue, err = utils.ProtoDeterministicMarshal(&nodeDetail)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
@@ -575,7 +677,7 @@ func (app *ABCIApplication) disableServiceDestinationByNDID(param string, nodeID
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
 	// Check role is AS
-	if nodeDetail.Role != "AS" {
+	if !hasRole(&nodeDetail, "AS") {
 		return app.ReturnDeliverTxLog(code.RoleIsNotAS, "Role of node ID is not AS", "")
 	}
 	var service data.ServiceDetail
@@ -620,6 +722,7 @@ func (app *ABCIApplication) enableNode(param string, nodeID string) types.Respon
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
 	nodeDetail.Active = true
+	app.setNodeDetailLastUpdate(&nodeDetail)
 	nodeDetailValue, err = utils.ProtoDeterministicMarshal(&nodeDetail)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
@@ -653,7 +756,7 @@ func (app *ABCIApplication) enableServiceDestinationByNDID(param string, nodeID
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
 	// Check role is AS
-	if nodeDetail.Role != "AS" {
+	if !hasRole(&nodeDetail, "AS") {
 		return app.ReturnDeliverTxLog(code.RoleIsNotAS, "Role of node ID is not AS", "")
 	}
 	var service data.ServiceDetail
@@ -723,7 +826,7 @@ func (app *ABCIApplication) enableService(param string, nodeID string) types.Res
 		return app.ReturnDeliverTxLog(code.ServiceIDNotFound, "Service ID not found", "")
 	}
 	// Delete detail in service directory
-	allServiceKey := "AllService"
+	allServiceKey := keys.AllServiceKey
 	allServiceValue, _ := app.state.Get([]byte(allServiceKey), false)
 	var services data.ServiceDetailList
 	err = proto.Unmarshal([]byte(allServiceValue), &services)
@@ -762,7 +865,7 @@ func (app *ABCIApplication) setTimeOutBlockRegisterIdentity(param string, nodeID
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
-	key := "TimeOutBlockRegisterIdentity"
+	key := keys.TimeOutBlockRegisterIdentityKey
 	var timeOut data.TimeOutBlockRegisterIdentity
 	timeOut.TimeOutBlock = funcParam.TimeOutBlock
 	// Check time out block > 0
@@ -777,6 +880,49 @@ func (app *ABCIApplication) setTimeOutBlockRegisterIdentity(param string, nodeID
 	return app.ReturnDeliverTxLog(code.OK, "success", "")
 }
 
+func (app *ABCIApplication) setMaxRequestTimeoutExtension(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetMaxRequestTimeoutExtension, Parameter: %s", param)
+	var funcParam MaxRequestTimeoutExtensionParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.MaxExtension < 0 {
+		return app.ReturnDeliverTxLog(code.MaxTimeoutExtensionMustBeGreaterOrEqualToZero, "Max request timeout extension must be greater or equal to 0", "")
+	}
+	var maxExtension data.MaxRequestTimeoutExtension
+	maxExtension.MaxExtension = funcParam.MaxExtension
+	value, err := utils.ProtoDeterministicMarshal(&maxExtension)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(maxRequestTimeoutExtensionKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+// setRequestListSizeLimit sets max number of services in data request list and max number of IdPs
+// in IdP ID list of request created by CreateRequest. 0 is no limit.
+func (app *ABCIApplication) setRequestListSizeLimit(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetRequestListSizeLimit, Parameter: %s", param)
+	var funcParam RequestListSizeLimitParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.MaxDataRequestCount < 0 || funcParam.MaxIdPCount < 0 {
+		return app.ReturnDeliverTxLog(code.InvalidRequestListSizeLimit, "Max data request count and max IdP count must be greater or equal to 0", "")
+	}
+	var limit data.RequestListSizeLimit
+	limit.MaxDataRequestCount = funcParam.MaxDataRequestCount
+	limit.MaxIdpCount = funcParam.MaxIdPCount
+	value, err := utils.ProtoDeterministicMarshal(&limit)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(requestListSizeLimitKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
 func (app *ABCIApplication) addNodeToProxyNode(param string, nodeID string) types.ResponseDeliverTx {
 	app.logger.Infof("AddNodeToProxyNode, Parameter: %s", param)
 	var funcParam AddNodeToProxyNodeParam
@@ -832,6 +978,7 @@ func (app *ABCIApplication) addNodeToProxyNode(param string, nodeID string) type
 	// Delete msq address
 	msqAddres := make([]*data.MQ, 0)
 	nodeDetail.Mq = msqAddres
+	app.setNodeDetailLastUpdate(&nodeDetail)
 	nodeDetailByte, err := utils.ProtoDeterministicMarshal(&nodeDetail)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
@@ -917,6 +1064,7 @@ func (app *ABCIApplication) updateNodeProxyNode(param string, nodeID string) typ
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
+	app.setNodeDetailLastUpdate(&nodeDetail)
 	nodeDetailByte, err := utils.ProtoDeterministicMarshal(&nodeDetail)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
@@ -980,6 +1128,7 @@ func (app *ABCIApplication) removeNodeFromProxyNode(param string, nodeID string)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
+	app.setNodeDetailLastUpdate(&nodeDetail)
 	nodeDetailByte, err := utils.ProtoDeterministicMarshal(&nodeDetail)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
@@ -1023,6 +1172,45 @@ func (app *ABCIApplication) SetInitData(param string, nodeID string) types.Respo
 	return app.ReturnDeliverTxLog(code.OK, "success", "")
 }
 
+// setChainHistoryInfo records previous chain which state is migrated from (in init state)
+// after chains which are already recorded in migrated state
+func (app *ABCIApplication) setChainHistoryInfo(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetChainHistoryInfo, Parameter: %s", param)
+	var funcParam SetChainHistoryInfoParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.ChainID == "" || funcParam.FinalBlockHeight <= 0 {
+		return app.ReturnDeliverTxLog(code.InvalidChainHistoryInfo, "Chain ID and final block height of previous chain are required", "")
+	}
+	finalAppHash, err := hex.DecodeString(funcParam.FinalAppHash)
+	if err != nil || len(finalAppHash) == 0 {
+		return app.ReturnDeliverTxLog(code.InvalidChainHistoryInfo, "Final app hash must be hex encoded", "")
+	}
+	var previousChainList data.PreviousChainList
+	value, _ := app.state.Get(previousChainListKeyBytes, false)
+	if value != nil {
+		err = proto.Unmarshal(value, &previousChainList)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+		}
+	}
+	var previousChain data.PreviousChain
+	previousChain.ChainId = funcParam.ChainID
+	previousChain.FinalBlockHeight = funcParam.FinalBlockHeight
+	previousChain.FinalAppHash = strings.ToUpper(funcParam.FinalAppHash)
+	previousChain.RecordedChainId = app.CurrentChain
+	previousChain.RecordedBlockHeight = app.state.CurrentBlockHeight
+	previousChainList.ChainList = append(previousChainList.ChainList, &previousChain)
+	value, err = utils.ProtoDeterministicMarshal(&previousChainList)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(previousChainListKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
 func (app *ABCIApplication) EndInit(param string, nodeID string) types.ResponseDeliverTx {
 	app.logger.Infof("EndInit, Parameter: %s", param)
 	var funcParam EndInitParam
@@ -1059,7 +1247,7 @@ func (app *ABCIApplication) SetAllowedMinIalForRegisterIdentityAtFirstIdp(param
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
-	allowedMinIalKey := "AllowedMinIalForRegisterIdentityAtFirstIdp"
+	allowedMinIalKey := keys.AllowedMinIalForRegisterIdentityAtFirstIdpKey
 	var allowedMinIal data.AllowedMinIalForRegisterIdentityAtFirstIdp
 	allowedMinIal.MinIal = funcParam.MinIal
 	allowedMinIalByte, err := utils.ProtoDeterministicMarshal(&allowedMinIal)
@@ -1097,6 +1285,10 @@ func (app *ABCIApplication) updateNamespace(param string, nodeID string) types.R
 			if funcParam.AllowedActiveIdentifierCountInReferenceGroup != 0 {
 				namespaces.Namespaces[index].AllowedActiveIdentifierCountInReferenceGroup = funcParam.AllowedActiveIdentifierCountInReferenceGroup
 			}
+			// Empty list clears restriction, omitted list keeps current one
+			if funcParam.AllowedModeList != nil {
+				namespaces.Namespaces[index].AllowedModeList = funcParam.AllowedModeList
+			}
 			break
 		}
 	}
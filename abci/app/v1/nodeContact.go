@@ -0,0 +1,141 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+const (
+	maxNodeContactEmailLength = 254
+	// webhookURLHashLength is length of hex encoded SHA-256 hash of webhook URL
+	webhookURLHashLength = 64
+)
+
+func getNodeContactKey(nodeID string) []byte {
+	return []byte(nodeContactKeyPrefix + keySeparator + nodeID)
+}
+
+func isValidContactEmail(email string) bool {
+	if len(email) > maxNodeContactEmailLength {
+		return false
+	}
+	at := strings.LastIndex(email, "@")
+	return at > 0 && at < len(email)-1
+}
+
+// setNodeContact sets operational contact of node (signed with master key) for incident tooling.
+// Only hash of webhook URL is kept on chain. Contact is removed when both fields are empty.
+func (app *ABCIApplication) setNodeContact(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetNodeContact, Parameter: %s", param)
+	var funcParam SetNodeContactParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	key := getNodeContactKey(nodeID)
+	if funcParam.Email == "" && funcParam.WebhookURLHash == "" {
+		app.state.Delete(key)
+		return app.ReturnDeliverTxLog(code.OK, "success", "")
+	}
+	if funcParam.Email != "" && !isValidContactEmail(funcParam.Email) {
+		return app.ReturnDeliverTxLog(code.InvalidNodeContact, "Invalid email", "")
+	}
+	if funcParam.WebhookURLHash != "" {
+		_, err := hex.DecodeString(funcParam.WebhookURLHash)
+		if err != nil || len(funcParam.WebhookURLHash) != webhookURLHashLength {
+			return app.ReturnDeliverTxLog(code.InvalidNodeContact, "Webhook URL hash must be hex encoded SHA-256 hash", "")
+		}
+	}
+	var contact data.NodeContact
+	contact.Email = funcParam.Email
+	contact.WebhookUrlHash = strings.ToLower(funcParam.WebhookURLHash)
+	contact.UpdatedBlockHeight = app.state.CurrentBlockHeight
+	value, err := utils.ProtoDeterministicMarshal(&contact)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(key, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+// getNodeContactList returns contact of nodes in node_id_list or of every node when list is empty
+func (app *ABCIApplication) getNodeContactList(param string) types.ResponseQuery {
+	app.logger.Infof("GetNodeContactList, Parameter: %s", param)
+	var funcParam GetNodeContactListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	var result GetNodeContactListResult
+	result.ContactList = make([]NodeContact, 0)
+	appendContact := func(nodeID string, value []byte) error {
+		var contact data.NodeContact
+		err := proto.Unmarshal(value, &contact)
+		if err != nil {
+			return err
+		}
+		result.ContactList = append(result.ContactList, NodeContact{
+			NodeID:             nodeID,
+			Email:              contact.Email,
+			WebhookURLHash:     contact.WebhookUrlHash,
+			UpdatedBlockHeight: contact.UpdatedBlockHeight,
+		})
+		return nil
+	}
+	if len(funcParam.NodeIDList) > 0 {
+		for _, nodeID := range funcParam.NodeIDList {
+			value, _ := app.state.Get(getNodeContactKey(nodeID), true)
+			if value == nil {
+				continue
+			}
+			err = appendContact(nodeID, value)
+			if err != nil {
+				return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+			}
+		}
+	} else {
+		prefix := nodeContactKeyPrefix + keySeparator
+		app.state.IterateCommitted([]byte(prefix), func(key, value []byte) bool {
+			err = appendContact(strings.TrimPrefix(string(key), prefix), value)
+			return err == nil
+		})
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,160 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+func getNodeIDAliasKey(alias string) []byte {
+	return []byte(nodeIDAliasKeyPrefix + keySeparator + alias)
+}
+
+// getNodeIDOfAlias returns node ID which alias is attached to, empty if alias does not exist
+func (app *ABCIApplication) getNodeIDOfAlias(alias string, committedState bool) string {
+	value, _ := app.state.Get(getNodeIDAliasKey(alias), committedState)
+	if value == nil {
+		return ""
+	}
+	var nodeIDAlias data.NodeIDAlias
+	err := proto.Unmarshal(value, &nodeIDAlias)
+	if err != nil {
+		return ""
+	}
+	return nodeIDAlias.NodeId
+}
+
+// setNodeIDAlias attaches alias (e.g. new name after organizational rename) to node.
+// Node keeps its node ID so keys and historical records referring to it are unchanged.
+// Node has at most one alias, setting another alias replaces it and empty alias removes it.
+func (app *ABCIApplication) setNodeIDAlias(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetNodeIDAlias, Parameter: %s", param)
+	var funcParam NodeIDAliasParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
+	nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), false)
+	if nodeDetailValue == nil {
+		return app.ReturnDeliverTxLog(code.NodeIDNotFound, "Node ID not found", "")
+	}
+	var nodeDetail data.NodeDetail
+	err = proto.Unmarshal(nodeDetailValue, &nodeDetail)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.Alias != "" {
+		if app.state.Has([]byte(nodeIDKeyPrefix+keySeparator+funcParam.Alias), false) {
+			return app.ReturnDeliverTxLog(code.DuplicateNodeID, "Alias is already used as node ID", "")
+		}
+		aliasNodeID := app.getNodeIDOfAlias(funcParam.Alias, false)
+		if aliasNodeID != "" && aliasNodeID != funcParam.NodeID {
+			return app.ReturnDeliverTxLog(code.NodeIDAliasIsAlreadyUsed, "Alias is already used by another node", "")
+		}
+	}
+	if nodeDetail.Alias != "" && nodeDetail.Alias != funcParam.Alias {
+		app.state.Delete(getNodeIDAliasKey(nodeDetail.Alias))
+	}
+	if funcParam.Alias != "" {
+		var nodeIDAlias data.NodeIDAlias
+		nodeIDAlias.NodeId = funcParam.NodeID
+		nodeIDAlias.BlockHeight = app.state.CurrentBlockHeight
+		nodeIDAliasValue, err := utils.ProtoDeterministicMarshal(&nodeIDAlias)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+		}
+		app.state.Set(getNodeIDAliasKey(funcParam.Alias), nodeIDAliasValue)
+	}
+	nodeDetail.Alias = funcParam.Alias
+	app.setNodeDetailLastUpdate(&nodeDetail)
+	nodeDetailValue, err = utils.ProtoDeterministicMarshal(&nodeDetail)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set([]byte(nodeDetailKey), nodeDetailValue)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+// resolveNodeIDAliasParam replaces alias in "node_id" of query parameter with node ID
+// so that query methods accept either form
+func (app *ABCIApplication) resolveNodeIDAliasParam(param string) string {
+	if !strings.Contains(param, `"node_id"`) {
+		return param
+	}
+	var funcParam map[string]json.RawMessage
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return param
+	}
+	var alias string
+	err = json.Unmarshal(funcParam["node_id"], &alias)
+	if err != nil || alias == "" {
+		return param
+	}
+	nodeID := app.getNodeIDOfAlias(alias, true)
+	if nodeID == "" {
+		return param
+	}
+	funcParam["node_id"], _ = json.Marshal(nodeID)
+	resolvedParam, err := json.Marshal(funcParam)
+	if err != nil {
+		return param
+	}
+	return string(resolvedParam)
+}
+
+// getNodeIDAlias returns node ID and alias of node given by either of them
+func (app *ABCIApplication) getNodeIDAlias(param string) types.ResponseQuery {
+	app.logger.Infof("GetNodeIDAlias, Parameter: %s", param)
+	var funcParam GetNodeIDAliasParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	nodeDetailValue, _ := app.state.Get([]byte(nodeIDKeyPrefix+keySeparator+funcParam.NodeID), true)
+	if nodeDetailValue == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var nodeDetail data.NodeDetail
+	err = proto.Unmarshal(nodeDetailValue, &nodeDetail)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result NodeIDAliasParam
+	result.NodeID = funcParam.NodeID
+	result.Alias = nodeDetail.Alias
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,205 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// additionalRoles are roles which node registered as one of them can hold in addition,
+// e.g. organization operating both IdP and AS functions under one node
+var additionalRoles = map[string]bool{
+	"RP":  true,
+	"IdP": true,
+	"AS":  true,
+}
+
+// hasRole returns true when node is registered with role or holds it as additional role
+func hasRole(nodeDetail *data.NodeDetail, role string) bool {
+	if nodeDetail.Role == role {
+		return true
+	}
+	for _, additionalRole := range nodeDetail.AdditionalRoleList {
+		if additionalRole == role {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeHasRole returns true when node in uncommitted state has role
+func (app *ABCIApplication) nodeHasRole(nodeID string, role string) bool {
+	key := nodeIDKeyPrefix + keySeparator + nodeID
+	value, _ := app.state.Get([]byte(key), false)
+	if value == nil {
+		return false
+	}
+	var nodeDetail data.NodeDetail
+	err := proto.Unmarshal(value, &nodeDetail)
+	if err != nil {
+		return false
+	}
+	return hasRole(&nodeDetail, role)
+}
+
+// updateRoleNodeIDList adds node ID to or removes it from node ID list of role
+func (app *ABCIApplication) updateRoleNodeIDList(role string, nodeID string, add bool) error {
+	listKey := []byte(nodeIDListKeyByRole[strings.ToLower(role)])
+	var nodeIDList data.AllList
+	value, _ := app.state.Get(listKey, false)
+	if value != nil {
+		err := proto.Unmarshal(value, &nodeIDList)
+		if err != nil {
+			return err
+		}
+	}
+	newNodeIDList := make([]string, 0, len(nodeIDList.NodeId)+1)
+	for _, listNodeID := range nodeIDList.NodeId {
+		if listNodeID != nodeID {
+			newNodeIDList = append(newNodeIDList, listNodeID)
+		}
+	}
+	if add {
+		newNodeIDList = append(newNodeIDList, nodeID)
+	}
+	nodeIDList.NodeId = newNodeIDList
+	value, err := utils.ProtoDeterministicMarshal(&nodeIDList)
+	if err != nil {
+		return err
+	}
+	app.state.Set(listKey, value)
+	return nil
+}
+
+// getNodeDetailForRoleChange returns node detail of node which role list can be changed
+func (app *ABCIApplication) getNodeDetailForRoleChange(nodeID string, role string) (nodeDetail data.NodeDetail, errCode uint32, errLog string) {
+	if !additionalRoles[role] {
+		return nodeDetail, code.WrongRole, "Wrong Role"
+	}
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
+	nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), false)
+	if nodeDetailValue == nil {
+		return nodeDetail, code.NodeIDNotFound, "Node ID not found"
+	}
+	err := proto.Unmarshal(nodeDetailValue, &nodeDetail)
+	if err != nil {
+		return nodeDetail, code.UnmarshalError, err.Error()
+	}
+	if !additionalRoles[nodeDetail.Role] {
+		return nodeDetail, code.WrongRole, "Only RP, IdP or AS node can hold additional role"
+	}
+	return nodeDetail, code.OK, ""
+}
+
+func (app *ABCIApplication) addNodeRole(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("AddNodeRole, Parameter: %s", param)
+	var funcParam AddNodeRoleParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	nodeDetail, errCode, errLog := app.getNodeDetailForRoleChange(funcParam.NodeID, funcParam.Role)
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
+	}
+	if hasRole(&nodeDetail, funcParam.Role) {
+		return app.ReturnDeliverTxLog(code.NodeAlreadyHasRole, "Node already has this role", "")
+	}
+	// IdP capability (max IAL and max AAL) is required when node becomes IdP
+	if funcParam.Role == "IdP" {
+		if funcParam.MaxIal <= 0 {
+			return app.ReturnDeliverTxLog(code.IALError, "Max IAL must be greater than 0", "")
+		}
+		if funcParam.MaxAal <= 0 {
+			return app.ReturnDeliverTxLog(code.AALError, "Max AAL must be greater than 0", "")
+		}
+		nodeDetail.MaxIal = funcParam.MaxIal
+		nodeDetail.MaxAal = funcParam.MaxAal
+		nodeDetail.SupportedRequestMessageDataUrlTypeList = make([]string, 0)
+	}
+	nodeDetail.AdditionalRoleList = append(nodeDetail.AdditionalRoleList, funcParam.Role)
+	err = app.updateRoleNodeIDList(funcParam.Role, funcParam.NodeID, true)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	app.setNodeDetailLastUpdate(&nodeDetail)
+	nodeDetailValue, err := utils.ProtoDeterministicMarshal(&nodeDetail)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
+	app.state.Set([]byte(nodeDetailKey), nodeDetailValue)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) removeNodeRole(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("RemoveNodeRole, Parameter: %s", param)
+	var funcParam RemoveNodeRoleParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	nodeDetail, errCode, errLog := app.getNodeDetailForRoleChange(funcParam.NodeID, funcParam.Role)
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
+	}
+	if nodeDetail.Role == funcParam.Role {
+		return app.ReturnDeliverTxLog(code.WrongRole, "Role which node is registered with can not be removed", "")
+	}
+	if !hasRole(&nodeDetail, funcParam.Role) {
+		return app.ReturnDeliverTxLog(code.NodeDoesNotHaveRole, "Node does not have this role", "")
+	}
+	additionalRoleList := make([]string, 0, len(nodeDetail.AdditionalRoleList))
+	for _, role := range nodeDetail.AdditionalRoleList {
+		if role != funcParam.Role {
+			additionalRoleList = append(additionalRoleList, role)
+		}
+	}
+	nodeDetail.AdditionalRoleList = additionalRoleList
+	// Remove IdP capability so that it is not used when node is no longer IdP
+	if funcParam.Role == "IdP" {
+		nodeDetail.MaxIal = 0
+		nodeDetail.MaxAal = 0
+		nodeDetail.SupportedRequestMessageDataUrlTypeList = nil
+	}
+	err = app.updateRoleNodeIDList(funcParam.Role, funcParam.NodeID, false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	app.setNodeDetailLastUpdate(&nodeDetail)
+	nodeDetailValue, err := utils.ProtoDeterministicMarshal(&nodeDetail)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
+	app.state.Set([]byte(nodeDetailKey), nodeDetailValue)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
@@ -0,0 +1,77 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+func (app *ABCIApplication) isMethodPaused(method string, committedState bool) bool {
+	key := pausedMethodKeyPrefix + keySeparator + method
+	return app.state.Has([]byte(key), committedState)
+}
+
+// setMethodPaused pauses or unpauses single method network-wide, e.g. during incident,
+// without disabling whole chain with SetLastBlock
+func (app *ABCIApplication) setMethodPaused(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetMethodPaused, Parameter: %s", param)
+	var funcParam SetMethodPausedParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if !IsMethod[funcParam.Method] {
+		return app.ReturnDeliverTxLog(code.UnknownMethod, unknownMethodLog(suggestMethod(funcParam.Method, IsMethod)), "")
+	}
+	if funcParam.Method == "SetMethodPaused" {
+		return app.ReturnDeliverTxLog(code.MethodCannotBePaused, "This method cannot be paused", "")
+	}
+	key := pausedMethodKeyPrefix + keySeparator + funcParam.Method
+	if funcParam.Paused {
+		app.state.Set([]byte(key), []byte{})
+	} else {
+		app.state.Delete([]byte(key))
+	}
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getPausedMethodList(param string) types.ResponseQuery {
+	app.logger.Infof("GetPausedMethodList, Parameter: %s", param)
+	var result GetPausedMethodListResult
+	result.MethodList = make([]string, 0)
+	prefix := []byte(pausedMethodKeyPrefix + keySeparator)
+	app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		result.MethodList = append(result.MethodList, strings.TrimPrefix(string(key), string(prefix)))
+		return true
+	})
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,160 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+func (app *ABCIApplication) getRequestPriorityClassList(committedState bool) (data.RequestPriorityClassList, error) {
+	var priorityClassList data.RequestPriorityClassList
+	value, _ := app.state.Get(requestPriorityClassListKeyBytes, committedState)
+	if value == nil {
+		return priorityClassList, nil
+	}
+	err := proto.Unmarshal(value, &priorityClassList)
+	return priorityClassList, err
+}
+
+// setRequestPriorityClassList replaces request priority classes. Each class limits number of
+// open (not closed and not timed out) requests of each RP in the class, 0 means no limit.
+func (app *ABCIApplication) setRequestPriorityClassList(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetRequestPriorityClassList, Parameter: %s", param)
+	var funcParam SetRequestPriorityClassListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	var priorityClassList data.RequestPriorityClassList
+	priorityClassNames := make(map[string]bool)
+	for _, priorityClass := range funcParam.PriorityClassList {
+		if priorityClass.Name == "" || priorityClassNames[priorityClass.Name] {
+			return app.ReturnDeliverTxLog(code.InvalidRequestPriorityClass, "Priority class name must be unique and not empty", "")
+		}
+		if priorityClass.MaxOpenRequestCount < 0 {
+			return app.ReturnDeliverTxLog(code.InvalidRequestPriorityClass, "Max open request count must not be negative", "")
+		}
+		priorityClassNames[priorityClass.Name] = true
+		priorityClassList.PriorityClasses = append(priorityClassList.PriorityClasses, &data.RequestPriorityClass{
+			Name:                priorityClass.Name,
+			MaxOpenRequestCount: priorityClass.MaxOpenRequestCount,
+		})
+	}
+	value, err := utils.ProtoDeterministicMarshal(&priorityClassList)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(requestPriorityClassListKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func getOpenRequestCountKey(nodeID string, priorityClass string) string {
+	return openRequestCountKeyPrefix + keySeparator + nodeID + keySeparator + priorityClass
+}
+
+func (app *ABCIApplication) getOpenRequestCount(nodeID string, priorityClass string, committedState bool) int64 {
+	countValue, _ := app.state.Get([]byte(getOpenRequestCountKey(nodeID, priorityClass)), committedState)
+	if countValue == nil {
+		return 0
+	}
+	count, err := strconv.ParseInt(string(countValue), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// checkRequestPriorityClass checks that priority class exists and RP has not reached
+// max open request count of the class. Request without priority class is not limited.
+func (app *ABCIApplication) checkRequestPriorityClass(nodeID string, priorityClass string) (errorCode uint32, errorLog string) {
+	if priorityClass == "" {
+		return code.OK, ""
+	}
+	priorityClassList, err := app.getRequestPriorityClassList(false)
+	if err != nil {
+		return code.UnmarshalError, err.Error()
+	}
+	for _, class := range priorityClassList.PriorityClasses {
+		if class.Name != priorityClass {
+			continue
+		}
+		if class.MaxOpenRequestCount > 0 && app.getOpenRequestCount(nodeID, priorityClass, false) >= class.MaxOpenRequestCount {
+			return code.OpenRequestLimitOfPriorityClassExceeded, "Max open request count of priority class is exceeded"
+		}
+		return code.OK, ""
+	}
+	return code.RequestPriorityClassNotFound, "Request priority class not found"
+}
+
+// changeOpenRequestCount adds delta to open request count of request owner in priority class of request
+func (app *ABCIApplication) changeOpenRequestCount(request *data.Request, delta int64) {
+	if request.PriorityClass == "" {
+		return
+	}
+	count := app.getOpenRequestCount(request.Owner, request.PriorityClass, false) + delta
+	key := getOpenRequestCountKey(request.Owner, request.PriorityClass)
+	if count <= 0 {
+		app.state.Delete([]byte(key))
+		return
+	}
+	app.state.Set([]byte(key), []byte(strconv.FormatInt(count, 10)))
+}
+
+func (app *ABCIApplication) getRequestPriorityClassListQuery(param string) types.ResponseQuery {
+	app.logger.Infof("GetRequestPriorityClassList, Parameter: %s", param)
+	var funcParam GetRequestPriorityClassListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	priorityClassList, err := app.getRequestPriorityClassList(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetRequestPriorityClassListResult
+	result.PriorityClassList = make([]RequestPriorityClass, 0, len(priorityClassList.PriorityClasses))
+	for _, priorityClass := range priorityClassList.PriorityClasses {
+		row := RequestPriorityClass{
+			Name:                priorityClass.Name,
+			MaxOpenRequestCount: priorityClass.MaxOpenRequestCount,
+		}
+		if funcParam.NodeID != "" {
+			openRequestCount := app.getOpenRequestCount(funcParam.NodeID, priorityClass.Name, true)
+			row.OpenRequestCount = &openRequestCount
+		}
+		result.PriorityClassList = append(result.PriorityClassList, row)
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -23,6 +23,8 @@
 package app
 
 import (
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -40,9 +42,41 @@ func init() {
 	prometheus.MustRegister(commitDurationHistogram)
 	prometheus.MustRegister(dbSaveDurationHistogram)
 	prometheus.MustRegister(appHashDurationHistogram)
+	prometheus.MustRegister(pruneBacklogGauge)
+	prometheus.MustRegister(prunedKeyCounter)
+	prometheus.MustRegister(panicCounter)
+	prometheus.MustRegister(deprecatedMethodCounter)
+	prometheus.MustRegister(lastBackupHeightGauge)
+	prometheus.MustRegister(backupDurationHistogram)
+	prometheus.MustRegister(handlerBudgetExceededCounter)
+	prometheus.MustRegister(handlerBreakerOpenGauge)
+	prometheus.MustRegister(queryInconsistencyCounter)
+	prometheus.MustRegister(queryLatencySummary)
+	prometheus.MustRegister(queryResultSizeSummary)
+	prometheus.MustRegister(blockStateWriteGauge)
+	prometheus.MustRegister(blockWriteLimitExceededCounter)
+}
+
+// metricsDisabled is set to 1 to stop recording metrics. It is set by config reload
+// while metrics are recorded in other goroutines, so it is accessed atomically.
+var metricsDisabled int32
+
+func setMetricsEnabled(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&metricsDisabled, 0)
+	} else {
+		atomic.StoreInt32(&metricsDisabled, 1)
+	}
+}
+
+func isMetricsEnabled() bool {
+	return atomic.LoadInt32(&metricsDisabled) == 0
 }
 
 func recordCheckTxMetrics(fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
 	checkTxCounter.With(prometheus.Labels{"function": fName}).Inc()
 }
 
@@ -56,6 +90,9 @@ var (
 )
 
 func recordCheckTxFailMetrics(fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
 	checkTxFailCounter.With(prometheus.Labels{"function": fName}).Inc()
 }
 
@@ -69,6 +106,9 @@ var (
 )
 
 func recordCheckTxDurationMetrics(duration time.Duration, fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
 	checkTxDurationHistogram.WithLabelValues(fName).Observe(duration.Seconds())
 }
 
@@ -84,6 +124,9 @@ var (
 )
 
 func recordDeliverTxMetrics(fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
 	deliverTxCounter.With(prometheus.Labels{"function": fName}).Inc()
 }
 
@@ -98,6 +141,9 @@ var (
 )
 
 func recordDeliverTxFailMetrics(fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
 	deliverTxFailCounter.With(prometheus.Labels{"function": fName}).Inc()
 }
 
@@ -112,6 +158,9 @@ var (
 )
 
 func recordDeliverTxDurationMetrics(duration time.Duration, fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
 	deliverTxDurationHistogram.WithLabelValues(fName).Observe(duration.Seconds())
 }
 
@@ -127,6 +176,9 @@ var (
 )
 
 func recordQueryMetrics(fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
 	queryCounter.With(prometheus.Labels{"function": fName}).Inc()
 }
 
@@ -141,6 +193,9 @@ var (
 )
 
 func recordQueryDurationMetrics(duration time.Duration, fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
 	queryDurationHistogram.WithLabelValues(fName).Observe(duration.Seconds())
 }
 
@@ -156,6 +211,9 @@ var (
 )
 
 func recordCommitDurationMetrics(duration time.Duration) {
+	if !isMetricsEnabled() {
+		return
+	}
 	commitDurationHistogram.Observe(duration.Seconds())
 }
 
@@ -170,6 +228,9 @@ var (
 )
 
 func recordDBSaveDurationMetrics(duration time.Duration) {
+	if !isMetricsEnabled() {
+		return
+	}
 	dbSaveDurationHistogram.Observe(duration.Seconds())
 }
 
@@ -184,6 +245,9 @@ var (
 )
 
 func recordAppHashDurationMetrics(duration time.Duration) {
+	if !isMetricsEnabled() {
+		return
+	}
 	appHashDurationHistogram.Observe(duration.Seconds())
 }
 
@@ -196,3 +260,218 @@ var (
 	},
 	)
 )
+
+func recordPruneBacklogMetrics(backlogBlocks int64) {
+	if !isMetricsEnabled() {
+		return
+	}
+	pruneBacklogGauge.Set(float64(backlogBlocks))
+}
+
+var (
+	pruneBacklogGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: "abci",
+		Name:      "prune_backlog_blocks",
+		Help:      "Number of blocks which state versions are not pruned yet",
+	},
+	)
+)
+
+func recordPrunedKeyMetrics(count int64) {
+	if !isMetricsEnabled() {
+		return
+	}
+	prunedKeyCounter.Add(float64(count))
+}
+
+var (
+	prunedKeyCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: "abci",
+		Name:      "pruned_keys_total",
+		Help:      "Total number of pruned state version keys",
+	},
+	)
+)
+
+func recordPanicMetrics(call string, fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
+	panicCounter.With(prometheus.Labels{"call": call, "function": fName}).Inc()
+}
+
+var (
+	panicCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "abci",
+		Name:      "panics_total",
+		Help:      "Total number of recovered panics in DeliverTx, CheckTx and Query",
+	},
+		[]string{"call", "function"},
+	)
+)
+
+func recordDeprecatedMethodMetrics(call string, fName string, nodeID string) {
+	if !isMetricsEnabled() {
+		return
+	}
+	deprecatedMethodCounter.With(prometheus.Labels{"call": call, "function": fName, "node_id": nodeID}).Inc()
+}
+
+var (
+	deprecatedMethodCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "abci",
+		Name:      "deprecated_method_calls_total",
+		Help:      "Total number of calls of deprecated Tx and query methods",
+	},
+		[]string{"call", "function", "node_id"},
+	)
+)
+
+func recordBackupMetrics(height int64, duration time.Duration) {
+	if !isMetricsEnabled() {
+		return
+	}
+	lastBackupHeightGauge.Set(float64(height))
+	backupDurationHistogram.Observe(duration.Seconds())
+}
+
+var (
+	lastBackupHeightGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: "abci",
+		Name:      "last_backup_height",
+		Help:      "Height of latest state backup",
+	},
+	)
+	backupDurationHistogram = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Subsystem: "abci",
+		Name:      "backup_duration_seconds",
+		Help:      "Duration of writing state backup in seconds",
+	},
+	)
+)
+
+func recordHandlerBudgetExceededMetrics(call string, fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
+	handlerBudgetExceededCounter.With(prometheus.Labels{"call": call, "function": fName}).Inc()
+}
+
+var (
+	handlerBudgetExceededCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "abci",
+		Name:      "handler_budget_exceeded_total",
+		Help:      "Total number of DeliverTx and query handler executions exceeding time budget",
+	},
+		[]string{"call", "function"},
+	)
+)
+
+// recordHandlerBreakerMetrics sets circuit breaker state of handler key ("<call>|<function>")
+func recordHandlerBreakerMetrics(key string, open bool) {
+	if !isMetricsEnabled() {
+		return
+	}
+	call, fName := key, ""
+	if index := strings.Index(key, "|"); index >= 0 {
+		call, fName = key[:index], key[index+1:]
+	}
+	value := 0.0
+	if open {
+		value = 1
+	}
+	handlerBreakerOpenGauge.With(prometheus.Labels{"call": call, "function": fName}).Set(value)
+}
+
+var (
+	handlerBreakerOpenGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "abci",
+		Name:      "handler_circuit_breaker_open",
+		Help:      "Whether circuit breaker of DeliverTx or query handler is open (1) for exceeding time budget",
+	},
+		[]string{"call", "function"},
+	)
+)
+
+func recordQueryInconsistencyMetrics(fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
+	queryInconsistencyCounter.With(prometheus.Labels{"function": fName}).Inc()
+}
+
+var (
+	queryInconsistencyCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "abci",
+		Name:      "query_inconsistencies_total",
+		Help:      "Total number of missing or corrupt records referenced by query result items",
+	},
+		[]string{"function"},
+	)
+)
+
+func recordQueryAccessMetrics(fName string, cacheHit bool, duration time.Duration, resultSize int) {
+	if !isMetricsEnabled() {
+		return
+	}
+	cacheHitLabel := "false"
+	if cacheHit {
+		cacheHitLabel = "true"
+	}
+	queryLatencySummary.With(prometheus.Labels{"function": fName, "cache_hit": cacheHitLabel}).Observe(duration.Seconds())
+	queryResultSizeSummary.With(prometheus.Labels{"function": fName}).Observe(float64(resultSize))
+}
+
+var (
+	queryAccessObjectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+
+	queryLatencySummary = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Subsystem:  "abci",
+		Name:       "query_latency_seconds",
+		Help:       "Percentiles of Query latency in seconds over last 10 minutes (recorded when query access log is enabled)",
+		Objectives: queryAccessObjectives,
+	},
+		[]string{"function", "cache_hit"},
+	)
+	queryResultSizeSummary = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Subsystem:  "abci",
+		Name:       "query_result_size_bytes",
+		Help:       "Percentiles of Query result size in bytes over last 10 minutes (recorded when query access log is enabled)",
+		Objectives: queryAccessObjectives,
+	},
+		[]string{"function"},
+	)
+)
+
+func recordBlockStateWriteMetrics(count int64) {
+	if !isMetricsEnabled() {
+		return
+	}
+	blockStateWriteGauge.Set(float64(count))
+}
+
+var (
+	blockStateWriteGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: "abci",
+		Name:      "block_state_writes",
+		Help:      "Number of state keys written by last committed block",
+	},
+	)
+)
+
+func recordBlockWriteLimitExceededMetrics(fName string) {
+	if !isMetricsEnabled() {
+		return
+	}
+	blockWriteLimitExceededCounter.With(prometheus.Labels{"function": fName}).Inc()
+}
+
+var (
+	blockWriteLimitExceededCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "abci",
+		Name:      "block_write_limit_exceeded_total",
+		Help:      "Number of DeliverTx failed because it exceeded remaining state write budget of block",
+	},
+		[]string{"function"},
+	)
+)
@@ -0,0 +1,236 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+const (
+	// pruneInterval is min number of blocks between prune runs
+	pruneInterval = 100
+	// pruneBatchSize is max number of keys deleted in one DB batch
+	pruneBatchSize = 1000
+)
+
+// heightKeyPrefixes are prefixes of keys saved for each block height ("<prefix>|<height>")
+// which are deleted with old versions once their height is pruned
+var heightKeyPrefixes = []string{
+	changeJournalKeyPrefix,
+	blockActivityKeyPrefix,
+}
+
+// statePruner deletes values of old versions of versioned keys and per-height records
+// (change journal and block activity) in background so that Commit is not slowed down.
+// Pruning trails committed height by keep blocks, values needed for queries at height
+// within keep blocks are not deleted. Version list of key is kept since it is read by
+// DeliverTx. Pruning does not affect app hash.
+type statePruner struct {
+	db     dbm.DB
+	logger *logrus.Entry
+
+	// mutex is held while state is saved and while pruned keys are deleted
+	// so that key count and byte size of state are updated consistently
+	mutex          sync.Mutex
+	prunedKeyCount int64
+	prunedByteSize int64
+
+	keepBlocks      int64
+	paused          int32
+	committedHeight int64
+	prunedHeight    int64
+	trigger         chan struct{}
+
+	// pruningHeight is height of latest prune run which is started. Records of height
+	// at or before it may be deleted. It is saved in app state metadata.
+	pruningHeight int64
+}
+
+func newStatePruner(db dbm.DB, logger *logrus.Entry, keepBlocks int64, prunedHeight int64) *statePruner {
+	pruner := &statePruner{
+		db:            db,
+		logger:        logger,
+		keepBlocks:    keepBlocks,
+		prunedHeight:  prunedHeight,
+		pruningHeight: prunedHeight,
+		trigger:       make(chan struct{}, 1),
+	}
+	go pruner.run()
+	return pruner
+}
+
+func (pruner *statePruner) setKeepBlocks(keepBlocks int64) {
+	atomic.StoreInt64(&pruner.keepBlocks, keepBlocks)
+}
+
+func (pruner *statePruner) setPaused(paused bool) {
+	if paused {
+		atomic.StoreInt32(&pruner.paused, 1)
+	} else {
+		atomic.StoreInt32(&pruner.paused, 0)
+	}
+}
+
+func (pruner *statePruner) isPaused() bool {
+	return atomic.LoadInt32(&pruner.paused) == 1
+}
+
+// saveState saves app state and updates its key count and byte size with keys pruned since last save
+func (pruner *statePruner) saveState(appState *AppState) {
+	pruner.mutex.Lock()
+	defer pruner.mutex.Unlock()
+	appState.Save()
+	appState.PrunedHeight = atomic.LoadInt64(&pruner.pruningHeight)
+	appState.KeyCount -= pruner.prunedKeyCount
+	appState.ByteSize -= pruner.prunedByteSize
+	pruner.prunedKeyCount = 0
+	pruner.prunedByteSize = 0
+}
+
+// isHeightPruned returns whether values at height may have been deleted by pruning.
+// Value of versioned key at pruned height itself is kept but per-height records are not,
+// so height at or before prune height is pruned for every query.
+func (pruner *statePruner) isHeightPruned(height int64) bool {
+	return height <= atomic.LoadInt64(&pruner.pruningHeight)
+}
+
+// notifyCommit wakes up prune worker after block at height is committed
+func (pruner *statePruner) notifyCommit(height int64) {
+	atomic.StoreInt64(&pruner.committedHeight, height)
+	keepBlocks := atomic.LoadInt64(&pruner.keepBlocks)
+	if backlog := height - keepBlocks - atomic.LoadInt64(&pruner.prunedHeight); keepBlocks > 0 && backlog > 0 {
+		recordPruneBacklogMetrics(backlog)
+	}
+	select {
+	case pruner.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (pruner *statePruner) run() {
+	for range pruner.trigger {
+		keepBlocks := atomic.LoadInt64(&pruner.keepBlocks)
+		if keepBlocks <= 0 || pruner.isPaused() {
+			continue
+		}
+		pruneHeight := atomic.LoadInt64(&pruner.committedHeight) - keepBlocks
+		if pruneHeight-atomic.LoadInt64(&pruner.prunedHeight) < pruneInterval {
+			continue
+		}
+		// Set before any key is deleted so that queries never read partially pruned height
+		atomic.StoreInt64(&pruner.pruningHeight, pruneHeight)
+		if pruner.prune(pruneHeight) {
+			atomic.StoreInt64(&pruner.prunedHeight, pruneHeight)
+			pruner.logger.Infof("Pruned state versions up to height %d", pruneHeight)
+		}
+	}
+}
+
+// prune deletes values of versions which are replaced by newer version at or before pruneHeight
+// and per-height records of height at or before pruneHeight.
+// It returns false when pruning is paused before it is done.
+func (pruner *statePruner) prune(pruneHeight int64) bool {
+	keys := make([][]byte, 0, pruneBatchSize)
+	itr := pruner.db.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		key := string(itr.Key())
+		if height, ok := getHeightKeyHeight(key); ok {
+			if height <= pruneHeight {
+				keys = append(keys, []byte(key))
+			}
+		} else if strings.HasSuffix(key, "|versions") {
+			keys = pruner.appendReplacedVersionKeys(keys, key, itr.Value(), pruneHeight)
+		}
+		if len(keys) >= pruneBatchSize {
+			if pruner.isPaused() {
+				return false
+			}
+			pruner.deleteKeys(keys)
+			keys = keys[:0]
+		}
+	}
+	pruner.deleteKeys(keys)
+	return true
+}
+
+// getHeightKeyHeight returns height of per-height record key
+func getHeightKeyHeight(key string) (int64, bool) {
+	for _, prefix := range heightKeyPrefixes {
+		if !strings.HasPrefix(key, prefix+keySeparator) {
+			continue
+		}
+		height, err := strconv.ParseInt(strings.TrimPrefix(key, prefix+keySeparator), 10, 64)
+		return height, err == nil
+	}
+	return 0, false
+}
+
+// appendReplacedVersionKeys appends keys of values of versions in version list of key
+// which are replaced by newer version at or before pruneHeight
+func (pruner *statePruner) appendReplacedVersionKeys(keys [][]byte, versionsKey string, versionsValue []byte, pruneHeight int64) [][]byte {
+	var keyVersions data.KeyVersions
+	err := proto.Unmarshal(versionsValue, &keyVersions)
+	if err != nil {
+		pruner.logger.Errorf("Error unmarshaling versions of %s: %s", versionsKey, err.Error())
+		return keys
+	}
+	key := strings.TrimSuffix(versionsKey, "versions")
+	versions := keyVersions.Versions
+	for i := 0; i+1 < len(versions) && versions[i+1] <= pruneHeight; i++ {
+		keys = append(keys, []byte(key+strconv.FormatInt(versions[i], 10)))
+	}
+	return keys
+}
+
+func (pruner *statePruner) deleteKeys(keys [][]byte) {
+	if len(keys) == 0 {
+		return
+	}
+	pruner.mutex.Lock()
+	defer pruner.mutex.Unlock()
+	batch := pruner.db.NewBatch()
+	defer batch.Close()
+	var count int64
+	for _, key := range keys {
+		value := pruner.db.Get(key)
+		if value == nil {
+			continue
+		}
+		batch.Delete(key)
+		pruner.prunedKeyCount++
+		pruner.prunedByteSize += int64(len(key) + len(value))
+		count++
+	}
+	batch.WriteSync()
+	recordPrunedKeyMetrics(count)
+}
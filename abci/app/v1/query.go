@@ -23,28 +23,192 @@
 package app
 
 import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+
 	"github.com/tendermint/tendermint/abci/types"
 
 	"github.com/ndidplatform/smart-contract/v4/abci/code"
 )
 
-// ReturnQuery return types.ResponseQuery
+// ReturnQuery return successful types.ResponseQuery
 func (app *ABCIApplication) ReturnQuery(value []byte, log string, height int64) types.ResponseQuery {
+	return app.ReturnQueryWithCode(code.OK, value, log, height)
+}
+
+// ReturnQueryWithCode return types.ResponseQuery with result code so client can tell
+// not found (code.ResultNotFound) from invalid parameter (code.InvalidQueryParameter)
+// and internal error (e.g. code.UnmarshalError) without parsing log message
+func (app *ABCIApplication) ReturnQueryWithCode(code uint32, value []byte, log string, height int64) types.ResponseQuery {
 	app.logger.Infof("Query result: %s", string(value))
 	var res types.ResponseQuery
+	res.Code = code
 	res.Value = value
 	res.Log = log
+	res.Info = app.getQueryInfo()
 	res.Height = height
 	return res
 }
 
-// QueryRouter is Pointer to function
+// getQueryInfo returns app hash (upper-case hex) of committed state which query results are served from.
+// It is set as Info of every query result so client can cross-check results from different nodes.
+func (app *ABCIApplication) getQueryInfo() string {
+	return fmt.Sprintf("%X", app.state.AppHash)
+}
+
+// storeQueryPath is query path for getting raw value of a key in committed state.
+// It is for debugging by node operator and must be enabled with ABCI_STORE_QUERY_ENABLED env.
+const storeQueryPath = "/store"
+
+// gzipQueryPath is query path for getting query result value compressed with gzip
+// when it is not smaller than query_compression_min_size in config.
+// Client can tell compressed value by gzip header (0x1f 0x8b) since uncompressed value is JSON.
+const gzipQueryPath = "/gzip"
+
+// defaultQueryCompressMinSize is default min size in bytes of query result value to be compressed
+const defaultQueryCompressMinSize = 1024
+
+func (app *ABCIApplication) compressQueryResult(res types.ResponseQuery) types.ResponseQuery {
+	if len(res.Value) == 0 || len(res.Value) < app.compressionMinSize {
+		return res
+	}
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write(res.Value)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		app.logger.Errorf("Error compressing query result: %s", err.Error())
+		return res
+	}
+	if buf.Len() >= len(res.Value) {
+		return res
+	}
+	res.Value = buf.Bytes()
+	return res
+}
+
+// queryStore returns raw value of exact key (including prefix) given as query data
+func (app *ABCIApplication) queryStore(reqQuery types.RequestQuery) types.ResponseQuery {
+	app.logger.Infof("Query store, Key: %s", string(reqQuery.Data))
+	if !app.storeQueryEnabled {
+		return types.ResponseQuery{Code: code.StoreQueryIsDisabled, Log: "Store query is disabled", Info: app.getQueryInfo(), Height: app.state.Height}
+	}
+	value, _ := app.state.Get(reqQuery.Data, true)
+	var res types.ResponseQuery
+	res.Key = reqQuery.Data
+	res.Value = value
+	res.Info = app.getQueryInfo()
+	res.Height = app.state.Height
+	if value == nil {
+		res.Code = code.ResultNotFound
+		res.Log = "not found"
+	} else {
+		res.Log = "success"
+	}
+	return res
+}
+
+// IsQueryMethod is list of query methods
+var IsQueryMethod = map[string]bool{
+	"GetNodePublicKey":                              true,
+	"GetIdpNodes":                                   true,
+	"GetRequest":                                    true,
+	"GetRequestDetail":                              true,
+	"GetRequestMessageProof":                        true,
+	"GetRequestStatus":                              true,
+	"GetDataRequestProgress":                        true,
+	"GetAsNodesByServiceId":                         true,
+	"GetMqAddresses":                                true,
+	"GetNodeToken":                                  true,
+	"GetPriceFunc":                                  true,
+	"GetServiceDetail":                              true,
+	"GetNamespaceList":                              true,
+	"CheckExistingIdentity":                         true,
+	"GetAccessorKey":                                true,
+	"GetServiceList":                                true,
+	"GetNodeMasterPublicKey":                        true,
+	"GetNodeInfo":                                   true,
+	"CheckExistingAccessorID":                       true,
+	"GetIdentityInfo":                               true,
+	"GetIdentityModeList":                           true,
+	"GetDataSignature":                              true,
+	"GetServicesByAsID":                             true,
+	"GetIdpNodesInfo":                               true,
+	"GetAsNodesInfoByServiceId":                     true,
+	"GetNodesBehindProxyNode":                       true,
+	"GetNodeIDList":                                 true,
+	"GetAccessorOwner":                              true,
+	"IsInitEnded":                                   true,
+	"GetChainHistory":                               true,
+	"GetChainHistoryInfo":                           true,
+	"GetReferenceGroupCode":                         true,
+	"GetReferenceGroupCodeByAccessorID":             true,
+	"GetReferenceGroupIdPList":                      true,
+	"GetAccessorsInAccessorGroup":                   true,
+	"GetAccessorResponseList":                       true,
+	"GetAllowedModeList":                            true,
+	"GetAllowedMinIalForRegisterIdentityAtFirstIdp": true,
+	"GetIdPAgentList":                               true,
+	"CheckRevokedPublicKey":                         true,
+	"GetDataSchema":                                 true,
+	"GetConsentReceiptList":                         true,
+	"GetStatistics":                                 true,
+	"GetServiceStatistics":                          true,
+	"GetNodeQuota":                                  true,
+	"SimulateTx":                                    true,
+	"CheckInvariants":                               true,
+	"GetMaxRequestTimeoutExtension":                 true,
+	"GetRequestListSizeLimit":                       true,
+	"GetRandomnessBeaconConfig":                     true,
+	"GetAppHashScheme":                              true,
+	"GetValidatorNode":                              true,
+	"GetValidatorNodeList":                          true,
+	"GetValidatorMisbehaviorList":                   true,
+	"GetPendingValidatorUpdateList":                 true,
+	"GetTokenLedger":                                true,
+	"GetRequestEscrowPrice":                         true,
+	"GetRequestSettlement":                          true,
+	"GetLowTokenThreshold":                          true,
+	"GetAdminApprovalPolicy":                        true,
+	"GetPendingAdminProposalList":                   true,
+	"GetGovernanceActionDelay":                      true,
+	"GetPendingGovernanceActionList":                true,
+	"GetPausedMethodList":                           true,
+	"GetValidatorPowerPolicy":                       true,
+	"GetValidatorMissThreshold":                     true,
+	"GetBlockWriteLimit":                            true,
+	"GetRequestMessageHashCheckHeight":              true,
+	"GetRequestPriorityClassList":                   true,
+	"GetQueryVisibilityList":                        true,
+	"GetDataRetentionPolicy":                        true,
+	"GetEndBlockHookList":                           true,
+	"SignedQuery":                                   true,
+	"MultiQuery":                                    true,
+	"GetChangesAtHeight":                            true,
+	"GetNodeContactList":                            true,
+	"GetNodeIDAlias":                                true,
+	"GetBlockActivity":                              true,
+}
+
+// QueryRouter is Pointer to function. Query method restricted by NDID can only be called
+// in SignedQuery by allowed node.
 func (app *ABCIApplication) QueryRouter(method string, param string, height int64) types.ResponseQuery {
+	if method == "SignedQuery" {
+		return app.signedQuery(param, height)
+	}
+	errCode, errLog := app.checkQueryVisibility(method, "")
+	if errCode != code.OK {
+		return app.ReturnQueryWithCode(errCode, nil, errLog, app.state.Height)
+	}
 	result := app.callQuery(method, param, height)
 	return result
 }
 
 func (app *ABCIApplication) callQuery(name string, param string, height int64) types.ResponseQuery {
+	param = app.resolveNodeIDAliasParam(param)
 	switch name {
 	case "GetNodePublicKey":
 		return app.getNodePublicKey(param)
@@ -54,6 +218,12 @@ func (app *ABCIApplication) callQuery(name string, param string, height int64) t
 		return app.getRequest(param, height)
 	case "GetRequestDetail":
 		return app.getRequestDetail(param, height, true)
+	case "GetRequestMessageProof":
+		return app.getRequestMessageProof(param, height)
+	case "GetRequestStatus":
+		return app.getRequestStatusQuery(param, height)
+	case "GetDataRequestProgress":
+		return app.getDataRequestProgress(param, height)
 	case "GetAsNodesByServiceId":
 		return app.getAsNodesByServiceId(param)
 	case "GetMqAddresses":
@@ -78,6 +248,8 @@ func (app *ABCIApplication) callQuery(name string, param string, height int64) t
 		return app.getNodeInfo(param)
 	case "CheckExistingAccessorID":
 		return app.checkExistingAccessorID(param)
+	case "GetIdentityModeList":
+		return app.getIdentityModeList(param)
 	case "GetIdentityInfo":
 		return app.getIdentityInfo(param)
 	case "GetDataSignature":
@@ -98,15 +270,101 @@ func (app *ABCIApplication) callQuery(name string, param string, height int64) t
 		return app.isInitEnded(param)
 	case "GetChainHistory":
 		return app.getChainHistory(param)
+	case "GetChainHistoryInfo":
+		return app.getChainHistoryInfo(param)
 	case "GetReferenceGroupCode":
 		return app.GetReferenceGroupCode(param)
 	case "GetReferenceGroupCodeByAccessorID":
 		return app.GetReferenceGroupCodeByAccessorID(param)
+	case "GetReferenceGroupIdPList":
+		return app.getReferenceGroupIdPList(param)
+	case "GetAccessorsInAccessorGroup":
+		return app.getAccessorsInAccessorGroup(param)
+	case "GetAccessorResponseList":
+		return app.getAccessorResponseList(param)
 	case "GetAllowedModeList":
 		return app.GetAllowedModeList(param)
 	case "GetAllowedMinIalForRegisterIdentityAtFirstIdp":
 		return app.GetAllowedMinIalForRegisterIdentityAtFirstIdp(param)
+	case "GetIdPAgentList":
+		return app.getIdPAgentList(param)
+	case "CheckRevokedPublicKey":
+		return app.checkRevokedPublicKey(param)
+	case "GetDataSchema":
+		return app.getDataSchema(param)
+	case "GetConsentReceiptList":
+		return app.getConsentReceiptList(param)
+	case "GetStatistics":
+		return app.getStatistics(param)
+	case "GetServiceStatistics":
+		return app.getServiceStatistics(param)
+	case "GetNodeQuota":
+		return app.getNodeQuotaInfo(param)
+	case "SimulateTx":
+		return app.simulateTx(param)
+	case "CheckInvariants":
+		return app.checkInvariantsQuery(param)
+	case "GetMaxRequestTimeoutExtension":
+		return app.getMaxRequestTimeoutExtension(param)
+	case "GetRequestListSizeLimit":
+		return app.getRequestListSizeLimit(param)
+	case "GetRandomnessBeaconConfig":
+		return app.getRandomnessBeaconConfig(param)
+	case "GetAppHashScheme":
+		return app.getAppHashSchemeQuery(param)
+	case "GetValidatorNode":
+		return app.getValidatorNode(param)
+	case "GetValidatorNodeList":
+		return app.getValidatorNodeList(param)
+	case "GetValidatorMisbehaviorList":
+		return app.getValidatorMisbehaviorList(param)
+	case "GetPendingValidatorUpdateList":
+		return app.getPendingValidatorUpdateList(param)
+	case "GetTokenLedger":
+		return app.getTokenLedger(param)
+	case "GetRequestEscrowPrice":
+		return app.getRequestEscrowPrice(param)
+	case "GetRequestSettlement":
+		return app.getRequestSettlementQuery(param)
+	case "GetLowTokenThreshold":
+		return app.getLowTokenThreshold(param)
+	case "GetAdminApprovalPolicy":
+		return app.getAdminApprovalPolicyQuery(param)
+	case "GetPendingAdminProposalList":
+		return app.getPendingAdminProposalList(param)
+	case "GetGovernanceActionDelay":
+		return app.getGovernanceActionDelayQuery(param)
+	case "GetPendingGovernanceActionList":
+		return app.getPendingGovernanceActionList(param)
+	case "GetPausedMethodList":
+		return app.getPausedMethodList(param)
+	case "GetValidatorPowerPolicy":
+		return app.getValidatorPowerPolicyQuery(param)
+	case "GetValidatorMissThreshold":
+		return app.getValidatorMissThreshold(param)
+	case "GetBlockWriteLimit":
+		return app.getBlockWriteLimit(param)
+	case "GetRequestMessageHashCheckHeight":
+		return app.getRequestMessageHashCheckHeight(param)
+	case "GetRequestPriorityClassList":
+		return app.getRequestPriorityClassListQuery(param)
+	case "GetQueryVisibilityList":
+		return app.getQueryVisibilityList(param)
+	case "GetDataRetentionPolicy":
+		return app.getDataRetentionPolicyQuery(param)
+	case "GetEndBlockHookList":
+		return app.getEndBlockHookList(param)
+	case "MultiQuery":
+		return app.multiQuery(param, height)
+	case "GetChangesAtHeight":
+		return app.getChangesAtHeight(param)
+	case "GetBlockActivity":
+		return app.getBlockActivity(param)
+	case "GetNodeContactList":
+		return app.getNodeContactList(param)
+	case "GetNodeIDAlias":
+		return app.getNodeIDAlias(param)
 	default:
-		return types.ResponseQuery{Code: code.UnknownMethod, Log: "Unknown method name"}
+		return app.returnUnknownQueryMethod(name)
 	}
 }
@@ -0,0 +1,47 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+)
+
+// logQueryAccess writes structured access log of query and records its latency and
+// result size in percentile summaries. Result size is size before gzip compression.
+func (app *ABCIApplication) logQueryAccess(method string, param string, res types.ResponseQuery, duration time.Duration, cacheHit bool) {
+	app.logger.WithFields(logrus.Fields{
+		"method":      method,
+		"param_size":  len(param),
+		"param_hash":  utils.CanonicalParamHash(app.CurrentChain, param),
+		"result_size": len(res.Value),
+		"code":        res.Code,
+		"duration_ms": float64(duration.Nanoseconds()) / float64(time.Millisecond),
+		"cache_hit":   cacheHit,
+	}).Info("Query access")
+	go recordQueryAccessMetrics(method, cacheHit, duration, len(res.Value))
+}
@@ -0,0 +1,115 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"strconv"
+
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// defaultQueryCacheSize is default max number of query results kept in cache.
+// All results are dropped when cache is full.
+const defaultQueryCacheSize = 1000
+
+// isNotCacheableQuery is list of queries which result depends on time or height of latest block
+// or which may run such query (MultiQuery)
+var isNotCacheableQuery = map[string]bool{
+	"GetNodeQuota":       true,
+	"SimulateTx":         true,
+	"MultiQuery":         true,
+	"GetChangesAtHeight": true,
+	"GetBlockActivity":   true,
+	"SignedQuery":        true,
+	"GetAppHashScheme":   true,
+}
+
+type queryCacheEntry struct {
+	result      types.ResponseQuery
+	keyPrefixes map[string]bool
+}
+
+// queryCache keeps query results by method, parameter and requested height.
+// Result is kept until a key with prefix read by the query is changed.
+type queryCache struct {
+	entries map[string]*queryCacheEntry
+	maxSize int
+}
+
+func newQueryCache(maxSize int) *queryCache {
+	return &queryCache{
+		entries: make(map[string]*queryCacheEntry),
+		maxSize: maxSize,
+	}
+}
+
+// setMaxSize changes max number of results kept in cache. Size 0 disables cache.
+func (cache *queryCache) setMaxSize(maxSize int) {
+	cache.maxSize = maxSize
+	if len(cache.entries) > maxSize {
+		cache.entries = make(map[string]*queryCacheEntry)
+	}
+}
+
+func getQueryCacheKey(method string, param string, height int64) string {
+	return method + "|" + strconv.FormatInt(height, 10) + "|" + param
+}
+
+func (cache *queryCache) get(method string, param string, height int64) (types.ResponseQuery, bool) {
+	if isNotCacheableQuery[method] {
+		return types.ResponseQuery{}, false
+	}
+	entry, exist := cache.entries[getQueryCacheKey(method, param, height)]
+	if !exist {
+		return types.ResponseQuery{}, false
+	}
+	return entry.result, true
+}
+
+func (cache *queryCache) set(method string, param string, height int64, result types.ResponseQuery, keyPrefixes map[string]bool) {
+	if isNotCacheableQuery[method] || cache.maxSize <= 0 {
+		return
+	}
+	if len(cache.entries) >= cache.maxSize {
+		cache.entries = make(map[string]*queryCacheEntry)
+	}
+	cache.entries[getQueryCacheKey(method, param, height)] = &queryCacheEntry{
+		result:      result,
+		keyPrefixes: keyPrefixes,
+	}
+}
+
+// invalidate drops results of queries which read key with any of given prefixes
+func (cache *queryCache) invalidate(keyPrefixes map[string]bool) {
+	if len(keyPrefixes) == 0 {
+		return
+	}
+	for cacheKey, entry := range cache.entries {
+		for keyPrefix := range entry.keyPrefixes {
+			if keyPrefixes[keyPrefix] {
+				delete(cache.entries, cacheKey)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"sync"
+	"time"
+)
+
+// queryRateLimitAllMethods is key of rate limit for methods which have no own limit
+const queryRateLimitAllMethods = "*"
+
+type queryTokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// queryLimiter limits rate of queries by method (token bucket with burst of one second
+// worth of queries) and number of queries being processed at the same time.
+// Zero values mean no limit.
+type queryLimiter struct {
+	mutex          sync.Mutex
+	rateLimits     map[string]float64
+	buckets        map[string]*queryTokenBucket
+	maxConcurrent  int
+	concurrentSize int
+}
+
+func newQueryLimiter() *queryLimiter {
+	return &queryLimiter{
+		rateLimits: make(map[string]float64),
+		buckets:    make(map[string]*queryTokenBucket),
+	}
+}
+
+// setRateLimits replaces rate limits (queries per second) by method
+func (limiter *queryLimiter) setRateLimits(rateLimits map[string]float64) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	limiter.rateLimits = rateLimits
+	limiter.buckets = make(map[string]*queryTokenBucket)
+}
+
+func (limiter *queryLimiter) setMaxConcurrent(maxConcurrent int) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	limiter.maxConcurrent = maxConcurrent
+}
+
+// allow takes a token from bucket of method and returns false if there is none left
+func (limiter *queryLimiter) allow(method string) bool {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	bucketKey := method
+	rateLimit, exist := limiter.rateLimits[method]
+	if !exist {
+		bucketKey = queryRateLimitAllMethods
+		rateLimit = limiter.rateLimits[queryRateLimitAllMethods]
+	}
+	if rateLimit <= 0 {
+		return true
+	}
+	now := time.Now()
+	burst := rateLimit
+	if burst < 1 {
+		burst = 1
+	}
+	bucket, exist := limiter.buckets[bucketKey]
+	if !exist {
+		bucket = &queryTokenBucket{tokens: burst, lastRefill: now}
+		limiter.buckets[bucketKey] = bucket
+	}
+	bucket.tokens += now.Sub(bucket.lastRefill).Seconds() * rateLimit
+	if bucket.tokens > burst {
+		bucket.tokens = burst
+	}
+	bucket.lastRefill = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// acquire reserves a slot for query being processed. release must be called when acquire returns true.
+func (limiter *queryLimiter) acquire() bool {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	if limiter.maxConcurrent > 0 && limiter.concurrentSize >= limiter.maxConcurrent {
+		return false
+	}
+	limiter.concurrentSize++
+	return true
+}
+
+func (limiter *queryLimiter) release() {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	limiter.concurrentSize--
+}
@@ -0,0 +1,160 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// quotaDayFormat is format of day (in UTC) which daily quota usage is counted by
+const quotaDayFormat = "2006-01-02"
+
+func (app *ABCIApplication) getNodeQuota(nodeID string, method string, committedState bool) (data.NodeQuota, error) {
+	var nodeQuota data.NodeQuota
+	nodeQuotaKey := nodeQuotaKeyPrefix + keySeparator + nodeID + keySeparator + method
+	nodeQuotaValue, _ := app.state.Get([]byte(nodeQuotaKey), committedState)
+	if nodeQuotaValue == nil {
+		return nodeQuota, nil
+	}
+	err := proto.Unmarshal(nodeQuotaValue, &nodeQuota)
+	return nodeQuota, err
+}
+
+func (app *ABCIApplication) getNodeQuotaUsageKeys(nodeID string, method string) (dailyUsageKey string, monthlyUsageKey string) {
+	usageKey := nodeQuotaUsageKeyPrefix + keySeparator + nodeID + keySeparator + method + keySeparator
+	blockTime := app.CurrentBlockTime.UTC()
+	return usageKey + blockTime.Format(quotaDayFormat), usageKey + blockTime.Format(statisticsMonthFormat)
+}
+
+func (app *ABCIApplication) getNodeQuotaUsage(usageKey string, committedState bool) int64 {
+	usageValue, _ := app.state.Get([]byte(usageKey), committedState)
+	if usageValue == nil {
+		return 0
+	}
+	usage, err := strconv.ParseInt(string(usageValue), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return usage
+}
+
+// checkNodeQuota checks that node has not used up its daily and monthly quota of method
+func (app *ABCIApplication) checkNodeQuota(method string, nodeID string) (errorCode uint32, errorLog string) {
+	nodeQuota, err := app.getNodeQuota(nodeID, method, false)
+	if err != nil {
+		return code.UnmarshalError, err.Error()
+	}
+	if nodeQuota.DailyLimit == 0 && nodeQuota.MonthlyLimit == 0 {
+		return code.OK, ""
+	}
+	dailyUsageKey, monthlyUsageKey := app.getNodeQuotaUsageKeys(nodeID, method)
+	if nodeQuota.DailyLimit > 0 && app.getNodeQuotaUsage(dailyUsageKey, false) >= nodeQuota.DailyLimit {
+		return code.NodeQuotaExceeded, "Daily quota of " + method + " is exceeded"
+	}
+	if nodeQuota.MonthlyLimit > 0 && app.getNodeQuotaUsage(monthlyUsageKey, false) >= nodeQuota.MonthlyLimit {
+		return code.NodeQuotaExceeded, "Monthly quota of " + method + " is exceeded"
+	}
+	return code.OK, ""
+}
+
+// increaseNodeQuotaUsage counts usage of method by node. Usage is counted only when node has quota of method.
+func (app *ABCIApplication) increaseNodeQuotaUsage(method string, nodeID string) {
+	nodeQuota, err := app.getNodeQuota(nodeID, method, false)
+	if err != nil {
+		return
+	}
+	if nodeQuota.DailyLimit == 0 && nodeQuota.MonthlyLimit == 0 {
+		return
+	}
+	dailyUsageKey, monthlyUsageKey := app.getNodeQuotaUsageKeys(nodeID, method)
+	for _, usageKey := range []string{dailyUsageKey, monthlyUsageKey} {
+		usage := app.getNodeQuotaUsage(usageKey, false) + 1
+		app.state.Set([]byte(usageKey), []byte(strconv.FormatInt(usage, 10)))
+	}
+}
+
+func (app *ABCIApplication) setNodeQuota(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetNodeQuota, Parameter: %s", param)
+	var funcParam SetNodeQuotaParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	// Validate parameter
+	if funcParam.DailyLimit < 0 || funcParam.MonthlyLimit < 0 {
+		return app.ReturnDeliverTxLog(code.QuotaLimitMustBeGreaterOrEqualToZero, "Quota limit must be greater than or equal to zero", "")
+	}
+	if !IsMethod[funcParam.Method] {
+		return app.ReturnDeliverTxLog(code.UnknownMethod, unknownMethodLog(suggestMethod(funcParam.Method, IsMethod)), "")
+	}
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
+	if !app.state.Has([]byte(nodeDetailKey), false) {
+		return app.ReturnDeliverTxLog(code.NodeIDNotFound, "Node ID not found", "")
+	}
+	nodeQuotaKey := nodeQuotaKeyPrefix + keySeparator + funcParam.NodeID + keySeparator + funcParam.Method
+	if funcParam.DailyLimit == 0 && funcParam.MonthlyLimit == 0 {
+		app.state.Delete([]byte(nodeQuotaKey))
+		return app.ReturnDeliverTxLog(code.OK, "success", "")
+	}
+	var nodeQuota data.NodeQuota
+	nodeQuota.DailyLimit = funcParam.DailyLimit
+	nodeQuota.MonthlyLimit = funcParam.MonthlyLimit
+	nodeQuotaValue, err := utils.ProtoDeterministicMarshal(&nodeQuota)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set([]byte(nodeQuotaKey), []byte(nodeQuotaValue))
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getNodeQuotaInfo(param string) types.ResponseQuery {
+	app.logger.Infof("GetNodeQuota, Parameter: %s", param)
+	var funcParam GetNodeQuotaParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	nodeQuota, err := app.getNodeQuota(funcParam.NodeID, funcParam.Method, true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	dailyUsageKey, monthlyUsageKey := app.getNodeQuotaUsageKeys(funcParam.NodeID, funcParam.Method)
+	var result GetNodeQuotaResult
+	result.DailyLimit = nodeQuota.DailyLimit
+	result.MonthlyLimit = nodeQuota.MonthlyLimit
+	result.DailyUsage = app.getNodeQuotaUsage(dailyUsageKey, true)
+	result.MonthlyUsage = app.getNodeQuotaUsage(monthlyUsageKey, true)
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
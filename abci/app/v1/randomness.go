@@ -0,0 +1,131 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// blockSeedDomain separates block seed from other hashes of app hash
+const blockSeedDomain = "ndid-block-seed"
+
+// setBlockSeed derives seed of current block from app hash of previous block and current height.
+// Seed is known only after previous block is committed and is the same on every node.
+func (app *ABCIApplication) setBlockSeed() {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, uint64(app.state.CurrentBlockHeight))
+	hasher := sha256.New()
+	hasher.Write([]byte(blockSeedDomain))
+	hasher.Write(app.state.AppHash)
+	hasher.Write(heightBytes)
+	app.blockSeed = hasher.Sum(nil)
+}
+
+// deterministicUint64 returns pseudo-random number of label and counter in current block
+func (app *ABCIApplication) deterministicUint64(label string, counter uint64) uint64 {
+	counterBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(counterBytes, counter)
+	hasher := sha256.New()
+	hasher.Write(app.blockSeed)
+	hasher.Write([]byte(label))
+	hasher.Write(counterBytes)
+	return binary.BigEndian.Uint64(hasher.Sum(nil)[:8])
+}
+
+// deterministicShuffle shuffles list in place in pseudo-random order of label in current block.
+// Label should identify the use and the Tx (e.g. method, txLabel and request ID) so that lists
+// shuffled by different Txs in the same block are in independent orders. Fisher-Yates shuffle is implemented here instead
+// of using math/rand so that the order doesn't depend on Go version.
+func (app *ABCIApplication) deterministicShuffle(label string, list []string) {
+	for i := len(list) - 1; i > 0; i-- {
+		j := int(app.deterministicUint64(label, uint64(i)) % uint64(i+1))
+		list[i], list[j] = list[j], list[i]
+	}
+}
+
+// txLabel returns label of Tx being delivered from its hash and index in current block.
+// Index is decided by block proposer, so sender who grinds Tx content (e.g. request ID)
+// offline for an order it wants must also guess index of its Tx, unlike with label of
+// Tx content only. It is a mitigation, not a guarantee: sender can still guess index in
+// a quiet block and proposer colluding with sender can reorder Txs of its block.
+func (app *ABCIApplication) txLabel() string {
+	return hex.EncodeToString(app.deliverTxHash) + "|" + strconv.FormatInt(app.deliverTxIndex, 10)
+}
+
+func (app *ABCIApplication) isRandomnessBeaconEnabled(committedState bool) (bool, error) {
+	value, _ := app.state.Get(randomnessBeaconConfigKeyBytes, committedState)
+	if value == nil {
+		return false, nil
+	}
+	var config data.RandomnessBeaconConfig
+	err := proto.Unmarshal(value, &config)
+	if err != nil {
+		return false, err
+	}
+	return config.Enabled, nil
+}
+
+// setRandomnessBeaconConfig enables or disables deterministic pseudo-random ordering of
+// lists which are otherwise in order of registration (e.g. IdPs resolved from identity target)
+func (app *ABCIApplication) setRandomnessBeaconConfig(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetRandomnessBeaconConfig, Parameter: %s", param)
+	var funcParam RandomnessBeaconConfigParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	var config data.RandomnessBeaconConfig
+	config.Enabled = funcParam.Enabled
+	value, err := utils.ProtoDeterministicMarshal(&config)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(randomnessBeaconConfigKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getRandomnessBeaconConfig(param string) types.ResponseQuery {
+	app.logger.Infof("GetRandomnessBeaconConfig, Parameter: %s", param)
+	enabled, err := app.isRandomnessBeaconEnabled(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result RandomnessBeaconConfigParam
+	result.Enabled = enabled
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
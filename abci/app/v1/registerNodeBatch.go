@@ -0,0 +1,103 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+// maxRegisterNodeBatchSize is max number of nodes registered in one RegisterNodeBatch Tx
+const maxRegisterNodeBatchSize = 500
+
+// registerNodeBatch registers nodes (with initial token) listed in parameter one by one
+// like RegisterNode. Entry which fails is reverted and reported in result without failing
+// other entries so network can be bootstrapped in few transactions.
+func (app *ABCIApplication) registerNodeBatch(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("RegisterNodeBatch, Parameter: %s", param)
+	var funcParam RegisterNodeBatchParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if len(funcParam.NodeList) == 0 || len(funcParam.NodeList) > maxRegisterNodeBatchSize {
+		return app.ReturnDeliverTxLog(code.InvalidRegisterNodeBatchSize, "Node list must not be empty or exceed max batch size", "")
+	}
+	var result RegisterNodeBatchResult
+	result.ResultList = make([]RegisterNodeBatchEntryResult, 0, len(funcParam.NodeList))
+	for _, entry := range funcParam.NodeList {
+		snapshot := app.state.Snapshot()
+		eventCount := len(app.deliverTxEvents)
+		retCode, retLog := app.registerNodeBatchEntry(entry, nodeID)
+		if retCode != code.OK {
+			app.state.RevertToSnapshot(snapshot)
+			app.deliverTxEvents = app.deliverTxEvents[:eventCount]
+		} else {
+			result.RegisteredCount++
+		}
+		result.ResultList = append(result.ResultList, RegisterNodeBatchEntryResult{
+			NodeID: entry.NodeID,
+			Code:   retCode,
+			Log:    retLog,
+		})
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	return app.ReturnDeliverTxLog(code.OK, "success", string(value))
+}
+
+// registerNodeBatchEntry checks keys of node the same way as RegisterNode Tx is checked,
+// registers node and sets its initial token
+func (app *ABCIApplication) registerNodeBatchEntry(entry RegisterNodeBatchEntry, nodeID string) (uint32, string) {
+	registerNodeParam, err := json.Marshal(entry.RegisterNode)
+	if err != nil {
+		return code.MarshalError, err.Error()
+	}
+	retCode, retLog := checkNodePubKeys(string(registerNodeParam))
+	if retCode != code.OK {
+		return retCode, retLog
+	}
+	retCode, retLog = app.checkNodePubKeysNotRevoked(string(registerNodeParam), false)
+	if retCode != code.OK {
+		return retCode, retLog
+	}
+	result := app.registerNode(string(registerNodeParam), nodeID)
+	if result.Code != code.OK {
+		return result.Code, result.Log
+	}
+	if entry.Token < 0 {
+		return code.AmountMustBeGreaterOrEqualToZero, "Amount must be greater than or equal to zero"
+	}
+	if entry.Token > 0 {
+		err = app.setToken(entry.NodeID, entry.Token, "RegisterNodeBatch")
+		if err != nil {
+			return code.TokenAccountNotFound, err.Error()
+		}
+	}
+	return code.OK, "success"
+}
@@ -0,0 +1,78 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// Request status changes are emitted as "did.request_status" event so that RP, IdP and AS
+// can subscribe to them (by request_id or node_id attribute) instead of polling request.
+const (
+	requestStatusEventType = "did.request_status"
+
+	requestStatusResponseAdded = "response_added"
+	requestStatusDataSigned    = "data_signed"
+	requestStatusClosed        = "closed"
+	requestStatusTimedOut      = "timed_out"
+)
+
+// emitRequestStatusEvent emits request status event with node_id attribute for each node
+// involved in request (owner, IdPs and ASes) so node can subscribe to all of its requests.
+func (app *ABCIApplication) emitRequestStatusEvent(request *data.Request, status string, actorNodeID string, serviceID string) {
+	attributes := []cmn.KVPair{
+		{Key: []byte("request_id"), Value: []byte(request.RequestId)},
+		{Key: []byte("status"), Value: []byte(status)},
+		{Key: []byte("actor_node_id"), Value: []byte(actorNodeID)},
+	}
+	if serviceID != "" {
+		attributes = append(attributes, cmn.KVPair{Key: []byte("service_id"), Value: []byte(serviceID)})
+	}
+	nodeIDs := make(map[string]bool)
+	addNodeID := func(nodeID string) {
+		if nodeID == "" || nodeIDs[nodeID] {
+			return
+		}
+		nodeIDs[nodeID] = true
+		attributes = append(attributes, cmn.KVPair{Key: []byte("node_id"), Value: []byte(nodeID)})
+	}
+	addNodeID(request.Owner)
+	for _, idpID := range request.IdpIdList {
+		addNodeID(idpID)
+	}
+	for _, response := range request.ResponseList {
+		addNodeID(response.IdpId)
+	}
+	for _, dataRequest := range request.DataRequestList {
+		for _, asID := range dataRequest.AsIdList {
+			addNodeID(asID)
+		}
+	}
+	app.deliverTxEvents = append(app.deliverTxEvents, types.Event{
+		Type:       requestStatusEventType,
+		Attributes: attributes,
+	})
+}
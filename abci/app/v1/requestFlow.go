@@ -0,0 +1,153 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// States of request flow. Request is open until first IdP response, responded until
+// first AS signs data and data signed until it is closed or timed out which are final.
+const (
+	requestFlowStateOpen       = "open"
+	requestFlowStateResponded  = "responded"
+	requestFlowStateDataSigned = "data_signed"
+	requestFlowStateClosed     = "closed"
+	requestFlowStateTimedOut   = "timed_out"
+)
+
+// requestFlowAutoClose is transition of closing request created with auto close by smart contract
+const requestFlowAutoClose = "AutoClose"
+
+// requestFlowTransition is action (Tx method) which can be done to request in fromStates
+// and logs of rejecting it in final states
+type requestFlowTransition struct {
+	fromStates  []string
+	closedLog   string
+	timedOutLog string
+	// allowedOnAutoClose allows action to closed request created with auto close, which is
+	// closed when last AS signed data while RP still has to set data received from it
+	allowedOnAutoClose bool
+}
+
+var requestFlowNotFinishedStates = []string{
+	requestFlowStateOpen,
+	requestFlowStateResponded,
+	requestFlowStateDataSigned,
+}
+
+// requestFlowTransitions are legal transitions of request flow by action.
+// Every handler changing request must check its action with checkRequestFlowTransition.
+var requestFlowTransitions = map[string]requestFlowTransition{
+	"CreateIdpResponse": {
+		fromStates:  requestFlowNotFinishedStates,
+		closedLog:   "Can't response a request that's closed",
+		timedOutLog: "Can't response a request that's timed out",
+	},
+	"RegisterIdentityAndCreateIdpResponse": {
+		fromStates:  requestFlowNotFinishedStates,
+		closedLog:   "Can't response a request that's closed",
+		timedOutLog: "Can't response a request that's timed out",
+	},
+	"SignData": {
+		fromStates:  requestFlowNotFinishedStates,
+		closedLog:   "Request is closed",
+		timedOutLog: "Request is timed out",
+	},
+	"SetDataReceived": {
+		fromStates:         requestFlowNotFinishedStates,
+		closedLog:          "Request is closed",
+		timedOutLog:        "Request is timed out",
+		allowedOnAutoClose: true,
+	},
+	"CloseRequest": {
+		fromStates:  requestFlowNotFinishedStates,
+		closedLog:   "Can not close a closed request",
+		timedOutLog: "Can not close a timed out request",
+	},
+	requestFlowAutoClose: {
+		fromStates:  requestFlowNotFinishedStates,
+		closedLog:   "Request is closed",
+		timedOutLog: "Request is timed out",
+	},
+	"TimeOutRequest": {
+		fromStates:  requestFlowNotFinishedStates,
+		closedLog:   "Can not set time out a closed request",
+		timedOutLog: "Can not set time out a timed out request",
+	},
+	"ExtendRequestTimeout": {
+		fromStates:  requestFlowNotFinishedStates,
+		closedLog:   "Can not extend timeout of a closed request",
+		timedOutLog: "Can not extend timeout of a timed out request",
+	},
+}
+
+// getRequestFlowState returns state of request in request flow.
+// IdP responses and answered AS of request sub-records must be loaded.
+func getRequestFlowState(request *data.Request) string {
+	if request.Closed {
+		return requestFlowStateClosed
+	}
+	if request.TimedOut {
+		return requestFlowStateTimedOut
+	}
+	for _, dataRequest := range request.DataRequestList {
+		if len(dataRequest.AnsweredAsIdList) > 0 {
+			return requestFlowStateDataSigned
+		}
+	}
+	if len(request.ResponseList) > 0 {
+		return requestFlowStateResponded
+	}
+	return requestFlowStateOpen
+}
+
+// isRequestFinished returns whether request is closed or timed out
+func isRequestFinished(request *data.Request) bool {
+	state := getRequestFlowState(request)
+	return state == requestFlowStateClosed || state == requestFlowStateTimedOut
+}
+
+// checkRequestFlowTransition checks that action can be done to request in its current state
+func checkRequestFlowTransition(request *data.Request, action string) (errorCode uint32, errorLog string) {
+	transition, ok := requestFlowTransitions[action]
+	if !ok {
+		return code.UnknownMethod, "Unknown request flow action"
+	}
+	state := getRequestFlowState(request)
+	if state == requestFlowStateClosed && transition.allowedOnAutoClose && request.AutoClose {
+		return code.OK, ""
+	}
+	for _, fromState := range transition.fromStates {
+		if fromState == state {
+			return code.OK, ""
+		}
+	}
+	switch state {
+	case requestFlowStateTimedOut:
+		return code.RequestIsTimedOut, transition.timedOutLog
+	default:
+		return code.RequestIsClosed, transition.closedLog
+	}
+}
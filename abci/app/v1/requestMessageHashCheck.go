@@ -0,0 +1,79 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+// getRequestMessageHashCheckHeightFromStateDB returns creation block height from which
+// IdP response must have request message hash, 0 when it is not set
+func (app *ABCIApplication) getRequestMessageHashCheckHeightFromStateDB(committedState bool) int64 {
+	value, _ := app.state.Get(requestHashCheckHeightKeyBytes, committedState)
+	if value == nil {
+		return 0
+	}
+	height, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
+// setRequestMessageHashCheckHeight sets creation block height of requests from which IdP response
+// must have request message hash matching hash of the request. Requests created before it
+// (which IdP may have responded to without hash) are not affected.
+// Height cannot be changed once it is reached so that the check cannot be turned off.
+func (app *ABCIApplication) setRequestMessageHashCheckHeight(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetRequestMessageHashCheckHeight, Parameter: %s", param)
+	var funcParam RequestMessageHashCheckHeightParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.ActivationHeight <= app.state.CurrentBlockHeight {
+		return app.ReturnDeliverTxLog(code.InvalidActivationHeight, "Activation height must be greater than current block height", "")
+	}
+	currentHeight := app.getRequestMessageHashCheckHeightFromStateDB(false)
+	if currentHeight > 0 && currentHeight <= app.state.CurrentBlockHeight {
+		return app.ReturnDeliverTxLog(code.InvalidActivationHeight, "Request message hash check is already active", "")
+	}
+	app.state.Set(requestHashCheckHeightKeyBytes, []byte(strconv.FormatInt(funcParam.ActivationHeight, 10)))
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getRequestMessageHashCheckHeight(param string) types.ResponseQuery {
+	app.logger.Infof("GetRequestMessageHashCheckHeight, Parameter: %s", param)
+	var result RequestMessageHashCheckHeightParam
+	result.ActivationHeight = app.getRequestMessageHashCheckHeightFromStateDB(true)
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,89 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// maxRequestSaltLength is max length of salt of request message and request params
+const maxRequestSaltLength = 256
+
+// getRequestMessageProof returns hashes and salts of request message and request params with
+// signatures of IdP responses, so that RP or IdP can prove to auditor which message user consented to
+// by showing the message which hashes to stored hash with stored salt. Message itself is never on chain.
+func (app *ABCIApplication) getRequestMessageProof(param string, height int64) types.ResponseQuery {
+	app.logger.Infof("GetRequestMessageProof, Parameter: %s", param)
+	var funcParam GetRequestParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	value, _ := app.state.GetVersioned(getRequestKey(funcParam.RequestID), height, true)
+	if value == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var request data.Request
+	err = proto.Unmarshal(value, &request)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	err = app.loadRequestSubRecords(&request, height, true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetRequestMessageProofResult
+	result.RequestID = request.RequestId
+	result.RequesterNodeID = request.Owner
+	result.MessageHash = request.RequestMessageHash
+	result.MessageSalt = request.RequestMessageSalt
+	result.DataRequestList = make([]DataRequestParamsProof, 0, len(request.DataRequestList))
+	for _, dataRequest := range request.DataRequestList {
+		result.DataRequestList = append(result.DataRequestList, DataRequestParamsProof{
+			ServiceID:         dataRequest.ServiceId,
+			RequestParamsHash: dataRequest.RequestParamsHash,
+			RequestParamsSalt: dataRequest.RequestParamsSalt,
+		})
+	}
+	result.ResponseList = make([]ResponseSignatureProof, 0, len(request.ResponseList))
+	for _, response := range request.ResponseList {
+		result.ResponseList = append(result.ResponseList, ResponseSignatureProof{
+			IdpID:     response.IdpId,
+			Status:    response.Status,
+			Signature: response.Signature,
+		})
+	}
+	result.CreationBlockHeight = request.CreationBlockHeight
+	result.CreationBlockTime = request.CreationBlockTime
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(resultJSON, "success", app.state.Height)
+}
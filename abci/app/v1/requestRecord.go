@@ -0,0 +1,391 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/keys"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// Responses and status of data requests (answered AS and received data lists) of request
+// created with sub_records_split are stored in their own versioned keys so that IdP
+// response and AS sign data do not rewrite whole request. Requests created before keep
+// them in request itself.
+//
+// Summary (count of responses by status, count of ASes signed data of each service and
+// status of request derived from them) is updated with responses and status of data requests
+// so that it is not counted from lists every time. It is stored in its own key as well when sub records are split. Requests
+// created before summary was added have no summary until they get next response or signed data.
+
+func getRequestKey(requestID string) []byte {
+	return keys.Request(requestID)
+}
+
+func getResponseCountKey(requestID string) []byte {
+	return []byte(responseCountKeyPrefix + keySeparator + requestID)
+}
+
+func getRequestResponseKey(requestID string, index int) []byte {
+	return []byte(requestResponseKeyPrefix + keySeparator + requestID + keySeparator + strconv.Itoa(index))
+}
+
+func getDataRequestStatusKey(requestID string, serviceID string) []byte {
+	return []byte(dataRequestStatusKeyPrefix + keySeparator + requestID + keySeparator + serviceID)
+}
+
+func getRequestSummaryKey(requestID string) []byte {
+	return []byte(requestSummaryKeyPrefix + keySeparator + requestID)
+}
+
+const (
+	responseStatusAccept = "accept"
+	responseStatusReject = "reject"
+)
+
+// Status of request in addition to requestStatusClosed and requestStatusTimedOut
+const (
+	requestStatusPending     = "pending"
+	requestStatusConfirmed   = "confirmed"
+	requestStatusRejected    = "rejected"
+	requestStatusComplicated = "complicated"
+	requestStatusErrored     = "errored"
+	requestStatusCompleted   = "completed"
+)
+
+// getRequestStatus derives status of request from its summary. Request which has only
+// accepted responses is completed when accepted responses and signed data are enough.
+func getRequestStatus(request *data.Request, summary *data.RequestSummary) string {
+	if request.Closed {
+		return requestStatusClosed
+	}
+	if request.TimedOut {
+		return requestStatusTimedOut
+	}
+	switch {
+	case summary.AcceptCount == 0 && summary.RejectCount == 0 && summary.ErrorCount == 0:
+		return requestStatusPending
+	case summary.AcceptCount > 0 && summary.RejectCount > 0:
+		return requestStatusComplicated
+	case summary.RejectCount > 0:
+		return requestStatusRejected
+	case summary.AcceptCount == 0:
+		return requestStatusErrored
+	}
+	if summary.AcceptCount < request.MinIdp {
+		return requestStatusConfirmed
+	}
+	for _, dataRequest := range request.DataRequestList {
+		if getSummarySignedCount(summary, dataRequest.ServiceId) < dataRequest.MinAs {
+			return requestStatusConfirmed
+		}
+	}
+	return requestStatusCompleted
+}
+
+// addResponseToSummary counts response by its status. Status other than accept and reject
+// is counted as error.
+func addResponseToSummary(summary *data.RequestSummary, response *data.Response) {
+	switch response.Status {
+	case responseStatusAccept:
+		summary.AcceptCount++
+	case responseStatusReject:
+		summary.RejectCount++
+	default:
+		summary.ErrorCount++
+	}
+}
+
+// setSummarySignedCount sets count of ASes signed data of service
+func setSummarySignedCount(summary *data.RequestSummary, serviceID string, signedCount int64) {
+	for _, serviceSigned := range summary.SignedServiceList {
+		if serviceSigned.ServiceId == serviceID {
+			serviceSigned.SignedCount = signedCount
+			return
+		}
+	}
+	summary.SignedServiceList = append(summary.SignedServiceList, &data.ServiceSignedCount{
+		ServiceId:   serviceID,
+		SignedCount: signedCount,
+	})
+}
+
+// getSummarySignedCount returns count of ASes signed data of service
+func getSummarySignedCount(summary *data.RequestSummary, serviceID string) int64 {
+	for _, serviceSigned := range summary.SignedServiceList {
+		if serviceSigned.ServiceId == serviceID {
+			return serviceSigned.SignedCount
+		}
+	}
+	return 0
+}
+
+// newRequestSummary counts summary from response list and data request list of request
+func newRequestSummary(request *data.Request) *data.RequestSummary {
+	summary := &data.RequestSummary{
+		SignedServiceList: make([]*data.ServiceSignedCount, 0, len(request.DataRequestList)),
+	}
+	for _, response := range request.ResponseList {
+		addResponseToSummary(summary, response)
+	}
+	for _, dataRequest := range request.DataRequestList {
+		setSummarySignedCount(summary, dataRequest.ServiceId, int64(len(dataRequest.AnsweredAsIdList)))
+	}
+	summary.Status = getRequestStatus(request, summary)
+	return summary
+}
+
+// getRequestSummary returns summary of request, it is counted from lists of request created
+// before summary was added
+func getRequestSummary(request *data.Request) *data.RequestSummary {
+	if request.Summary != nil {
+		return request.Summary
+	}
+	return newRequestSummary(request)
+}
+
+// setRequestLastUpdate stamps request and its summary with height and time of block which saves them
+func (app *ABCIApplication) setRequestLastUpdate(request *data.Request) {
+	request.LastUpdateBlockHeight = app.state.CurrentBlockHeight
+	request.LastUpdateBlockTime = app.CurrentBlockTime.Unix()
+	if request.Summary != nil {
+		request.Summary.LastUpdateBlockHeight = request.LastUpdateBlockHeight
+		request.Summary.LastUpdateBlockTime = request.LastUpdateBlockTime
+	}
+}
+
+// getRequestLastUpdate returns height and time of block which request or any of its sub records
+// is last saved in, summary of request which sub records are split is saved with every sub record
+func getRequestLastUpdate(request *data.Request) (int64, int64) {
+	if request.Summary != nil && request.Summary.LastUpdateBlockHeight > request.LastUpdateBlockHeight {
+		return request.Summary.LastUpdateBlockHeight, request.Summary.LastUpdateBlockTime
+	}
+	return request.LastUpdateBlockHeight, request.LastUpdateBlockTime
+}
+
+// saveRequestSummary saves summary of request which sub records are split
+func (app *ABCIApplication) saveRequestSummary(request *data.Request) error {
+	request.Summary.LastUpdateBlockHeight = app.state.CurrentBlockHeight
+	request.Summary.LastUpdateBlockTime = app.CurrentBlockTime.Unix()
+	value, err := utils.ProtoDeterministicMarshal(request.Summary)
+	if err != nil {
+		return err
+	}
+	app.state.SetVersioned(getRequestSummaryKey(request.RequestId), value)
+	return nil
+}
+
+// loadRequestSubRecords fills responses and status of data requests stored in their own keys
+// into request read from state at the same height
+func (app *ABCIApplication) loadRequestSubRecords(request *data.Request, height int64, committedState bool) error {
+	if !request.SubRecordsSplit {
+		return nil
+	}
+	summaryValue, _ := app.state.GetVersioned(getRequestSummaryKey(request.RequestId), height, committedState)
+	if summaryValue != nil {
+		var summary data.RequestSummary
+		err := proto.Unmarshal(summaryValue, &summary)
+		if err != nil {
+			return err
+		}
+		request.Summary = &summary
+	}
+	request.ResponseList = make([]*data.Response, 0)
+	countValue, _ := app.state.GetVersioned(getResponseCountKey(request.RequestId), height, committedState)
+	if countValue != nil {
+		count, err := strconv.Atoi(string(countValue))
+		if err != nil {
+			return err
+		}
+		for index := 0; index < count; index++ {
+			value, _ := app.state.GetVersioned(getRequestResponseKey(request.RequestId, index), height, committedState)
+			var response data.Response
+			err = proto.Unmarshal(value, &response)
+			if err != nil {
+				return err
+			}
+			request.ResponseList = append(request.ResponseList, &response)
+		}
+	}
+	for _, dataRequest := range request.DataRequestList {
+		dataRequest.AnsweredAsIdList = make([]string, 0)
+		dataRequest.ReceivedDataFromList = make([]string, 0)
+		value, _ := app.state.GetVersioned(getDataRequestStatusKey(request.RequestId, dataRequest.ServiceId), height, committedState)
+		if value == nil {
+			continue
+		}
+		var status data.DataRequestStatus
+		err := proto.Unmarshal(value, &status)
+		if err != nil {
+			return err
+		}
+		dataRequest.AnsweredAsIdList = append(dataRequest.AnsweredAsIdList, status.AnsweredAsIdList...)
+		dataRequest.ReceivedDataFromList = append(dataRequest.ReceivedDataFromList, status.ReceivedDataFromList...)
+	}
+	return nil
+}
+
+// saveRequest saves request without responses and status of data requests when they are stored
+// in their own keys
+func (app *ABCIApplication) saveRequest(request *data.Request) error {
+	app.setRequestLastUpdate(request)
+	header := *request
+	if request.SubRecordsSplit {
+		header.Summary = nil
+		header.ResponseList = nil
+		header.DataRequestList = make([]*data.DataRequest, 0, len(request.DataRequestList))
+		for _, dataRequest := range request.DataRequestList {
+			dataRequestHeader := *dataRequest
+			dataRequestHeader.AnsweredAsIdList = nil
+			dataRequestHeader.ReceivedDataFromList = nil
+			header.DataRequestList = append(header.DataRequestList, &dataRequestHeader)
+		}
+	}
+	value, err := utils.ProtoDeterministicMarshal(&header)
+	if err != nil {
+		return err
+	}
+	app.state.SetVersioned(getRequestKey(request.RequestId), value)
+	return nil
+}
+
+// saveFinishedRequest saves request which is closed or timed out with its status
+func (app *ABCIApplication) saveFinishedRequest(request *data.Request) error {
+	if request.Summary == nil {
+		request.Summary = newRequestSummary(request)
+	} else {
+		request.Summary.Status = getRequestStatus(request, request.Summary)
+	}
+	err := app.saveRequest(request)
+	if err != nil {
+		return err
+	}
+	if !request.SubRecordsSplit {
+		return nil
+	}
+	return app.saveRequestSummary(request)
+}
+
+// addRequestResponse saves response appended to response list of request and counts it in summary
+func (app *ABCIApplication) addRequestResponse(request *data.Request) error {
+	if request.Summary == nil {
+		request.Summary = newRequestSummary(request)
+	} else {
+		addResponseToSummary(request.Summary, request.ResponseList[len(request.ResponseList)-1])
+		request.Summary.Status = getRequestStatus(request, request.Summary)
+	}
+	if !request.SubRecordsSplit {
+		return app.saveRequest(request)
+	}
+	err := app.saveRequestResponse(request, len(request.ResponseList)-1)
+	if err != nil {
+		return err
+	}
+	app.state.SetVersioned(getResponseCountKey(request.RequestId), []byte(strconv.Itoa(len(request.ResponseList))))
+	return app.saveRequestSummary(request)
+}
+
+// saveRequestResponse saves response at index of response list of request
+func (app *ABCIApplication) saveRequestResponse(request *data.Request, index int) error {
+	if !request.SubRecordsSplit {
+		return app.saveRequest(request)
+	}
+	value, err := utils.ProtoDeterministicMarshal(request.ResponseList[index])
+	if err != nil {
+		return err
+	}
+	app.state.SetVersioned(getRequestResponseKey(request.RequestId, index), value)
+	return nil
+}
+
+// saveResponseValidList saves responses of IdPs in response valid list
+func (app *ABCIApplication) saveResponseValidList(request *data.Request, responseValidList []ResponseValid) error {
+	if !request.SubRecordsSplit {
+		return app.saveRequest(request)
+	}
+	for _, valid := range responseValidList {
+		for index, response := range request.ResponseList {
+			if response.IdpId != valid.IdpID {
+				continue
+			}
+			err := app.saveRequestResponse(request, index)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if request.Summary == nil {
+		request.Summary = newRequestSummary(request)
+	}
+	return app.saveRequestSummary(request)
+}
+
+// saveDataRequestStatus saves answered AS and received data lists of data request of service
+// and count of answered AS in summary
+func (app *ABCIApplication) saveDataRequestStatus(request *data.Request, serviceID string) error {
+	if request.Summary == nil {
+		request.Summary = newRequestSummary(request)
+	}
+	for _, dataRequest := range request.DataRequestList {
+		if dataRequest.ServiceId == serviceID {
+			setSummarySignedCount(request.Summary, serviceID, int64(len(dataRequest.AnsweredAsIdList)))
+		}
+	}
+	request.Summary.Status = getRequestStatus(request, request.Summary)
+	if !request.SubRecordsSplit {
+		return app.saveRequest(request)
+	}
+	for _, dataRequest := range request.DataRequestList {
+		if dataRequest.ServiceId != serviceID {
+			continue
+		}
+		var status data.DataRequestStatus
+		status.AnsweredAsIdList = dataRequest.AnsweredAsIdList
+		status.ReceivedDataFromList = dataRequest.ReceivedDataFromList
+		value, err := utils.ProtoDeterministicMarshal(&status)
+		if err != nil {
+			return err
+		}
+		app.state.SetVersioned(getDataRequestStatusKey(request.RequestId, serviceID), value)
+	}
+	return app.saveRequestSummary(request)
+}
+
+// purgeRequest deletes request with all versions of its responses and status of data requests
+func (app *ABCIApplication) purgeRequest(request *data.Request) {
+	if request.SubRecordsSplit {
+		for index := range request.ResponseList {
+			app.state.PurgeVersioned(getRequestResponseKey(request.RequestId, index))
+		}
+		app.state.PurgeVersioned(getResponseCountKey(request.RequestId))
+		for _, dataRequest := range request.DataRequestList {
+			app.state.PurgeVersioned(getDataRequestStatusKey(request.RequestId, dataRequest.ServiceId))
+		}
+		app.state.PurgeVersioned(getRequestSummaryKey(request.RequestId))
+	}
+	app.state.PurgeVersioned(getRequestKey(request.RequestId))
+}
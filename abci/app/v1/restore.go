@@ -0,0 +1,109 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"bytes"
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// Operations of restoring key of backup to DB
+const (
+	RestoreCreate    = "create"
+	RestoreOverwrite = "overwrite"
+	RestoreConflict  = "conflict"
+)
+
+type RestoreResult struct {
+	CreatedKeyCount     int64
+	OverwrittenKeyCount int64
+	ConflictKeyCount    int64
+}
+
+// RestoreKeyChange is change of key in DB by restoring it from backup. Value is value in
+// backup and OldValue is value in DB (nil when key is created).
+type RestoreKeyChange struct {
+	Operation string
+	Key       []byte
+	Value     []byte
+	OldValue  []byte
+}
+
+// RestoreState copies every key of backupDB (which keyFilter accepts, if set) to db. Key missing
+// from db is created, key with the same value is overwritten and key with different value is
+// conflict which is overwritten only if overwriteConflicts is true, otherwise nothing is written.
+// Keys in db missing from backup are kept. With dryRun, changes are reported to fn without writing to db.
+func RestoreState(backupDB dbm.DB, db dbm.DB, dryRun bool, overwriteConflicts bool, keyFilter func(key []byte) bool, fn func(change RestoreKeyChange)) (result RestoreResult, err error) {
+	result = diffRestore(backupDB, db, keyFilter, fn)
+	if dryRun {
+		return result, nil
+	}
+	if result.ConflictKeyCount > 0 && !overwriteConflicts {
+		return result, fmt.Errorf("%d keys in DB have different value from backup, review them with dry run and restore with overwrite of conflicts", result.ConflictKeyCount)
+	}
+	batch := db.NewBatch()
+	defer batch.Close()
+	itr := backupDB.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		if keyFilter != nil && !keyFilter(itr.Key()) {
+			continue
+		}
+		batch.Set(itr.Key(), itr.Value())
+	}
+	batch.WriteSync()
+	RecomputeStateStats(db)
+	return result, nil
+}
+
+func diffRestore(backupDB dbm.DB, db dbm.DB, keyFilter func(key []byte) bool, fn func(change RestoreKeyChange)) (result RestoreResult) {
+	itr := backupDB.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		if keyFilter != nil && !keyFilter(itr.Key()) {
+			continue
+		}
+		change := RestoreKeyChange{
+			Key:      itr.Key(),
+			Value:    itr.Value(),
+			OldValue: db.Get(itr.Key()),
+		}
+		switch {
+		case change.OldValue == nil:
+			change.Operation = RestoreCreate
+			result.CreatedKeyCount++
+		case bytes.Equal(change.OldValue, change.Value):
+			change.Operation = RestoreOverwrite
+			result.OverwrittenKeyCount++
+		default:
+			change.Operation = RestoreConflict
+			result.ConflictKeyCount++
+		}
+		if fn != nil {
+			fn(change)
+		}
+	}
+	return result
+}
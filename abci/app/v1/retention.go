@@ -0,0 +1,184 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+const (
+	// dataRetentionSweepInterval is number of blocks between sweeps of expired data
+	dataRetentionSweepInterval = 100
+	// maxSweptRequestCount is max number of requests removed in one sweep
+	maxSweptRequestCount = 1000
+)
+
+// retentionKeyPrefix is list of key prefixes which retention can be set by NDID
+var retentionKeyPrefix = map[string]bool{
+	requestKeyPrefix:        true,
+	dataSignatureKeyPrefix:  true,
+	consentReceiptKeyPrefix: true,
+}
+
+func (app *ABCIApplication) getDataRetentionPolicy(committedState bool) (data.DataRetentionPolicy, error) {
+	var policy data.DataRetentionPolicy
+	value, _ := app.state.Get(dataRetentionPolicyKeyBytes, committedState)
+	if value == nil {
+		return policy, nil
+	}
+	err := proto.Unmarshal(value, &policy)
+	return policy, err
+}
+
+func (app *ABCIApplication) setDataRetentionPolicy(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetDataRetentionPolicy, Parameter: %s", param)
+	var funcParam DataRetentionPolicyParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	var policy data.DataRetentionPolicy
+	for _, rule := range funcParam.RuleList {
+		if !retentionKeyPrefix[rule.KeyPrefix] {
+			return app.ReturnDeliverTxLog(code.InvalidDataRetentionPolicy, "Retention can't be set for key prefix "+rule.KeyPrefix, "")
+		}
+		if rule.RetentionBlock <= 0 {
+			return app.ReturnDeliverTxLog(code.InvalidDataRetentionPolicy, "Retention block must be greater than zero", "")
+		}
+		for _, existingRule := range policy.RuleList {
+			if existingRule.KeyPrefix == rule.KeyPrefix {
+				return app.ReturnDeliverTxLog(code.InvalidDataRetentionPolicy, "Duplicate key prefix "+rule.KeyPrefix, "")
+			}
+		}
+		policy.RuleList = append(policy.RuleList, &data.DataRetentionRule{
+			KeyPrefix:      rule.KeyPrefix,
+			RetentionBlock: rule.RetentionBlock,
+		})
+	}
+	value, err := utils.ProtoDeterministicMarshal(&policy)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(dataRetentionPolicyKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getDataRetentionPolicyQuery(param string) types.ResponseQuery {
+	app.logger.Infof("GetDataRetentionPolicy, Parameter: %s", param)
+	policy, err := app.getDataRetentionPolicy(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result DataRetentionPolicyParam
+	result.RuleList = make([]DataRetentionRule, 0)
+	for _, rule := range policy.RuleList {
+		result.RuleList = append(result.RuleList, DataRetentionRule{
+			KeyPrefix:      rule.KeyPrefix,
+			RetentionBlock: rule.RetentionBlock,
+		})
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
+
+// sweepExpiredData removes data of closed or timed out requests older than retention
+// set by NDID. Age is counted from request creation block height. Data signatures and
+// consent receipts of request are removed together with request.
+func (app *ABCIApplication) sweepExpiredData(height int64) {
+	if height%dataRetentionSweepInterval != 0 {
+		return
+	}
+	policy, err := app.getDataRetentionPolicy(false)
+	if err != nil {
+		app.logger.Errorf("Error unmarshaling data retention policy: %s", err.Error())
+		return
+	}
+	if len(policy.RuleList) == 0 {
+		return
+	}
+	retentionBlock := make(map[string]int64)
+	for _, rule := range policy.RuleList {
+		retentionBlock[rule.KeyPrefix] = rule.RetentionBlock
+	}
+	expired := func(keyPrefix string, creationBlockHeight int64) bool {
+		retention, ok := retentionBlock[keyPrefix]
+		return ok && height-creationBlockHeight >= retention
+	}
+
+	sweptCount := 0
+	prefix := requestKeyPrefix + keySeparator
+	app.state.IterateCommitted([]byte(prefix), func(key, _ []byte) bool {
+		if !strings.HasSuffix(string(key), "|versions") {
+			return true
+		}
+		requestID := strings.TrimSuffix(strings.TrimPrefix(string(key), prefix), "|versions")
+		requestKey := []byte(prefix + requestID)
+		requestValue, _ := app.state.GetVersioned(requestKey, 0, true)
+		if requestValue == nil {
+			return true
+		}
+		var request data.Request
+		err := proto.Unmarshal(requestValue, &request)
+		if err != nil {
+			app.logger.Errorf("Error unmarshaling request %s: %s", requestID, err.Error())
+			return true
+		}
+		if !isRequestFinished(&request) {
+			return true
+		}
+		err = app.loadRequestSubRecords(&request, 0, true)
+		if err != nil {
+			app.logger.Errorf("Error unmarshaling request %s: %s", requestID, err.Error())
+			return true
+		}
+		removeRequest := expired(requestKeyPrefix, request.CreationBlockHeight)
+		if removeRequest || expired(dataSignatureKeyPrefix, request.CreationBlockHeight) {
+			for _, dataRequest := range request.DataRequestList {
+				for _, asID := range dataRequest.AnsweredAsIdList {
+					signDataKey := dataSignatureKeyPrefix + keySeparator + asID + keySeparator + dataRequest.ServiceId + keySeparator + requestID
+					app.state.Delete([]byte(signDataKey))
+				}
+			}
+		}
+		if removeRequest || expired(consentReceiptKeyPrefix, request.CreationBlockHeight) {
+			app.state.Delete([]byte(consentReceiptKeyPrefix + keySeparator + requestID))
+		}
+		if removeRequest {
+			app.purgeRequest(&request)
+			sweptCount++
+			app.logger.Infof("Removed expired request %s", requestID)
+		}
+		return sweptCount < maxSweptRequestCount
+	})
+}
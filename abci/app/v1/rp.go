@@ -24,15 +24,21 @@ package app
 
 import (
 	"encoding/json"
+	"regexp"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
 
 	"github.com/ndidplatform/smart-contract/v4/abci/code"
 	"github.com/ndidplatform/smart-contract/v4/abci/utils"
 	"github.com/ndidplatform/smart-contract/v4/protos/data"
 )
 
+// requestIDFormat is format of UUID version 4 which request ID must be in
+var requestIDFormat = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`)
+
 func (app *ABCIApplication) createRequest(param string, nodeID string) types.ResponseDeliverTx {
 	app.logger.Infof("CreateRequest, Parameter: %s", param)
 	var funcParam CreateRequestParam
@@ -45,11 +51,23 @@ func (app *ABCIApplication) createRequest(param string, nodeID string) types.Res
 	var request data.Request
 	// set request data
 	request.RequestId = funcParam.RequestID
+	if !requestIDFormat.MatchString(request.RequestId) {
+		return app.ReturnDeliverTxLog(code.InvalidRequestIDFormat, "Request ID must be UUID version 4", "")
+	}
 
 	key := requestKeyPrefix + keySeparator + request.RequestId
-	requestIDExist := app.state.HasVersioned([]byte(key), false)
-	if requestIDExist {
-		return app.ReturnDeliverTxLog(code.DuplicateRequestID, "Duplicate Request ID", "")
+	existingRequestValue, _ := app.state.GetVersioned([]byte(key), 0, false)
+	if existingRequestValue != nil {
+		// Give creation height of existing request so client can tell retry of its own request
+		var existingRequest data.Request
+		err = proto.Unmarshal(existingRequestValue, &existingRequest)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+		}
+		attributes := []cmn.KVPair{
+			{Key: []byte("creation_block_height"), Value: []byte(strconv.FormatInt(existingRequest.CreationBlockHeight, 10))},
+		}
+		return app.ReturnDeliverTxLogWithAttributes(code.DuplicateRequestID, "Duplicate Request ID", attributes)
 	}
 
 	request.MinIdp = int64(funcParam.MinIdp)
@@ -57,7 +75,15 @@ func (app *ABCIApplication) createRequest(param string, nodeID string) types.Res
 	request.MinIal = funcParam.MinIal
 	request.RequestTimeout = int64(funcParam.Timeout)
 	// request.DataRequestList = funcParam.DataRequestList
+	// Only salted hash of request message is stored, message itself is delivered via MQ
+	if funcParam.MessageHash == "" {
+		return app.ReturnDeliverTxLog(code.RequestMessageHashCannotBeEmpty, "Please input request message hash", "")
+	}
 	request.RequestMessageHash = funcParam.MessageHash
+	if len(funcParam.MessageSalt) > maxRequestSaltLength {
+		return app.ReturnDeliverTxLog(code.InvalidRequestSalt, "Request message salt is too long", "")
+	}
+	request.RequestMessageSalt = funcParam.MessageSalt
 	request.Mode = funcParam.Mode
 	// Check valid mode
 	allowedMode := app.GetAllowedModeFromStateDB(funcParam.Purpose, false)
@@ -72,16 +98,40 @@ func (app *ABCIApplication) createRequest(param string, nodeID string) types.Res
 		return app.ReturnDeliverTxLog(code.InvalidMode, "Must be create request on valid mode", "")
 	}
 	request.IdpIdList = funcParam.IdPIDList
+	if funcParam.IdentityTarget != nil {
+		if request.Mode == 1 {
+			return app.ReturnDeliverTxLog(code.IdentityTargetIsNotAllowedInMode1, "Identity target is not allowed in mode 1", "")
+		}
+		if len(funcParam.IdPIDList) > 0 {
+			return app.ReturnDeliverTxLog(code.FoundIdPIDListAndIdentityTarget, "Found IdP ID list and identity target in parameter", "")
+		}
+		idpIDList, errCode, errLog := app.resolveIdPIDListForIdentity(funcParam.IdentityTarget, &request)
+		if errCode != code.OK {
+			return app.ReturnDeliverTxLog(errCode, errLog, "")
+		}
+		request.IdpIdList = idpIDList
+	}
+	// Check size of lists against limit set by NDID before checking each of their items
+	listSizeLimit, err := app.getRequestListSizeLimitFromStateDB(false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if listSizeLimit.MaxDataRequestCount > 0 && int64(len(funcParam.DataRequestList)) > listSizeLimit.MaxDataRequestCount {
+		return app.ReturnDeliverTxLog(code.TooManyDataRequestsInRequest, "Number of services in data request list is greater than max data request count", "")
+	}
+	if listSizeLimit.MaxIdpCount > 0 && int64(len(request.IdpIdList)) > listSizeLimit.MaxIdpCount {
+		return app.ReturnDeliverTxLog(code.TooManyIdPsInRequest, "Number of IdPs in IdP ID list is greater than max IdP count", "")
+	}
 	// Check all IdP in list is active
 	for _, idp := range request.IdpIdList {
 		// Get node detail
 		nodeDetailKey := nodeIDKeyPrefix + keySeparator + idp
 		nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), false)
-		if nodeDetaiValue == nil {
+		if nodeDetailValue == nil {
 			return app.ReturnDeliverTxLog(code.NodeIDNotFound, "Node ID not found", "")
 		}
 		var node data.NodeDetail
-		err = proto.Unmarshal([]byte(nodeDetaiValue), &node)
+		err = proto.Unmarshal([]byte(nodeDetailValue), &node)
 		if err != nil {
 			return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 		}
@@ -89,6 +139,10 @@ func (app *ABCIApplication) createRequest(param string, nodeID string) types.Res
 		if !node.Active {
 			return app.ReturnDeliverTxLog(code.NodeIDInIdPListIsNotActive, "Node ID in IdP list is not active", "")
 		}
+		// Check IdP can respond with IAL and AAL required by request
+		if node.MaxIal < request.MinIal || node.MaxAal < request.MinAal {
+			return app.ReturnDeliverTxLog(code.IdPMaxIalAalIsLessThanRequestMinIalAal, "Max IAL or AAL of node ID in IdP list is less than min IAL or AAL of request", "")
+		}
 
 		// If node is behind proxy
 		if node.ProxyNodeId != "" {
@@ -126,6 +180,10 @@ func (app *ABCIApplication) createRequest(param string, nodeID string) types.Res
 		serviceIDInDataRequestList[newRow.ServiceId]++
 
 		newRow.RequestParamsHash = funcParam.DataRequestList[index].RequestParamsHash
+		if len(funcParam.DataRequestList[index].RequestParamsSalt) > maxRequestSaltLength {
+			return app.ReturnDeliverTxLog(code.InvalidRequestSalt, "Request params salt is too long", "")
+		}
+		newRow.RequestParamsSalt = funcParam.DataRequestList[index].RequestParamsSalt
 		newRow.MinAs = int64(funcParam.DataRequestList[index].Count)
 		newRow.AsIdList = funcParam.DataRequestList[index].As
 		if funcParam.DataRequestList[index].As == nil {
@@ -139,11 +197,11 @@ func (app *ABCIApplication) createRequest(param string, nodeID string) types.Res
 			if nodeDetailMap[as] == nil {
 				// Get node detail
 				nodeDetailKey := nodeIDKeyPrefix + keySeparator + as
-				nodeDetaiValue, _ := app.state.Get([]byte(nodeDetailKey), false)
-				if nodeDetaiValue == nil {
+				nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), false)
+				if nodeDetailValue == nil {
 					return app.ReturnDeliverTxLog(code.NodeIDNotFound, "Node ID not found", "")
 				}
-				err = proto.Unmarshal([]byte(nodeDetaiValue), &node)
+				err = proto.Unmarshal([]byte(nodeDetailValue), &node)
 				if err != nil {
 					return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 				}
@@ -189,22 +247,63 @@ func (app *ABCIApplication) createRequest(param string, nodeID string) types.Res
 	// set Owner
 	request.Owner = nodeID
 	// set Can add accossor
-	ownerRole := app.getRoleFromNodeID(nodeID)
-	if string(ownerRole) == "IdP" {
+	if app.nodeHasRole(nodeID, "IdP") {
 		request.Purpose = funcParam.Purpose
 	}
+	// Request with purpose is used by identity operations which require
+	// response valid list given when requester closes request
+	if funcParam.AutoClose && request.Purpose != "" {
+		return app.ReturnDeliverTxLog(code.AutoCloseIsNotAllowedForRequestWithPurpose, "Auto close is not allowed for request with purpose", "")
+	}
+	request.AutoClose = funcParam.AutoClose
+	// IdP response deadline must be within request timeout
+	if funcParam.IdPResponseTimeout < 0 ||
+		(funcParam.IdPResponseTimeout > 0 && funcParam.IdPResponseTimeout > request.RequestTimeout) {
+		return app.ReturnDeliverTxLog(code.InvalidIdPResponseTimeout, "IdP response timeout must not be negative or greater than request timeout", "")
+	}
+	request.IdpResponseTimeout = funcParam.IdPResponseTimeout
+	errCode, errLog := app.checkRequestPriorityClass(nodeID, funcParam.PriorityClass)
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
+	}
+	request.PriorityClass = funcParam.PriorityClass
 	// set default value
 	request.ResponseList = make([]*data.Response, 0)
+	request.SubRecordsSplit = true
+	request.Summary = newRequestSummary(&request)
 	// set creation_block_height
 	request.CreationBlockHeight = app.state.CurrentBlockHeight
+	request.CreationBlockTime = app.CurrentBlockTime.Unix()
 	// set chain_id
 	request.ChainId = app.CurrentChain
+	// hold escrow for expected responses
+	errCode, errLog = app.holdRequestEscrow(&request)
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
+	}
 
-	value, err := utils.ProtoDeterministicMarshal(&request)
+	err = app.saveRequest(&request)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
-	app.state.SetVersioned([]byte(key), []byte(value))
+	err = app.saveRequestSummary(&request)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.changeOpenRequestCount(&request, 1)
+	// Identity management requests are not counted in usage statistics
+	if request.Purpose == "" {
+		err = app.increaseStatistics("CreateRequest", "")
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+		}
+	}
+	for _, dataRequest := range request.DataRequestList {
+		err = app.increaseServiceUsage(dataRequest.ServiceId, "")
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+		}
+	}
 	return app.ReturnDeliverTxLog(code.OK, "success", request.RequestId)
 }
 
@@ -225,38 +324,40 @@ func (app *ABCIApplication) closeRequest(param string, nodeID string) types.Resp
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
-	if request.Closed {
-		return app.ReturnDeliverTxLog(code.RequestIsClosed, "Can not close a closed request", "")
+	err = app.loadRequestSubRecords(&request, 0, false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
-	if request.TimedOut {
-		return app.ReturnDeliverTxLog(code.RequestIsTimedOut, "Can not close a timed out request", "")
+	errCode, errLog := checkRequestFlowTransition(&request, "CloseRequest")
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
 	}
-	for _, valid := range funcParam.ResponseValidList {
-		for index := range request.ResponseList {
-			if valid.IdpID == request.ResponseList[index].IdpId {
-				if valid.ValidIal != nil {
-					if *valid.ValidIal {
-						request.ResponseList[index].ValidIal = "true"
-					} else {
-						request.ResponseList[index].ValidIal = "false"
-					}
-				}
-				if valid.ValidSignature != nil {
-					if *valid.ValidSignature {
-						request.ResponseList[index].ValidSignature = "true"
-					} else {
-						request.ResponseList[index].ValidSignature = "false"
-					}
-				}
-			}
-		}
+	errCode, errLog = setResponseValidList(&request, funcParam.ResponseValidList)
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
 	}
 	request.Closed = true
-	value, err = utils.ProtoDeterministicMarshal(&request)
+	err = app.settleRequestEscrow(&request)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.TokenAccountNotFound, err.Error(), "")
+	}
+	app.changeOpenRequestCount(&request, -1)
+	err = app.saveFinishedRequest(&request)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
-	app.state.SetVersioned([]byte(key), []byte(value))
+	err = app.saveResponseValidList(&request, funcParam.ResponseValidList)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	// Identity management requests are not counted in usage statistics
+	if request.Purpose == "" {
+		err = app.increaseStatistics("CloseRequest", "")
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+		}
+	}
+	app.emitRequestStatusEvent(&request, requestStatusClosed, nodeID, "")
 	return app.ReturnDeliverTxLog(code.OK, "success", funcParam.RequestID)
 }
 
@@ -277,38 +378,40 @@ func (app *ABCIApplication) timeOutRequest(param string, nodeID string) types.Re
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
-	if request.TimedOut {
-		return app.ReturnDeliverTxLog(code.RequestIsTimedOut, "Can not set time out a timed out request", "")
+	err = app.loadRequestSubRecords(&request, 0, false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
-	if request.Closed {
-		return app.ReturnDeliverTxLog(code.RequestIsClosed, "Can not set time out a closed request", "")
+	errCode, errLog := checkRequestFlowTransition(&request, "TimeOutRequest")
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
 	}
-	for _, valid := range funcParam.ResponseValidList {
-		for index := range request.ResponseList {
-			if valid.IdpID == request.ResponseList[index].IdpId {
-				if valid.ValidIal != nil {
-					if *valid.ValidIal {
-						request.ResponseList[index].ValidIal = "true"
-					} else {
-						request.ResponseList[index].ValidIal = "false"
-					}
-				}
-				if valid.ValidSignature != nil {
-					if *valid.ValidSignature {
-						request.ResponseList[index].ValidSignature = "true"
-					} else {
-						request.ResponseList[index].ValidSignature = "false"
-					}
-				}
-			}
-		}
+	errCode, errLog = setResponseValidList(&request, funcParam.ResponseValidList)
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
 	}
 	request.TimedOut = true
-	value, err = utils.ProtoDeterministicMarshal(&request)
+	err = app.settleRequestEscrow(&request)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.TokenAccountNotFound, err.Error(), "")
+	}
+	app.changeOpenRequestCount(&request, -1)
+	err = app.saveFinishedRequest(&request)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
 	}
-	app.state.SetVersioned([]byte(key), []byte(value))
+	err = app.saveResponseValidList(&request, funcParam.ResponseValidList)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	// Identity management requests are not counted in usage statistics
+	if request.Purpose == "" {
+		err = app.increaseStatistics("TimeOutRequest", "")
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+		}
+	}
+	app.emitRequestStatusEvent(&request, requestStatusTimedOut, nodeID, "")
 	return app.ReturnDeliverTxLog(code.OK, "success", funcParam.RequestID)
 }
 
@@ -329,15 +432,16 @@ func (app *ABCIApplication) setDataReceived(param string, nodeID string) types.R
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
-
-	// Check IsClosed
-	if request.Closed {
-		return app.ReturnDeliverTxLog(code.RequestIsClosed, "Request is closed", "")
+	err = app.loadRequestSubRecords(&request, 0, false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
 	}
 
-	// Check IsTimedOut
-	if request.TimedOut {
-		return app.ReturnDeliverTxLog(code.RequestIsTimedOut, "Request is timed out", "")
+	// Check request flow state
+	// Auto closed request is closed when last AS signed data, RP can still set data received from it
+	errCode, errLog := checkRequestFlowTransition(&request, "SetDataReceived")
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
 	}
 
 	// Check as_id is exist in as_id_list
@@ -376,6 +480,288 @@ func (app *ABCIApplication) setDataReceived(param string, nodeID string) types.R
 			request.DataRequestList[index].ReceivedDataFromList = append(dataRequest.ReceivedDataFromList, funcParam.AsID)
 		}
 	}
+	err = app.saveDataRequestStatus(&request, funcParam.ServiceID)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	return app.ReturnDeliverTxLog(code.OK, "success", funcParam.RequestID)
+}
+
+func (app *ABCIApplication) anchorConsentReceipt(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("AnchorConsentReceipt, Parameter: %s", param)
+	var funcParam AnchorConsentReceiptParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.ConsentReceiptHash == "" {
+		return app.ReturnDeliverTxLog(code.ConsentReceiptHashCannotBeEmpty, "Consent receipt hash cannot be empty", "")
+	}
+	key := requestKeyPrefix + keySeparator + funcParam.RequestID
+	value, _ := app.state.GetVersioned([]byte(key), 0, false)
+	if value == nil {
+		return app.ReturnDeliverTxLog(code.RequestIDNotFound, "Request ID not found", "")
+	}
+	var request data.Request
+	err = proto.Unmarshal([]byte(value), &request)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	err = app.loadRequestSubRecords(&request, 0, false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+
+	// Only owner of request or IdP which has responded to request can anchor consent receipt
+	participant := request.Owner == nodeID
+	for _, response := range request.ResponseList {
+		if response.IdpId == nodeID {
+			participant = true
+			break
+		}
+	}
+	if !participant {
+		return app.ReturnDeliverTxLog(code.NotRequestOwnerOrRespondedIdP, "This node is not owner of request or IdP which has responded to request", "")
+	}
+
+	consentReceiptKey := consentReceiptKeyPrefix + keySeparator + funcParam.RequestID
+	consentReceiptValue, _ := app.state.Get([]byte(consentReceiptKey), false)
+	var consentReceipts data.ConsentReceiptList
+	if consentReceiptValue != nil {
+		err = proto.Unmarshal(consentReceiptValue, &consentReceipts)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+		}
+	}
+	var consentReceipt data.ConsentReceipt
+	consentReceipt.NodeId = nodeID
+	consentReceipt.ConsentReceiptHash = funcParam.ConsentReceiptHash
+	consentReceipt.BlockHeight = app.state.CurrentBlockHeight
+	consentReceipts.ConsentReceipts = append(consentReceipts.ConsentReceipts, &consentReceipt)
+	consentReceiptValue, err = utils.ProtoDeterministicMarshal(&consentReceipts)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set([]byte(consentReceiptKey), consentReceiptValue)
+	return app.ReturnDeliverTxLog(code.OK, "success", funcParam.RequestID)
+}
+
+// setResponseValidList sets validity of IdP responses in request given by requester.
+// Every IdP in response valid list must have responded to request and must not be listed twice.
+func setResponseValidList(request *data.Request, responseValidList []ResponseValid) (uint32, string) {
+	listedIdP := make(map[string]bool)
+	for _, valid := range responseValidList {
+		if listedIdP[valid.IdpID] {
+			return code.DuplicateIdPInResponseValidList, "Duplicate IdP ID in response valid list"
+		}
+		listedIdP[valid.IdpID] = true
+		responded := false
+		for index := range request.ResponseList {
+			if valid.IdpID == request.ResponseList[index].IdpId {
+				responded = true
+			}
+		}
+		if !responded {
+			return code.IdPInResponseValidListHasNotResponded, "IdP in response valid list has not responded to this request"
+		}
+	}
+	for _, valid := range responseValidList {
+		for index := range request.ResponseList {
+			if valid.IdpID == request.ResponseList[index].IdpId {
+				if valid.ValidIal != nil {
+					if *valid.ValidIal {
+						request.ResponseList[index].ValidIal = "true"
+					} else {
+						request.ResponseList[index].ValidIal = "false"
+					}
+				}
+				if valid.ValidSignature != nil {
+					if *valid.ValidSignature {
+						request.ResponseList[index].ValidSignature = "true"
+					} else {
+						request.ResponseList[index].ValidSignature = "false"
+					}
+				}
+			}
+		}
+	}
+	return code.OK, ""
+}
+
+// closeRequestIfCompleted closes request created with auto close when it has
+// at least min_idp accepted responses and every data request has at least min_as answered AS
+func (app *ABCIApplication) closeRequestIfCompleted(request *data.Request) error {
+	if !request.AutoClose {
+		return nil
+	}
+	if errCode, _ := checkRequestFlowTransition(request, requestFlowAutoClose); errCode != code.OK {
+		return nil
+	}
+	summary := getRequestSummary(request)
+	if summary.AcceptCount < request.MinIdp {
+		return nil
+	}
+	for _, dataRequest := range request.DataRequestList {
+		if getSummarySignedCount(summary, dataRequest.ServiceId) < dataRequest.MinAs {
+			return nil
+		}
+	}
+	app.logger.Infof("Auto close request: %s", request.RequestId)
+	request.Closed = true
+	err := app.settleRequestEscrow(request)
+	if err != nil {
+		return err
+	}
+	app.changeOpenRequestCount(request, -1)
+	err = app.saveFinishedRequest(request)
+	if err != nil {
+		return err
+	}
+	app.emitRequestStatusEvent(request, requestStatusClosed, "", "")
+	return app.increaseStatistics("CloseRequest", "")
+}
+
+// resolveIdPIDListForIdentity returns active IdPs associated with the identity in target
+// which have IAL not less than min IAL of target and support mode of request and which can
+// respond with IAL and AAL required by request (active node, not behind inactive proxy)
+func (app *ABCIApplication) resolveIdPIDListForIdentity(target *RequestIdentityTarget, request *data.Request) ([]string, uint32, string) {
+	identityToRefCodeKey := identityToRefCodeKeyPrefix + keySeparator + target.IdentityNamespace + keySeparator + target.IdentityIdentifierHash
+	refGroupCode, _ := app.state.Get([]byte(identityToRefCodeKey), false)
+	if refGroupCode == nil {
+		return nil, code.RefGroupNotFound, "Reference group not found"
+	}
+	refGroupKey := refGroupCodeKeyPrefix + keySeparator + string(refGroupCode)
+	refGroupValue, _ := app.state.Get([]byte(refGroupKey), false)
+	if refGroupValue == nil {
+		return nil, code.RefGroupNotFound, "Reference group not found"
+	}
+	var refGroup data.ReferenceGroup
+	err := proto.Unmarshal(refGroupValue, &refGroup)
+	if err != nil {
+		return nil, code.UnmarshalError, err.Error()
+	}
+	idpIDList := make([]string, 0)
+	for _, idp := range refGroup.Idps {
+		if !idp.Active || idp.Ial < target.MinIal || idp.Ial < request.MinIal {
+			continue
+		}
+		supportedMode := false
+		for _, mode := range idp.Mode {
+			if mode == request.Mode {
+				supportedMode = true
+				break
+			}
+		}
+		if !supportedMode {
+			continue
+		}
+		nodeDetailValue, _ := app.state.Get([]byte(nodeIDKeyPrefix+keySeparator+idp.NodeId), false)
+		if nodeDetailValue == nil {
+			continue
+		}
+		var node data.NodeDetail
+		err = proto.Unmarshal(nodeDetailValue, &node)
+		if err != nil {
+			return nil, code.UnmarshalError, err.Error()
+		}
+		if !node.Active || node.MaxIal < request.MinIal || node.MaxAal < request.MinAal {
+			continue
+		}
+		if node.ProxyNodeId != "" && !app.getActiveStatusByNodeID(node.ProxyNodeId, false) {
+			continue
+		}
+		idpIDList = append(idpIDList, idp.NodeId)
+	}
+	if len(idpIDList) == 0 {
+		return nil, code.NoIdPFoundForIdentityTarget, "No IdP found for identity target"
+	}
+	// IdPs are in order of association with the identity unless NDID enables pseudo-random order
+	randomOrder, err := app.isRandomnessBeaconEnabled(false)
+	if err != nil {
+		return nil, code.UnmarshalError, err.Error()
+	}
+	if randomOrder {
+		app.deterministicShuffle("CreateRequest|"+app.txLabel()+"|"+request.RequestId, idpIDList)
+	}
+	return idpIDList, code.OK, ""
+}
+
+func (app *ABCIApplication) getMaxRequestTimeoutExtensionFromStateDB(committedState bool) (int64, error) {
+	value, _ := app.state.Get(maxRequestTimeoutExtensionKeyBytes, committedState)
+	if value == nil {
+		return 0, nil
+	}
+	var maxExtension data.MaxRequestTimeoutExtension
+	err := proto.Unmarshal(value, &maxExtension)
+	if err != nil {
+		return 0, err
+	}
+	return maxExtension.MaxExtension, nil
+}
+
+func (app *ABCIApplication) getRequestListSizeLimitFromStateDB(committedState bool) (data.RequestListSizeLimit, error) {
+	var limit data.RequestListSizeLimit
+	value, _ := app.state.Get(requestListSizeLimitKeyBytes, committedState)
+	if value == nil {
+		return limit, nil
+	}
+	err := proto.Unmarshal(value, &limit)
+	return limit, err
+}
+
+func (app *ABCIApplication) getRequestListSizeLimit(param string) types.ResponseQuery {
+	app.logger.Infof("GetRequestListSizeLimit, Parameter: %s", param)
+	limit, err := app.getRequestListSizeLimitFromStateDB(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result RequestListSizeLimitParam
+	result.MaxDataRequestCount = limit.MaxDataRequestCount
+	result.MaxIdPCount = limit.MaxIdpCount
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
+
+// extendRequestTimeout extends timeout of open request by requester.
+// Timeout of request can be extended only once and not more than max extension set by NDID.
+func (app *ABCIApplication) extendRequestTimeout(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("ExtendRequestTimeout, Parameter: %s", param)
+	var funcParam ExtendRequestTimeoutParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	key := requestKeyPrefix + keySeparator + funcParam.RequestID
+	value, _ := app.state.GetVersioned([]byte(key), 0, false)
+	if value == nil {
+		return app.ReturnDeliverTxLog(code.RequestIDNotFound, "Request ID not found", "")
+	}
+	var request data.Request
+	err = proto.Unmarshal([]byte(value), &request)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	errCode, errLog := checkRequestFlowTransition(&request, "ExtendRequestTimeout")
+	if errCode != code.OK {
+		return app.ReturnDeliverTxLog(errCode, errLog, "")
+	}
+	if request.TimeoutExtension > 0 {
+		return app.ReturnDeliverTxLog(code.RequestTimeoutIsAlreadyExtended, "Request timeout is already extended", "")
+	}
+	maxExtension, err := app.getMaxRequestTimeoutExtensionFromStateDB(false)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.Extension <= 0 || funcParam.Extension > maxExtension {
+		return app.ReturnDeliverTxLog(code.InvalidRequestTimeoutExtension, "Extension must be greater than 0 and not greater than max request timeout extension", "")
+	}
+	request.RequestTimeout = request.RequestTimeout + funcParam.Extension
+	request.TimeoutExtension = funcParam.Extension
+	request.TimeoutExtensionBlockHeight = app.state.CurrentBlockHeight
+	app.setRequestLastUpdate(&request)
 	value, err = utils.ProtoDeterministicMarshal(&request)
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
@@ -383,3 +769,18 @@ func (app *ABCIApplication) setDataReceived(param string, nodeID string) types.R
 	app.state.SetVersioned([]byte(key), []byte(value))
 	return app.ReturnDeliverTxLog(code.OK, "success", funcParam.RequestID)
 }
+
+func (app *ABCIApplication) getMaxRequestTimeoutExtension(param string) types.ResponseQuery {
+	app.logger.Infof("GetMaxRequestTimeoutExtension, Parameter: %s", param)
+	maxExtension, err := app.getMaxRequestTimeoutExtensionFromStateDB(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result MaxRequestTimeoutExtensionParam
+	result.MaxExtension = maxExtension
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
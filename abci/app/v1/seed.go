@@ -0,0 +1,121 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// SeedNodeKey is test keys which replace keys of a node when seeding state
+type SeedNodeKey struct {
+	PublicKey       string `json:"public_key"`
+	MasterPublicKey string `json:"master_public_key"`
+}
+
+type SeedResult struct {
+	CopiedKeyCount   int64
+	ScrubbedKeyCount int64
+	RemappedKeyCount int64
+	DroppedKeyCount  int64
+}
+
+// SeedState copies app state from srcDB (e.g. production backup) to empty dstDB for use in
+// another environment. MQ addresses of nodes are removed, node keys are replaced with keys
+// in nodeKeyMap (by node ID) and validators and change journal of source chain are dropped.
+// Metadata is reset to height 0 so that the state can be used by a new chain.
+func SeedState(srcDB dbm.DB, dstDB dbm.DB, nodeKeyMap map[string]SeedNodeKey) (result SeedResult, err error) {
+	dstItr := dstDB.Iterator(nil, nil)
+	dstEmpty := !dstItr.Valid()
+	dstItr.Close()
+	if !dstEmpty {
+		return result, fmt.Errorf("Destination DB is not empty")
+	}
+	nodeIDPrefix := nodeIDKeyPrefix + keySeparator
+	nodeKeyPrefix := nodeKeyKeyPrefix + keySeparator
+	batch := dstDB.NewBatch()
+	defer batch.Close()
+	itr := srcDB.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		key := string(itr.Key())
+		value := itr.Value()
+		switch {
+		case key == string(appStateMetadataKey),
+			strings.HasPrefix(key, ValidatorSetChangePrefix),
+			strings.HasPrefix(key, changeJournalKeyPrefix+keySeparator):
+			result.DroppedKeyCount++
+			continue
+		case strings.HasPrefix(key, nodeIDPrefix):
+			var nodeDetail data.NodeDetail
+			err = proto.Unmarshal(value, &nodeDetail)
+			if err != nil {
+				return result, fmt.Errorf("Error unmarshaling node detail %s: %v", key, err)
+			}
+			nodeDetail.Mq = nil
+			nodeKey, remap := nodeKeyMap[strings.TrimPrefix(key, nodeIDPrefix)]
+			if remap {
+				nodeDetail.PublicKey = nodeKey.PublicKey
+				nodeDetail.MasterPublicKey = nodeKey.MasterPublicKey
+				result.RemappedKeyCount++
+			}
+			value, err = utils.ProtoDeterministicMarshal(&nodeDetail)
+			if err != nil {
+				return result, err
+			}
+			result.ScrubbedKeyCount++
+		case strings.HasPrefix(key, nodeKeyPrefix) && !strings.HasSuffix(key, "|versions"):
+			// Versioned key history of node, key is NodeKey|<node ID>|<height>
+			nodeID := strings.TrimPrefix(key, nodeKeyPrefix)
+			if index := strings.LastIndex(nodeID, keySeparator); index >= 0 {
+				nodeID = nodeID[:index]
+			}
+			seedNodeKey, remap := nodeKeyMap[nodeID]
+			if remap {
+				var nodeKey data.NodeKey
+				err = proto.Unmarshal(value, &nodeKey)
+				if err != nil {
+					return result, fmt.Errorf("Error unmarshaling node key %s: %v", key, err)
+				}
+				nodeKey.PublicKey = seedNodeKey.PublicKey
+				nodeKey.MasterPublicKey = seedNodeKey.MasterPublicKey
+				value, err = utils.ProtoDeterministicMarshal(&nodeKey)
+				if err != nil {
+					return result, err
+				}
+				result.RemappedKeyCount++
+			}
+		}
+		batch.Set([]byte(key), value)
+		result.CopiedKeyCount++
+	}
+	batch.WriteSync()
+	RecomputeStateStats(dstDB)
+	return result, nil
+}
@@ -0,0 +1,128 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// isResponseValid returns false when signature or IAL of response is checked and found invalid
+func isResponseValid(response *data.Response) bool {
+	return response.ValidSignature != "false" && response.ValidIal != "false"
+}
+
+// getRequestSettlement attributes escrow price of request to each responding IdP and answering AS.
+// Only valid IdP responses (up to min_idp) and AS answers (up to min_as of each service) are priced.
+func getRequestSettlement(request *data.Request, blockHeight int64) *data.RequestSettlement {
+	var settlement data.RequestSettlement
+	settlement.Owner = request.Owner
+	settlement.BlockHeight = blockHeight
+	settlement.EntryList = make([]*data.SettlementEntry, 0)
+	var pricedIdp int64
+	for _, response := range request.ResponseList {
+		var entry data.SettlementEntry
+		entry.NodeId = response.IdpId
+		entry.Role = "IdP"
+		entry.Valid = isResponseValid(response)
+		if entry.Valid && pricedIdp < request.MinIdp {
+			entry.Amount = request.EscrowIdpResponsePrice
+			pricedIdp++
+		}
+		settlement.Total += entry.Amount
+		settlement.EntryList = append(settlement.EntryList, &entry)
+	}
+	for _, dataRequest := range request.DataRequestList {
+		for index, asID := range dataRequest.AnsweredAsIdList {
+			var entry data.SettlementEntry
+			entry.NodeId = asID
+			entry.Role = "AS"
+			entry.ServiceId = dataRequest.ServiceId
+			entry.Valid = true
+			if int64(index) < dataRequest.MinAs {
+				entry.Amount = request.EscrowAsDataPrice
+			}
+			settlement.Total += entry.Amount
+			settlement.EntryList = append(settlement.EntryList, &entry)
+		}
+	}
+	return &settlement
+}
+
+// saveRequestSettlement records fee attribution of closed or timed out request
+// as basis for billing between members. Identity management requests are not recorded.
+func (app *ABCIApplication) saveRequestSettlement(request *data.Request) error {
+	if request.Purpose != "" {
+		return nil
+	}
+	value, err := utils.ProtoDeterministicMarshal(getRequestSettlement(request, app.state.CurrentBlockHeight))
+	if err != nil {
+		return err
+	}
+	app.state.Set([]byte(requestSettlementKeyPrefix+keySeparator+request.RequestId), value)
+	return nil
+}
+
+func (app *ABCIApplication) getRequestSettlementQuery(param string) types.ResponseQuery {
+	app.logger.Infof("GetRequestSettlement, Parameter: %s", param)
+	var funcParam GetRequestSettlementParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	value, _ := app.state.Get([]byte(requestSettlementKeyPrefix+keySeparator+funcParam.RequestID), true)
+	if value == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var settlement data.RequestSettlement
+	err = proto.Unmarshal(value, &settlement)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result GetRequestSettlementResult
+	result.RequestID = funcParam.RequestID
+	result.Owner = settlement.Owner
+	result.BlockHeight = settlement.BlockHeight
+	result.Total = settlement.Total
+	result.EntryList = make([]SettlementEntry, 0, len(settlement.EntryList))
+	for _, entry := range settlement.EntryList {
+		result.EntryList = append(result.EntryList, SettlementEntry{
+			NodeID:    entry.NodeId,
+			Role:      entry.Role,
+			ServiceID: entry.ServiceId,
+			Amount:    entry.Amount,
+			Valid:     entry.Valid,
+		})
+	}
+	value, err = json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
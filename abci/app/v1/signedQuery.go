@@ -0,0 +1,216 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// maxUsedQueryNonceCount is max number of used signed query nonces kept in memory.
+// Oldest nonce is dropped when it is full.
+const maxUsedQueryNonceCount = 100000
+
+// setQueryVisibility restricts query method to signed queries from nodes with role in allowed
+// role list (any node if list is empty) or makes it public again. NDID is always allowed.
+func (app *ABCIApplication) setQueryVisibility(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetQueryVisibility, Parameter: %s", param)
+	var funcParam SetQueryVisibilityParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.Method == "" || funcParam.Method == "SignedQuery" {
+		return app.ReturnDeliverTxLog(code.UnknownMethod, "Invalid query method", "")
+	}
+	key := queryVisibilityKeyPrefix + keySeparator + funcParam.Method
+	if funcParam.Public {
+		app.state.Delete([]byte(key))
+		return app.ReturnDeliverTxLog(code.OK, "success", "")
+	}
+	var queryVisibility data.QueryVisibility
+	queryVisibility.AllowedRoleList = funcParam.AllowedRoleList
+	value, err := utils.ProtoDeterministicMarshal(&queryVisibility)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set([]byte(key), value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+// ndidOnlyQueryMethods are query methods which only NDID can call (in SignedQuery)
+// regardless of query visibility set by NDID
+var ndidOnlyQueryMethods = map[string]bool{
+	"GetRequestSettlement": true,
+	"GetNodeContactList":   true,
+}
+
+// checkQueryVisibility checks that query method is public or caller node (verified by
+// signed query, empty for unsigned query) is allowed to call it
+func (app *ABCIApplication) checkQueryVisibility(method string, callerNodeID string) (errorCode uint32, errorLog string) {
+	if ndidOnlyQueryMethods[method] {
+		if callerNodeID == "" {
+			return code.QueryIsNotAllowed, "Query must be signed by NDID"
+		}
+		nodeDetail, errCode, errLog := app.getQueryCallerNodeDetail(callerNodeID)
+		if errCode != code.OK {
+			return errCode, errLog
+		}
+		if nodeDetail.Role != "NDID" {
+			return code.QueryIsNotAllowed, "Query is not allowed for role of node"
+		}
+		return code.OK, ""
+	}
+	value, _ := app.state.Get([]byte(queryVisibilityKeyPrefix+keySeparator+method), true)
+	if value == nil {
+		return code.OK, ""
+	}
+	if callerNodeID == "" {
+		return code.QueryIsNotAllowed, "Query must be signed by allowed node"
+	}
+	var queryVisibility data.QueryVisibility
+	err := proto.Unmarshal(value, &queryVisibility)
+	if err != nil {
+		return code.UnmarshalError, err.Error()
+	}
+	nodeDetail, errCode, errLog := app.getQueryCallerNodeDetail(callerNodeID)
+	if errCode != code.OK {
+		return errCode, errLog
+	}
+	if len(queryVisibility.AllowedRoleList) == 0 || nodeDetail.Role == "NDID" {
+		return code.OK, ""
+	}
+	for _, role := range queryVisibility.AllowedRoleList {
+		if strings.EqualFold(role, nodeDetail.Role) {
+			return code.OK, ""
+		}
+		for _, additionalRole := range nodeDetail.AdditionalRoleList {
+			if strings.EqualFold(role, additionalRole) {
+				return code.OK, ""
+			}
+		}
+	}
+	return code.QueryIsNotAllowed, "Query is not allowed for role of node"
+}
+
+// getQueryCallerNodeDetail returns committed node detail of caller of signed query
+func (app *ABCIApplication) getQueryCallerNodeDetail(callerNodeID string) (*data.NodeDetail, uint32, string) {
+	nodeDetailValue, _ := app.state.Get([]byte(nodeIDKeyPrefix+keySeparator+callerNodeID), true)
+	if nodeDetailValue == nil {
+		return nil, code.NodeIDNotFound, "Node ID not found"
+	}
+	var nodeDetail data.NodeDetail
+	err := proto.Unmarshal(nodeDetailValue, &nodeDetail)
+	if err != nil {
+		return nil, code.UnmarshalError, err.Error()
+	}
+	return &nodeDetail, code.OK, ""
+}
+
+// signedQuery verifies signature of node over inner query (signed the same way as Tx)
+// and runs inner query with node as caller. Nonce of signed query can be used only once.
+func (app *ABCIApplication) signedQuery(param string, height int64) types.ResponseQuery {
+	app.logger.Infof("SignedQuery, Parameter: %s", param)
+	var funcParam SignedQueryParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if funcParam.Method == "SignedQuery" {
+		return app.ReturnQueryWithCode(code.UnknownMethod, nil, "SignedQuery can't be nested", app.state.Height)
+	}
+	err = utils.ValidateNonce(funcParam.Nonce)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	publicKey := app.getPublicKeyFromNodeID(funcParam.NodeID, true)
+	if publicKey == "" || !app.getActiveStatusByNodeID(funcParam.NodeID, true) {
+		return app.ReturnQueryWithCode(code.InvalidQuerySignature, nil, "Node ID not found or not active", app.state.Height)
+	}
+	verified, err := verifySignature(funcParam.Params, []byte(funcParam.Nonce), funcParam.Signature, publicKey, funcParam.Method)
+	if err != nil || !verified {
+		return app.ReturnQueryWithCode(code.InvalidQuerySignature, nil, "Invalid query signature", app.state.Height)
+	}
+	nonceKey := funcParam.NodeID + keySeparator + funcParam.Nonce
+	if app.usedQueryNonces[nonceKey] {
+		return app.ReturnQueryWithCode(code.DuplicateNonce, nil, "Duplicate nonce", app.state.Height)
+	}
+	app.addUsedQueryNonce(nonceKey)
+	errCode, errLog := app.checkQueryVisibility(funcParam.Method, funcParam.NodeID)
+	if errCode != code.OK {
+		return app.ReturnQueryWithCode(errCode, nil, errLog, app.state.Height)
+	}
+	return app.callQuery(funcParam.Method, funcParam.Params, height)
+}
+
+// addUsedQueryNonce keeps nonce of signed query in ring of max size
+// so that only the oldest nonce is dropped when it is full
+func (app *ABCIApplication) addUsedQueryNonce(nonceKey string) {
+	if len(app.usedQueryNonceList) < maxUsedQueryNonceCount {
+		app.usedQueryNonceList = append(app.usedQueryNonceList, nonceKey)
+	} else {
+		delete(app.usedQueryNonces, app.usedQueryNonceList[app.nextUsedQueryNonceIndex])
+		app.usedQueryNonceList[app.nextUsedQueryNonceIndex] = nonceKey
+		app.nextUsedQueryNonceIndex = (app.nextUsedQueryNonceIndex + 1) % maxUsedQueryNonceCount
+	}
+	app.usedQueryNonces[nonceKey] = true
+}
+
+func (app *ABCIApplication) getQueryVisibilityList(param string) types.ResponseQuery {
+	app.logger.Infof("GetQueryVisibilityList, Parameter: %s", param)
+	var result GetQueryVisibilityListResult
+	result.QueryVisibilityList = make([]QueryVisibility, 0)
+	var err error
+	prefix := []byte(queryVisibilityKeyPrefix + keySeparator)
+	app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		var queryVisibility data.QueryVisibility
+		err = proto.Unmarshal(value, &queryVisibility)
+		if err != nil {
+			return false
+		}
+		row := QueryVisibility{
+			Method:          strings.TrimPrefix(string(key), string(prefix)),
+			AllowedRoleList: queryVisibility.AllowedRoleList,
+		}
+		if row.AllowedRoleList == nil {
+			row.AllowedRoleList = make([]string, 0)
+		}
+		result.QueryVisibilityList = append(result.QueryVisibilityList, row)
+		return true
+	})
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -0,0 +1,109 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+// simulateTx runs Tx as in DeliverTx against latest committed state without signature verification.
+// All changes made by the Tx are discarded.
+func (app *ABCIApplication) simulateTx(param string) types.ResponseQuery {
+	app.logger.Infof("SimulateTx, Parameter: %s", param)
+	var funcParam SimulateTxParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	if !IsMethod[funcParam.Method] {
+		suggestion := suggestMethod(funcParam.Method, IsMethod)
+		return app.ReturnQueryWithCode(code.UnknownMethod, unknownMethodDetail(funcParam.Method, suggestion), unknownMethodLog(suggestion), app.state.Height)
+	}
+	txParam := string(funcParam.Params)
+	nodeID := funcParam.NodeID
+
+	// Run on committed state only and restore uncommitted state, validator updates
+	// and events of Tx being delivered afterward
+	snapshot := app.state.SnapshotAndClear()
+	valUpdates := app.copyValUpdates()
+	deliverTxEvents := app.deliverTxEvents
+	app.deliverTxEvents = make([]types.Event, 0)
+	defer func() {
+		app.state.RevertToSnapshot(snapshot)
+		app.valUpdates = valUpdates
+		app.deliverTxEvents = deliverTxEvents
+	}()
+
+	// Fee and escrow are taken from token before Tx as in CheckTx
+	var fee float64
+	chargeFee := !app.checkNDID(txParam, nodeID, false) && !isNDIDMethod[funcParam.Method]
+	tokenCode, tokenLog := uint32(code.OK), ""
+	if chargeFee {
+		fee = app.getTxTokenPrice(funcParam.Method, txParam, nodeID, false)
+		escrow := app.getTxEscrowAmount(funcParam.Method, txParam, nodeID, false)
+		nodeToken, err := app.getToken(nodeID, false)
+		if err != nil {
+			tokenCode, tokenLog = code.TokenAccountNotFound, "token account not found"
+		} else if nodeToken < fee+escrow {
+			tokenCode, tokenLog = code.TokenNotEnough, "token not enough"
+		}
+	}
+
+	var result types.ResponseDeliverTx
+	checkTxResult := app.CheckTxRouter(funcParam.Method, txParam, nil, nil, nodeID, false)
+	if checkTxResult.Code != code.OK {
+		result = app.ReturnDeliverTxLog(checkTxResult.Code, checkTxResult.Log, "")
+	} else if tokenCode != code.OK {
+		result = app.ReturnDeliverTxLog(tokenCode, tokenLog, "")
+	} else if quotaCode, quotaLog := app.checkNodeQuota(funcParam.Method, nodeID); quotaCode != code.OK {
+		result = app.ReturnDeliverTxLog(quotaCode, quotaLog, "")
+	} else {
+		result = app.callDeliverTxOrSchedule(funcParam.Method, txParam, nodeID)
+	}
+
+	// Fee is charged after Tx as in DeliverTx, Tx may have spent token (e.g. escrow of requests in batch)
+	if result.Code == code.OK && chargeFee {
+		nodeToken, err := app.getToken(nodeID, false)
+		if err != nil {
+			result.Code = code.TokenAccountNotFound
+			result.Log = "token account not found"
+		} else if nodeToken < fee {
+			result.Code = code.TokenNotEnough
+			result.Log = "token not enough"
+		}
+	}
+
+	var res SimulateTxResult
+	res.Code = result.Code
+	res.Log = result.Log
+	res.Fee = fee
+	value, err := json.Marshal(res)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
@@ -23,25 +23,39 @@
 package app
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	dbm "github.com/tendermint/tendermint/libs/db"
 
+	"github.com/ndidplatform/smart-contract/v4/abci/keys"
 	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/abci/version"
 	"github.com/ndidplatform/smart-contract/v4/protos/data"
 )
 
 var (
-	appStateMetadataKey = []byte("stateKey")
+	appStateMetadataKey = []byte(keys.StateMetadataKey)
 	// nonceKeyPrefix  = []byte("nonce:")
 )
 
 type AppStateMetadata struct {
 	Height  int64  `json:"height"`
 	AppHash []byte `json:"app_hash"`
+	// KeyCount and ByteSize are number of keys and total size of keys and values in DB
+	KeyCount      int64 `json:"key_count"`
+	ByteSize      int64 `json:"byte_size"`
+	SchemaVersion int64 `json:"schema_version"`
+	// NetworkNamespace is namespace of state keys in shared DB, empty when DB is not shared
+	NetworkNamespace string `json:"network_namespace,omitempty"`
+	// PrunedHeight is height at or before which values may have been deleted by pruning worker
+	PrunedHeight int64 `json:"pruned_height,omitempty"`
 }
 
 type AppState struct {
@@ -51,10 +65,22 @@ type AppState struct {
 	HashData                 []byte
 	uncommittedState         map[string][]byte
 	uncommittedVersionsState map[string][]int64
+	readKeyPrefixes          map[string]bool
+	// txActivity is Txs delivered in current block which is saved as block activity
+	txActivity []*data.TxActivity
+	// profiler records state access by method when state access profiler is enabled
+	profiler *stateProfiler
+	// txJournal records values of keys before they are written by Tx being delivered, nil when not recording
+	txJournal *txJournal
 }
 
 func NewAppState(db dbm.DB) (appState AppState) {
 	appStateMetadata := loadAppStateMetadata(db)
+	// Refuse to run on state written by newer version since it may not be read or written correctly
+	if appStateMetadata.SchemaVersion > version.StateSchemaVersion {
+		panic(fmt.Errorf("State schema version %d is newer than version %d supported by this ABCI app version %s. Please upgrade ABCI app", appStateMetadata.SchemaVersion, version.StateSchemaVersion, version.Version))
+	}
+	appStateMetadata.SchemaVersion = version.StateSchemaVersion
 	appState = AppState{
 		AppStateMetadata:         appStateMetadata,
 		db:                       db,
@@ -87,13 +113,26 @@ func (appState *AppState) SaveMetadata() {
 }
 
 func (appState *AppState) Set(key, value []byte) {
+	appState.profiler.recordSet(len(value))
 	appState.HashData = append(appState.HashData, key...)
 	appState.HashData = append(appState.HashData, value...)
 
+	appState.journalWrite(string(key))
 	appState.uncommittedState[string(key)] = value
 }
 
 func (appState *AppState) SetVersioned(key, value []byte) {
+	appState.setVersioned(key, value, appState.CurrentBlockHeight)
+}
+
+// SetVersionedAtHeight sets value of key as version at given height. Height must not be
+// lower than latest version of key, it is used to seed history of key written before it was versioned.
+func (appState *AppState) SetVersionedAtHeight(key, value []byte, height int64) {
+	appState.setVersioned(key, value, height)
+}
+
+func (appState *AppState) setVersioned(key, value []byte, height int64) {
+	appState.profiler.recordSet(len(value))
 	versionsKeyStr := string(key) + "|versions"
 	versionsKey := []byte(versionsKeyStr)
 
@@ -111,7 +150,7 @@ func (appState *AppState) SetVersioned(key, value []byte) {
 		}
 	}
 
-	if len(versions) == 0 || versions[len(versions)-1] != appState.CurrentBlockHeight {
+	if len(versions) == 0 || versions[len(versions)-1] != height {
 		appState.HashData = append(appState.HashData, versionsKey...)
 		versionBytes := make([]byte, 8)
 		for _, version := range versions {
@@ -119,23 +158,72 @@ func (appState *AppState) SetVersioned(key, value []byte) {
 			appState.HashData = append(appState.HashData, versionBytes...)
 		}
 
-		appState.uncommittedVersionsState[versionsKeyStr] = append(versions, appState.CurrentBlockHeight)
+		appState.journalVersionsWrite(versionsKeyStr)
+		appState.uncommittedVersionsState[versionsKeyStr] = append(versions, height)
 	}
 
-	keyWithVersionStr := string(key) + "|" + strconv.FormatInt(appState.CurrentBlockHeight, 10)
+	keyWithVersionStr := string(key) + "|" + strconv.FormatInt(height, 10)
 
 	appState.HashData = append(appState.HashData, key...)
 	appState.HashData = append(appState.HashData, value...)
 
+	appState.journalWrite(keyWithVersionStr)
 	appState.uncommittedState[keyWithVersionStr] = value
 }
 
+// getKeyPrefix returns part of key before first key separator
+func getKeyPrefix(key string) string {
+	index := strings.Index(key, "|")
+	if index < 0 {
+		return key
+	}
+	return key[:index]
+}
+
+// StartRecordingReads starts recording prefix of every key read from state
+func (appState *AppState) StartRecordingReads() {
+	appState.readKeyPrefixes = make(map[string]bool)
+}
+
+// StopRecordingReads stops recording and returns prefixes of keys read since recording started
+func (appState *AppState) StopRecordingReads() map[string]bool {
+	readKeyPrefixes := appState.readKeyPrefixes
+	appState.readKeyPrefixes = nil
+	return readKeyPrefixes
+}
+
+func (appState *AppState) recordRead(key []byte) {
+	if appState.readKeyPrefixes != nil {
+		appState.readKeyPrefixes[getKeyPrefix(string(key))] = true
+	}
+}
+
+// UncommittedKeyCount returns number of keys written (set or deleted) since last commit
+func (appState *AppState) UncommittedKeyCount() int64 {
+	return int64(len(appState.uncommittedState) + len(appState.uncommittedVersionsState))
+}
+
+// UncommittedKeyPrefixes returns prefixes of keys changed since last commit
+func (appState *AppState) UncommittedKeyPrefixes() map[string]bool {
+	keyPrefixes := make(map[string]bool)
+	for key := range appState.uncommittedState {
+		keyPrefixes[getKeyPrefix(key)] = true
+	}
+	for key := range appState.uncommittedVersionsState {
+		keyPrefixes[getKeyPrefix(key)] = true
+	}
+	return keyPrefixes
+}
+
 func (appState *AppState) Get(key []byte, committed bool) (value []byte, err error) {
+	appState.recordRead(key)
 	if committed {
-		return appState.getCommitted(key)
+		value, err = appState.getCommitted(key)
 	} else {
-		return appState.get(key)
+		value, err = appState.get(key)
 	}
+	appState.profiler.recordGet(len(value))
+	return value, err
 }
 
 func (appState *AppState) get(key []byte) (value []byte, err error) {
@@ -154,11 +242,14 @@ func (appState *AppState) getCommitted(key []byte) (value []byte, err error) {
 }
 
 func (appState *AppState) GetVersioned(key []byte, height int64, committed bool) (value []byte, err error) {
+	appState.recordRead(key)
 	if committed {
-		return appState.getCommittedVersioned(key, height)
+		value, err = appState.getCommittedVersioned(key, height)
 	} else {
-		return appState.getVersioned(key, height)
+		value, err = appState.getVersioned(key, height)
 	}
+	appState.profiler.recordGet(len(value))
+	return value, err
 }
 
 func (appState *AppState) getVersioned(key []byte, height int64) (value []byte, err error) {
@@ -244,7 +335,56 @@ func (appState *AppState) getCommittedVersioned(key []byte, height int64) (value
 	return value, nil
 }
 
+// IterateCommitted calls fn with every key and value in committed state which key starts with prefix.
+// Iteration stops when fn returns false.
+func (appState *AppState) IterateCommitted(prefix []byte, fn func(key, value []byte) bool) {
+	appState.recordRead(prefix)
+	end := append([]byte(nil), prefix...)
+	for len(end) > 0 && end[len(end)-1] == 0xff {
+		end = end[:len(end)-1]
+	}
+	if len(end) > 0 {
+		end[len(end)-1]++
+	} else {
+		end = nil
+	}
+	itr := appState.db.Iterator(prefix, end)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		if !fn(itr.Key(), itr.Value()) {
+			return
+		}
+	}
+}
+
+// Keys returns keys in uncommitted state which start with prefix in key order.
+// Versions keys of versioned values changed in uncommitted state are included.
+func (appState *AppState) Keys(prefix []byte) []string {
+	keySet := make(map[string]bool)
+	appState.IterateCommitted(prefix, func(key, value []byte) bool {
+		keySet[string(key)] = true
+		return true
+	})
+	for key, value := range appState.uncommittedState {
+		if !strings.HasPrefix(key, string(prefix)) {
+			continue
+		}
+		if value != nil {
+			keySet[key] = true
+		} else {
+			delete(keySet, key)
+		}
+	}
+	for key := range appState.uncommittedVersionsState {
+		if strings.HasPrefix(key, string(prefix)) {
+			keySet[key] = true
+		}
+	}
+	return utils.SortedKeys(keySet)
+}
+
 func (appState *AppState) Has(key []byte, committed bool) bool {
+	appState.recordRead(key)
 	if committed {
 		return appState.hasCommitted(key)
 	} else {
@@ -265,6 +405,7 @@ func (appState *AppState) hasCommitted(key []byte) bool {
 }
 
 func (appState *AppState) HasVersioned(key []byte, committed bool) bool {
+	appState.recordRead(key)
 	if committed {
 		return appState.hasCommittedVersioned(key)
 	} else {
@@ -296,7 +437,9 @@ func (appState *AppState) Delete(key []byte) {
 	}
 	appState.HashData = append(appState.HashData, key...)
 	appState.HashData = append(appState.HashData, []byte("delete")...) // Remove or replace with something else?
+	appState.profiler.recordDelete()
 
+	appState.journalWrite(string(key))
 	appState.uncommittedState[string(key)] = nil
 }
 
@@ -307,20 +450,187 @@ func (appState *AppState) DeleteVersioned(key []byte) {
 	appState.SetVersioned(key, nil)
 }
 
+// PurgeVersioned deletes key with all of its versions. Only version list of key is
+// added to hash data since old versions may already be pruned.
+func (appState *AppState) PurgeVersioned(key []byte) {
+	if !appState.hasVersioned(key) {
+		return
+	}
+	versionsKeyStr := string(key) + "|versions"
+	versions, existInUncommittedState := appState.uncommittedVersionsState[versionsKeyStr]
+	if !existInUncommittedState {
+		keyVersionsProtobuf := appState.db.Get([]byte(versionsKeyStr))
+		if keyVersionsProtobuf != nil {
+			var keyVersions data.KeyVersions
+			if err := proto.Unmarshal([]byte(keyVersionsProtobuf), &keyVersions); err != nil {
+				panic(err)
+			}
+			versions = keyVersions.Versions
+		}
+	}
+	appState.HashData = append(appState.HashData, []byte(versionsKeyStr)...)
+	appState.HashData = append(appState.HashData, []byte("purge")...)
+	appState.profiler.recordDelete()
+
+	for _, version := range versions {
+		keyWithVersion := string(key) + "|" + strconv.FormatInt(version, 10)
+		if appState.has([]byte(keyWithVersion)) {
+			appState.journalWrite(keyWithVersion)
+			appState.uncommittedState[keyWithVersion] = nil
+		}
+	}
+	appState.journalVersionsWrite(versionsKeyStr)
+	delete(appState.uncommittedVersionsState, versionsKeyStr)
+	appState.journalWrite(versionsKeyStr)
+	appState.uncommittedState[versionsKeyStr] = nil
+}
+
+// AppStateSnapshot is a copy of uncommitted state which can be restored
+// to discard changes made after it was taken
+type AppStateSnapshot struct {
+	hashDataLength           int
+	uncommittedState         map[string][]byte
+	uncommittedVersionsState map[string][]int64
+}
+
+func (appState *AppState) Snapshot() AppStateSnapshot {
+	snapshot := AppStateSnapshot{
+		hashDataLength:           len(appState.HashData),
+		uncommittedState:         make(map[string][]byte, len(appState.uncommittedState)),
+		uncommittedVersionsState: make(map[string][]int64, len(appState.uncommittedVersionsState)),
+	}
+	for key, value := range appState.uncommittedState {
+		snapshot.uncommittedState[key] = value
+	}
+	for key, versions := range appState.uncommittedVersionsState {
+		snapshot.uncommittedVersionsState[key] = append(make([]int64, 0, len(versions)), versions...)
+	}
+	return snapshot
+}
+
+// SnapshotAndClear takes snapshot and then discards uncommitted state
+// so that following reads and writes are done on top of committed state
+func (appState *AppState) SnapshotAndClear() AppStateSnapshot {
+	snapshot := AppStateSnapshot{
+		hashDataLength:           len(appState.HashData),
+		uncommittedState:         appState.uncommittedState,
+		uncommittedVersionsState: appState.uncommittedVersionsState,
+	}
+	appState.uncommittedState = make(map[string][]byte)
+	appState.uncommittedVersionsState = make(map[string][]int64)
+	return snapshot
+}
+
+func (appState *AppState) RevertToSnapshot(snapshot AppStateSnapshot) {
+	appState.HashData = appState.HashData[:snapshot.hashDataLength]
+	appState.uncommittedState = snapshot.uncommittedState
+	appState.uncommittedVersionsState = snapshot.uncommittedVersionsState
+}
+
+// txJournal keeps uncommitted value of each key before it is first written by Tx so that
+// changes of Tx can be reverted in time proportional to number of keys written by the Tx
+// instead of copying whole uncommitted state of block as Snapshot does.
+// Reverting to Snapshot taken within the Tx does not invalidate journal since journal
+// only restores values from before the Tx.
+type txJournal struct {
+	hashDataLength int
+	state          map[string]journalValue
+	versionsState  map[string]journalVersions
+}
+
+type journalValue struct {
+	value []byte
+	exist bool
+}
+
+type journalVersions struct {
+	versions []int64
+	exist    bool
+}
+
+// BeginTxJournal starts recording values of keys before they are written
+func (appState *AppState) BeginTxJournal() {
+	appState.txJournal = &txJournal{
+		hashDataLength: len(appState.HashData),
+		state:          make(map[string]journalValue),
+		versionsState:  make(map[string]journalVersions),
+	}
+}
+
+// EndTxJournal stops recording and keeps changes written since BeginTxJournal
+func (appState *AppState) EndTxJournal() {
+	appState.txJournal = nil
+}
+
+// RevertTxJournal discards changes written since BeginTxJournal and stops recording
+func (appState *AppState) RevertTxJournal() {
+	journal := appState.txJournal
+	if journal == nil {
+		return
+	}
+	appState.txJournal = nil
+	appState.HashData = appState.HashData[:journal.hashDataLength]
+	for key, entry := range journal.state {
+		if entry.exist {
+			appState.uncommittedState[key] = entry.value
+		} else {
+			delete(appState.uncommittedState, key)
+		}
+	}
+	for key, entry := range journal.versionsState {
+		if entry.exist {
+			appState.uncommittedVersionsState[key] = entry.versions
+		} else {
+			delete(appState.uncommittedVersionsState, key)
+		}
+	}
+}
+
+func (appState *AppState) journalWrite(key string) {
+	if appState.txJournal == nil {
+		return
+	}
+	if _, recorded := appState.txJournal.state[key]; recorded {
+		return
+	}
+	value, exist := appState.uncommittedState[key]
+	appState.txJournal.state[key] = journalValue{value: value, exist: exist}
+}
+
+func (appState *AppState) journalVersionsWrite(key string) {
+	if appState.txJournal == nil {
+		return
+	}
+	if _, recorded := appState.txJournal.versionsState[key]; recorded {
+		return
+	}
+	versions, exist := appState.uncommittedVersionsState[key]
+	// Version list is appended in place, so it is copied
+	appState.txJournal.versionsState[key] = journalVersions{
+		versions: append(make([]int64, 0, len(versions)), versions...),
+		exist:    exist,
+	}
+}
+
 func (appState *AppState) Save() {
 	batch := appState.db.NewBatch()
 	defer batch.Close()
 
-	for key := range appState.uncommittedState {
+	var journal data.ChangeJournal
+	journal.Changes = make([]*data.KeyChange, 0, len(appState.uncommittedState)+len(appState.uncommittedVersionsState))
+
+	for _, key := range utils.SortedKeys(appState.uncommittedState) {
 		value := appState.uncommittedState[key]
 		if value != nil {
 			batch.Set([]byte(key), value)
 		} else {
 			batch.Delete([]byte(key))
 		}
+		appState.updateStateStats([]byte(key), value)
+		journal.Changes = append(journal.Changes, newKeyChange(key, value))
 	}
 
-	for key := range appState.uncommittedVersionsState {
+	for _, key := range utils.SortedKeys(appState.uncommittedVersionsState) {
 		versions := appState.uncommittedVersionsState[key]
 		var keyVersions data.KeyVersions
 		keyVersions.Versions = versions
@@ -329,10 +639,106 @@ func (appState *AppState) Save() {
 			panic(err) // Should panic or return err?
 		}
 		batch.Set([]byte(key), value)
+		appState.updateStateStats([]byte(key), value)
+		journal.Changes = append(journal.Changes, newKeyChange(key, value))
+	}
+
+	// Journal of changed keys is saved with the changes in the same batch
+	// so that it is always consistent with state
+	if len(journal.Changes) > 0 {
+		sort.Slice(journal.Changes, func(i, j int) bool {
+			return journal.Changes[i].Key < journal.Changes[j].Key
+		})
+		journalValue, err := utils.ProtoDeterministicMarshal(&journal)
+		if err != nil {
+			panic(err)
+		}
+		journalKey := getChangeJournalKey(appState.CurrentBlockHeight)
+		batch.Set(journalKey, journalValue)
+		appState.updateStateStats(journalKey, journalValue)
+	}
+
+	// Block activity is not part of app hash like change journal
+	if len(appState.txActivity) > 0 {
+		var activity data.BlockActivity
+		activity.TxList = appState.txActivity
+		activityValue, err := utils.ProtoDeterministicMarshal(&activity)
+		if err != nil {
+			panic(err)
+		}
+		activityKey := getBlockActivityKey(appState.CurrentBlockHeight)
+		batch.Set(activityKey, activityValue)
+		appState.updateStateStats(activityKey, activityValue)
 	}
 
 	batch.WriteSync()
 
 	appState.uncommittedState = make(map[string][]byte)
 	appState.uncommittedVersionsState = make(map[string][]int64)
+	appState.txActivity = nil
+}
+
+func newKeyChange(key string, value []byte) *data.KeyChange {
+	var change data.KeyChange
+	change.Key = key
+	if value == nil {
+		change.Deleted = true
+	} else {
+		valueHash := sha256.Sum256(value)
+		change.ValueHash = valueHash[:]
+	}
+	return &change
+}
+
+func getChangeJournalKey(height int64) []byte {
+	return []byte(changeJournalKeyPrefix + keySeparator + strconv.FormatInt(height, 10))
+}
+
+// updateStateStats updates key count and byte size with change of key to be saved.
+// It must be called before the change is written to DB.
+func (appState *AppState) updateStateStats(key, value []byte) {
+	oldValue := appState.db.Get(key)
+	if oldValue == nil {
+		if value != nil {
+			appState.KeyCount++
+			appState.ByteSize += int64(len(key) + len(value))
+		}
+		return
+	}
+	if value == nil {
+		appState.KeyCount--
+		appState.ByteSize -= int64(len(key) + len(oldValue))
+		return
+	}
+	appState.ByteSize += int64(len(value) - len(oldValue))
+}
+
+// RecomputeStateStats counts keys and their size in DB and saves the result in app state metadata.
+// It is for DB created before key count was tracked or when the count is suspected to be wrong.
+func RecomputeStateStats(db dbm.DB) (keyCount int64, byteSize int64) {
+	keyCount, byteSize = countStateStats(db)
+	appStateMetadata := loadAppStateMetadata(db)
+	appStateMetadata.KeyCount = keyCount
+	appStateMetadata.ByteSize = byteSize
+	appStateMetadataBytes, err := json.Marshal(appStateMetadata)
+	if err != nil {
+		panic(err)
+	}
+	db.SetSync(appStateMetadataKey, appStateMetadataBytes)
+	return keyCount, byteSize
+}
+
+// countStateStats counts keys except app state metadata and their size in DB
+func countStateStats(db dbm.DB) (keyCount int64, byteSize int64) {
+	itr := db.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		key := itr.Key()
+		if string(key) == string(appStateMetadataKey) {
+			continue
+		}
+		keyCount++
+		byteSize += int64(len(key) + len(itr.Value()))
+	}
+	return keyCount, byteSize
 }
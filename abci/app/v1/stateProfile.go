@@ -0,0 +1,178 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+)
+
+// stateProfileQueryPath is query path for getting state access profile since last dump
+const stateProfileQueryPath = "/state_profile"
+
+// StateAccessProfile is number of state reads and writes and their size, and number and size of
+// protobuf messages marshaled, by Tx method, query method or block phase
+type StateAccessProfile struct {
+	GetCount       int64 `json:"get_count"`
+	GetBytes       int64 `json:"get_bytes"`
+	SetCount       int64 `json:"set_count"`
+	SetBytes       int64 `json:"set_bytes"`
+	DeleteCount    int64 `json:"delete_count"`
+	MarshalCount   int64 `json:"marshal_count"`
+	MarshaledBytes int64 `json:"marshaled_bytes"`
+}
+
+// stateProfiler records state access of current method for prioritizing performance work with
+// real traffic. Access is counted in method set last, so accesses of queries running at the same
+// time as Tx or block phase may be counted in method of each other. Nil profiler records nothing.
+type stateProfiler struct {
+	mutex   sync.Mutex
+	method  string
+	methods map[string]*StateAccessProfile
+	// dumpInterval is number of blocks between profiles logged at EndBlock, 0 to not log
+	dumpInterval int64
+	logger       *logrus.Entry
+}
+
+// newStateProfiler returns profiler when it is enabled, nil otherwise
+func newStateProfiler(enabled bool, dumpInterval int64, logger *logrus.Entry) *stateProfiler {
+	if !enabled {
+		return nil
+	}
+	profiler := &stateProfiler{
+		methods:      make(map[string]*StateAccessProfile),
+		dumpInterval: dumpInterval,
+		logger:       logger,
+	}
+	utils.MarshaledBytesObserver = profiler.recordMarshal
+	return profiler
+}
+
+// setMethod sets method which following state access is counted in
+func (profiler *stateProfiler) setMethod(method string) {
+	if profiler == nil {
+		return
+	}
+	profiler.mutex.Lock()
+	defer profiler.mutex.Unlock()
+	profiler.method = method
+}
+
+// current returns profile of current method, mutex must be held
+func (profiler *stateProfiler) current() *StateAccessProfile {
+	profile, exist := profiler.methods[profiler.method]
+	if !exist {
+		profile = &StateAccessProfile{}
+		profiler.methods[profiler.method] = profile
+	}
+	return profile
+}
+
+func (profiler *stateProfiler) recordGet(size int) {
+	if profiler == nil {
+		return
+	}
+	profiler.mutex.Lock()
+	defer profiler.mutex.Unlock()
+	profile := profiler.current()
+	profile.GetCount++
+	profile.GetBytes += int64(size)
+}
+
+func (profiler *stateProfiler) recordSet(size int) {
+	if profiler == nil {
+		return
+	}
+	profiler.mutex.Lock()
+	defer profiler.mutex.Unlock()
+	profile := profiler.current()
+	profile.SetCount++
+	profile.SetBytes += int64(size)
+}
+
+func (profiler *stateProfiler) recordDelete() {
+	if profiler == nil {
+		return
+	}
+	profiler.mutex.Lock()
+	defer profiler.mutex.Unlock()
+	profiler.current().DeleteCount++
+}
+
+func (profiler *stateProfiler) recordMarshal(size int) {
+	profiler.mutex.Lock()
+	defer profiler.mutex.Unlock()
+	profile := profiler.current()
+	profile.MarshalCount++
+	profile.MarshaledBytes += int64(size)
+}
+
+// snapshot returns copy of profiles by method and resets them when reset is true
+func (profiler *stateProfiler) snapshot(reset bool) map[string]StateAccessProfile {
+	profiler.mutex.Lock()
+	defer profiler.mutex.Unlock()
+	result := make(map[string]StateAccessProfile, len(profiler.methods))
+	for method, profile := range profiler.methods {
+		result[method] = *profile
+	}
+	if reset {
+		profiler.methods = make(map[string]*StateAccessProfile)
+	}
+	return result
+}
+
+// dumpAtEndBlock logs profiles since last dump every dump interval blocks
+func (profiler *stateProfiler) dumpAtEndBlock(height int64) {
+	if profiler == nil || profiler.dumpInterval <= 0 || height%profiler.dumpInterval != 0 {
+		return
+	}
+	profileJSON, err := json.Marshal(profiler.snapshot(true))
+	if err != nil {
+		profiler.logger.Errorf("Error marshaling state access profile: %s", err.Error())
+		return
+	}
+	profiler.logger.Infof("State access profile of %d blocks until height %d: %s", profiler.dumpInterval, height, profileJSON)
+}
+
+// queryStateProfile returns state access profile by method since last dump
+func (app *ABCIApplication) queryStateProfile() types.ResponseQuery {
+	if app.state.profiler == nil {
+		return types.ResponseQuery{Code: code.StateProfileIsDisabled, Log: "State access profiler is disabled", Info: app.getQueryInfo(), Height: app.state.Height}
+	}
+	value, err := json.Marshal(app.state.profiler.snapshot(false))
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	var res types.ResponseQuery
+	res.Value = value
+	res.Log = "success"
+	res.Info = app.getQueryInfo()
+	res.Height = app.state.Height
+	return res
+}
@@ -25,9 +25,11 @@ package app
 import (
 	"encoding/json"
 	"errors"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
 
 	"github.com/ndidplatform/smart-contract/v4/abci/code"
 	"github.com/ndidplatform/smart-contract/v4/abci/utils"
@@ -49,6 +51,64 @@ func (app *ABCIApplication) getTokenPriceByFunc(fnName string, committedState bo
 	return tokenPrice.Price
 }
 
+// getTxTokenPrice returns token price of Tx. Txs of identity management request are free.
+func (app *ABCIApplication) getTxTokenPrice(method string, param string, nodeID string, committedState bool) float64 {
+	if method == "Batch" {
+		return app.getBatchTokenPrice(param, nodeID, committedState)
+	}
+	if app.isIdentityManagementRequestTx(method, param, nodeID, committedState) {
+		return 0
+	}
+	return app.getTokenPriceByFunc(method, committedState)
+}
+
+// isIdentityManagementRequestTx checks whether Tx creates or is made to request with
+// identity management purpose (e.g. AddAccessor). Such request can only be created by IdP.
+func (app *ABCIApplication) isIdentityManagementRequestTx(method string, param string, nodeID string, committedState bool) bool {
+	switch method {
+	case "CreateRequest":
+		var funcParam CreateRequestParam
+		err := json.Unmarshal([]byte(param), &funcParam)
+		if err != nil || !modeFunctionMap[funcParam.Purpose] {
+			return false
+		}
+		nodeDetailKey := nodeIDKeyPrefix + keySeparator + nodeID
+		nodeDetailValue, _ := app.state.Get([]byte(nodeDetailKey), committedState)
+		if nodeDetailValue == nil {
+			return false
+		}
+		var nodeDetail data.NodeDetail
+		err = proto.Unmarshal(nodeDetailValue, &nodeDetail)
+		if err != nil {
+			return false
+		}
+		return hasRole(&nodeDetail, "IdP")
+	case "CreateIdpResponse",
+		"CloseRequest",
+		"TimeOutRequest",
+		"SetDataReceived",
+		"ExtendRequestTimeout":
+		var funcParam RequestIDParam
+		err := json.Unmarshal([]byte(param), &funcParam)
+		if err != nil {
+			return false
+		}
+		requestKey := requestKeyPrefix + keySeparator + funcParam.RequestID
+		requestValue, _ := app.state.GetVersioned([]byte(requestKey), 0, committedState)
+		if requestValue == nil {
+			return false
+		}
+		var request data.Request
+		err = proto.Unmarshal(requestValue, &request)
+		if err != nil {
+			return false
+		}
+		// Purpose is set only when request is created by IdP
+		return request.Purpose != ""
+	}
+	return false
+}
+
 func (app *ABCIApplication) setTokenPriceByFunc(fnName string, price float64) error {
 	key := tokenPriceFuncKeyPrefix + keySeparator + fnName
 	var tokenPrice data.TokenPrice
@@ -69,7 +129,7 @@ func (app *ABCIApplication) createTokenAccount(nodeID string) {
 	app.state.Set([]byte(key), []byte(value))
 }
 
-func (app *ABCIApplication) setToken(nodeID string, amount float64) error {
+func (app *ABCIApplication) setToken(nodeID string, amount float64, reason string) error {
 	key := tokenKeyPrefix + keySeparator + nodeID
 	value, _ := app.state.Get([]byte(key), false)
 	if value == nil {
@@ -80,7 +140,13 @@ func (app *ABCIApplication) setToken(nodeID string, amount float64) error {
 	if err != nil {
 		return errors.New("token account not found")
 	}
+	delta := amount - token.Amount
+	app.emitLowTokenEventIfCrossed(nodeID, token.Amount, amount)
 	token.Amount = amount
+	err = app.appendTokenLedgerEntry(nodeID, &token, tokenLedgerEntryTypeSet, delta, reason)
+	if err != nil {
+		return err
+	}
 	value, err = utils.ProtoDeterministicMarshal(&token)
 	if err != nil {
 		return errors.New("token account not found")
@@ -108,7 +174,7 @@ func (app *ABCIApplication) getPriceFunc(param string, committedState bool) type
 	var funcParam GetPriceFuncParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
 	}
 	price := app.getTokenPriceByFunc(funcParam.Func, committedState)
 	var res = GetPriceFuncResult{
@@ -116,12 +182,12 @@ func (app *ABCIApplication) getPriceFunc(param string, committedState bool) type
 	}
 	value, err := json.Marshal(res)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(value, "success", app.state.Height)
 }
 
-func (app *ABCIApplication) addToken(nodeID string, amount float64) error {
+func (app *ABCIApplication) addToken(nodeID string, amount float64, entryType string, reason string) error {
 	key := tokenKeyPrefix + keySeparator + nodeID
 	value, _ := app.state.Get([]byte(key), false)
 	if value == nil {
@@ -133,6 +199,10 @@ func (app *ABCIApplication) addToken(nodeID string, amount float64) error {
 		return errors.New("token account not found")
 	}
 	token.Amount = token.Amount + amount
+	err = app.appendTokenLedgerEntry(nodeID, &token, entryType, amount, reason)
+	if err != nil {
+		return err
+	}
 	value, err = utils.ProtoDeterministicMarshal(&token)
 	if err != nil {
 		return errors.New("token account not found")
@@ -155,7 +225,7 @@ func (app *ABCIApplication) checkTokenAccount(nodeID string) bool {
 	return true
 }
 
-func (app *ABCIApplication) reduceToken(nodeID string, amount float64) (errorCode uint32, errorLog string) {
+func (app *ABCIApplication) reduceToken(nodeID string, amount float64, entryType string, reason string) (errorCode uint32, errorLog string) {
 	key := tokenKeyPrefix + keySeparator + nodeID
 	value, _ := app.state.Get([]byte(key), false)
 	if value == nil {
@@ -169,7 +239,12 @@ func (app *ABCIApplication) reduceToken(nodeID string, amount float64) (errorCod
 	if amount > token.Amount {
 		return code.TokenNotEnough, "token not enough"
 	}
+	app.emitLowTokenEventIfCrossed(nodeID, token.Amount, token.Amount-amount)
 	token.Amount = token.Amount - amount
+	err = app.appendTokenLedgerEntry(nodeID, &token, entryType, -amount, reason)
+	if err != nil {
+		return code.MarshalError, err.Error()
+	}
 	value, err = utils.ProtoDeterministicMarshal(&token)
 	if err != nil {
 		return code.TokenAccountNotFound, "token account not found"
@@ -207,7 +282,7 @@ func (app *ABCIApplication) setNodeToken(param string, nodeID string) types.Resp
 	if !app.checkTokenAccount(funcParam.NodeID) {
 		return app.ReturnDeliverTxLog(code.TokenAccountNotFound, "token account not found", "")
 	}
-	err = app.setToken(funcParam.NodeID, funcParam.Amount)
+	err = app.setToken(funcParam.NodeID, funcParam.Amount, "SetNodeToken")
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.TokenAccountNotFound, err.Error(), "")
 	}
@@ -229,7 +304,7 @@ func (app *ABCIApplication) addNodeToken(param string, nodeID string) types.Resp
 	if !app.checkTokenAccount(funcParam.NodeID) {
 		return app.ReturnDeliverTxLog(code.TokenAccountNotFound, "token account not found", "")
 	}
-	err = app.addToken(funcParam.NodeID, funcParam.Amount)
+	err = app.addToken(funcParam.NodeID, funcParam.Amount, tokenLedgerEntryTypeMint, "AddNodeToken")
 	if err != nil {
 		return app.ReturnDeliverTxLog(code.TokenAccountNotFound, err.Error(), "")
 	}
@@ -251,30 +326,120 @@ func (app *ABCIApplication) reduceNodeToken(param string, nodeID string) types.R
 	if !app.checkTokenAccount(funcParam.NodeID) {
 		return app.ReturnDeliverTxLog(code.TokenAccountNotFound, "token account not found", "")
 	}
-	errCode, errLog := app.reduceToken(funcParam.NodeID, funcParam.Amount)
+	errCode, errLog := app.reduceToken(funcParam.NodeID, funcParam.Amount, tokenLedgerEntryTypeBurn, "ReduceNodeToken")
 	if errCode != code.OK {
 		return app.ReturnDeliverTxLog(errCode, errLog, "")
 	}
 	return app.ReturnDeliverTxLog(code.OK, "success", "")
 }
 
+// refundToken gives token back to node in exceptional cases, e.g. fee charged for Tx
+// which could not be served due to platform failure
+func (app *ABCIApplication) refundToken(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("RefundToken, Parameter: %s", param)
+	var funcParam RefundNodeTokenParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	// Validate parameter
+	if funcParam.Amount < 0 {
+		return app.ReturnDeliverTxLog(code.AmountMustBeGreaterOrEqualToZero, "Amount must be greater than or equal to zero", "")
+	}
+	// Check token account
+	if !app.checkTokenAccount(funcParam.NodeID) {
+		return app.ReturnDeliverTxLog(code.TokenAccountNotFound, "token account not found", "")
+	}
+	reason := funcParam.Reason
+	if reason == "" {
+		reason = "RefundToken"
+	}
+	err = app.addToken(funcParam.NodeID, funcParam.Amount, tokenLedgerEntryTypeRefund, reason)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.TokenAccountNotFound, err.Error(), "")
+	}
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getLowTokenThresholdFromStateDB(committedState bool) float64 {
+	value, _ := app.state.Get(lowTokenThresholdKeyBytes, committedState)
+	if value == nil {
+		return 0
+	}
+	var threshold data.LowTokenThreshold
+	err := proto.Unmarshal(value, &threshold)
+	if err != nil {
+		return 0
+	}
+	return threshold.Threshold
+}
+
+func (app *ABCIApplication) setLowTokenThreshold(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetLowTokenThreshold, Parameter: %s", param)
+	var funcParam LowTokenThresholdParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.Threshold < 0 {
+		return app.ReturnDeliverTxLog(code.ThresholdMustBeGreaterOrEqualToZero, "Threshold must be greater than or equal to zero", "")
+	}
+	var threshold data.LowTokenThreshold
+	threshold.Threshold = funcParam.Threshold
+	value, err := utils.ProtoDeterministicMarshal(&threshold)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(lowTokenThresholdKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getLowTokenThreshold(param string) types.ResponseQuery {
+	app.logger.Infof("GetLowTokenThreshold, Parameter: %s", param)
+	var result LowTokenThresholdParam
+	result.Threshold = app.getLowTokenThresholdFromStateDB(true)
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
+
+// emitLowTokenEventIfCrossed emits event when token of node drops below threshold set by NDID
+// so node operator can top up before running out of token. Event is emitted only once when
+// token crosses threshold, not on every Tx made while token stays below it.
+func (app *ABCIApplication) emitLowTokenEventIfCrossed(nodeID string, before float64, after float64) {
+	threshold := app.getLowTokenThresholdFromStateDB(false)
+	if !(before >= threshold && after < threshold) {
+		return
+	}
+	app.deliverTxEvents = append(app.deliverTxEvents, types.Event{
+		Type: "did.low_token",
+		Attributes: []cmn.KVPair{
+			{Key: []byte("node_id"), Value: []byte(nodeID)},
+			{Key: []byte("amount"), Value: []byte(strconv.FormatFloat(after, 'f', -1, 64))},
+			{Key: []byte("threshold"), Value: []byte(strconv.FormatFloat(threshold, 'f', -1, 64))},
+		},
+	})
+}
+
 func (app *ABCIApplication) getNodeToken(param string, committedState bool) types.ResponseQuery {
 	app.logger.Infof("GetNodeToken, Parameter: %s", param)
 	var funcParam GetNodeTokenParam
 	err := json.Unmarshal([]byte(param), &funcParam)
 	if err != nil {
-		return app.ReturnQuery([]byte("{}"), err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, []byte("{}"), err.Error(), app.state.Height)
 	}
 	tokenAmount, err := app.getToken(funcParam.NodeID, committedState)
 	if err != nil {
-		return app.ReturnQuery([]byte("{}"), "not found", app.state.Height)
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
 	}
 	var res = GetNodeTokenResult{
 		tokenAmount,
 	}
 	value, err := json.Marshal(res)
 	if err != nil {
-		return app.ReturnQuery(nil, err.Error(), app.state.Height)
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
 	}
 	return app.ReturnQuery(value, "success", app.state.Height)
 }
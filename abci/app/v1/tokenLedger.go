@@ -0,0 +1,135 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// Types of token ledger entry
+const (
+	tokenLedgerEntryTypeMint   = "mint"
+	tokenLedgerEntryTypeBurn   = "burn"
+	tokenLedgerEntryTypeCharge = "charge"
+	tokenLedgerEntryTypeRefund = "refund"
+	tokenLedgerEntryTypeSet    = "set"
+	tokenLedgerEntryTypeEscrow = "escrow"
+)
+
+const (
+	defaultTokenLedgerQueryLimit = 100
+	maxTokenLedgerQueryLimit     = 1000
+)
+
+func getTokenLedgerEntryKey(nodeID string, index int64) string {
+	// Zero padded index keeps entries of node ordered by key
+	return tokenLedgerKeyPrefix + keySeparator + nodeID + keySeparator + fmt.Sprintf("%020d", index)
+}
+
+// appendTokenLedgerEntry records token movement of amount (negative when token is taken from node)
+// after it is applied to token. Ledger is single-entry log of movements of node with no counter-account,
+// token amount stays source of truth of balance. Movement of zero amount is not recorded.
+func (app *ABCIApplication) appendTokenLedgerEntry(nodeID string, token *data.Token, entryType string, amount float64, reason string) error {
+	if amount == 0 {
+		return nil
+	}
+	var entry data.TokenLedgerEntry
+	entry.Type = entryType
+	entry.Amount = amount
+	entry.Balance = token.Amount
+	entry.BlockHeight = app.state.CurrentBlockHeight
+	entry.Reason = reason
+	value, err := utils.ProtoDeterministicMarshal(&entry)
+	if err != nil {
+		return err
+	}
+	app.state.Set([]byte(getTokenLedgerEntryKey(nodeID, token.LedgerEntryCount)), value)
+	token.LedgerEntryCount++
+	return nil
+}
+
+func (app *ABCIApplication) getTokenLedger(param string) types.ResponseQuery {
+	app.logger.Infof("GetTokenLedger, Parameter: %s", param)
+	var funcParam GetTokenLedgerParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	tokenKey := tokenKeyPrefix + keySeparator + funcParam.NodeID
+	tokenValue, _ := app.state.Get([]byte(tokenKey), true)
+	if tokenValue == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var token data.Token
+	err = proto.Unmarshal(tokenValue, &token)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	limit := funcParam.Limit
+	if limit <= 0 {
+		limit = defaultTokenLedgerQueryLimit
+	}
+	if limit > maxTokenLedgerQueryLimit {
+		limit = maxTokenLedgerQueryLimit
+	}
+	offset := funcParam.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	var result GetTokenLedgerResult
+	result.Amount = token.Amount
+	result.Total = token.LedgerEntryCount
+	result.EntryList = make([]TokenLedgerEntry, 0)
+	for index := offset; index < token.LedgerEntryCount && index < offset+limit; index++ {
+		entryValue, _ := app.state.Get([]byte(getTokenLedgerEntryKey(funcParam.NodeID, index)), true)
+		if entryValue == nil {
+			continue
+		}
+		var entry data.TokenLedgerEntry
+		err = proto.Unmarshal(entryValue, &entry)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+		var row TokenLedgerEntry
+		row.Index = index
+		row.Type = entry.Type
+		row.Amount = entry.Amount
+		row.Balance = entry.Balance
+		row.BlockHeight = entry.BlockHeight
+		row.Reason = entry.Reason
+		result.EntryList = append(result.EntryList, row)
+	}
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
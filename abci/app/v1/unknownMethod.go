@@ -0,0 +1,138 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+)
+
+// maxSuggestedMethodLength is max length of unknown method name which known method is
+// suggested for, longer name is not a typo of method name
+const maxSuggestedMethodLength = 100
+
+// suggestMethod returns known method nearest to unknown method by edit distance ignoring
+// case, empty when no method is near enough to be a likely typo. Ties are broken by method
+// name so suggestion is the same on every node.
+func suggestMethod(method string, methods map[string]bool) string {
+	if method == "" || len(method) > maxSuggestedMethodLength {
+		return ""
+	}
+	maxDistance := len(method) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+	lowerMethod := strings.ToLower(method)
+	var suggestion string
+	bestDistance := maxDistance + 1
+	for _, knownMethod := range utils.SortedKeys(methods) {
+		distance := editDistance(lowerMethod, strings.ToLower(knownMethod))
+		if distance < bestDistance {
+			bestDistance = distance
+			suggestion = knownMethod
+		}
+	}
+	return suggestion
+}
+
+// editDistance returns Levenshtein distance between a and b
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func unknownMethodLog(suggestion string) string {
+	if suggestion == "" {
+		return "Unknown method name"
+	}
+	return fmt.Sprintf("Unknown method name, did you mean %s?", suggestion)
+}
+
+// unknownMethodDetail returns JSON of unknown method and suggested method
+func unknownMethodDetail(method string, suggestion string) []byte {
+	detail, _ := json.Marshal(UnknownMethodDetail{
+		Method:          method,
+		SuggestedMethod: suggestion,
+	})
+	return detail
+}
+
+// returnUnknownMethodCheckTx returns CheckTx result of unknown Tx method with detail in data
+func returnUnknownMethodCheckTx(method string) types.ResponseCheckTx {
+	suggestion := suggestMethod(method, IsMethod)
+	return types.ResponseCheckTx{
+		Code: code.UnknownMethod,
+		Log:  unknownMethodLog(suggestion),
+		Data: unknownMethodDetail(method, suggestion),
+	}
+}
+
+// returnUnknownMethodDeliverTx returns DeliverTx result of unknown Tx method with method and
+// suggested method in attributes of result event (data is not used since it is part of
+// results hash of block)
+func (app *ABCIApplication) returnUnknownMethodDeliverTx(method string) types.ResponseDeliverTx {
+	suggestion := suggestMethod(method, IsMethod)
+	attributes := []cmn.KVPair{
+		{Key: []byte("method"), Value: []byte(method)},
+	}
+	if suggestion != "" {
+		attributes = append(attributes, cmn.KVPair{Key: []byte("suggested_method"), Value: []byte(suggestion)})
+	}
+	return app.ReturnDeliverTxLogWithAttributes(code.UnknownMethod, unknownMethodLog(suggestion), attributes)
+}
+
+// returnUnknownQueryMethod returns query result of unknown query method with detail in value
+func (app *ABCIApplication) returnUnknownQueryMethod(method string) types.ResponseQuery {
+	suggestion := suggestMethod(method, IsQueryMethod)
+	return app.ReturnQueryWithCode(code.UnknownMethod, unknownMethodDetail(method, suggestion), unknownMethodLog(suggestion), app.state.Height)
+}
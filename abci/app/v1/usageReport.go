@@ -0,0 +1,174 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// UsageReportRow is usage of method by node in height range of usage report.
+// Token ledger entries other than charge (e.g. refund or escrow) are reported
+// with entry type in parentheses as method.
+type UsageReportRow struct {
+	NodeID       string
+	Method       string
+	TxCount      int64
+	SuccessCount int64
+	// Fee is token taken from node, negative when token is given back to node (e.g. refund)
+	Fee float64
+}
+
+// UsageReport is per node, per method usage and fee of blocks from FromHeight to ToHeight
+type UsageReport struct {
+	FromHeight int64
+	ToHeight   int64
+	Rows       []UsageReportRow
+}
+
+// GenerateUsageReport walks block activity (Txs delivered in every block) and token ledger
+// of db (latest version) and sums usage and fee by node and method of blocks in height range.
+// toHeight 0 is latest committed height.
+func GenerateUsageReport(db dbm.DB, fromHeight int64, toHeight int64) (report UsageReport, err error) {
+	if toHeight == 0 {
+		toHeight = loadAppStateMetadata(db).Height
+	}
+	if fromHeight <= 0 || toHeight < fromHeight {
+		return report, fmt.Errorf("Invalid height range %d-%d", fromHeight, toHeight)
+	}
+	report.FromHeight = fromHeight
+	report.ToHeight = toHeight
+	rows := make(map[string]*UsageReportRow)
+	getRow := func(nodeID string, method string) *UsageReportRow {
+		rowKey := nodeID + keySeparator + method
+		row, ok := rows[rowKey]
+		if !ok {
+			row = &UsageReportRow{NodeID: nodeID, Method: method}
+			rows[rowKey] = row
+		}
+		return row
+	}
+
+	for height := fromHeight; height <= toHeight; height++ {
+		activityValue := db.Get(getBlockActivityKey(height))
+		if activityValue == nil {
+			continue
+		}
+		var activity data.BlockActivity
+		err = proto.Unmarshal(activityValue, &activity)
+		if err != nil {
+			return report, fmt.Errorf("Error unmarshaling block activity of height %d: %v", height, err)
+		}
+		for _, tx := range activity.TxList {
+			row := getRow(tx.NodeId, tx.Method)
+			row.TxCount++
+			if tx.Code == code.OK {
+				row.SuccessCount++
+			}
+		}
+	}
+
+	prefix := tokenLedgerKeyPrefix + keySeparator
+	itr := db.Iterator([]byte(prefix), []byte(tokenLedgerKeyPrefix+"}"))
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		key := strings.TrimPrefix(string(itr.Key()), prefix)
+		separatorIndex := strings.LastIndex(key, keySeparator)
+		if separatorIndex < 0 {
+			continue
+		}
+		nodeID := key[:separatorIndex]
+		var entry data.TokenLedgerEntry
+		err = proto.Unmarshal(itr.Value(), &entry)
+		if err != nil {
+			return report, fmt.Errorf("Error unmarshaling token ledger entry %s: %v", itr.Key(), err)
+		}
+		if entry.BlockHeight < fromHeight || entry.BlockHeight > toHeight {
+			continue
+		}
+		switch entry.Type {
+		case tokenLedgerEntryTypeCharge:
+			// Reason of charge is method of Tx
+			getRow(nodeID, entry.Reason).Fee -= entry.Amount
+		case tokenLedgerEntryTypeRefund, tokenLedgerEntryTypeEscrow:
+			getRow(nodeID, "("+entry.Type+")").Fee -= entry.Amount
+		}
+	}
+
+	report.Rows = make([]UsageReportRow, 0, len(rows))
+	for _, rowKey := range sortedUsageReportRowKeys(rows) {
+		report.Rows = append(report.Rows, *rows[rowKey])
+	}
+	return report, nil
+}
+
+func sortedUsageReportRowKeys(rows map[string]*UsageReportRow) []string {
+	rowKeys := make([]string, 0, len(rows))
+	for rowKey := range rows {
+		rowKeys = append(rowKeys, rowKey)
+	}
+	sort.Slice(rowKeys, func(i, j int) bool {
+		rowI, rowJ := rows[rowKeys[i]], rows[rowKeys[j]]
+		if rowI.NodeID != rowJ.NodeID {
+			return rowI.NodeID < rowJ.NodeID
+		}
+		return rowI.Method < rowJ.Method
+	})
+	return rowKeys
+}
+
+// CSV returns usage report as CSV with header row. The same report always gives the same
+// bytes so that its signature can be verified against CSV regenerated from the same state.
+func (report UsageReport) CSV() ([]byte, error) {
+	rows := [][]string{{"from_height", "to_height", "node_id", "method", "tx_count", "success_count", "fee"}}
+	from := strconv.FormatInt(report.FromHeight, 10)
+	to := strconv.FormatInt(report.ToHeight, 10)
+	for _, row := range report.Rows {
+		rows = append(rows, []string{
+			from,
+			to,
+			row.NodeID,
+			row.Method,
+			strconv.FormatInt(row.TxCount, 10),
+			strconv.FormatInt(row.SuccessCount, 10),
+			strconv.FormatFloat(row.Fee, 'f', -1, 64),
+		})
+	}
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	err := writer.WriteAll(rows)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
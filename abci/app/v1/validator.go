@@ -27,15 +27,23 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cmn "github.com/tendermint/tendermint/libs/common"
 
 	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/keys"
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
 )
 
 const (
-	ValidatorSetChangePrefix string = "val:"
+	ValidatorSetChangePrefix string = keys.ValidatorPrefix
 )
 
 func isValidatorTx(tx []byte) bool {
@@ -58,10 +66,16 @@ func (app *ABCIApplication) Validators() (validators []types.Validator) {
 	return
 }
 
-// add, update, or remove a validator
+// add, update, or remove a validator. Validator can be updated only once in a block
+// so that validator updates returned from EndBlock don't conflict, and update which
+// does not change power of validator is ignored.
 func (app *ABCIApplication) updateValidator(v types.ValidatorUpdate) types.ResponseDeliverTx {
 	pubKeyBase64 := base64.StdEncoding.EncodeToString(v.PubKey.GetData())
-	key := []byte("val:" + pubKeyBase64)
+	key := keys.Validator(pubKeyBase64)
+
+	if _, exist := app.valUpdates[pubKeyBase64]; exist {
+		return app.ReturnDeliverTxLog(code.DuplicateValidatorUpdate, "Validator is already updated in this block", "")
+	}
 
 	if v.Power == 0 {
 		// remove validator
@@ -70,6 +84,14 @@ func (app *ABCIApplication) updateValidator(v types.ValidatorUpdate) types.Respo
 		}
 		app.state.Delete(key)
 	} else {
+		currentValue, _ := app.state.Get(key, false)
+		if currentValue != nil {
+			var current types.ValidatorUpdate
+			err := types.ReadMessage(bytes.NewBuffer(currentValue), &current)
+			if err == nil && current.Power == v.Power {
+				return app.ReturnDeliverTxLog(code.OK, "success", "")
+			}
+		}
 		// add or update validator
 		value := bytes.NewBuffer(make([]byte, 0))
 		if err := types.WriteMessage(&v, value); err != nil {
@@ -99,5 +121,572 @@ func (app *ABCIApplication) setValidator(param string, nodeID string) types.Resp
 	var newValidator types.ValidatorUpdate
 	newValidator.PubKey = pubKeyObj
 	newValidator.Power = funcParam.Power
+	// Power of added or updated validator is decided by NDID policy if set
+	if newValidator.Power != 0 {
+		policy, err := app.getValidatorPowerPolicy(false)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+		}
+		switch policy.Mode {
+		case validatorPowerPolicyModeEqual:
+			newValidator.Power = policy.EqualPower
+		case validatorPowerPolicyModeTiered:
+			found := false
+			for _, powerClass := range policy.PowerClassList {
+				if powerClass.Name == funcParam.PowerClass {
+					newValidator.Power = powerClass.Power
+					found = true
+					break
+				}
+			}
+			if !found {
+				return app.ReturnDeliverTxLog(code.ValidatorPowerClassNotFound, "Validator power class not found", "")
+			}
+		}
+	}
+	if funcParam.ActivationHeight != 0 {
+		if funcParam.ActivationHeight <= app.state.CurrentBlockHeight {
+			return app.ReturnDeliverTxLog(code.InvalidActivationHeight, "Activation height must be greater than current block height", "")
+		}
+		return app.stageValidatorUpdate(funcParam.ActivationHeight, newValidator)
+	}
 	return app.updateValidator(newValidator)
 }
+
+// copyValUpdates returns copy of validator updates of current block for restoring them
+// when changes made after it are reverted
+func (app *ABCIApplication) copyValUpdates() map[string]types.ValidatorUpdate {
+	valUpdates := make(map[string]types.ValidatorUpdate, len(app.valUpdates))
+	for key, valUpdate := range app.valUpdates {
+		valUpdates[key] = valUpdate
+	}
+	return valUpdates
+}
+
+func getPendingValidatorUpdateKey(activationHeight int64) []byte {
+	return []byte(pendingValidatorKeyPrefix + keySeparator + strconv.FormatInt(activationHeight, 10))
+}
+
+// stageValidatorUpdate keeps validator change to be applied and returned from EndBlock at activation height
+func (app *ABCIApplication) stageValidatorUpdate(activationHeight int64, v types.ValidatorUpdate) types.ResponseDeliverTx {
+	key := getPendingValidatorUpdateKey(activationHeight)
+	var pendingList data.PendingValidatorUpdateList
+	value, _ := app.state.Get(key, false)
+	if value != nil {
+		err := proto.Unmarshal(value, &pendingList)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+		}
+	}
+	pendingList.ValidatorUpdates = append(pendingList.ValidatorUpdates, &data.PendingValidatorUpdate{
+		PublicKey: base64.StdEncoding.EncodeToString(v.PubKey.GetData()),
+		Power:     v.Power,
+	})
+	value, err := utils.ProtoDeterministicMarshal(&pendingList)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(key, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) activatePendingValidatorUpdates(height int64) {
+	key := getPendingValidatorUpdateKey(height)
+	value, _ := app.state.Get(key, false)
+	if value == nil {
+		return
+	}
+	app.state.Delete(key)
+	var pendingList data.PendingValidatorUpdateList
+	err := proto.Unmarshal(value, &pendingList)
+	if err != nil {
+		app.logger.Errorf("Error unmarshaling pending validator update list: %s", err.Error())
+		return
+	}
+	for _, pending := range pendingList.ValidatorUpdates {
+		pubKey, err := base64.StdEncoding.DecodeString(pending.PublicKey)
+		if err != nil {
+			app.logger.Errorf("Error decoding pending validator public key: %s", err.Error())
+			continue
+		}
+		var validator types.ValidatorUpdate
+		validator.PubKey = types.PubKey{Type: "ed25519", Data: pubKey}
+		validator.Power = pending.Power
+		res := app.updateValidator(validator)
+		if res.Code != code.OK {
+			app.logger.Errorf("Error activating pending validator update %s: %s", pending.PublicKey, res.Log)
+			continue
+		}
+		app.logger.Infof("Activated pending validator update %s with power %d", pending.PublicKey, pending.Power)
+	}
+}
+
+const (
+	validatorPowerPolicyModeEqual  = "equal"
+	validatorPowerPolicyModeTiered = "tiered"
+)
+
+func (app *ABCIApplication) getValidatorPowerPolicy(committedState bool) (data.ValidatorPowerPolicy, error) {
+	var policy data.ValidatorPowerPolicy
+	value, _ := app.state.Get(validatorPowerPolicyKeyBytes, committedState)
+	if value == nil {
+		return policy, nil
+	}
+	err := proto.Unmarshal(value, &policy)
+	return policy, err
+}
+
+// setValidatorPowerPolicy sets how power of validator added or updated by SetValidator is decided.
+// Mode "equal" gives every validator the same power, "tiered" gives power of power class
+// named in SetValidator and empty mode uses power in SetValidator as is.
+func (app *ABCIApplication) setValidatorPowerPolicy(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetValidatorPowerPolicy, Parameter: %s", param)
+	var funcParam ValidatorPowerPolicyParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	var policy data.ValidatorPowerPolicy
+	policy.Mode = funcParam.Mode
+	switch funcParam.Mode {
+	case "":
+		app.state.Delete(validatorPowerPolicyKeyBytes)
+		return app.ReturnDeliverTxLog(code.OK, "success", "")
+	case validatorPowerPolicyModeEqual:
+		if funcParam.EqualPower <= 0 {
+			return app.ReturnDeliverTxLog(code.InvalidValidatorPowerPolicy, "Equal power must be greater than zero", "")
+		}
+		policy.EqualPower = funcParam.EqualPower
+	case validatorPowerPolicyModeTiered:
+		if len(funcParam.PowerClassList) == 0 {
+			return app.ReturnDeliverTxLog(code.InvalidValidatorPowerPolicy, "Power class list cannot be empty", "")
+		}
+		powerClassNames := make(map[string]bool)
+		for _, powerClass := range funcParam.PowerClassList {
+			if powerClass.Name == "" || powerClassNames[powerClass.Name] {
+				return app.ReturnDeliverTxLog(code.InvalidValidatorPowerPolicy, "Power class name must be unique and not empty", "")
+			}
+			if powerClass.Power <= 0 {
+				return app.ReturnDeliverTxLog(code.InvalidValidatorPowerPolicy, "Power of power class must be greater than zero", "")
+			}
+			powerClassNames[powerClass.Name] = true
+			policy.PowerClassList = append(policy.PowerClassList, &data.ValidatorPowerClass{
+				Name:  powerClass.Name,
+				Power: powerClass.Power,
+			})
+		}
+	default:
+		return app.ReturnDeliverTxLog(code.InvalidValidatorPowerPolicy, "Unknown validator power policy mode", "")
+	}
+	value, err := utils.ProtoDeterministicMarshal(&policy)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(validatorPowerPolicyKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getValidatorPowerPolicyQuery(param string) types.ResponseQuery {
+	app.logger.Infof("GetValidatorPowerPolicy, Parameter: %s", param)
+	policy, err := app.getValidatorPowerPolicy(true)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	var result ValidatorPowerPolicyParam
+	result.Mode = policy.Mode
+	result.EqualPower = policy.EqualPower
+	result.PowerClassList = make([]ValidatorPowerClass, 0, len(policy.PowerClassList))
+	for _, powerClass := range policy.PowerClassList {
+		result.PowerClassList = append(result.PowerClassList, ValidatorPowerClass{
+			Name:  powerClass.Name,
+			Power: powerClass.Power,
+		})
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
+
+// getValidatorAddress returns Tendermint validator address of base64 encoded ed25519 public key
+func getValidatorAddress(publicKey string) (string, error) {
+	pubKey, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", err
+	}
+	if len(pubKey) != ed25519.PubKeyEd25519Size {
+		return "", fmt.Errorf("Invalid ed25519 public key length: %d", len(pubKey))
+	}
+	var pubKeyEd25519 ed25519.PubKeyEd25519
+	copy(pubKeyEd25519[:], pubKey)
+	return fmt.Sprintf("%X", pubKeyEd25519.Address()), nil
+}
+
+func (app *ABCIApplication) setValidatorNode(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetValidatorNode, Parameter: %s", param)
+	var funcParam SetValidatorNodeParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	address, err := getValidatorAddress(funcParam.PublicKey)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.InvalidValidatorPublicKey, err.Error(), "")
+	}
+	validatorNodeKey := validatorNodeKeyPrefix + keySeparator + address
+	// Remove binding if node ID is empty
+	if funcParam.NodeID == "" {
+		app.state.Delete([]byte(validatorNodeKey))
+		return app.ReturnDeliverTxLog(code.OK, "success", "")
+	}
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
+	if !app.state.Has([]byte(nodeDetailKey), false) {
+		return app.ReturnDeliverTxLog(code.NodeIDNotFound, "Node ID not found", "")
+	}
+	var validatorNode data.ValidatorNode
+	validatorNodeValue, _ := app.state.Get([]byte(validatorNodeKey), false)
+	if validatorNodeValue != nil {
+		err = proto.Unmarshal(validatorNodeValue, &validatorNode)
+		if err != nil {
+			return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+		}
+	}
+	// Accountability counters belong to node, reset when validator is bound to another node
+	if validatorNode.NodeId != funcParam.NodeID {
+		validatorNode = data.ValidatorNode{}
+	}
+	validatorNode.PublicKey = funcParam.PublicKey
+	validatorNode.NodeId = funcParam.NodeID
+	validatorNodeValue, err = utils.ProtoDeterministicMarshal(&validatorNode)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set([]byte(validatorNodeKey), validatorNodeValue)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+// recordValidatorAccountability counts missed blocks and byzantine evidences
+// of validators bound to node using consensus data of the block. Returns event
+// for each validator which consecutive missed blocks reach threshold set by NDID.
+func (app *ABCIApplication) recordValidatorAccountability(req types.RequestBeginBlock) (events []types.Event) {
+	threshold := app.getValidatorMissThresholdFromStateDB(false)
+	for _, vote := range req.LastCommitInfo.Votes {
+		if vote.SignedLastBlock {
+			app.resetValidatorConsecutiveMissedBlockCount(vote.Validator.Address)
+			continue
+		}
+		app.updateValidatorNodeCounter(vote.Validator.Address, func(validatorNode *data.ValidatorNode) {
+			validatorNode.MissedBlockCount++
+			validatorNode.ConsecutiveMissedBlockCount++
+			app.logger.Warnf("Validator %X of node %s did not sign last block", vote.Validator.Address, validatorNode.NodeId)
+			// Emit only once when threshold is reached, not on every block missed after that
+			if threshold > 0 && validatorNode.ConsecutiveMissedBlockCount == threshold {
+				events = append(events, types.Event{
+					Type: "did.validator_missed_blocks",
+					Attributes: []cmn.KVPair{
+						{Key: []byte("node_id"), Value: []byte(validatorNode.NodeId)},
+						{Key: []byte("address"), Value: []byte(fmt.Sprintf("%X", vote.Validator.Address))},
+						{Key: []byte("consecutive_missed_block_count"), Value: []byte(strconv.FormatInt(validatorNode.ConsecutiveMissedBlockCount, 10))},
+						{Key: []byte("threshold"), Value: []byte(strconv.FormatInt(threshold, 10))},
+					},
+				})
+			}
+		})
+	}
+	for _, evidence := range req.ByzantineEvidence {
+		app.updateValidatorNodeCounter(evidence.Validator.Address, func(validatorNode *data.ValidatorNode) {
+			validatorNode.ByzantineEvidenceCount++
+			app.logger.Errorf("Byzantine evidence %s at height %d against validator %X of node %s", evidence.Type, evidence.Height, evidence.Validator.Address, validatorNode.NodeId)
+			app.recordValidatorMisbehavior(evidence, validatorNode)
+		})
+	}
+	return events
+}
+
+const (
+	validatorMisbehaviorActionSuspended = "suspended"
+	validatorMisbehaviorActionRecorded  = "recorded"
+)
+
+// recordValidatorMisbehavior suspends validator with byzantine evidence (removes it from
+// validator set) and keeps record of evidence and action taken under node of validator
+func (app *ABCIApplication) recordValidatorMisbehavior(evidence types.Evidence, validatorNode *data.ValidatorNode) {
+	action := validatorMisbehaviorActionRecorded
+	pubKey, err := base64.StdEncoding.DecodeString(validatorNode.PublicKey)
+	if err == nil && app.state.Has([]byte(ValidatorSetChangePrefix+validatorNode.PublicKey), false) {
+		var validator types.ValidatorUpdate
+		validator.PubKey = types.PubKey{Type: "ed25519", Data: pubKey}
+		validator.Power = 0
+		res := app.updateValidator(validator)
+		if res.Code == code.OK {
+			action = validatorMisbehaviorActionSuspended
+		} else {
+			app.logger.Errorf("Error suspending validator %X: %s", evidence.Validator.Address, res.Log)
+		}
+	}
+	misbehaviorKey := misbehaviorKeyPrefix + keySeparator + validatorNode.NodeId
+	var misbehaviorList data.ValidatorMisbehaviorList
+	misbehaviorValue, _ := app.state.Get([]byte(misbehaviorKey), false)
+	if misbehaviorValue != nil {
+		err = proto.Unmarshal(misbehaviorValue, &misbehaviorList)
+		if err != nil {
+			app.logger.Errorf("Error unmarshaling validator misbehavior list: %s", err.Error())
+			return
+		}
+	}
+	misbehaviorList.Misbehaviors = append(misbehaviorList.Misbehaviors, &data.ValidatorMisbehavior{
+		Address:        fmt.Sprintf("%X", evidence.Validator.Address),
+		PublicKey:      validatorNode.PublicKey,
+		EvidenceType:   evidence.Type,
+		EvidenceHeight: evidence.Height,
+		RecordedHeight: app.state.CurrentBlockHeight,
+		Action:         action,
+	})
+	misbehaviorValue, err = utils.ProtoDeterministicMarshal(&misbehaviorList)
+	if err != nil {
+		app.logger.Errorf("Error marshaling validator misbehavior list: %s", err.Error())
+		return
+	}
+	app.state.Set([]byte(misbehaviorKey), misbehaviorValue)
+}
+
+func (app *ABCIApplication) updateValidatorNodeCounter(address []byte, update func(validatorNode *data.ValidatorNode)) {
+	validatorNodeKey := validatorNodeKeyPrefix + keySeparator + fmt.Sprintf("%X", address)
+	validatorNodeValue, _ := app.state.Get([]byte(validatorNodeKey), false)
+	if validatorNodeValue == nil {
+		return
+	}
+	var validatorNode data.ValidatorNode
+	err := proto.Unmarshal(validatorNodeValue, &validatorNode)
+	if err != nil {
+		app.logger.Errorf("Error unmarshaling validator node: %s", err.Error())
+		return
+	}
+	update(&validatorNode)
+	validatorNodeValue, err = utils.ProtoDeterministicMarshal(&validatorNode)
+	if err != nil {
+		app.logger.Errorf("Error marshaling validator node: %s", err.Error())
+		return
+	}
+	app.state.Set([]byte(validatorNodeKey), validatorNodeValue)
+}
+
+// resetValidatorConsecutiveMissedBlockCount writes validator node only when there is
+// streak of missed blocks to reset so signing validators do not change state every block
+func (app *ABCIApplication) resetValidatorConsecutiveMissedBlockCount(address []byte) {
+	validatorNodeKey := validatorNodeKeyPrefix + keySeparator + fmt.Sprintf("%X", address)
+	validatorNodeValue, _ := app.state.Get([]byte(validatorNodeKey), false)
+	if validatorNodeValue == nil {
+		return
+	}
+	var validatorNode data.ValidatorNode
+	err := proto.Unmarshal(validatorNodeValue, &validatorNode)
+	if err != nil {
+		app.logger.Errorf("Error unmarshaling validator node: %s", err.Error())
+		return
+	}
+	if validatorNode.ConsecutiveMissedBlockCount == 0 {
+		return
+	}
+	app.updateValidatorNodeCounter(address, func(validatorNode *data.ValidatorNode) {
+		validatorNode.ConsecutiveMissedBlockCount = 0
+	})
+}
+
+func (app *ABCIApplication) getValidatorMissThresholdFromStateDB(committedState bool) int64 {
+	value, _ := app.state.Get(validatorMissThresholdKeyBytes, committedState)
+	if value == nil {
+		return 0
+	}
+	var threshold data.ValidatorMissThreshold
+	err := proto.Unmarshal(value, &threshold)
+	if err != nil {
+		return 0
+	}
+	return threshold.Threshold
+}
+
+// setValidatorMissThreshold sets number of consecutive blocks a validator bound to node
+// may miss before did.validator_missed_blocks event is emitted. 0 disables the event.
+func (app *ABCIApplication) setValidatorMissThreshold(param string, nodeID string) types.ResponseDeliverTx {
+	app.logger.Infof("SetValidatorMissThreshold, Parameter: %s", param)
+	var funcParam ValidatorMissThresholdParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.UnmarshalError, err.Error(), "")
+	}
+	if funcParam.Threshold < 0 {
+		return app.ReturnDeliverTxLog(code.ThresholdMustBeGreaterOrEqualToZero, "Threshold must be greater than or equal to zero", "")
+	}
+	var threshold data.ValidatorMissThreshold
+	threshold.Threshold = funcParam.Threshold
+	value, err := utils.ProtoDeterministicMarshal(&threshold)
+	if err != nil {
+		return app.ReturnDeliverTxLog(code.MarshalError, err.Error(), "")
+	}
+	app.state.Set(validatorMissThresholdKeyBytes, value)
+	return app.ReturnDeliverTxLog(code.OK, "success", "")
+}
+
+func (app *ABCIApplication) getValidatorMissThreshold(param string) types.ResponseQuery {
+	app.logger.Infof("GetValidatorMissThreshold, Parameter: %s", param)
+	var result ValidatorMissThresholdParam
+	result.Threshold = app.getValidatorMissThresholdFromStateDB(true)
+	value, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(value, "success", app.state.Height)
+}
+
+func newValidatorNodeResult(address string, validatorNode *data.ValidatorNode) ValidatorNodeResult {
+	return ValidatorNodeResult{
+		PublicKey:                   validatorNode.PublicKey,
+		Address:                     address,
+		NodeID:                      validatorNode.NodeId,
+		MissedBlockCount:            validatorNode.MissedBlockCount,
+		ConsecutiveMissedBlockCount: validatorNode.ConsecutiveMissedBlockCount,
+		ByzantineEvidenceCount:      validatorNode.ByzantineEvidenceCount,
+	}
+}
+
+func (app *ABCIApplication) getValidatorNode(param string) types.ResponseQuery {
+	app.logger.Infof("GetValidatorNode, Parameter: %s", param)
+	var funcParam GetValidatorNodeParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	address, err := getValidatorAddress(funcParam.PublicKey)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	validatorNodeKey := validatorNodeKeyPrefix + keySeparator + address
+	validatorNodeValue, _ := app.state.Get([]byte(validatorNodeKey), true)
+	if validatorNodeValue == nil {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var validatorNode data.ValidatorNode
+	err = proto.Unmarshal(validatorNodeValue, &validatorNode)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	returnValue, err := json.Marshal(newValidatorNodeResult(address, &validatorNode))
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+func (app *ABCIApplication) getValidatorNodeList(param string) types.ResponseQuery {
+	app.logger.Infof("GetValidatorNodeList, Parameter: %s", param)
+	var funcParam GetValidatorNodeListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	var result GetValidatorNodeListResult
+	result.ValidatorList = make([]ValidatorNodeResult, 0)
+	prefix := []byte(validatorNodeKeyPrefix + keySeparator)
+	app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		var validatorNode data.ValidatorNode
+		err = proto.Unmarshal(value, &validatorNode)
+		if err != nil {
+			return false
+		}
+		if funcParam.NodeID != "" && validatorNode.NodeId != funcParam.NodeID {
+			return true
+		}
+		address := strings.TrimPrefix(string(key), string(prefix))
+		result.ValidatorList = append(result.ValidatorList, newValidatorNodeResult(address, &validatorNode))
+		return true
+	})
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+func (app *ABCIApplication) getValidatorMisbehaviorList(param string) types.ResponseQuery {
+	app.logger.Infof("GetValidatorMisbehaviorList, Parameter: %s", param)
+	var funcParam GetValidatorMisbehaviorListParam
+	err := json.Unmarshal([]byte(param), &funcParam)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.InvalidQueryParameter, nil, err.Error(), app.state.Height)
+	}
+	nodeDetailKey := nodeIDKeyPrefix + keySeparator + funcParam.NodeID
+	if !app.state.Has([]byte(nodeDetailKey), true) {
+		return app.ReturnQueryWithCode(code.ResultNotFound, []byte("{}"), "not found", app.state.Height)
+	}
+	var result GetValidatorMisbehaviorListResult
+	result.NodeID = funcParam.NodeID
+	result.MisbehaviorList = make([]ValidatorMisbehaviorResult, 0)
+	misbehaviorKey := misbehaviorKeyPrefix + keySeparator + funcParam.NodeID
+	misbehaviorValue, _ := app.state.Get([]byte(misbehaviorKey), true)
+	if misbehaviorValue != nil {
+		var misbehaviorList data.ValidatorMisbehaviorList
+		err = proto.Unmarshal(misbehaviorValue, &misbehaviorList)
+		if err != nil {
+			return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+		}
+		for _, misbehavior := range misbehaviorList.Misbehaviors {
+			result.MisbehaviorList = append(result.MisbehaviorList, ValidatorMisbehaviorResult{
+				Address:        misbehavior.Address,
+				PublicKey:      misbehavior.PublicKey,
+				EvidenceType:   misbehavior.EvidenceType,
+				EvidenceHeight: misbehavior.EvidenceHeight,
+				RecordedHeight: misbehavior.RecordedHeight,
+				Action:         misbehavior.Action,
+			})
+		}
+	}
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
+
+func (app *ABCIApplication) getPendingValidatorUpdateList(param string) types.ResponseQuery {
+	app.logger.Infof("GetPendingValidatorUpdateList, Parameter: %s", param)
+	var result GetPendingValidatorUpdateListResult
+	result.ValidatorUpdateList = make([]PendingValidatorUpdateResult, 0)
+	var err error
+	prefix := []byte(pendingValidatorKeyPrefix + keySeparator)
+	app.state.IterateCommitted(prefix, func(key, value []byte) bool {
+		var activationHeight int64
+		activationHeight, err = strconv.ParseInt(strings.TrimPrefix(string(key), string(prefix)), 10, 64)
+		if err != nil {
+			return false
+		}
+		var pendingList data.PendingValidatorUpdateList
+		err = proto.Unmarshal(value, &pendingList)
+		if err != nil {
+			return false
+		}
+		for _, pending := range pendingList.ValidatorUpdates {
+			result.ValidatorUpdateList = append(result.ValidatorUpdateList, PendingValidatorUpdateResult{
+				ActivationHeight: activationHeight,
+				PublicKey:        pending.PublicKey,
+				Power:            pending.Power,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return app.ReturnQueryWithCode(code.UnmarshalError, nil, err.Error(), app.state.Height)
+	}
+	sort.SliceStable(result.ValidatorUpdateList, func(i, j int) bool {
+		return result.ValidatorUpdateList[i].ActivationHeight < result.ValidatorUpdateList[j].ActivationHeight
+	})
+	returnValue, err := json.Marshal(result)
+	if err != nil {
+		return app.ReturnQueryWithCode(code.MarshalError, nil, err.Error(), app.state.Height)
+	}
+	return app.ReturnQuery(returnValue, "success", app.state.Height)
+}
@@ -0,0 +1,130 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/protos/data"
+)
+
+// versionedValueKeyFormat matches key of value of a version of versioned key
+var versionedValueKeyFormat = regexp.MustCompile(`^(.*)\|[0-9]+$`)
+
+type VerifyStateResult struct {
+	Height          int64
+	CheckedKeyCount int64
+	// Mismatches are keys which values in DB are different from change journal
+	Mismatches []string
+	// KeyCount and ByteSize are counted from DB, they may be different from app state
+	// metadata when node stopped while old versions were being pruned
+	KeyCount int64
+	ByteSize int64
+}
+
+// VerifyState checks state in DB against change journal of latest journalBlocks blocks.
+// Latest change of each key in the journals must match value in DB. Value of old version
+// of versioned key may be missing since it may be pruned. App hash is chained from
+// changes of every block so it can't be recomputed from DB, only its presence is checked.
+func VerifyState(db dbm.DB, journalBlocks int64) (result VerifyStateResult, err error) {
+	metadata := loadAppStateMetadata(db)
+	result.Height = metadata.Height
+	if metadata.Height > 0 && len(metadata.AppHash) == 0 {
+		return result, fmt.Errorf("App hash of height %d is missing", metadata.Height)
+	}
+	checkedKeys := make(map[string]bool)
+	for height := metadata.Height; height > 0 && height > metadata.Height-journalBlocks; height-- {
+		journalValue := db.Get(getChangeJournalKey(height))
+		if journalValue == nil {
+			continue
+		}
+		var journal data.ChangeJournal
+		err = proto.Unmarshal(journalValue, &journal)
+		if err != nil {
+			return result, fmt.Errorf("Error unmarshaling change journal of height %d: %v", height, err)
+		}
+		for _, change := range journal.Changes {
+			// Newer change of key is checked already
+			if checkedKeys[change.Key] {
+				continue
+			}
+			checkedKeys[change.Key] = true
+			result.CheckedKeyCount++
+			value := db.Get([]byte(change.Key))
+			if change.Deleted {
+				if value != nil {
+					result.Mismatches = append(result.Mismatches, fmt.Sprintf("%q exists but is deleted at height %d", change.Key, height))
+				}
+				continue
+			}
+			if value == nil {
+				if matches := versionedValueKeyFormat.FindStringSubmatch(change.Key); matches != nil && db.Has([]byte(matches[1]+"|versions")) {
+					continue
+				}
+				result.Mismatches = append(result.Mismatches, fmt.Sprintf("%q is missing but is set at height %d", change.Key, height))
+				continue
+			}
+			valueHash := sha256.Sum256(value)
+			if !bytes.Equal(valueHash[:], change.ValueHash) {
+				result.Mismatches = append(result.Mismatches, fmt.Sprintf("%q has different value from value set at height %d", change.Key, height))
+			}
+		}
+	}
+	itr := db.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		if bytes.Equal(itr.Key(), appStateMetadataKey) {
+			continue
+		}
+		result.KeyCount++
+		result.ByteSize += int64(len(itr.Key()) + len(itr.Value()))
+	}
+	if len(result.Mismatches) > 0 {
+		return result, fmt.Errorf("State of height %d does not match change journal: %d mismatched keys", metadata.Height, len(result.Mismatches))
+	}
+	return result, nil
+}
+
+// VerifyState verifies state loaded by app, see VerifyState
+func (app *ABCIApplication) VerifyState(journalBlocks int64) (VerifyStateResult, error) {
+	result, err := VerifyState(app.state.db, journalBlocks)
+	for _, mismatch := range result.Mismatches {
+		app.logger.Errorf("State verification: %s", mismatch)
+	}
+	if err != nil {
+		return result, err
+	}
+	if result.KeyCount != app.state.KeyCount || result.ByteSize != app.state.ByteSize {
+		app.logger.Warnf("State verification: key count %d and byte size %d in DB are different from %d and %d in app state metadata",
+			result.KeyCount, result.ByteSize, app.state.KeyCount, app.state.ByteSize)
+	}
+	app.logger.Infof("State verification: %d keys changed in latest %d blocks up to height %d match change journal",
+		result.CheckedKeyCount, journalBlocks, result.Height)
+	return result, nil
+}
@@ -0,0 +1,446 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Package bench drives local ABCI app instance with generated signed Txs
+// and measures throughput, DeliverTx latency and state growth.
+package bench
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	mathRand "math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tendermint/tendermint/abci/types"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/abci/storage"
+	"github.com/ndidplatform/smart-contract/v4/client"
+)
+
+const (
+	chainID   = "bench-chain"
+	serviceID = "bench_service"
+)
+
+// DefaultMix is method mix used when no mix is given
+const DefaultMix = "CreateRequest=4,CreateIdpResponse=3,SignData=2,CloseRequest=1"
+
+// supportedMethods are methods which can be generated by bench
+var supportedMethods = map[string]bool{
+	"CreateRequest":     true,
+	"CreateIdpResponse": true,
+	"SignData":          true,
+	"SetDataReceived":   true,
+	"CloseRequest":      true,
+	"SetMqAddresses":    true,
+}
+
+// Config is configuration of bench run
+type Config struct {
+	TxCount   int
+	BlockSize int
+	Mix       map[string]int
+	DBType    string
+	DBDir     string
+	Seed      int64
+}
+
+// Result is result of bench run
+type Result struct {
+	TxCount              int
+	FailedTxCount        int
+	BlockCount           int
+	Duration             time.Duration
+	TxPerSecond          float64
+	DeliverTxLatencyP50  time.Duration
+	DeliverTxLatencyP99  time.Duration
+	StateSizeBytes       int64
+	StateGrowthPer10kTxs int64
+}
+
+func (result *Result) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Txs:                       %d (%d failed)\n", result.TxCount, result.FailedTxCount)
+	fmt.Fprintf(&b, "Blocks:                    %d\n", result.BlockCount)
+	fmt.Fprintf(&b, "Duration:                  %s\n", result.Duration)
+	fmt.Fprintf(&b, "Throughput:                %.2f tx/s\n", result.TxPerSecond)
+	fmt.Fprintf(&b, "DeliverTx latency p50:     %s\n", result.DeliverTxLatencyP50)
+	fmt.Fprintf(&b, "DeliverTx latency p99:     %s\n", result.DeliverTxLatencyP99)
+	fmt.Fprintf(&b, "State size:                %d bytes\n", result.StateSizeBytes)
+	fmt.Fprintf(&b, "State growth per 10k txs:  %d bytes\n", result.StateGrowthPer10kTxs)
+	return b.String()
+}
+
+// ParseMix parses method mix in format "Method=weight,Method=weight"
+func ParseMix(mix string) (map[string]int, error) {
+	result := make(map[string]int)
+	for _, item := range strings.Split(mix, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid mix item: %s", item)
+		}
+		if !supportedMethods[parts[0]] {
+			return nil, fmt.Errorf("Unsupported method: %s", parts[0])
+		}
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("Invalid weight of %s: %s", parts[0], parts[1])
+		}
+		result[parts[0]] = weight
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("Mix cannot be empty")
+	}
+	return result, nil
+}
+
+type node struct {
+	id      string
+	privKey *rsa.PrivateKey
+}
+
+type request struct {
+	id        string
+	responded bool
+	signed    bool
+	closed    bool
+}
+
+type runner struct {
+	app      *appV1.ABCIApplication
+	rand     *mathRand.Rand
+	height   int64
+	time     time.Time
+	nonce    int64
+	ndid     *node
+	rp       *node
+	idp      *node
+	as       *node
+	requests []*request
+	methods  []string
+}
+
+// Run runs bench with given config on new DB in config.DBDir
+func Run(config Config) (*Result, error) {
+	if config.TxCount <= 0 {
+		return nil, fmt.Errorf("Tx count must be greater than 0")
+	}
+	if config.BlockSize <= 0 {
+		return nil, fmt.Errorf("Block size must be greater than 0")
+	}
+	if config.DBDir == "" {
+		dbDir, err := ioutil.TempDir("", "abci-bench")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dbDir)
+		config.DBDir = dbDir
+	}
+	db, err := storage.OpenDB(config.DBType, config.DBDir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	r := &runner{
+		app:  appV1.NewABCIApplication(logrus.NewEntry(logger).WithFields(logrus.Fields{"module": "abci-bench"}), db),
+		rand: mathRand.New(mathRand.NewSource(config.Seed)),
+		time: time.Now(),
+	}
+	for method, weight := range config.Mix {
+		for i := 0; i < weight; i++ {
+			r.methods = append(r.methods, method)
+		}
+	}
+	if len(r.methods) == 0 {
+		return nil, fmt.Errorf("Mix must have at least one method with weight greater than 0")
+	}
+	// Map iteration order is random, sort to keep generated Txs reproducible by seed
+	sort.Strings(r.methods)
+
+	if err := r.setup(); err != nil {
+		return nil, err
+	}
+	initialSize, err := dirSize(config.DBDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Result
+	latencies := make([]time.Duration, 0, config.TxCount)
+	start := time.Now()
+	for result.TxCount < config.TxCount {
+		blockSize := config.BlockSize
+		if remaining := config.TxCount - result.TxCount; remaining < blockSize {
+			blockSize = remaining
+		}
+		txs := make([][]byte, 0, blockSize)
+		for i := 0; i < blockSize; i++ {
+			txs = append(txs, r.nextTx())
+		}
+		r.beginBlock()
+		for _, tx := range txs {
+			txStart := time.Now()
+			res := r.app.DeliverTx(types.RequestDeliverTx{Tx: tx})
+			latencies = append(latencies, time.Since(txStart))
+			if res.Code != code.OK {
+				result.FailedTxCount++
+			}
+		}
+		r.endBlock()
+		result.TxCount += len(txs)
+		result.BlockCount++
+	}
+	result.Duration = time.Since(start)
+
+	finalSize, err := dirSize(config.DBDir)
+	if err != nil {
+		return nil, err
+	}
+	result.TxPerSecond = float64(result.TxCount) / result.Duration.Seconds()
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	result.DeliverTxLatencyP50 = percentile(latencies, 50)
+	result.DeliverTxLatencyP99 = percentile(latencies, 99)
+	result.StateSizeBytes = finalSize
+	result.StateGrowthPer10kTxs = (finalSize - initialSize) * 10000 / int64(result.TxCount)
+	return &result, nil
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := (len(sorted)*p+99)/100 - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func newNode(nodeID string) (*node, error) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return &node{id: nodeID, privKey: privKey}, nil
+}
+
+func (n *node) publicKey() string {
+	pubKeyBytes, err := x509.MarshalPKIXPublicKey(&n.privKey.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKeyBytes}))
+}
+
+func (r *runner) setup() (err error) {
+	if r.ndid, err = newNode("bench_ndid"); err != nil {
+		return err
+	}
+	if r.rp, err = newNode("bench_rp"); err != nil {
+		return err
+	}
+	if r.idp, err = newNode("bench_idp"); err != nil {
+		return err
+	}
+	if r.as, err = newNode("bench_as"); err != nil {
+		return err
+	}
+
+	var initNDIDParam appV1.InitNDIDParam
+	initNDIDParam.NodeID = r.ndid.id
+	initNDIDParam.PublicKey = r.ndid.publicKey()
+	initNDIDParam.MasterPublicKey = r.ndid.publicKey()
+	txs := [][]byte{
+		r.createTx("InitNDID", initNDIDParam, r.ndid),
+		r.createTx("EndInit", appV1.EndInitParam{}, r.ndid),
+	}
+	for _, n := range []struct {
+		node *node
+		role string
+	}{{r.rp, "RP"}, {r.idp, "IdP"}, {r.as, "AS"}} {
+		var registerNodeParam appV1.RegisterNode
+		registerNodeParam.NodeID = n.node.id
+		registerNodeParam.PublicKey = n.node.publicKey()
+		registerNodeParam.MasterPublicKey = n.node.publicKey()
+		registerNodeParam.NodeName = n.node.id
+		registerNodeParam.Role = n.role
+		if n.role == "IdP" {
+			registerNodeParam.MaxIal = 3
+			registerNodeParam.MaxAal = 3
+		}
+		txs = append(txs,
+			r.createTx("RegisterNode", registerNodeParam, r.ndid),
+			r.createTx("SetNodeToken", appV1.SetNodeTokenParam{NodeID: n.node.id, Amount: 1e12}, r.ndid),
+		)
+	}
+	var addServiceParam appV1.AddServiceParam
+	addServiceParam.ServiceID = serviceID
+	addServiceParam.ServiceName = "Bench service"
+	txs = append(txs,
+		r.createTx("AddService", addServiceParam, r.ndid),
+		r.createTx("RegisterServiceDestinationByNDID", appV1.RegisterServiceDestinationByNDIDParam{ServiceID: serviceID, NodeID: r.as.id}, r.ndid),
+		r.createTx("RegisterServiceDestination", appV1.RegisterServiceDestinationParam{ServiceID: serviceID, MinIal: 1.1, MinAal: 1}, r.as),
+	)
+	for _, tx := range txs {
+		r.beginBlock()
+		res := r.app.DeliverTx(types.RequestDeliverTx{Tx: tx})
+		r.endBlock()
+		if res.Code != code.OK {
+			return fmt.Errorf("Setup failed: %s", res.Log)
+		}
+	}
+	return nil
+}
+
+func (r *runner) beginBlock() {
+	r.height++
+	r.time = r.time.Add(time.Second)
+	var header types.Header
+	header.ChainID = chainID
+	header.Height = r.height
+	header.Time = r.time
+	r.app.BeginBlock(types.RequestBeginBlock{Header: header})
+}
+
+func (r *runner) endBlock() {
+	r.app.EndBlock(types.RequestEndBlock{Height: r.height})
+	r.app.Commit()
+}
+
+func (r *runner) createTx(method string, param interface{}, n *node) []byte {
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		panic(err)
+	}
+	r.nonce++
+	nonce := base64.StdEncoding.EncodeToString([]byte(strconv.FormatInt(r.nonce, 10)))
+	signature, err := client.Sign(method, paramJSON, nonce, n.privKey)
+	if err != nil {
+		panic(err)
+	}
+	txBytes, err := client.NewTx(method, paramJSON, nonce, signature, n.id)
+	if err != nil {
+		panic(err)
+	}
+	return txBytes
+}
+
+// findRequest returns random request matching filter from recent requests
+func (r *runner) findRequest(filter func(*request) bool) *request {
+	start := len(r.requests) - 100
+	if start < 0 {
+		start = 0
+	}
+	for i := len(r.requests) - 1; i >= start; i-- {
+		if filter(r.requests[i]) {
+			return r.requests[i]
+		}
+	}
+	return nil
+}
+
+func (r *runner) nextTx() []byte {
+	switch r.methods[r.rand.Intn(len(r.methods))] {
+	case "CreateIdpResponse":
+		if req := r.findRequest(func(req *request) bool { return !req.responded && !req.closed }); req != nil {
+			req.responded = true
+			var param appV1.CreateIdpResponseParam
+			param.RequestID = req.id
+			param.Ial = 2.3
+			param.Aal = 3
+			param.Status = "accept"
+			param.Signature = "signature"
+			return r.createTx("CreateIdpResponse", param, r.idp)
+		}
+	case "SignData":
+		if req := r.findRequest(func(req *request) bool { return req.responded && !req.signed && !req.closed }); req != nil {
+			req.signed = true
+			return r.createTx("SignData", appV1.SignDataParam{RequestID: req.id, ServiceID: serviceID, Signature: "signature"}, r.as)
+		}
+	case "SetDataReceived":
+		if req := r.findRequest(func(req *request) bool { return req.signed && !req.closed }); req != nil {
+			return r.createTx("SetDataReceived", appV1.SetDataReceivedParam{RequestID: req.id, ServiceID: serviceID, AsID: r.as.id}, r.rp)
+		}
+	case "CloseRequest":
+		if req := r.findRequest(func(req *request) bool { return !req.closed }); req != nil {
+			req.closed = true
+			return r.createTx("CloseRequest", appV1.CloseRequestParam{RequestID: req.id}, r.rp)
+		}
+	case "SetMqAddresses":
+		return r.createTx("SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "127.0.0.1", Port: 8000 + r.rand.Int63n(1000)}}}, r.rp)
+	}
+	return r.createRequestTx()
+}
+
+func (r *runner) createRequestTx() []byte {
+	// Request ID must be UUID version 4
+	req := &request{id: fmt.Sprintf("%08x-0000-4000-8000-%012x", r.height, len(r.requests))}
+	r.requests = append(r.requests, req)
+	var param appV1.CreateRequestParam
+	param.RequestID = req.id
+	param.MinIdp = 1
+	param.MinIal = 1.1
+	param.MinAal = 1
+	param.Timeout = 3600
+	param.IdPIDList = []string{r.idp.id}
+	param.MessageHash = "hash"
+	param.Mode = 1
+	param.DataRequestList = []appV1.DataRequest{
+		{ServiceID: serviceID, As: []string{r.as.id}, Count: 1, RequestParamsHash: "hash"},
+	}
+	return r.createTx("CreateRequest", param, r.rp)
+}
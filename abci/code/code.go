@@ -127,5 +127,89 @@ const (
 	CannotRevokeAllAccessorsInThisIdP                  uint32 = 103
 	DuplicateIdentifier                                uint32 = 104
 	NewModeListMustBeHigherThanCurrentModeList         uint32 = 105
+	ParentIdPNotFound                                  uint32 = 106
+	NoPermissionForCallIdPOrIdPAgentMethod             uint32 = 107
+	ParentIdPIsNotActive                               uint32 = 108
+	PublicKeyIsRevoked                                 uint32 = 109
+	RequestMessageHashCannotBeEmpty                    uint32 = 110
+	RequestMessageHashMismatch                         uint32 = 111
+	DataSchemaVersionAlreadyExisted                    uint32 = 112
+	DataSchemaVersionNotFound                          uint32 = 113
+	ConsentReceiptHashCannotBeEmpty                    uint32 = 114
+	NotRequestOwnerOrRespondedIdP                      uint32 = 115
+	DuplicateTransaction                               uint32 = 116
+	BatchTxListCannotBeEmpty                           uint32 = 117
+	MethodIsNotAllowedInBatch                          uint32 = 118
+	StoreQueryIsDisabled                               uint32 = 119
+	NodeQuotaExceeded                                  uint32 = 120
+	QuotaLimitMustBeGreaterOrEqualToZero               uint32 = 121
+	IdPInResponseValidListHasNotResponded              uint32 = 122
+	DuplicateIdPInResponseValidList                    uint32 = 123
+	AutoCloseIsNotAllowedForRequestWithPurpose         uint32 = 124
+	MaxTimeoutExtensionMustBeGreaterOrEqualToZero      uint32 = 125
+	RequestTimeoutIsAlreadyExtended                    uint32 = 126
+	InvalidRequestTimeoutExtension                     uint32 = 127
+	DataSignatureAlreadyExisted                        uint32 = 128
+	NoPermissionForSetSupportedFeatureList             uint32 = 129
+	InvalidSupportedFeatureList                        uint32 = 130
+	InvalidValidatorPublicKey                          uint32 = 131
+	EscrowPriceMustBeGreaterOrEqualToZero              uint32 = 132
+	ThresholdMustBeGreaterOrEqualToZero                uint32 = 133
+	MethodRequiresAdminApproval                        uint32 = 134
+	MethodDoesNotRequireAdminApproval                  uint32 = 135
+	InvalidAdminApprovalPolicy                         uint32 = 136
+	DuplicateAdminProposalID                           uint32 = 137
+	AdminProposalNotFound                              uint32 = 138
+	AdminProposalIsAlreadyExecuted                     uint32 = 139
+	NotAdminOperator                                   uint32 = 140
+	OperatorAlreadyApprovedAdminProposal               uint32 = 141
+	DelayBlockMustBeGreaterOrEqualToZero               uint32 = 142
+	GovernanceActionNotFound                           uint32 = 143
+	MethodIsPaused                                     uint32 = 144
+	MethodCannotBePaused                               uint32 = 145
+	ResultNotFound                                     uint32 = 146
+	InvalidQueryParameter                              uint32 = 147
+	IdPMaxIalAalIsLessThanRequestMinIalAal             uint32 = 148
+	ActiveIdentifierCountIsGreaterThanAllowedCount     uint32 = 149
+	ModeIsNotAllowedForNamespace                       uint32 = 150
+	InvalidValidatorPowerPolicy                        uint32 = 151
+	ValidatorPowerClassNotFound                        uint32 = 152
+	InvalidActivationHeight                            uint32 = 153
+	QueryRateLimitExceeded                             uint32 = 154
+	TooManyConcurrentQueries                           uint32 = 155
+	InvalidIdPResponseTimeout                          uint32 = 156
+	IdPResponseTimeoutPassed                           uint32 = 157
+	IdentityTargetIsNotAllowedInMode1                  uint32 = 158
+	FoundIdPIDListAndIdentityTarget                    uint32 = 159
+	NoIdPFoundForIdentityTarget                        uint32 = 160
+	InvalidRequestPriorityClass                        uint32 = 161
+	RequestPriorityClassNotFound                       uint32 = 162
+	OpenRequestLimitOfPriorityClassExceeded            uint32 = 163
+	InvalidRequestIDFormat                             uint32 = 164
+	QueryIsNotAllowed                                  uint32 = 165
+	InvalidQuerySignature                              uint32 = 166
+	InvalidDataRetentionPolicy                         uint32 = 167
+	InvalidDataSignature                               uint32 = 168
+	AccessorIsRevoked                                  uint32 = 169
+	AccessorIsNotActive                                uint32 = 170
+	InvalidRegisterNodeBatchSize                       uint32 = 171
+	InvalidChainHistoryInfo                            uint32 = 172
+	UnknownEndBlockHook                                uint32 = 173
+	InvalidRequestListSizeLimit                        uint32 = 174
+	TooManyDataRequestsInRequest                       uint32 = 175
+	TooManyIdPsInRequest                               uint32 = 176
+	InvalidNodeContact                                 uint32 = 177
+	NodeAlreadyHasRole                                 uint32 = 178
+	NodeDoesNotHaveRole                                uint32 = 179
+	InvalidRequestSalt                                 uint32 = 180
+	StateProfileIsDisabled                             uint32 = 181
+	InvalidAppHashSchemeVersion                        uint32 = 182
+	IdentityModeIsAlreadyUpgraded                      uint32 = 183
+	NodeIDAliasIsAlreadyUsed                           uint32 = 184
+	DuplicateValidatorUpdate                           uint32 = 185
+	NodeCatchingUp                                     uint32 = 186
+	UnknownIndex                                       uint32 = 187
+	BlockWriteLimitExceeded                            uint32 = 188
+	HeightPruned                                       uint32 = 189
 	UnknownError                                       uint32 = 999
 )
@@ -23,10 +23,28 @@
 package main
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tendermint/libs/db"
 
+	"github.com/ndidplatform/smart-contract/v4/abci/anchor"
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/bench"
+	"github.com/ndidplatform/smart-contract/v4/abci/keys"
+	"github.com/ndidplatform/smart-contract/v4/abci/storage"
 	"github.com/ndidplatform/smart-contract/v4/abci/version"
 )
 
@@ -37,3 +55,658 @@ var abciVersionCmd = &cobra.Command{
 		fmt.Println(version.Version)
 	},
 }
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Run DID ABCI app benchmark on local DB",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		txCount, _ := cmd.Flags().GetInt("txs")
+		blockSize, _ := cmd.Flags().GetInt("block_size")
+		mixStr, _ := cmd.Flags().GetString("mix")
+		dbType, _ := cmd.Flags().GetString("db_type")
+		dbDir, _ := cmd.Flags().GetString("db_dir")
+		seed, _ := cmd.Flags().GetInt64("seed")
+		mix, err := bench.ParseMix(mixStr)
+		if err != nil {
+			return err
+		}
+		result, err := bench.Run(bench.Config{
+			TxCount:   txCount,
+			BlockSize: blockSize,
+			Mix:       mix,
+			DBType:    dbType,
+			DBDir:     dbDir,
+			Seed:      seed,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Print(result.String())
+		return nil
+	},
+}
+
+var recomputeStateStatsCmd = &cobra.Command{
+	Use:   "recompute_state_stats",
+	Short: "Recompute key count and byte size of DID ABCI app state (node must be stopped)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openStateDB(cmd, "", false)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		keyCount, byteSize := appV1.RecomputeStateStats(db)
+		fmt.Printf("Key count: %d\nByte size: %d\n", keyCount, byteSize)
+		return nil
+	},
+}
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "DID ABCI app state migration tools",
+}
+
+var migrateSeedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Create staging DID ABCI app state from backup of production DB (with MQ addresses removed and node keys remapped)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srcDBDir, _ := cmd.Flags().GetString("src_db_dir")
+		dbDir, _ := cmd.Flags().GetString("db_dir")
+		keyMappingFilePath, _ := cmd.Flags().GetString("key_mapping")
+		if srcDBDir == "" || dbDir == "" {
+			return fmt.Errorf("src_db_dir and db_dir are required")
+		}
+		nodeKeyMap := make(map[string]appV1.SeedNodeKey)
+		if keyMappingFilePath != "" {
+			keyMappingJSON, err := ioutil.ReadFile(keyMappingFilePath)
+			if err != nil {
+				return err
+			}
+			err = json.Unmarshal(keyMappingJSON, &nodeKeyMap)
+			if err != nil {
+				return fmt.Errorf("Invalid key mapping file: %v", err)
+			}
+		}
+		srcDB, err := openStateDB(cmd, "src_", false)
+		if err != nil {
+			return err
+		}
+		defer srcDB.Close()
+		db, err := openStateDB(cmd, "", true)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		result, err := appV1.SeedState(srcDB, db, nodeKeyMap)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Copied keys: %d\nScrubbed keys: %d\nRemapped keys: %d\nDropped keys: %d\n",
+			result.CopiedKeyCount, result.ScrubbedKeyCount, result.RemappedKeyCount, result.DroppedKeyCount)
+		return nil
+	},
+}
+
+var migrateRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore DID ABCI app state from backup DB to existing DB (node must be stopped)",
+	Long: "Restore DID ABCI app state from backup DB to existing DB (node must be stopped).\n" +
+		"Keys missing from DB are created and keys with the same value are overwritten. Restore is refused when any key\n" +
+		"has different value (conflict) unless overwrite_conflicts is set. Use dry_run to report changes without writing.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srcDBDir, _ := cmd.Flags().GetString("src_db_dir")
+		dryRun, _ := cmd.Flags().GetBool("dry_run")
+		overwriteConflicts, _ := cmd.Flags().GetBool("overwrite_conflicts")
+		limit, _ := cmd.Flags().GetInt("limit")
+		keyPrefixes, _ := cmd.Flags().GetStringSlice("key_prefix")
+		if srcDBDir == "" {
+			return fmt.Errorf("src_db_dir is required")
+		}
+		keyFilter, err := getRegisteredKeyFilter(keyPrefixes)
+		if err != nil {
+			return err
+		}
+		srcDB, err := openStateDB(cmd, "src_", false)
+		if err != nil {
+			return err
+		}
+		defer srcDB.Close()
+		db, err := openStateDB(cmd, "", !dryRun)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		var reported int
+		result, err := appV1.RestoreState(srcDB, db, dryRun, overwriteConflicts, keyFilter, func(change appV1.RestoreKeyChange) {
+			if !dryRun || (limit > 0 && reported >= limit) {
+				return
+			}
+			reported++
+			switch change.Operation {
+			case appV1.RestoreConflict:
+				fmt.Printf("%-9s %q (%X != %X)\n", change.Operation, change.Key, sha256.Sum256(change.Value), sha256.Sum256(change.OldValue))
+			default:
+				fmt.Printf("%-9s %q\n", change.Operation, change.Key)
+			}
+		})
+		fmt.Printf("Created keys: %d\nOverwritten keys: %d\nConflict keys: %d\n",
+			result.CreatedKeyCount, result.OverwrittenKeyCount, result.ConflictKeyCount)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Println("Dry run, DB is not changed")
+		}
+		return nil
+	},
+}
+
+var migrateExportGenesisValidatorsCmd = &cobra.Command{
+	Use:   "export_genesis_validators",
+	Short: "Write validators of DID ABCI app state to validators of Tendermint genesis file",
+	Long: "Write validators of DID ABCI app state to validators of Tendermint genesis file.\n" +
+		"Use for creating genesis.json of chain restored from backup so that it agrees with validators in app state.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genesisFilePath, _ := cmd.Flags().GetString("genesis")
+		if genesisFilePath == "" {
+			return fmt.Errorf("genesis is required")
+		}
+		genesis, err := readGenesisFile(genesisFilePath)
+		if err != nil {
+			return err
+		}
+		db, err := openStateDB(cmd, "", false)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		validators, err := appV1.ExportGenesisValidators(db)
+		if err != nil {
+			return err
+		}
+		genesis["validators"], err = json.Marshal(validators)
+		if err != nil {
+			return err
+		}
+		genesisJSON, err := json.MarshalIndent(genesis, "", "  ")
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(genesisFilePath, genesisJSON, 0644)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Validators: %d\n", len(validators))
+		return nil
+	},
+}
+
+var migrateImportGenesisValidatorsCmd = &cobra.Command{
+	Use:   "import_genesis_validators",
+	Short: "Replace validators of DID ABCI app state with validators of Tendermint genesis file (node must be stopped)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genesisFilePath, _ := cmd.Flags().GetString("genesis")
+		if genesisFilePath == "" {
+			return fmt.Errorf("genesis is required")
+		}
+		genesis, err := readGenesisFile(genesisFilePath)
+		if err != nil {
+			return err
+		}
+		var validators []appV1.GenesisValidator
+		err = json.Unmarshal(genesis["validators"], &validators)
+		if err != nil {
+			return fmt.Errorf("Invalid validators in genesis file: %v", err)
+		}
+		db, err := openStateDB(cmd, "", true)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		err = appV1.ImportGenesisValidators(db, validators)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Validators: %d\n", len(validators))
+		return nil
+	},
+}
+
+var migrateDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check DID ABCI app state DB and latest backup and report problems with remediation hints (node must be stopped)",
+	Long: "Check DID ABCI app state DB and latest backup and report problems with remediation hints (node must be stopped).\n" +
+		"Checks that DB opens, latest version loads, app hash is not empty, validators are present\n" +
+		"and latest backup in backup_dir is complete. DB is not written.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backupDir, _ := cmd.Flags().GetString("backup_dir")
+		checks := make([]appV1.DoctorCheck, 0)
+		db, err := openStateDB(cmd, "", false)
+		if err != nil {
+			checks = append(checks, appV1.DoctorCheck{
+				Name:   "db_open",
+				Status: appV1.DoctorFail,
+				Detail: err.Error(),
+				Hint:   "Check db_type and db_dir. DB of running node is locked, stop the node first.",
+			})
+		} else {
+			defer db.Close()
+			checks = append(checks, appV1.DoctorCheck{Name: "db_open", Status: appV1.DoctorPass})
+			checks = append(checks, appV1.RunDoctor(db, backupDir)...)
+		}
+		var failed int
+		for _, check := range checks {
+			fmt.Printf("[%s] %-15s %s\n", check.Status, check.Name, check.Detail)
+			if check.Status == appV1.DoctorFail {
+				failed++
+				fmt.Printf("       hint: %s\n", check.Hint)
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+		}
+		return nil
+	},
+}
+
+var migrateCheckIndexesCmd = &cobra.Command{
+	Use:   "check_indexes",
+	Short: "Check secondary indexes of DID ABCI app state DB against primary records and report drift (node must be stopped)",
+	Long: "Check secondary indexes of DID ABCI app state DB against primary records and report drift (node must be stopped).\n" +
+		"Indexes are role_list (IdP, RP, AS and all node ID lists), service_destination and open_request_count.\n" +
+		"DB is not written. Repairing DB of one node is not part of app hash and forks the node from the chain,\n" +
+		"send RebuildIndexes transaction to repair indexes of every node in consensus.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		indexes, _ := cmd.Flags().GetStringSlice("index")
+		db, err := openStateDB(cmd, "", false)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		driftList, err := appV1.CheckIndexes(db, indexes)
+		if err != nil {
+			return err
+		}
+		for _, drift := range driftList {
+			fmt.Printf("%-20s %-45s %s\n", drift.Index, drift.Key, drift.Detail)
+		}
+		fmt.Printf("Drifted keys: %d\n", len(driftList))
+		return nil
+	},
+}
+
+// readGenesisFile reads Tendermint genesis file as fields by name
+// so that fields other than validators are written back unchanged
+func readGenesisFile(path string) (map[string]json.RawMessage, error) {
+	genesisJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var genesis map[string]json.RawMessage
+	err = json.Unmarshal(genesisJSON, &genesis)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid genesis file: %v", err)
+	}
+	return genesis, nil
+}
+
+var compareStateCmd = &cobra.Command{
+	Use:   "compare_state",
+	Short: "Compare DID ABCI app state DB with another (e.g. backup or copy of another node's DB) and report divergent keys",
+	Long: "Compare DID ABCI app state DB with another (e.g. backup or copy of another node's DB) and report divergent keys.\n" +
+		"DB of running node is locked, use snapshot or copy of its DB directory instead.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		otherDBDir, _ := cmd.Flags().GetString("other_db_dir")
+		limit, _ := cmd.Flags().GetInt("limit")
+		if otherDBDir == "" {
+			return fmt.Errorf("other_db_dir is required")
+		}
+		db, err := openStateDB(cmd, "", false)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		otherDB, err := openStateDB(cmd, "other_", false)
+		if err != nil {
+			return err
+		}
+		defer otherDB.Close()
+		var onlyInDB, onlyInOtherDB, different int
+		storage.CompareDB(db, otherDB, func(diff storage.KeyDiff) bool {
+			switch {
+			case diff.OtherValue == nil:
+				onlyInDB++
+				fmt.Printf("only in db_dir:       %q\n", diff.Key)
+			case diff.Value == nil:
+				onlyInOtherDB++
+				fmt.Printf("only in other_db_dir: %q\n", diff.Key)
+			default:
+				different++
+				fmt.Printf("different value:      %q (%X != %X)\n", diff.Key, sha256.Sum256(diff.Value), sha256.Sum256(diff.OtherValue))
+			}
+			return limit <= 0 || onlyInDB+onlyInOtherDB+different < limit
+		})
+		fmt.Printf("Only in db_dir: %d\nOnly in other_db_dir: %d\nDifferent value: %d\n", onlyInDB, onlyInOtherDB, different)
+		return nil
+	},
+}
+
+var exportAnchorCmd = &cobra.Command{
+	Use:   "export_anchor",
+	Short: "Export app hash of every N blocks with signed header and validator set as proof file and/or to external endpoint",
+	Long: "Export app hash of every N blocks with signed header and validator set as proof file and/or to external endpoint.\n" +
+		"App hash of height H is exported from header and commit of block H+1 read from Tendermint RPC.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tendermintAddr, _ := cmd.Flags().GetString("tendermint")
+		interval, _ := cmd.Flags().GetInt64("interval")
+		outputDir, _ := cmd.Flags().GetString("output_dir")
+		endpoint, _ := cmd.Flags().GetString("endpoint")
+		pollInterval, _ := cmd.Flags().GetDuration("poll_interval")
+		return anchor.Run(anchor.Config{
+			TendermintAddr: tendermintAddr,
+			Interval:       interval,
+			OutputDir:      outputDir,
+			Endpoint:       endpoint,
+			PollInterval:   pollInterval,
+		})
+	},
+}
+
+var exportAnalyticsCmd = &cobra.Command{
+	Use:   "export_analytics",
+	Short: "Export de-identified aggregate datasets (request volume by hour, IAL distribution, AS response latency) of DID ABCI app state as CSV",
+	Long: "Export de-identified aggregate datasets (request volume by hour, IAL distribution, AS response latency) of DID ABCI app state as CSV.\n" +
+		"Only counts are exported, no node ID, request ID, identity or message.\n" +
+		"DB of running node is locked, use snapshot or copy of its DB directory instead.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputDir, _ := cmd.Flags().GetString("output_dir")
+		err := os.MkdirAll(outputDir, 0755)
+		if err != nil {
+			return err
+		}
+		db, err := openStateDB(cmd, "", false)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		result, err := appV1.ExportAnalytics(db)
+		if err != nil {
+			return err
+		}
+		return result.WriteCSV(outputDir)
+	},
+}
+
+var exportUsageReportCmd = &cobra.Command{
+	Use:   "export_usage_report",
+	Short: "Export per node, per method usage and fee of height range of DID ABCI app state as CSV signed with operator key",
+	Long: "Export per node, per method usage and fee of height range of DID ABCI app state as CSV signed with operator key.\n" +
+		"Usage is counted from block activity and fee from token ledger. Signature (base64 of RSA PKCS #1 v1.5 signature of\n" +
+		"SHA-256 of CSV) is written to <output>.sig when signing_key is set.\n" +
+		"DB of running node is locked, use snapshot or copy of its DB directory instead.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromHeight, _ := cmd.Flags().GetInt64("from_height")
+		toHeight, _ := cmd.Flags().GetInt64("to_height")
+		output, _ := cmd.Flags().GetString("output")
+		signingKeyFilePath, _ := cmd.Flags().GetString("signing_key")
+		var privKey *rsa.PrivateKey
+		if signingKeyFilePath != "" {
+			var err error
+			privKey, err = readRSAPrivateKeyFile(signingKeyFilePath)
+			if err != nil {
+				return err
+			}
+		}
+		db, err := openStateDB(cmd, "", false)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		report, err := appV1.GenerateUsageReport(db, fromHeight, toHeight)
+		if err != nil {
+			return err
+		}
+		reportCSV, err := report.CSV()
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(output, reportCSV, 0644)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rows: %d\nReport: %s\n", len(report.Rows), output)
+		if privKey == nil {
+			return nil
+		}
+		hashed := sha256.Sum256(reportCSV)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA256, hashed[:])
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(output+".sig", []byte(base64.StdEncoding.EncodeToString(signature)), 0644)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Signature: %s.sig\n", output)
+		return nil
+	},
+}
+
+// readRSAPrivateKeyFile reads PEM encoded RSA private key (PKCS #1 or PKCS #8)
+func readRSAPrivateKeyFile(path string) (*rsa.PrivateKey, error) {
+	keyPEM, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("Invalid signing key file: cannot decode PEM")
+	}
+	if privKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privKey, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid signing key file: %v", err)
+	}
+	privKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("Invalid signing key file: not RSA private key")
+	}
+	return privKey, nil
+}
+
+var inspectStateCmd = &cobra.Command{
+	Use:   "inspect_state",
+	Short: "Report number and size of keys of every registered key prefix in DID ABCI app state DB and keys which are not registered",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		db, err := openStateDB(cmd, "", false)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		var reported int
+		stats, unknown := storage.InspectDB(db, func(key []byte) {
+			if limit > 0 && reported >= limit {
+				return
+			}
+			reported++
+			fmt.Printf("unregistered key: %q\n", key)
+		})
+		for _, stat := range stats {
+			if stat.KeyCount == 0 {
+				continue
+			}
+			fmt.Printf("%-45s %-7s %10d keys %14d bytes  %s\n", stat.Entry.Name, stat.Entry.Kind, stat.KeyCount, stat.Size, stat.Entry.Description)
+		}
+		fmt.Printf("%-45s %-7s %10d keys %14d bytes\n", "(unregistered)", "", unknown.KeyCount, unknown.Size)
+		return nil
+	},
+}
+
+// getRegisteredKeyFilter returns filter accepting keys of given registered key prefixes
+// or single keys (nil filter for no names)
+func getRegisteredKeyFilter(names []string) (func(key []byte) bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	entries := make([]keys.Entry, 0, len(names))
+	for _, name := range names {
+		entry, ok := keys.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("Key prefix %q is not registered", name)
+		}
+		entries = append(entries, entry)
+	}
+	return func(key []byte) bool {
+		matched, ok := keys.Match(key)
+		if !ok {
+			return false
+		}
+		for _, entry := range entries {
+			if entry.Name == matched.Name {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+var listNetworkNamespacesCmd = &cobra.Command{
+	Use:   "list_network_namespaces",
+	Short: "List network namespaces of DID ABCI app states kept in DB",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbType, _ := cmd.Flags().GetString("db_type")
+		dbDir, _ := cmd.Flags().GetString("db_dir")
+		db, err := storage.OpenDB(dbType, dbDir)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		for _, namespace := range storage.ListNetworkNamespaces(db) {
+			fmt.Println(namespace)
+		}
+		return nil
+	},
+}
+
+// openStateDB opens DB of "<flagPrefix>db_type" and "<flagPrefix>db_dir" flags
+// with only state of "<flagPrefix>network_namespace" flag which is registered if register is true.
+// Values of encrypted keys are decrypted with ABCI_STORAGE_ENCRYPTION_KEY_FILE.
+func openStateDB(cmd *cobra.Command, flagPrefix string, register bool) (dbm.DB, error) {
+	dbType, _ := cmd.Flags().GetString(flagPrefix + "db_type")
+	dbDir, _ := cmd.Flags().GetString(flagPrefix + "db_dir")
+	networkNamespace, _ := cmd.Flags().GetString(flagPrefix + "network_namespace")
+	db, err := storage.OpenDB(dbType, dbDir)
+	if err != nil {
+		return nil, err
+	}
+	if register {
+		err = storage.RegisterNetworkNamespace(db, networkNamespace)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	namespaceDB, err := storage.NamespaceDB(db, networkNamespace)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	encryptedDB, err := storage.EncryptedDB(namespaceDB, getEnv("ABCI_STORAGE_ENCRYPTION_KEY_FILE", ""), strings.Split(getEnv("ABCI_STORAGE_ENCRYPTION_KEY_PREFIXES", ""), ","))
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return encryptedDB, nil
+}
+
+func init() {
+	exportAnchorCmd.Flags().String("tendermint", getEnv("TENDERMINT_RPC_ADDRESS", "http://localhost:26657"), "Tendermint RPC address")
+	exportAnchorCmd.Flags().Int64("interval", 1000, "Number of blocks between anchors")
+	exportAnchorCmd.Flags().String("output_dir", "", "Output directory of anchor files")
+	exportAnchorCmd.Flags().String("endpoint", "", "URL which anchor is POSTed to as JSON")
+	exportAnchorCmd.Flags().Duration("poll_interval", 5*time.Second, "Interval of polling latest block height")
+
+	exportAnalyticsCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+	exportAnalyticsCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+	exportAnalyticsCmd.Flags().String("network_namespace", getEnv("ABCI_NETWORK_NAMESPACE", ""), "Network namespace of state in DB")
+	exportAnalyticsCmd.Flags().String("output_dir", "./analytics", "Output directory of CSV files")
+
+	exportUsageReportCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+	exportUsageReportCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+	exportUsageReportCmd.Flags().String("network_namespace", getEnv("ABCI_NETWORK_NAMESPACE", ""), "Network namespace of state in DB")
+	exportUsageReportCmd.Flags().Int64("from_height", 1, "First block height of report")
+	exportUsageReportCmd.Flags().Int64("to_height", 0, "Last block height of report (0 for latest committed height)")
+	exportUsageReportCmd.Flags().String("output", "./usage_report.csv", "Output CSV file")
+	exportUsageReportCmd.Flags().String("signing_key", "", "PEM file of operator RSA private key for signing report (no signature when empty)")
+
+	compareStateCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+	compareStateCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+	compareStateCmd.Flags().String("other_db_type", "goleveldb", "Other DB backend type")
+	compareStateCmd.Flags().String("network_namespace", getEnv("ABCI_NETWORK_NAMESPACE", ""), "Network namespace of state in DB")
+	compareStateCmd.Flags().String("other_db_dir", "", "Other DB directory")
+	compareStateCmd.Flags().String("other_network_namespace", "", "Network namespace of state in other DB")
+	compareStateCmd.Flags().Int("limit", 100, "Maximum number of divergent keys to report (0 for no limit)")
+
+	migrateSeedCmd.Flags().String("src_db_type", "goleveldb", "Backup DB backend type")
+	migrateSeedCmd.Flags().String("src_db_dir", "", "Backup DB directory")
+	migrateSeedCmd.Flags().String("src_network_namespace", "", "Network namespace of state in backup DB")
+	migrateSeedCmd.Flags().String("db_type", "goleveldb", "Seeded DB backend type")
+	migrateSeedCmd.Flags().String("db_dir", "", "Seeded DB directory (must be empty)")
+	migrateSeedCmd.Flags().String("network_namespace", "", "Network namespace of seeded state (state of other namespaces may already exist in DB)")
+	migrateSeedCmd.Flags().String("key_mapping", "", "JSON file mapping node ID to test keys ({\"<node_id>\": {\"public_key\": \"...\", \"master_public_key\": \"...\"}})")
+	migrateCmd.AddCommand(migrateSeedCmd)
+
+	migrateRestoreCmd.Flags().String("src_db_type", "goleveldb", "Backup DB backend type")
+	migrateRestoreCmd.Flags().String("src_db_dir", "", "Backup DB directory")
+	migrateRestoreCmd.Flags().String("src_network_namespace", "", "Network namespace of state in backup DB")
+	migrateRestoreCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+	migrateRestoreCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+	migrateRestoreCmd.Flags().String("network_namespace", getEnv("ABCI_NETWORK_NAMESPACE", ""), "Network namespace of state in DB")
+	migrateRestoreCmd.Flags().Bool("dry_run", false, "Report keys which would be created, overwritten or conflict without writing to DB")
+	migrateRestoreCmd.Flags().Bool("overwrite_conflicts", false, "Overwrite keys which have different value in DB with value in backup")
+	migrateRestoreCmd.Flags().Int("limit", 0, "Maximum number of keys to report in dry run (0 for no limit)")
+	migrateRestoreCmd.Flags().StringSlice("key_prefix", nil, "Restore only keys of registered key prefix or single key (can be repeated)")
+	migrateCmd.AddCommand(migrateRestoreCmd)
+
+	for _, genesisValidatorsCmd := range []*cobra.Command{migrateExportGenesisValidatorsCmd, migrateImportGenesisValidatorsCmd} {
+		genesisValidatorsCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+		genesisValidatorsCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+		genesisValidatorsCmd.Flags().String("network_namespace", getEnv("ABCI_NETWORK_NAMESPACE", ""), "Network namespace of state in DB")
+		genesisValidatorsCmd.Flags().String("genesis", "", "Tendermint genesis file (genesis.json)")
+		migrateCmd.AddCommand(genesisValidatorsCmd)
+	}
+
+	migrateDoctorCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+	migrateDoctorCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+	migrateDoctorCmd.Flags().String("network_namespace", getEnv("ABCI_NETWORK_NAMESPACE", ""), "Network namespace of state in DB")
+	migrateDoctorCmd.Flags().String("backup_dir", getEnv("ABCI_BACKUP_DIR", ""), "Backup directory (skip backup check when empty)")
+	migrateCmd.AddCommand(migrateDoctorCmd)
+
+	migrateCheckIndexesCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+	migrateCheckIndexesCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+	migrateCheckIndexesCmd.Flags().String("network_namespace", getEnv("ABCI_NETWORK_NAMESPACE", ""), "Network namespace of state in DB")
+	migrateCheckIndexesCmd.Flags().StringSlice("index", nil, "Index to check (can be repeated, default all)")
+	migrateCmd.AddCommand(migrateCheckIndexesCmd)
+
+	recomputeStateStatsCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+	recomputeStateStatsCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+	recomputeStateStatsCmd.Flags().String("network_namespace", getEnv("ABCI_NETWORK_NAMESPACE", ""), "Network namespace of state in DB")
+
+	inspectStateCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+	inspectStateCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+	inspectStateCmd.Flags().String("network_namespace", getEnv("ABCI_NETWORK_NAMESPACE", ""), "Network namespace of state in DB")
+	inspectStateCmd.Flags().Int("limit", 20, "Maximum number of unregistered keys to report (0 for no limit)")
+
+	listNetworkNamespacesCmd.Flags().String("db_type", getEnv("ABCI_DB_TYPE", "goleveldb"), "DB backend type")
+	listNetworkNamespacesCmd.Flags().String("db_dir", getEnv("ABCI_DB_DIR_PATH", "./DID"), "DB directory")
+
+	benchCmd.Flags().Int("txs", 10000, "Number of Txs to deliver")
+	benchCmd.Flags().Int("block_size", 100, "Number of Txs per block")
+	benchCmd.Flags().String("mix", bench.DefaultMix, "Method mix in format \"Method=weight,...\"")
+	benchCmd.Flags().String("db_type", "goleveldb", "DB backend type")
+	benchCmd.Flags().String("db_dir", "", "DB directory (default new temporary directory)")
+	benchCmd.Flags().Int64("seed", 1, "Random seed")
+}
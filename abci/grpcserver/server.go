@@ -0,0 +1,183 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Package grpcserver is optional read-only gRPC API for internal NDID tools.
+// Methods are backed by ABCI queries on committed state. Request and response messages
+// are JSON with the same parameters and results as the queries.
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+const serviceName = "ndid.smartcontract.Query"
+
+// methods is list of query methods exposed as gRPC methods
+var methods = []string{
+	"GetNodeInfo",
+	"GetRequestDetail",
+	"GetStatistics",
+	"GetServiceStatistics",
+}
+
+// QueryFunc runs query method with params on committed state
+type QueryFunc func(method string, params []byte) (resultCode uint32, value []byte, log string)
+
+// Config is address and mTLS settings of gRPC server. Client certificate must be
+// signed by CA in ClientCAFile.
+type Config struct {
+	Address      string
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+}
+
+type Server struct {
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+// jsonCodec passes JSON messages as they are
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(*json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("Unexpected message type %T", v)
+	}
+	return *message, nil
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	message, ok := v.(*json.RawMessage)
+	if !ok {
+		return fmt.Errorf("Unexpected message type %T", v)
+	}
+	*message = append((*message)[0:0], data...)
+	return nil
+}
+
+func (jsonCodec) String() string {
+	return "json"
+}
+
+func NewServer(config Config, query QueryFunc) (*Server, error) {
+	certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	clientCA, err := ioutil.ReadFile(config.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	clientCAPool := x509.NewCertPool()
+	if !clientCAPool.AppendCertsFromPEM(clientCA) {
+		return nil, fmt.Errorf("No certificate found in client CA file")
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    clientCAPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	listener, err := net.Listen("tcp", config.Address)
+	if err != nil {
+		return nil, err
+	}
+	grpcServer := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.CustomCodec(jsonCodec{}),
+	)
+	serviceDesc := grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*interface{})(nil),
+	}
+	for _, method := range methods {
+		serviceDesc.Methods = append(serviceDesc.Methods, grpc.MethodDesc{
+			MethodName: method,
+			Handler:    newMethodHandler(method, query),
+		})
+	}
+	grpcServer.RegisterService(&serviceDesc, struct{}{})
+	return &Server{
+		grpcServer: grpcServer,
+		listener:   listener,
+	}, nil
+}
+
+func newMethodHandler(method string, query QueryFunc) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		params := *req.(*json.RawMessage)
+		resultCode, value, log := query(method, params)
+		switch resultCode {
+		case code.OK:
+			result := json.RawMessage(value)
+			return &result, nil
+		case code.ResultNotFound:
+			return nil, status.Error(codes.NotFound, log)
+		case code.InvalidQueryParameter, code.UnmarshalError:
+			return nil, status.Error(codes.InvalidArgument, log)
+		case code.QueryRateLimitExceeded, code.TooManyConcurrentQueries:
+			return nil, status.Error(codes.ResourceExhausted, log)
+		case code.QueryIsNotAllowed:
+			return nil, status.Error(codes.PermissionDenied, log)
+		default:
+			return nil, status.Error(codes.Internal, log)
+		}
+	}
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		var in json.RawMessage
+		if err := dec(&in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return handler(ctx, &in)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: "/" + serviceName + "/" + method,
+		}
+		return interceptor(ctx, &in, info, handler)
+	}
+}
+
+// Serve accepts connections until server is stopped
+func (server *Server) Serve() error {
+	return server.grpcServer.Serve(server.listener)
+}
+
+func (server *Server) Stop() {
+	server.grpcServer.GracefulStop()
+}
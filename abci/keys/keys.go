@@ -0,0 +1,273 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Package keys defines keys of DID ABCI app state. Every key prefix and single key
+// written to state must be listed in registry so tooling (state inspector, restore
+// filter) recognizes it.
+package keys
+
+import (
+	"sort"
+	"strings"
+)
+
+// Separator separates key prefix and parts of key. Versioned key is stored as
+// "<key>|<version>" with version list at "<key>|versions".
+const Separator = "|"
+
+// Key prefixes of state. Key is prefix and parts joined with Separator.
+const (
+	NodeIDPrefix                 = "NodeID"
+	BehindProxyNodePrefix        = "BehindProxyNode"
+	TokenPrefix                  = "Token"
+	TokenPriceFuncPrefix         = "TokenPriceFunc"
+	ServicePrefix                = "Service"
+	ServiceDestinationPrefix     = "ServiceDestination"
+	ApprovedServicePrefix        = "ApproveKey"
+	ProvidedServicesPrefix       = "ProvideService"
+	RefGroupCodePrefix           = "RefGroupCode"
+	IdentityToRefCodePrefix      = "identityToRefCodeKey"
+	AccessorToRefCodePrefix      = "accessorToRefCodeKey"
+	AllowedModeListPrefix        = "AllowedModeList"
+	RequestPrefix                = "Request"
+	DataSignaturePrefix          = "SignData"
+	IdPAgentListPrefix           = "IdPAgentList"
+	NodeKeyPrefix                = "NodeKey"
+	RevokedPublicKeyPrefix       = "RevokedPublicKey"
+	DataSchemaPrefix             = "DataSchema"
+	ConsentReceiptPrefix         = "ConsentReceipt"
+	StatisticsPrefix             = "Statistics"
+	NodeQuotaPrefix              = "NodeQuota"
+	NodeQuotaUsagePrefix         = "NodeQuotaUsage"
+	ValidatorNodePrefix          = "ValidatorNode"
+	TokenLedgerPrefix            = "TokenLedger"
+	AdminProposalPrefix          = "AdminProposal"
+	GovernanceActionPrefix       = "GovernanceAction"
+	PausedMethodPrefix           = "PausedMethod"
+	ChangeJournalPrefix          = "ChangeJournal"
+	BlockActivityPrefix          = "BlockActivity"
+	ValidatorMisbehaviorPrefix   = "ValidatorMisbehavior"
+	PendingValidatorUpdatePrefix = "PendingValidatorUpdate"
+	ServiceUsagePrefix           = "ServiceUsage"
+	OpenRequestCountPrefix       = "OpenRequestCount"
+	QueryVisibilityPrefix        = "QueryVisibility"
+	RequestResponsePrefix        = "RequestResponse"
+	RequestResponseCountPrefix   = "RequestResponseCount"
+	RequestDataStatusPrefix      = "RequestDataStatus"
+	RequestSummaryPrefix         = "RequestSummary"
+	RequestSettlementPrefix      = "RequestSettlement"
+	AccessorResponsePrefix       = "AccessorResponse"
+	NodeContactPrefix            = "NodeContact"
+	NodeIDAliasPrefix            = "NodeIDAlias"
+)
+
+// ValidatorPrefix is prefix of validator keys ("val:<base64 public key>").
+// It is followed by public key without Separator.
+const ValidatorPrefix = "val:"
+
+// Single keys of state
+const (
+	StateMetadataKey                              = "stateKey"
+	MasterNDIDKey                                 = "MasterNDID"
+	InitStateKey                                  = "InitState"
+	LastBlockKey                                  = "lastBlock"
+	IdPListKey                                    = "IdPList"
+	RPListKey                                     = "rpList"
+	ASListKey                                     = "asList"
+	AllListKey                                    = "allList"
+	AllNamespaceKey                               = "AllNamespace"
+	AllServiceKey                                 = "AllService"
+	ChainHistoryInfoKey                           = "ChainHistoryInfo"
+	TimeOutBlockRegisterIdentityKey               = "TimeOutBlockRegisterIdentity"
+	AllowedMinIalForRegisterIdentityAtFirstIdpKey = "AllowedMinIalForRegisterIdentityAtFirstIdp"
+	MaxRequestTimeoutExtensionKey                 = "MaxRequestTimeoutExtension"
+	RequestEscrowPriceKey                         = "RequestEscrowPrice"
+	LowTokenThresholdKey                          = "LowTokenThreshold"
+	AdminApprovalPolicyKey                        = "AdminApprovalPolicy"
+	GovernanceActionDelayKey                      = "GovernanceActionDelay"
+	ValidatorPowerPolicyKey                       = "ValidatorPowerPolicy"
+	ValidatorMissThresholdKey                     = "ValidatorMissThreshold"
+	BlockWriteLimitKey                            = "BlockWriteLimit"
+	RequestMessageHashCheckHeightKey              = "RequestMessageHashCheckHeight"
+	RequestPriorityClassListKey                   = "RequestPriorityClassList"
+	DataRetentionPolicyKey                        = "DataRetentionPolicy"
+	PreviousChainListKey                          = "PreviousChainList"
+	EndBlockHookFlagListKey                       = "EndBlockHookFlagList"
+	RequestListSizeLimitKey                       = "RequestListSizeLimit"
+	RandomnessBeaconConfigKey                     = "RandomnessBeaconConfig"
+	AppHashSchemeConfigKey                        = "AppHashSchemeConfig"
+)
+
+// Kinds of registered key
+const (
+	KindPrefix = "prefix"
+	KindSingle = "single"
+)
+
+// Entry is key prefix or single key in registry
+type Entry struct {
+	Name        string
+	Kind        string
+	Description string
+}
+
+var registry = []Entry{
+	{NodeIDPrefix, KindPrefix, "node detail"},
+	{BehindProxyNodePrefix, KindPrefix, "nodes behind proxy node"},
+	{TokenPrefix, KindPrefix, "token account of node"},
+	{TokenPriceFuncPrefix, KindPrefix, "token price of method"},
+	{ServicePrefix, KindPrefix, "service detail"},
+	{ServiceDestinationPrefix, KindPrefix, "AS providing service"},
+	{ApprovedServicePrefix, KindPrefix, "service approved for AS"},
+	{ProvidedServicesPrefix, KindPrefix, "services provided by AS"},
+	{RefGroupCodePrefix, KindPrefix, "reference group"},
+	{IdentityToRefCodePrefix, KindPrefix, "reference group code of identity"},
+	{AccessorToRefCodePrefix, KindPrefix, "reference group code of accessor"},
+	{AllowedModeListPrefix, KindPrefix, "allowed mode list of purpose"},
+	{RequestPrefix, KindPrefix, "request"},
+	{DataSignaturePrefix, KindPrefix, "data signature of AS"},
+	{IdPAgentListPrefix, KindPrefix, "agents of IdP"},
+	{NodeKeyPrefix, KindPrefix, "node of public key"},
+	{RevokedPublicKeyPrefix, KindPrefix, "revoked public key"},
+	{DataSchemaPrefix, KindPrefix, "data schema of service"},
+	{ConsentReceiptPrefix, KindPrefix, "consent receipt"},
+	{StatisticsPrefix, KindPrefix, "statistics"},
+	{NodeQuotaPrefix, KindPrefix, "quota of node"},
+	{NodeQuotaUsagePrefix, KindPrefix, "quota usage of node"},
+	{ValidatorNodePrefix, KindPrefix, "node of validator"},
+	{TokenLedgerPrefix, KindPrefix, "token ledger entry"},
+	{AdminProposalPrefix, KindPrefix, "admin proposal"},
+	{GovernanceActionPrefix, KindPrefix, "pending governance action"},
+	{PausedMethodPrefix, KindPrefix, "paused method"},
+	{ChangeJournalPrefix, KindPrefix, "changes at height"},
+	{BlockActivityPrefix, KindPrefix, "Txs delivered at height"},
+	{ValidatorMisbehaviorPrefix, KindPrefix, "validator misbehavior"},
+	{PendingValidatorUpdatePrefix, KindPrefix, "pending validator update"},
+	{ServiceUsagePrefix, KindPrefix, "service usage"},
+	{OpenRequestCountPrefix, KindPrefix, "open request count"},
+	{QueryVisibilityPrefix, KindPrefix, "query visibility"},
+	{RequestResponsePrefix, KindPrefix, "response of request"},
+	{RequestResponseCountPrefix, KindPrefix, "response count of request"},
+	{RequestDataStatusPrefix, KindPrefix, "data request status of request"},
+	{RequestSummaryPrefix, KindPrefix, "summary of request"},
+	{RequestSettlementPrefix, KindPrefix, "settlement of request"},
+	{AccessorResponsePrefix, KindPrefix, "response signed with accessor"},
+	{NodeContactPrefix, KindPrefix, "operational contact of node"},
+	{NodeIDAliasPrefix, KindPrefix, "node ID of alias"},
+	{ValidatorPrefix, KindPrefix, "validator"},
+	{StateMetadataKey, KindSingle, "app state metadata"},
+	{MasterNDIDKey, KindSingle, "NDID node ID"},
+	{InitStateKey, KindSingle, "init state flag"},
+	{LastBlockKey, KindSingle, "last block height"},
+	{IdPListKey, KindSingle, "IdP node ID list"},
+	{RPListKey, KindSingle, "RP node ID list"},
+	{ASListKey, KindSingle, "AS node ID list"},
+	{AllListKey, KindSingle, "node ID list"},
+	{AllNamespaceKey, KindSingle, "namespace list"},
+	{AllServiceKey, KindSingle, "service list"},
+	{ChainHistoryInfoKey, KindSingle, "chain history info"},
+	{TimeOutBlockRegisterIdentityKey, KindSingle, "timeout block of register identity"},
+	{AllowedMinIalForRegisterIdentityAtFirstIdpKey, KindSingle, "allowed min IAL for register identity at first IdP"},
+	{MaxRequestTimeoutExtensionKey, KindSingle, "max request timeout extension"},
+	{RequestEscrowPriceKey, KindSingle, "request escrow price"},
+	{LowTokenThresholdKey, KindSingle, "low token threshold"},
+	{AdminApprovalPolicyKey, KindSingle, "admin approval policy"},
+	{GovernanceActionDelayKey, KindSingle, "governance action delay"},
+	{ValidatorPowerPolicyKey, KindSingle, "validator power policy"},
+	{ValidatorMissThresholdKey, KindSingle, "validator consecutive missed block threshold"},
+	{BlockWriteLimitKey, KindSingle, "max number of state keys written by Txs of block"},
+	{RequestMessageHashCheckHeightKey, KindSingle, "creation block height of requests from which IdP response must have request message hash"},
+	{RequestPriorityClassListKey, KindSingle, "request priority class list"},
+	{DataRetentionPolicyKey, KindSingle, "data retention policy"},
+	{PreviousChainListKey, KindSingle, "previous chains which state is migrated from"},
+	{EndBlockHookFlagListKey, KindSingle, "EndBlock hooks enabled or disabled by NDID"},
+	{RequestListSizeLimitKey, KindSingle, "max number of services and IdPs in request"},
+	{RandomnessBeaconConfigKey, KindSingle, "deterministic pseudo-random ordering enabled by NDID"},
+	{AppHashSchemeConfigKey, KindSingle, "app hash scheme versions and their activation heights set by NDID"},
+}
+
+// Registry returns every registered key prefix and single key ordered by name
+func Registry() []Entry {
+	entries := append(make([]Entry, 0, len(registry)), registry...)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// Lookup returns registered entry of name
+func Lookup(name string) (Entry, bool) {
+	for _, entry := range registry {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Match returns registered entry which key belongs to. Versions and versioned keys
+// of single key match the single key.
+func Match(key []byte) (Entry, bool) {
+	keyStr := string(key)
+	for _, entry := range registry {
+		if HasPrefix(key, entry) {
+			return entry, true
+		}
+		if entry.Kind == KindSingle && keyStr == entry.Name {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// HasPrefix returns whether key is key of prefix entry or versioned key of single key entry
+func HasPrefix(key []byte, entry Entry) bool {
+	if entry.Name == ValidatorPrefix {
+		return strings.HasPrefix(string(key), ValidatorPrefix)
+	}
+	return strings.HasPrefix(string(key), entry.Name+Separator)
+}
+
+// Make returns key of prefix and parts
+func Make(prefix string, parts ...string) []byte {
+	return []byte(prefix + Separator + strings.Join(parts, Separator))
+}
+
+// NodeID returns key of node detail
+func NodeID(nodeID string) []byte {
+	return Make(NodeIDPrefix, nodeID)
+}
+
+// Token returns key of token account of node
+func Token(nodeID string) []byte {
+	return Make(TokenPrefix, nodeID)
+}
+
+// Request returns key of request
+func Request(requestID string) []byte {
+	return Make(RequestPrefix, requestID)
+}
+
+// Validator returns key of validator with base64 encoded public key
+func Validator(pubKeyBase64 string) []byte {
+	return []byte(ValidatorPrefix + pubKeyBase64)
+}
@@ -0,0 +1,330 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Command rest serves read-only REST façade over Tendermint RPC abci_query.
+// Each query method in appV1.IsQueryMethod (including SimulateTx) is mapped to
+// /query/{method} and described in OpenAPI document served at /openapi.json.
+// Request status changes are pushed to WebSocket clients of /subscribe.
+//
+// Usage:
+//
+//	go run ./abci/rest -listen :8080 -tendermint http://localhost:26657
+//	curl 'http://localhost:8080/query/GetNodeInfo?params={"node_id":"NDID"}'
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/client"
+)
+
+const (
+	queryPathPrefix = "/query/"
+	maxParamSize    = 1024 * 1024
+)
+
+type abciQueryResponse struct {
+	Result struct {
+		Response struct {
+			Code   uint32 `json:"code"`
+			Log    string `json:"log"`
+			Value  []byte `json:"value"`
+			Height string `json:"height"`
+		} `json:"response"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+type errorResponse struct {
+	Code   uint32 `json:"code"`
+	Log    string `json:"log"`
+	Height string `json:"height,omitempty"`
+}
+
+type server struct {
+	tendermintAddr *url.URL
+	client         *http.Client
+	openAPI        []byte
+}
+
+func main() {
+	listenAddr := flag.String("listen", getEnv("REST_LISTEN_ADDRESS", ":8080"), "address to listen for HTTP requests")
+	tendermintAddr := flag.String("tendermint", getEnv("TENDERMINT_RPC_ADDRESS", "http://localhost:26657"), "Tendermint RPC address")
+	flag.Parse()
+
+	tmURL, err := url.Parse(*tendermintAddr)
+	if err != nil {
+		log.Fatalf("invalid Tendermint RPC address: %s", err.Error())
+	}
+	openAPI, err := json.Marshal(buildOpenAPI())
+	if err != nil {
+		log.Fatalf("cannot build OpenAPI document: %s", err.Error())
+	}
+	s := &server{
+		tendermintAddr: tmURL,
+		client:         &http.Client{Timeout: 30 * time.Second},
+		openAPI:        openAPI,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", s.handleOpenAPI)
+	mux.HandleFunc(queryPathPrefix, s.handleQuery)
+
+	bridge := newSubscriptionBridge(tmURL)
+	go bridge.run()
+	mux.HandleFunc("/subscribe", bridge.handleSubscribe)
+
+	log.Printf("REST façade listening on %s, Tendermint RPC: %s", *listenAddr, tmURL.String())
+	log.Fatal(http.ListenAndServe(*listenAddr, mux))
+}
+
+func (s *server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(s.openAPI)
+}
+
+// handleQuery accepts params as JSON body (POST) or params query string (GET).
+// Optional height query string selects historical state.
+func (s *server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	method := strings.TrimPrefix(r.URL.Path, queryPathPrefix)
+	if !appV1.IsQueryMethod[method] {
+		writeError(w, http.StatusNotFound, errorResponse{Code: code.UnknownMethod, Log: "Unknown method name"})
+		return
+	}
+	var params string
+	switch r.Method {
+	case http.MethodGet:
+		params = r.URL.Query().Get("params")
+	case http.MethodPost:
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxParamSize))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errorResponse{Code: code.UnmarshalError, Log: err.Error()})
+			return
+		}
+		params = string(body)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if params == "" {
+		params = "{}"
+	}
+	if !json.Valid([]byte(params)) {
+		writeError(w, http.StatusBadRequest, errorResponse{Code: code.UnmarshalError, Log: "params is not valid JSON"})
+		return
+	}
+	height := r.URL.Query().Get("height")
+	if height != "" {
+		if _, err := strconv.ParseInt(height, 10, 64); err != nil {
+			writeError(w, http.StatusBadRequest, errorResponse{Code: code.UnmarshalError, Log: "height must be integer"})
+			return
+		}
+	}
+
+	res, err := s.abciQuery(method, params, height)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, errorResponse{Code: code.UnknownError, Log: err.Error()})
+		return
+	}
+	response := res.Result.Response
+	if response.Code != code.OK {
+		writeError(w, statusFromCode(response.Code), errorResponse{
+			Code:   response.Code,
+			Log:    response.Log,
+			Height: response.Height,
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Block-Height", response.Height)
+	w.Write(response.Value)
+}
+
+func (s *server) abciQuery(method, params, height string) (*abciQueryResponse, error) {
+	data, err := client.NewQuery(method, []byte(params))
+	if err != nil {
+		return nil, err
+	}
+	URL := *s.tendermintAddr
+	URL.Path = strings.TrimSuffix(URL.Path, "/") + "/abci_query"
+	parameters := url.Values{}
+	parameters.Add("data", client.EncodeRPCBytes(data))
+	if height != "" {
+		parameters.Add("height", height)
+	}
+	URL.RawQuery = parameters.Encode()
+	resp, err := s.client.Get(URL.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var res abciQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("%s: %s", res.Error.Message, res.Error.Data)
+	}
+	return &res, nil
+}
+
+func statusFromCode(errCode uint32) int {
+	switch errCode {
+	case code.UnmarshalError, code.UnknownMethod:
+		return http.StatusBadRequest
+	case code.QueryIsNotAllowed, code.InvalidQuerySignature:
+		return http.StatusForbidden
+	case code.ResultNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusUnprocessableEntity
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, res errorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(res)
+}
+
+// buildOpenAPI generates OpenAPI 3 document from query method registry so that
+// new query methods are exposed without changing this command.
+func buildOpenAPI() map[string]interface{} {
+	methods := make([]string, 0, len(appV1.IsQueryMethod))
+	for method := range appV1.IsQueryMethod {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	errorSchema := map[string]interface{}{"$ref": "#/components/schemas/Error"}
+	paths := make(map[string]interface{}, len(methods))
+	for _, method := range methods {
+		responses := map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Query result as returned by smart contract",
+				"headers": map[string]interface{}{
+					"X-Block-Height": map[string]interface{}{
+						"description": "Block height of state used to answer query",
+						"schema":      map[string]interface{}{"type": "string"},
+					},
+				},
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": map[string]interface{}{"type": "object"},
+					},
+				},
+			},
+			"default": map[string]interface{}{
+				"description": "Smart contract error code and log",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": errorSchema},
+				},
+			},
+		}
+		heightParam := map[string]interface{}{
+			"name":     "height",
+			"in":       "query",
+			"required": false,
+			"schema":   map[string]interface{}{"type": "integer", "format": "int64"},
+		}
+		paths[queryPathPrefix+method] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"operationId": "get" + method,
+				"summary":     method,
+				"parameters": []interface{}{
+					map[string]interface{}{
+						"name":        "params",
+						"in":          "query",
+						"required":    false,
+						"description": "JSON encoded query parameters",
+						"schema":      map[string]interface{}{"type": "string"},
+					},
+					heightParam,
+				},
+				"responses": responses,
+			},
+			"post": map[string]interface{}{
+				"operationId": "post" + method,
+				"summary":     method,
+				"parameters":  []interface{}{heightParam},
+				"requestBody": map[string]interface{}{
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"type": "object"},
+						},
+					},
+				},
+				"responses": responses,
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   "NDID smart contract query API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"Error": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"code":   map[string]interface{}{"type": "integer"},
+						"log":    map[string]interface{}{"type": "string"},
+						"height": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		value = defaultValue
+	}
+	return value
+}
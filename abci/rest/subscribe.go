@@ -0,0 +1,304 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Subscription bridge keeps one subscription to Tx events of Tendermint node and pushes
+// "did.request_status" events of successful Txs to WebSocket clients subscribed by
+// request_id and/or node_id.
+
+const (
+	requestStatusEventType  = "did.request_status"
+	subscriberBufferSize    = 64
+	subscriberWriteTimeout  = 10 * time.Second
+	subscriberPingInterval  = 30 * time.Second
+	tendermintRetryInterval = 5 * time.Second
+	tendermintSubscribeID   = "request_status"
+)
+
+// RequestStatusEvent is message pushed to subscribers
+type RequestStatusEvent struct {
+	RequestID   string   `json:"request_id"`
+	Status      string   `json:"status"`
+	ActorNodeID string   `json:"actor_node_id,omitempty"`
+	ServiceID   string   `json:"service_id,omitempty"`
+	NodeIDList  []string `json:"node_id_list"`
+	Height      int64    `json:"height,string"`
+}
+
+type tendermintEventMessage struct {
+	ID     string `json:"id"`
+	Result struct {
+		Data struct {
+			Type  string `json:"type"`
+			Value struct {
+				TxResult struct {
+					Height int64 `json:"height,string"`
+					Result struct {
+						Code   uint32 `json:"code"`
+						Events []struct {
+							Type       string `json:"type"`
+							Attributes []struct {
+								Key   []byte `json:"key"`
+								Value []byte `json:"value"`
+							} `json:"attributes"`
+						} `json:"events"`
+					} `json:"result"`
+				} `json:"TxResult"`
+			} `json:"value"`
+		} `json:"data"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+type subscriber struct {
+	requestIDs map[string]bool
+	nodeIDs    map[string]bool
+	send       chan RequestStatusEvent
+}
+
+func (s *subscriber) matches(event *RequestStatusEvent) bool {
+	if s.requestIDs[event.RequestID] {
+		return true
+	}
+	for _, nodeID := range event.NodeIDList {
+		if s.nodeIDs[nodeID] {
+			return true
+		}
+	}
+	return false
+}
+
+type subscriptionBridge struct {
+	tendermintAddr *url.URL
+	upgrader       websocket.Upgrader
+	mutex          sync.Mutex
+	subscribers    map[*subscriber]bool
+}
+
+func newSubscriptionBridge(tendermintAddr *url.URL) *subscriptionBridge {
+	return &subscriptionBridge{
+		tendermintAddr: tendermintAddr,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		subscribers: make(map[*subscriber]bool),
+	}
+}
+
+// run subscribes to Tendermint node and reconnects when connection is lost
+func (b *subscriptionBridge) run() {
+	for {
+		err := b.subscribeTendermint()
+		log.Printf("Tendermint event subscription stopped: %v, retry in %s", err, tendermintRetryInterval)
+		time.Sleep(tendermintRetryInterval)
+	}
+}
+
+func (b *subscriptionBridge) subscribeTendermint() error {
+	wsURL := *b.tendermintAddr
+	switch wsURL.Scheme {
+	case "https":
+		wsURL.Scheme = "wss"
+	default:
+		wsURL.Scheme = "ws"
+	}
+	wsURL.Path = strings.TrimSuffix(wsURL.Path, "/") + "/websocket"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// Subscribe to all Txs and filter here. Only one subscription is used so that
+	// number of clients is not limited by max subscriptions per client of Tendermint.
+	err = conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      tendermintSubscribeID,
+		"method":  "subscribe",
+		"params":  map[string]string{"query": "tm.event='Tx'"},
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Subscribed to Tx events of %s", wsURL.String())
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		var eventMessage tendermintEventMessage
+		err = json.Unmarshal(message, &eventMessage)
+		if err != nil {
+			log.Printf("Error unmarshaling Tendermint event: %s", err.Error())
+			continue
+		}
+		if eventMessage.Error != nil {
+			log.Printf("Tendermint subscription error: %s: %s", eventMessage.Error.Message, eventMessage.Error.Data)
+			continue
+		}
+		for _, event := range parseRequestStatusEvents(&eventMessage) {
+			b.broadcast(event)
+		}
+	}
+}
+
+// parseRequestStatusEvents returns request status events of successful Tx only since
+// events emitted before Tx fails do not reflect committed state.
+func parseRequestStatusEvents(eventMessage *tendermintEventMessage) []RequestStatusEvent {
+	txResult := eventMessage.Result.Data.Value.TxResult
+	if txResult.Result.Code != 0 {
+		return nil
+	}
+	events := make([]RequestStatusEvent, 0)
+	for _, event := range txResult.Result.Events {
+		if event.Type != requestStatusEventType {
+			continue
+		}
+		statusEvent := RequestStatusEvent{
+			NodeIDList: make([]string, 0),
+			Height:     txResult.Height,
+		}
+		for _, attribute := range event.Attributes {
+			value := string(attribute.Value)
+			switch string(attribute.Key) {
+			case "request_id":
+				statusEvent.RequestID = value
+			case "status":
+				statusEvent.Status = value
+			case "actor_node_id":
+				statusEvent.ActorNodeID = value
+			case "service_id":
+				statusEvent.ServiceID = value
+			case "node_id":
+				statusEvent.NodeIDList = append(statusEvent.NodeIDList, value)
+			}
+		}
+		events = append(events, statusEvent)
+	}
+	return events
+}
+
+func (b *subscriptionBridge) broadcast(event RequestStatusEvent) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for sub := range b.subscribers {
+		if !sub.matches(&event) {
+			continue
+		}
+		select {
+		case sub.send <- event:
+		default:
+			// Drop slow subscriber instead of blocking other subscribers
+			delete(b.subscribers, sub)
+			close(sub.send)
+		}
+	}
+}
+
+func (b *subscriptionBridge) removeSubscriber(sub *subscriber) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.subscribers[sub] {
+		delete(b.subscribers, sub)
+		close(sub.send)
+	}
+}
+
+// handleSubscribe upgrades connection to WebSocket and pushes request status events
+// matching request_id or node_id query strings (both can be repeated).
+func (b *subscriptionBridge) handleSubscribe(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	sub := &subscriber{
+		requestIDs: make(map[string]bool),
+		nodeIDs:    make(map[string]bool),
+		send:       make(chan RequestStatusEvent, subscriberBufferSize),
+	}
+	for _, requestID := range query["request_id"] {
+		sub.requestIDs[requestID] = true
+	}
+	for _, nodeID := range query["node_id"] {
+		sub.nodeIDs[nodeID] = true
+	}
+	if len(sub.requestIDs) == 0 && len(sub.nodeIDs) == 0 {
+		http.Error(w, "request_id or node_id is required", http.StatusBadRequest)
+		return
+	}
+	conn, err := b.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	b.mutex.Lock()
+	b.subscribers[sub] = true
+	b.mutex.Unlock()
+	defer b.removeSubscriber(sub)
+
+	// Read until client closes connection
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(subscriberPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case event, ok := <-sub.send:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "subscriber is too slow"), time.Now().Add(subscriberWriteTimeout))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(subscriberWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(subscriberWriteTimeout)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
@@ -27,10 +27,13 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	cmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/cli"
@@ -38,9 +41,9 @@ import (
 	nm "github.com/tendermint/tendermint/node"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/privval"
-	"github.com/tendermint/tendermint/proxy"
 
 	abciApp "github.com/ndidplatform/smart-contract/v4/abci/app"
+	"github.com/ndidplatform/smart-contract/v4/abci/grpcserver"
 	"github.com/tendermint/tendermint/abci/types"
 )
 
@@ -113,7 +116,16 @@ func main() {
 		cmd.ShowNodeIDCmd,
 		cmd.GenNodeKeyCmd,
 		cmd.VersionCmd,
-		abciVersionCmd)
+		abciVersionCmd,
+		benchCmd,
+		recomputeStateStatsCmd,
+		migrateCmd,
+		compareStateCmd,
+		inspectStateCmd,
+		exportAnalyticsCmd,
+		exportUsageReportCmd,
+		listNetworkNamespacesCmd,
+		exportAnchorCmd)
 
 	// NOTE:
 	// Users wishing to:
@@ -126,7 +138,10 @@ func main() {
 	nodeFunc := newNode
 
 	// Create & start node
-	rootCmd.AddCommand(cmd.NewRunNodeCmd(nodeFunc))
+	runNodeCmd := cmd.NewRunNodeCmd(nodeFunc)
+	runNodeCmd.Flags().Bool("verify_state", false, "Verify app state against change journal before starting node")
+	runNodeCmd.Flags().Int64("verify_state_blocks", 100, "Number of latest blocks which changes are verified with verify_state")
+	rootCmd.AddCommand(runNodeCmd)
 
 	cmd := cli.PrepareBaseCmd(rootCmd, "TM", os.ExpandEnv(filepath.Join("$HOME", cfg.DefaultTendermintDir)))
 	if err := cmd.Execute(); err != nil {
@@ -136,8 +151,42 @@ func main() {
 
 // Ref: github.com/tendermint/tendermint/node/node.go (func DefaultNewNode)
 func newNode(config *cfg.Config, logger log.Logger) (*nm.Node, error) {
+	abciApplication := abciApp.NewABCIApplicationInterface()
+	// Fail fast on corrupted DB instead of producing bad blocks
+	if viper.GetBool("verify_state") {
+		err := abciApplication.VerifyState(viper.GetInt64("verify_state_blocks"))
+		if err != nil {
+			return nil, err
+		}
+	}
 	var app types.Application
-	app = abciApp.NewABCIApplicationInterface()
+	app = abciApplication
+
+	// ABCI connections and gRPC server share mutex so that gRPC queries are not run
+	// while app is processing other ABCI requests
+	appMutex := new(sync.Mutex)
+	var grpcAddress = getEnv("ABCI_GRPC_ADDRESS", "")
+	if grpcAddress != "" {
+		grpcServer, err := grpcserver.NewServer(grpcserver.Config{
+			Address:      grpcAddress,
+			CertFile:     getEnv("ABCI_GRPC_TLS_CERT_FILE", ""),
+			KeyFile:      getEnv("ABCI_GRPC_TLS_KEY_FILE", ""),
+			ClientCAFile: getEnv("ABCI_GRPC_TLS_CLIENT_CA_FILE", ""),
+		}, func(method string, params []byte) (uint32, []byte, string) {
+			appMutex.Lock()
+			defer appMutex.Unlock()
+			res := abciApplication.QueryMethod(method, params)
+			return res.Code, res.Value, res.Log
+		})
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			if err := grpcServer.Serve(); err != nil {
+				logger.Error("gRPC server stopped", "err", err)
+			}
+		}()
+	}
 
 	// Generate node PrivKey
 	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
@@ -165,7 +214,7 @@ func newNode(config *cfg.Config, logger log.Logger) (*nm.Node, error) {
 	return nm.NewNode(config,
 		privval.LoadOrGenFilePV(newPrivValKey, newPrivValState),
 		nodeKey,
-		proxy.NewLocalClientCreator(app),
+		&localClientCreator{mtx: appMutex, app: app},
 		nm.DefaultGenesisDocProviderFunc(config),
 		nm.DefaultDBProvider,
 		nm.DefaultMetricsProvider(config.Instrumentation),
@@ -173,6 +222,16 @@ func newNode(config *cfg.Config, logger log.Logger) (*nm.Node, error) {
 	)
 }
 
+// localClientCreator is proxy.NewLocalClientCreator with mutex given by caller
+type localClientCreator struct {
+	mtx *sync.Mutex
+	app types.Application
+}
+
+func (l *localClientCreator) NewABCIClient() (abcicli.Client, error) {
+	return abcicli.NewLocalClient(l.mtx, l.app), nil
+}
+
 func getEnv(key, defaultValue string) string {
 	value, exists := os.LookupEnv(key)
 	if !exists {
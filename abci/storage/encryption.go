@@ -0,0 +1,166 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// encryptedValueHeader is prepended to encrypted value. Value without it is read as is
+// so that encryption can be enabled on existing DB, values are encrypted when written again.
+var encryptedValueHeader = []byte{0x00, 'E', 'N', 'C', 0x01}
+
+// EncryptedDB returns view of DB which encrypts values of keys with one of keyPrefixes
+// with AES-256-GCM using key in keyFile (hex encoded 32 bytes) before they are written and
+// decrypts them when they are read. Key is used as additional data so that encrypted value
+// can't be moved to another key. Empty keyFile returns DB itself.
+func EncryptedDB(db dbm.DB, keyFile string, keyPrefixes []string) (dbm.DB, error) {
+	if keyFile == "" {
+		return db, nil
+	}
+	keyHex, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read encryption key file: %v", err.Error())
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(keyHex)))
+	if err != nil {
+		return nil, fmt.Errorf("Encryption key is not hex encoded: %v", err.Error())
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("Encryption key must be 32 bytes, got %d bytes", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	encryptedDB := &encryptedDB{DB: db, aead: aead}
+	for _, keyPrefix := range keyPrefixes {
+		if keyPrefix != "" {
+			encryptedDB.keyPrefixes = append(encryptedDB.keyPrefixes, []byte(keyPrefix))
+		}
+	}
+	return encryptedDB, nil
+}
+
+type encryptedDB struct {
+	dbm.DB
+	aead        cipher.AEAD
+	keyPrefixes [][]byte
+}
+
+func (db *encryptedDB) isEncrypted(key []byte) bool {
+	for _, keyPrefix := range db.keyPrefixes {
+		if bytes.HasPrefix(key, keyPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (db *encryptedDB) encrypt(key, value []byte) []byte {
+	if value == nil || !db.isEncrypted(key) {
+		return value
+	}
+	nonce := make([]byte, db.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		panic(err)
+	}
+	encrypted := make([]byte, 0, len(encryptedValueHeader)+len(nonce)+len(value)+db.aead.Overhead())
+	encrypted = append(encrypted, encryptedValueHeader...)
+	encrypted = append(encrypted, nonce...)
+	return db.aead.Seal(encrypted, nonce, value, key)
+}
+
+// decrypt panics when value can't be decrypted (e.g. wrong key) like DB read error
+// since state can't be read correctly
+func (db *encryptedDB) decrypt(key, value []byte) []byte {
+	if !bytes.HasPrefix(value, encryptedValueHeader) {
+		return value
+	}
+	nonceSize := db.aead.NonceSize()
+	encrypted := value[len(encryptedValueHeader):]
+	if len(encrypted) < nonceSize {
+		panic(fmt.Errorf("Encrypted value of key %q is too short", key))
+	}
+	decrypted, err := db.aead.Open(nil, encrypted[:nonceSize], encrypted[nonceSize:], key)
+	if err != nil {
+		panic(fmt.Errorf("Could not decrypt value of key %q: %v", key, err.Error()))
+	}
+	return decrypted
+}
+
+func (db *encryptedDB) Get(key []byte) []byte {
+	return db.decrypt(key, db.DB.Get(key))
+}
+
+func (db *encryptedDB) Set(key []byte, value []byte) {
+	db.DB.Set(key, db.encrypt(key, value))
+}
+
+func (db *encryptedDB) SetSync(key []byte, value []byte) {
+	db.DB.SetSync(key, db.encrypt(key, value))
+}
+
+func (db *encryptedDB) Iterator(start, end []byte) dbm.Iterator {
+	return &encryptedIterator{Iterator: db.DB.Iterator(start, end), db: db}
+}
+
+func (db *encryptedDB) ReverseIterator(start, end []byte) dbm.Iterator {
+	return &encryptedIterator{Iterator: db.DB.ReverseIterator(start, end), db: db}
+}
+
+func (db *encryptedDB) NewBatch() dbm.Batch {
+	return &encryptedBatch{Batch: db.DB.NewBatch(), db: db}
+}
+
+type encryptedIterator struct {
+	dbm.Iterator
+	db *encryptedDB
+}
+
+func (itr *encryptedIterator) Value() []byte {
+	return itr.db.decrypt(itr.Key(), itr.Iterator.Value())
+}
+
+type encryptedBatch struct {
+	dbm.Batch
+	db *encryptedDB
+}
+
+func (batch *encryptedBatch) Set(key, value []byte) {
+	batch.Batch.Set(key, batch.db.encrypt(key, value))
+}
@@ -0,0 +1,186 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Package faultdb wraps dbm.DB with injected latency, write errors and disk full for
+// testing how Commit, backup and restore handle storage faults deterministically.
+// Like Tendermint DB backends, injected errors are raised as panic.
+package faultdb
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+var (
+	// ErrInjectedWrite is raised by write which is set to fail by FailNthWrite
+	ErrInjectedWrite = errors.New("faultdb: injected write error")
+	// ErrDiskFull is raised by write exceeding capacity set by SetCapacity
+	ErrDiskFull = errors.New("faultdb: no space left on device")
+)
+
+// DB is dbm.DB with injectable faults. Write is Set, SetSync, Delete, DeleteSync or
+// Write/WriteSync of batch. Failed write does not change underlying DB, failed batch
+// write discards the whole batch.
+type DB struct {
+	dbm.DB
+	mutex   sync.Mutex
+	latency time.Duration
+	// failWriteAt is write count at which write fails, 0 to disable
+	failWriteAt int64
+	writeCount  int64
+	// capacity is max total size of keys and values written, negative for no limit
+	capacity int64
+	used     int64
+}
+
+// New returns DB without faults wrapping db
+func New(db dbm.DB) *DB {
+	return &DB{DB: db, capacity: -1}
+}
+
+// SetLatency sets delay added to every read and write
+func (db *DB) SetLatency(latency time.Duration) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.latency = latency
+}
+
+// FailNthWrite makes nth write from now fail with ErrInjectedWrite. Only one write fails,
+// writes after it succeed. 0 cancels it.
+func (db *DB) FailNthWrite(n int64) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if n <= 0 {
+		db.failWriteAt = 0
+		return
+	}
+	db.failWriteAt = db.writeCount + n
+}
+
+// SetCapacity sets number of bytes (keys and values) which can be written from now before
+// writes fail with ErrDiskFull. Deleted keys don't free space. Negative for no limit.
+func (db *DB) SetCapacity(capacity int64) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.capacity = capacity
+	db.used = 0
+}
+
+// WriteCount returns number of writes attempted including failed ones
+func (db *DB) WriteCount() int64 {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return db.writeCount
+}
+
+func (db *DB) delay() {
+	db.mutex.Lock()
+	latency := db.latency
+	db.mutex.Unlock()
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+}
+
+// checkWrite counts write of size bytes and panics if it is set to fail
+func (db *DB) checkWrite(size int64) {
+	db.delay()
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.writeCount++
+	if db.writeCount == db.failWriteAt {
+		db.failWriteAt = 0
+		panic(ErrInjectedWrite)
+	}
+	if db.capacity >= 0 && db.used+size > db.capacity {
+		panic(ErrDiskFull)
+	}
+	db.used += size
+}
+
+func (db *DB) Get(key []byte) []byte {
+	db.delay()
+	return db.DB.Get(key)
+}
+
+func (db *DB) Has(key []byte) bool {
+	db.delay()
+	return db.DB.Has(key)
+}
+
+func (db *DB) Set(key []byte, value []byte) {
+	db.checkWrite(int64(len(key) + len(value)))
+	db.DB.Set(key, value)
+}
+
+func (db *DB) SetSync(key []byte, value []byte) {
+	db.checkWrite(int64(len(key) + len(value)))
+	db.DB.SetSync(key, value)
+}
+
+func (db *DB) Delete(key []byte) {
+	db.checkWrite(0)
+	db.DB.Delete(key)
+}
+
+func (db *DB) DeleteSync(key []byte) {
+	db.checkWrite(0)
+	db.DB.DeleteSync(key)
+}
+
+func (db *DB) Iterator(start, end []byte) dbm.Iterator {
+	db.delay()
+	return db.DB.Iterator(start, end)
+}
+
+func (db *DB) ReverseIterator(start, end []byte) dbm.Iterator {
+	db.delay()
+	return db.DB.ReverseIterator(start, end)
+}
+
+func (db *DB) NewBatch() dbm.Batch {
+	return &batch{Batch: db.DB.NewBatch(), db: db}
+}
+
+type batch struct {
+	dbm.Batch
+	db   *DB
+	size int64
+}
+
+func (b *batch) Set(key, value []byte) {
+	b.size += int64(len(key) + len(value))
+	b.Batch.Set(key, value)
+}
+
+func (b *batch) Write() {
+	b.db.checkWrite(b.size)
+	b.Batch.Write()
+}
+
+func (b *batch) WriteSync() {
+	b.db.checkWrite(b.size)
+	b.Batch.WriteSync()
+}
@@ -0,0 +1,206 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Package storage opens the database used by ABCI app so that every tool
+// reading app state uses the same database name and backend.
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/keys"
+)
+
+// DBName is name of ABCI app database in DB directory
+const DBName = "didDB"
+
+// OpenDB creates DB directory if it does not exist and opens ABCI app database in it
+func OpenDB(dbType string, dbDir string) (dbm.DB, error) {
+	if err := cmn.EnsureDir(dbDir, 0700); err != nil {
+		return nil, fmt.Errorf("Could not create DB directory: %v", err.Error())
+	}
+	return dbm.NewDB(DBName, dbm.DBBackendType(dbType), dbDir), nil
+}
+
+// State of network namespace is stored with key prefix "ns:<namespace>:" in shared DB
+// and namespace is registered with key "networkNamespace:<namespace>"
+const (
+	networkNamespaceStateKeyPrefix = "ns:"
+	networkNamespaceKeyPrefix      = "networkNamespace:"
+)
+
+// stateMetadataKey is key of ABCI app state metadata which exists in every non-empty state
+var stateMetadataKey = []byte(keys.StateMetadataKey)
+
+var networkNamespacePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// RegisterNetworkNamespace registers network namespace in DB before its state is written.
+// Empty namespace is not registered. DB must contain either state without namespace or
+// states of network namespaces, not both, since keys of one would be read as keys of the other.
+func RegisterNetworkNamespace(db dbm.DB, namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	if !networkNamespacePattern.MatchString(namespace) {
+		return fmt.Errorf("Invalid network namespace %q: must contain only letters, digits, '_' and '-'", namespace)
+	}
+	if db.Has(stateMetadataKey) {
+		return fmt.Errorf("DB contains state without network namespace")
+	}
+	db.SetSync([]byte(networkNamespaceKeyPrefix+namespace), []byte(namespace))
+	return nil
+}
+
+// NamespaceDB returns view of DB containing only state of registered network namespace so that
+// states of multiple environments (e.g. production and staging) can be kept in one DB without
+// conflicting. Empty namespace returns DB itself if it does not contain any network namespace.
+func NamespaceDB(db dbm.DB, namespace string) (dbm.DB, error) {
+	if namespace == "" {
+		namespaces := ListNetworkNamespaces(db)
+		if len(namespaces) > 0 {
+			return nil, fmt.Errorf("DB contains state of network namespaces %v, network namespace is required", namespaces)
+		}
+		return db, nil
+	}
+	if !db.Has([]byte(networkNamespaceKeyPrefix + namespace)) {
+		return nil, fmt.Errorf("Network namespace %q does not exist in DB", namespace)
+	}
+	return dbm.NewPrefixDB(db, NetworkNamespaceStatePrefix(namespace)), nil
+}
+
+// NetworkNamespaceStatePrefix returns prefix of state keys of network namespace in DB
+func NetworkNamespaceStatePrefix(namespace string) []byte {
+	if namespace == "" {
+		return nil
+	}
+	return []byte(networkNamespaceStateKeyPrefix + namespace + ":")
+}
+
+// ListNetworkNamespaces returns network namespaces registered in DB
+func ListNetworkNamespaces(db dbm.DB) []string {
+	namespaces := make([]string, 0)
+	prefix := []byte(networkNamespaceKeyPrefix)
+	itr := db.Iterator(prefix, prefixEnd(prefix))
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		namespaces = append(namespaces, string(itr.Key()[len(prefix):]))
+	}
+	return namespaces
+}
+
+// prefixEnd returns smallest key greater than every key with given prefix
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	end[len(end)-1]++
+	return end
+}
+
+// KeyStat is number and total size of keys (with values) of registered key prefix or single key
+type KeyStat struct {
+	Entry    keys.Entry
+	KeyCount int64
+	Size     int64
+}
+
+// InspectDB counts keys of every registered key prefix and single key in db. Key which
+// doesn't belong to any registered entry (e.g. nonce of Tx) is counted in unknown stat
+// and passed to fn.
+func InspectDB(db dbm.DB, fn func(key []byte)) (stats []KeyStat, unknown KeyStat) {
+	registry := keys.Registry()
+	statIndex := make(map[string]int, len(registry))
+	stats = make([]KeyStat, 0, len(registry))
+	for index, entry := range registry {
+		statIndex[entry.Name] = index
+		stats = append(stats, KeyStat{Entry: entry})
+	}
+	itr := db.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		size := int64(len(itr.Key()) + len(itr.Value()))
+		entry, ok := keys.Match(itr.Key())
+		if !ok {
+			unknown.KeyCount++
+			unknown.Size += size
+			if fn != nil {
+				fn(itr.Key())
+			}
+			continue
+		}
+		stats[statIndex[entry.Name]].KeyCount++
+		stats[statIndex[entry.Name]].Size += size
+	}
+	return stats, unknown
+}
+
+// KeyDiff is key which differs between two databases. Value is nil when key is missing.
+type KeyDiff struct {
+	Key        []byte
+	Value      []byte
+	OtherValue []byte
+}
+
+// CompareDB iterates keys of both databases in order and calls fn with every key which is
+// missing from either database or has different values. Comparison stops when fn returns false.
+func CompareDB(db dbm.DB, otherDB dbm.DB, fn func(diff KeyDiff) bool) {
+	itr := db.Iterator(nil, nil)
+	defer itr.Close()
+	otherItr := otherDB.Iterator(nil, nil)
+	defer otherItr.Close()
+	for itr.Valid() || otherItr.Valid() {
+		var diff KeyDiff
+		var cmp int
+		switch {
+		case !otherItr.Valid():
+			cmp = -1
+		case !itr.Valid():
+			cmp = 1
+		default:
+			cmp = bytes.Compare(itr.Key(), otherItr.Key())
+		}
+		switch {
+		case cmp < 0:
+			diff = KeyDiff{Key: itr.Key(), Value: itr.Value()}
+			itr.Next()
+		case cmp > 0:
+			diff = KeyDiff{Key: otherItr.Key(), OtherValue: otherItr.Value()}
+			otherItr.Next()
+		default:
+			value, otherValue := itr.Value(), otherItr.Value()
+			key := itr.Key()
+			itr.Next()
+			otherItr.Next()
+			if bytes.Equal(value, otherValue) {
+				continue
+			}
+			diff = KeyDiff{Key: key, Value: value, OtherValue: otherValue}
+		}
+		if !fn(diff) {
+			return
+		}
+	}
+}
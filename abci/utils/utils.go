@@ -23,14 +23,26 @@
 package utils
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
+	"sort"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 )
 
+// MarshaledBytesObserver is called with size of every message marshaled by ProtoDeterministicMarshal
+// when it is set (e.g. by state access profiler). It must be set before messages are marshaled.
+var MarshaledBytesObserver func(size int)
+
 func ProtoDeterministicMarshal(m proto.Message) ([]byte, error) {
 	var b proto.Buffer
 	b.SetDeterministic(true)
@@ -41,9 +53,116 @@ func ProtoDeterministicMarshal(m proto.Message) ([]byte, error) {
 	if retBytes == nil {
 		retBytes = make([]byte, 0)
 	}
+	if MarshaledBytesObserver != nil {
+		MarshaledBytesObserver(len(retBytes))
+	}
 	return retBytes, nil
 }
 
+// SortedKeys returns keys of map with string keys in ascending order.
+// Map iteration order in Go is random, so maps must be iterated with sorted keys
+// whenever order affects state writes, app hash, events or result of Tx.
+func SortedKeys(m interface{}) []string {
+	mapValue := reflect.ValueOf(m)
+	if mapValue.Kind() != reflect.Map || mapValue.Type().Key().Kind() != reflect.String {
+		panic(fmt.Sprintf("SortedKeys: %T is not map with string keys", m))
+	}
+	keys := make([]string, 0, mapValue.Len())
+	for _, key := range mapValue.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MaxNonceLength is max length of nonce (base64 encoded) of Tx and signed query
+const MaxNonceLength = 128
+
+// ValidateNonce checks that nonce is in canonical format: non-empty standard base64
+// (with padding, no line breaks) of at most MaxNonceLength characters. Only one encoding
+// of the same nonce bytes is accepted so that nonce uniqueness can't be bypassed.
+func ValidateNonce(nonce string) error {
+	if nonce == "" {
+		return fmt.Errorf("Nonce can't be empty")
+	}
+	if len(nonce) > MaxNonceLength {
+		return fmt.Errorf("Nonce is too long. Max length is %d", MaxNonceLength)
+	}
+	nonceBytes, err := base64.StdEncoding.Strict().DecodeString(nonce)
+	if err != nil {
+		return fmt.Errorf("Nonce is not valid base64: %s", err.Error())
+	}
+	if base64.StdEncoding.EncodeToString(nonceBytes) != nonce {
+		return fmt.Errorf("Nonce is not canonically encoded")
+	}
+	return nil
+}
+
+// EnvelopeVersionBase64URL is first byte of Tx or query envelope which is encoded as
+// base64url (RFC 4648 section 5, without padding) after this byte. Canonical protobuf
+// encoding of Tx and query starts with tag of field 1 or 2 (0x0A or 0x12) so envelope
+// without version byte is decoded as raw protobuf as before.
+const EnvelopeVersionBase64URL byte = '1'
+
+// paramHashSaltPrefix is prefix of chain ID which salt of parameter hash is derived from
+const paramHashSaltPrefix = "NDID param hash salt:"
+
+// CanonicalParamHash returns hex encoded HMAC-SHA256 of canonical JSON of Tx or query
+// parameter keyed by salt derived from chain ID. It is written to audit logs instead of
+// parameter so that operator holding parameter can find its Tx in logs while logs do not
+// contain personal data. Canonical JSON has object keys sorted and no whitespace with
+// numbers and strings unchanged, so parameter with the same content has the same hash.
+// Parameter which is not valid JSON is hashed as is.
+func CanonicalParamHash(chainID string, param string) string {
+	salt := sha256.Sum256([]byte(paramHashSaltPrefix + chainID))
+	mac := hmac.New(sha256.New, salt[:])
+	mac.Write(canonicalJSON(param))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func canonicalJSON(param string) []byte {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(param)))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return []byte(param)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return []byte(param)
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return []byte(param)
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))
+}
+
+// EncodeBase64URLEnvelope returns Tx or query envelope encoded as base64url with version
+// byte. Encoded envelope has only URL-safe characters and can be passed in URL query string.
+func EncodeBase64URLEnvelope(envelope []byte) []byte {
+	return append([]byte{EnvelopeVersionBase64URL}, []byte(base64.RawURLEncoding.EncodeToString(envelope))...)
+}
+
+// DecodeEnvelope returns protobuf encoded Tx or query of envelope. Envelope with
+// EnvelopeVersionBase64URL version byte must be in canonical base64url without padding
+// (no line breaks, unused bits are zero). Other envelope is returned as is.
+func DecodeEnvelope(envelope []byte) ([]byte, error) {
+	if len(envelope) == 0 || envelope[0] != EnvelopeVersionBase64URL {
+		return envelope, nil
+	}
+	encoded := string(envelope[1:])
+	decoded, err := base64.RawURLEncoding.Strict().DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("Envelope is not valid base64url: %s", err.Error())
+	}
+	if base64.RawURLEncoding.EncodeToString(decoded) != encoded {
+		return nil, fmt.Errorf("Envelope is not canonically encoded")
+	}
+	return decoded, nil
+}
+
 func WriteEventLogTx(filename string, time time.Time, name string, function string, nonce string) {
 	createDirIfNotExist("event_log")
 	f, err := os.OpenFile("event_log/"+filename+".log", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
@@ -31,6 +31,9 @@ var (
 
 	// AppProtocolVersion is ABCI App protocol version.
 	AppProtocolVersion uint64 = ABCIAppProtocolVersion
+
+	// StateSchemaVersion is version of app state schema in DB supported by this build.
+	StateSchemaVersion int64 = ABCIAppStateSchemaVersion
 )
 
 func init() {
@@ -45,4 +48,8 @@ const (
 
 	// ABCIAppProtocolVersion is ABCI App protocol version.
 	ABCIAppProtocolVersion = 2
+
+	// ABCIAppStateSchemaVersion is app state schema version.
+	// It must be increased when stored data is changed in a way older version can't read.
+	ABCIAppStateSchemaVersion = 2
 )
@@ -0,0 +1,175 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Package client builds transactions and queries of NDID smart contract: Tx envelope,
+// signing payload, nonce and query encoding. Smart contract verifies Tx with the same
+// SigningPayload so tools using this package follow envelope changes automatically.
+package client
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/utils"
+	protoTm "github.com/ndidplatform/smart-contract/v4/protos/tendermint"
+)
+
+// NonceSize is number of random bytes of nonce generated by GenerateNonce
+const NonceSize = 12
+
+// SignedQueryMethod is query method which runs inner query signed by caller node
+const SignedQueryMethod = "SignedQuery"
+
+// SignedQueryParam is parameter of SignedQuery
+type SignedQueryParam struct {
+	NodeID    string `json:"node_id"`
+	Method    string `json:"method"`
+	Params    string `json:"params"`
+	Nonce     string `json:"nonce"`
+	Signature []byte `json:"signature"`
+}
+
+// GenerateNonce returns base64 encoded random nonce. Nonce of Tx (and of signed query)
+// can be used only once by smart contract.
+func GenerateNonce() (string, error) {
+	nonce := make([]byte, NonceSize)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(nonce), nil
+}
+
+// SigningPayload returns message which is hashed with SHA-256 and signed for Tx (or
+// signed query) of method, params and nonce
+func SigningPayload(method string, paramJSON []byte, nonce string) []byte {
+	message := append([]byte(method), paramJSON...)
+	message = append(message, []byte(nonce)...)
+	return []byte(base64.StdEncoding.EncodeToString(message))
+}
+
+// Sign signs signing payload of method, params and nonce with RSA PKCS #1 v1.5 private key of node
+func Sign(method string, paramJSON []byte, nonce string, privKey *rsa.PrivateKey) ([]byte, error) {
+	hashed := sha256.Sum256(SigningPayload(method, paramJSON, nonce))
+	return rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA256, hashed[:])
+}
+
+// VerifySignature verifies signature of method, params and nonce with RSA public key of node
+func VerifySignature(method string, paramJSON []byte, nonce string, signature []byte, publicKey *rsa.PublicKey) error {
+	hashed := sha256.Sum256(SigningPayload(method, paramJSON, nonce))
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], signature)
+}
+
+// NewTx returns canonically encoded Tx envelope. Smart contract rejects Tx which is not
+// canonically encoded.
+func NewTx(method string, paramJSON []byte, nonce string, signature []byte, nodeID string) ([]byte, error) {
+	var tx protoTm.Tx
+	tx.Method = method
+	tx.Params = string(paramJSON)
+	tx.Nonce = []byte(nonce)
+	tx.Signature = signature
+	tx.NodeId = nodeID
+	return utils.ProtoDeterministicMarshal(&tx)
+}
+
+// CreateTx marshals param to JSON and returns Tx of method signed by node with new nonce
+func CreateTx(method string, param interface{}, nodeID string, privKey *rsa.PrivateKey) ([]byte, error) {
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := Sign(method, paramJSON, nonce, privKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewTx(method, paramJSON, nonce, signature, nodeID)
+}
+
+// NewQuery returns encoded query of method with params
+func NewQuery(method string, paramJSON []byte) ([]byte, error) {
+	var query protoTm.Query
+	query.Method = method
+	query.Params = string(paramJSON)
+	return utils.ProtoDeterministicMarshal(&query)
+}
+
+// CreateQuery marshals param to JSON and returns encoded query of method
+func CreateQuery(method string, param interface{}) ([]byte, error) {
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		return nil, err
+	}
+	return NewQuery(method, paramJSON)
+}
+
+// CreateSignedQuery returns encoded SignedQuery of method signed by node with new nonce.
+// It is required for query methods which are not public.
+func CreateSignedQuery(method string, param interface{}, nodeID string, privKey *rsa.PrivateKey) ([]byte, error) {
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := Sign(method, paramJSON, nonce, privKey)
+	if err != nil {
+		return nil, err
+	}
+	return CreateQuery(SignedQueryMethod, SignedQueryParam{
+		NodeID:    nodeID,
+		Method:    method,
+		Params:    string(paramJSON),
+		Nonce:     nonce,
+		Signature: signature,
+	})
+}
+
+// ParamHash returns salted hash of Tx or query parameter on chain with chain ID which
+// is written to audit logs of ABCI app (crash reports, circuit breaker and query access
+// logs and block activity) for finding Tx or query in them.
+func ParamHash(chainID string, param string) string {
+	return utils.CanonicalParamHash(chainID, param)
+}
+
+// EncodeBase64URL wraps Tx or query in base64url envelope (version byte followed by
+// base64url without padding) which has only URL-safe characters. Smart contract accepts
+// both raw and base64url envelope.
+func EncodeBase64URL(data []byte) []byte {
+	return utils.EncodeBase64URLEnvelope(data)
+}
+
+// EncodeRPCBytes encodes Tx or query as hex string with 0x prefix which is used as tx
+// and data parameters of Tendermint RPC (broadcast_tx_*, abci_query) with URI over HTTP
+func EncodeRPCBytes(data []byte) string {
+	return "0x" + hex.EncodeToString(data)
+}
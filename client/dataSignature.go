@@ -0,0 +1,91 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package client
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// ParsePublicKey parses PEM encoded RSA public key of node
+func ParsePublicKey(publicKeyPEM string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(strings.Replace(publicKeyPEM, "\t", "", -1)))
+	if block == nil {
+		return nil, fmt.Errorf("Invalid key format. Cannot decode PEM.")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported key type. Only RSA is allowed.")
+	}
+	return rsaPublicKey, nil
+}
+
+// SignData signs data sent by AS to RP with RSA PKCS #1 v1.5 private key of AS
+// and returns base64 encoded signature for signature of SignData
+func SignData(data []byte, privKey *rsa.PrivateKey) (string, error) {
+	hashed := sha256.Sum256(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// DecodeDataSignature decodes base64 encoded data signature and checks that
+// its length is the length of signature made by RSA key
+func DecodeDataSignature(signature string, publicKey *rsa.PublicKey) ([]byte, error) {
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, fmt.Errorf("Signature is not base64 encoded: %v", err)
+	}
+	if len(signatureBytes) != publicKey.Size() {
+		return nil, fmt.Errorf("Signature length is %d bytes, expected %d bytes", len(signatureBytes), publicKey.Size())
+	}
+	return signatureBytes, nil
+}
+
+// VerifyASDataSignature verifies signature of data received by RP from AS
+// with PEM encoded public key of AS
+func VerifyASDataSignature(data []byte, signature string, publicKeyPEM string) error {
+	publicKey, err := ParsePublicKey(publicKeyPEM)
+	if err != nil {
+		return err
+	}
+	signatureBytes, err := DecodeDataSignature(signature, publicKey)
+	if err != nil {
+		return err
+	}
+	hashed := sha256.Sum256(data)
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], signatureBytes)
+}
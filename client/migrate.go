@@ -0,0 +1,68 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+package client
+
+// Method and parameter types of transactions used by migrate tooling to move state
+// of previous chain into new chain. They are the same types smart contract unmarshals
+// so scripts should use them instead of importing versioned ABCI app package.
+const (
+	InitNDIDMethod            = "InitNDID"
+	SetInitDataMethod         = "SetInitData"
+	EndInitMethod             = "EndInit"
+	SetLastBlockMethod        = "SetLastBlock"
+	SetChainHistoryInfoMethod = "SetChainHistoryInfo"
+)
+
+// InitNDIDParam is parameter of InitNDID. ChainHistoryInfo is JSON of previous chains.
+type InitNDIDParam struct {
+	NodeID           string `json:"node_id"`
+	PublicKey        string `json:"public_key"`
+	MasterPublicKey  string `json:"master_public_key"`
+	ChainHistoryInfo string `json:"chain_history_info"`
+}
+
+// KeyValue is state key and value exported from previous chain
+type KeyValue struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// SetInitDataParam is parameter of SetInitData
+type SetInitDataParam struct {
+	KVList []KeyValue `json:"kv_list"`
+}
+
+// EndInitParam is parameter of EndInit
+type EndInitParam struct{}
+
+// SetLastBlockParam is parameter of SetLastBlock
+type SetLastBlockParam struct {
+	BlockHeight int64 `json:"block_height"`
+}
+
+// SetChainHistoryInfoParam is parameter of SetChainHistoryInfo
+type SetChainHistoryInfoParam struct {
+	ChainID          string `json:"chain_id"`
+	FinalBlockHeight int64  `json:"final_block_height"`
+	FinalAppHash     string `json:"final_app_hash"`
+}
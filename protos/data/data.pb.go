@@ -71,6 +71,14 @@ type NodeDetail struct {
 	ProxyNodeId                            string   `protobuf:"bytes,9,opt,name=proxy_node_id,json=proxyNodeId,proto3" json:"proxy_node_id,omitempty"`
 	ProxyConfig                            string   `protobuf:"bytes,10,opt,name=proxy_config,json=proxyConfig,proto3" json:"proxy_config,omitempty"`
 	SupportedRequestMessageDataUrlTypeList []string `protobuf:"bytes,11,rep,name=supported_request_message_data_url_type_list,json=supportedRequestMessageDataUrlTypeList,proto3" json:"supported_request_message_data_url_type_list,omitempty"`
+	ParentIdpId                            string   `protobuf:"bytes,12,opt,name=parent_idp_id,json=parentIdpId,proto3" json:"parent_idp_id,omitempty"`
+	SupportedFeatureList                   []string `protobuf:"bytes,13,rep,name=supported_feature_list,json=supportedFeatureList,proto3" json:"supported_feature_list,omitempty"`
+	CreationBlockHeight                    int64    `protobuf:"varint,14,opt,name=creation_block_height,json=creationBlockHeight,proto3" json:"creation_block_height,omitempty"`
+	CreationBlockTime                      int64    `protobuf:"varint,15,opt,name=creation_block_time,json=creationBlockTime,proto3" json:"creation_block_time,omitempty"`
+	LastUpdateBlockHeight                  int64    `protobuf:"varint,16,opt,name=last_update_block_height,json=lastUpdateBlockHeight,proto3" json:"last_update_block_height,omitempty"`
+	LastUpdateBlockTime                    int64    `protobuf:"varint,17,opt,name=last_update_block_time,json=lastUpdateBlockTime,proto3" json:"last_update_block_time,omitempty"`
+	AdditionalRoleList                     []string `protobuf:"bytes,18,rep,name=additional_role_list,json=additionalRoleList,proto3" json:"additional_role_list,omitempty"`
+	Alias                                  string   `protobuf:"bytes,19,opt,name=alias,proto3" json:"alias,omitempty"`
 	XXX_NoUnkeyedLiteral                   struct{} `json:"-"`
 	XXX_unrecognized                       []byte   `json:"-"`
 	XXX_sizecache                          int32    `json:"-"`
@@ -178,9 +186,67 @@ func (m *NodeDetail) GetSupportedRequestMessageDataUrlTypeList() []string {
 	return nil
 }
 
+func (m *NodeDetail) GetParentIdpId() string {
+	if m != nil {
+		return m.ParentIdpId
+	}
+	return ""
+}
+
+func (m *NodeDetail) GetSupportedFeatureList() []string {
+	if m != nil {
+		return m.SupportedFeatureList
+	}
+	return nil
+}
+
+func (m *NodeDetail) GetCreationBlockHeight() int64 {
+	if m != nil {
+		return m.CreationBlockHeight
+	}
+	return 0
+}
+
+func (m *NodeDetail) GetCreationBlockTime() int64 {
+	if m != nil {
+		return m.CreationBlockTime
+	}
+	return 0
+}
+
+func (m *NodeDetail) GetLastUpdateBlockHeight() int64 {
+	if m != nil {
+		return m.LastUpdateBlockHeight
+	}
+	return 0
+}
+
+func (m *NodeDetail) GetLastUpdateBlockTime() int64 {
+	if m != nil {
+		return m.LastUpdateBlockTime
+	}
+	return 0
+}
+
+func (m *NodeDetail) GetAdditionalRoleList() []string {
+	if m != nil {
+		return m.AdditionalRoleList
+	}
+	return nil
+}
+
+func (m *NodeDetail) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
 type MQ struct {
 	Ip                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
 	Port                 int64    `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Priority             int64    `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	Inactive             bool     `protobuf:"varint,4,opt,name=inactive,proto3" json:"inactive,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -225,6 +291,20 @@ func (m *MQ) GetPort() int64 {
 	return 0
 }
 
+func (m *MQ) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *MQ) GetInactive() bool {
+	if m != nil {
+		return m.Inactive
+	}
+	return false
+}
+
 type IdPList struct {
 	NodeId               []string `protobuf:"bytes,1,rep,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -309,6 +389,7 @@ type Namespace struct {
 	Active                                       bool     `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
 	AllowedIdentifierCountInReferenceGroup       int32    `protobuf:"varint,4,opt,name=allowed_identifier_count_in_reference_group,json=allowedIdentifierCountInReferenceGroup,proto3" json:"allowed_identifier_count_in_reference_group,omitempty"`
 	AllowedActiveIdentifierCountInReferenceGroup int32    `protobuf:"varint,5,opt,name=allowed_active_identifier_count_in_reference_group,json=allowedActiveIdentifierCountInReferenceGroup,proto3" json:"allowed_active_identifier_count_in_reference_group,omitempty"`
+	AllowedModeList                              []int32  `protobuf:"varint,6,rep,packed,name=allowed_mode_list,json=allowedModeList,proto3" json:"allowed_mode_list,omitempty"`
 	XXX_NoUnkeyedLiteral                         struct{} `json:"-"`
 	XXX_unrecognized                             []byte   `json:"-"`
 	XXX_sizecache                                int32    `json:"-"`
@@ -374,6 +455,13 @@ func (m *Namespace) GetAllowedActiveIdentifierCountInReferenceGroup() int32 {
 	return 0
 }
 
+func (m *Namespace) GetAllowedModeList() []int32 {
+	if m != nil {
+		return m.AllowedModeList
+	}
+	return nil
+}
+
 type ServiceDetailList struct {
 	Services             []*ServiceDetail `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -562,6 +650,217 @@ func (m *TimeOutBlockRegisterIdentity) GetTimeOutBlock() int64 {
 	return 0
 }
 
+type MaxRequestTimeoutExtension struct {
+	MaxExtension         int64    `protobuf:"varint,1,opt,name=max_extension,json=maxExtension,proto3" json:"max_extension,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MaxRequestTimeoutExtension) Reset()         { *m = MaxRequestTimeoutExtension{} }
+func (m *MaxRequestTimeoutExtension) String() string { return proto.CompactTextString(m) }
+func (*MaxRequestTimeoutExtension) ProtoMessage()    {}
+func (*MaxRequestTimeoutExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{10}
+}
+
+func (m *MaxRequestTimeoutExtension) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MaxRequestTimeoutExtension.Unmarshal(m, b)
+}
+func (m *MaxRequestTimeoutExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MaxRequestTimeoutExtension.Marshal(b, m, deterministic)
+}
+func (m *MaxRequestTimeoutExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaxRequestTimeoutExtension.Merge(m, src)
+}
+func (m *MaxRequestTimeoutExtension) XXX_Size() int {
+	return xxx_messageInfo_MaxRequestTimeoutExtension.Size(m)
+}
+func (m *MaxRequestTimeoutExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaxRequestTimeoutExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaxRequestTimeoutExtension proto.InternalMessageInfo
+
+func (m *MaxRequestTimeoutExtension) GetMaxExtension() int64 {
+	if m != nil {
+		return m.MaxExtension
+	}
+	return 0
+}
+
+type RequestListSizeLimit struct {
+	MaxDataRequestCount  int64    `protobuf:"varint,1,opt,name=max_data_request_count,json=maxDataRequestCount,proto3" json:"max_data_request_count,omitempty"`
+	MaxIdpCount          int64    `protobuf:"varint,2,opt,name=max_idp_count,json=maxIdpCount,proto3" json:"max_idp_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestListSizeLimit) Reset()         { *m = RequestListSizeLimit{} }
+func (m *RequestListSizeLimit) String() string { return proto.CompactTextString(m) }
+func (*RequestListSizeLimit) ProtoMessage()    {}
+func (*RequestListSizeLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{11}
+}
+
+func (m *RequestListSizeLimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestListSizeLimit.Unmarshal(m, b)
+}
+func (m *RequestListSizeLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestListSizeLimit.Marshal(b, m, deterministic)
+}
+func (m *RequestListSizeLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestListSizeLimit.Merge(m, src)
+}
+func (m *RequestListSizeLimit) XXX_Size() int {
+	return xxx_messageInfo_RequestListSizeLimit.Size(m)
+}
+func (m *RequestListSizeLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestListSizeLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestListSizeLimit proto.InternalMessageInfo
+
+func (m *RequestListSizeLimit) GetMaxDataRequestCount() int64 {
+	if m != nil {
+		return m.MaxDataRequestCount
+	}
+	return 0
+}
+
+func (m *RequestListSizeLimit) GetMaxIdpCount() int64 {
+	if m != nil {
+		return m.MaxIdpCount
+	}
+	return 0
+}
+
+type RandomnessBeaconConfig struct {
+	Enabled              bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RandomnessBeaconConfig) Reset()         { *m = RandomnessBeaconConfig{} }
+func (m *RandomnessBeaconConfig) String() string { return proto.CompactTextString(m) }
+func (*RandomnessBeaconConfig) ProtoMessage()    {}
+func (*RandomnessBeaconConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{12}
+}
+
+func (m *RandomnessBeaconConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RandomnessBeaconConfig.Unmarshal(m, b)
+}
+func (m *RandomnessBeaconConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RandomnessBeaconConfig.Marshal(b, m, deterministic)
+}
+func (m *RandomnessBeaconConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RandomnessBeaconConfig.Merge(m, src)
+}
+func (m *RandomnessBeaconConfig) XXX_Size() int {
+	return xxx_messageInfo_RandomnessBeaconConfig.Size(m)
+}
+func (m *RandomnessBeaconConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_RandomnessBeaconConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RandomnessBeaconConfig proto.InternalMessageInfo
+
+func (m *RandomnessBeaconConfig) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type AppHashSchemeConfig struct {
+	ActivationList       []*AppHashSchemeActivation `protobuf:"bytes,1,rep,name=activation_list,json=activationList,proto3" json:"activation_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *AppHashSchemeConfig) Reset()         { *m = AppHashSchemeConfig{} }
+func (m *AppHashSchemeConfig) String() string { return proto.CompactTextString(m) }
+func (*AppHashSchemeConfig) ProtoMessage()    {}
+func (*AppHashSchemeConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{13}
+}
+
+func (m *AppHashSchemeConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppHashSchemeConfig.Unmarshal(m, b)
+}
+func (m *AppHashSchemeConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppHashSchemeConfig.Marshal(b, m, deterministic)
+}
+func (m *AppHashSchemeConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppHashSchemeConfig.Merge(m, src)
+}
+func (m *AppHashSchemeConfig) XXX_Size() int {
+	return xxx_messageInfo_AppHashSchemeConfig.Size(m)
+}
+func (m *AppHashSchemeConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppHashSchemeConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppHashSchemeConfig proto.InternalMessageInfo
+
+func (m *AppHashSchemeConfig) GetActivationList() []*AppHashSchemeActivation {
+	if m != nil {
+		return m.ActivationList
+	}
+	return nil
+}
+
+type AppHashSchemeActivation struct {
+	Version              int64    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	ActivationHeight     int64    `protobuf:"varint,2,opt,name=activation_height,json=activationHeight,proto3" json:"activation_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppHashSchemeActivation) Reset()         { *m = AppHashSchemeActivation{} }
+func (m *AppHashSchemeActivation) String() string { return proto.CompactTextString(m) }
+func (*AppHashSchemeActivation) ProtoMessage()    {}
+func (*AppHashSchemeActivation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{14}
+}
+
+func (m *AppHashSchemeActivation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppHashSchemeActivation.Unmarshal(m, b)
+}
+func (m *AppHashSchemeActivation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppHashSchemeActivation.Marshal(b, m, deterministic)
+}
+func (m *AppHashSchemeActivation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppHashSchemeActivation.Merge(m, src)
+}
+func (m *AppHashSchemeActivation) XXX_Size() int {
+	return xxx_messageInfo_AppHashSchemeActivation.Size(m)
+}
+func (m *AppHashSchemeActivation) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppHashSchemeActivation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppHashSchemeActivation proto.InternalMessageInfo
+
+func (m *AppHashSchemeActivation) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *AppHashSchemeActivation) GetActivationHeight() int64 {
+	if m != nil {
+		return m.ActivationHeight
+	}
+	return 0
+}
+
 type Proxy struct {
 	ProxyNodeId          string   `protobuf:"bytes,1,opt,name=proxy_node_id,json=proxyNodeId,proto3" json:"proxy_node_id,omitempty"`
 	Config               string   `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
@@ -574,7 +873,7 @@ func (m *Proxy) Reset()         { *m = Proxy{} }
 func (m *Proxy) String() string { return proto.CompactTextString(m) }
 func (*Proxy) ProtoMessage()    {}
 func (*Proxy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{10}
+	return fileDescriptor_492be2f0ffbab25c, []int{15}
 }
 
 func (m *Proxy) XXX_Unmarshal(b []byte) error {
@@ -620,7 +919,7 @@ func (m *BehindNodeList) Reset()         { *m = BehindNodeList{} }
 func (m *BehindNodeList) String() string { return proto.CompactTextString(m) }
 func (*BehindNodeList) ProtoMessage()    {}
 func (*BehindNodeList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{11}
+	return fileDescriptor_492be2f0ffbab25c, []int{16}
 }
 
 func (m *BehindNodeList) XXX_Unmarshal(b []byte) error {
@@ -649,33 +948,47 @@ func (m *BehindNodeList) GetNodes() []string {
 }
 
 type Request struct {
-	RequestId            string         `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	MinIdp               int64          `protobuf:"varint,2,opt,name=min_idp,json=minIdp,proto3" json:"min_idp,omitempty"`
-	MinAal               float64        `protobuf:"fixed64,3,opt,name=min_aal,json=minAal,proto3" json:"min_aal,omitempty"`
-	MinIal               float64        `protobuf:"fixed64,4,opt,name=min_ial,json=minIal,proto3" json:"min_ial,omitempty"`
-	RequestTimeout       int64          `protobuf:"varint,5,opt,name=request_timeout,json=requestTimeout,proto3" json:"request_timeout,omitempty"`
-	IdpIdList            []string       `protobuf:"bytes,6,rep,name=idp_id_list,json=idpIdList,proto3" json:"idp_id_list,omitempty"`
-	DataRequestList      []*DataRequest `protobuf:"bytes,7,rep,name=data_request_list,json=dataRequestList,proto3" json:"data_request_list,omitempty"`
-	RequestMessageHash   string         `protobuf:"bytes,8,opt,name=request_message_hash,json=requestMessageHash,proto3" json:"request_message_hash,omitempty"`
-	ResponseList         []*Response    `protobuf:"bytes,9,rep,name=response_list,json=responseList,proto3" json:"response_list,omitempty"`
-	Closed               bool           `protobuf:"varint,10,opt,name=closed,proto3" json:"closed,omitempty"`
-	TimedOut             bool           `protobuf:"varint,11,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
-	Purpose              string         `protobuf:"bytes,12,opt,name=purpose,proto3" json:"purpose,omitempty"`
-	Owner                string         `protobuf:"bytes,13,opt,name=owner,proto3" json:"owner,omitempty"`
-	Mode                 int32          `protobuf:"varint,14,opt,name=mode,proto3" json:"mode,omitempty"`
-	UseCount             int64          `protobuf:"varint,15,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
-	CreationBlockHeight  int64          `protobuf:"varint,16,opt,name=creation_block_height,json=creationBlockHeight,proto3" json:"creation_block_height,omitempty"`
-	ChainId              string         `protobuf:"bytes,17,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	RequestId                   string          `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	MinIdp                      int64           `protobuf:"varint,2,opt,name=min_idp,json=minIdp,proto3" json:"min_idp,omitempty"`
+	MinAal                      float64         `protobuf:"fixed64,3,opt,name=min_aal,json=minAal,proto3" json:"min_aal,omitempty"`
+	MinIal                      float64         `protobuf:"fixed64,4,opt,name=min_ial,json=minIal,proto3" json:"min_ial,omitempty"`
+	RequestTimeout              int64           `protobuf:"varint,5,opt,name=request_timeout,json=requestTimeout,proto3" json:"request_timeout,omitempty"`
+	IdpIdList                   []string        `protobuf:"bytes,6,rep,name=idp_id_list,json=idpIdList,proto3" json:"idp_id_list,omitempty"`
+	DataRequestList             []*DataRequest  `protobuf:"bytes,7,rep,name=data_request_list,json=dataRequestList,proto3" json:"data_request_list,omitempty"`
+	RequestMessageHash          string          `protobuf:"bytes,8,opt,name=request_message_hash,json=requestMessageHash,proto3" json:"request_message_hash,omitempty"`
+	ResponseList                []*Response     `protobuf:"bytes,9,rep,name=response_list,json=responseList,proto3" json:"response_list,omitempty"`
+	Closed                      bool            `protobuf:"varint,10,opt,name=closed,proto3" json:"closed,omitempty"`
+	TimedOut                    bool            `protobuf:"varint,11,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	Purpose                     string          `protobuf:"bytes,12,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Owner                       string          `protobuf:"bytes,13,opt,name=owner,proto3" json:"owner,omitempty"`
+	Mode                        int32           `protobuf:"varint,14,opt,name=mode,proto3" json:"mode,omitempty"`
+	UseCount                    int64           `protobuf:"varint,15,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
+	CreationBlockHeight         int64           `protobuf:"varint,16,opt,name=creation_block_height,json=creationBlockHeight,proto3" json:"creation_block_height,omitempty"`
+	ChainId                     string          `protobuf:"bytes,17,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	AutoClose                   bool            `protobuf:"varint,18,opt,name=auto_close,json=autoClose,proto3" json:"auto_close,omitempty"`
+	TimeoutExtension            int64           `protobuf:"varint,19,opt,name=timeout_extension,json=timeoutExtension,proto3" json:"timeout_extension,omitempty"`
+	TimeoutExtensionBlockHeight int64           `protobuf:"varint,20,opt,name=timeout_extension_block_height,json=timeoutExtensionBlockHeight,proto3" json:"timeout_extension_block_height,omitempty"`
+	EscrowAmount                float64         `protobuf:"fixed64,21,opt,name=escrow_amount,json=escrowAmount,proto3" json:"escrow_amount,omitempty"`
+	EscrowIdpResponsePrice      float64         `protobuf:"fixed64,22,opt,name=escrow_idp_response_price,json=escrowIdpResponsePrice,proto3" json:"escrow_idp_response_price,omitempty"`
+	EscrowAsDataPrice           float64         `protobuf:"fixed64,23,opt,name=escrow_as_data_price,json=escrowAsDataPrice,proto3" json:"escrow_as_data_price,omitempty"`
+	CreationBlockTime           int64           `protobuf:"varint,24,opt,name=creation_block_time,json=creationBlockTime,proto3" json:"creation_block_time,omitempty"`
+	IdpResponseTimeout          int64           `protobuf:"varint,25,opt,name=idp_response_timeout,json=idpResponseTimeout,proto3" json:"idp_response_timeout,omitempty"`
+	PriorityClass               string          `protobuf:"bytes,26,opt,name=priority_class,json=priorityClass,proto3" json:"priority_class,omitempty"`
+	SubRecordsSplit             bool            `protobuf:"varint,27,opt,name=sub_records_split,json=subRecordsSplit,proto3" json:"sub_records_split,omitempty"`
+	Summary                     *RequestSummary `protobuf:"bytes,28,opt,name=summary,proto3" json:"summary,omitempty"`
+	LastUpdateBlockHeight       int64           `protobuf:"varint,29,opt,name=last_update_block_height,json=lastUpdateBlockHeight,proto3" json:"last_update_block_height,omitempty"`
+	LastUpdateBlockTime         int64           `protobuf:"varint,30,opt,name=last_update_block_time,json=lastUpdateBlockTime,proto3" json:"last_update_block_time,omitempty"`
+	RequestMessageSalt          string          `protobuf:"bytes,31,opt,name=request_message_salt,json=requestMessageSalt,proto3" json:"request_message_salt,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{}        `json:"-"`
+	XXX_unrecognized            []byte          `json:"-"`
+	XXX_sizecache               int32           `json:"-"`
 }
 
 func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{12}
+	return fileDescriptor_492be2f0ffbab25c, []int{17}
 }
 
 func (m *Request) XXX_Unmarshal(b []byte) error {
@@ -815,106 +1128,350 @@ func (m *Request) GetChainId() string {
 	return ""
 }
 
-type DataRequest struct {
-	ServiceId            string   `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
-	AsIdList             []string `protobuf:"bytes,2,rep,name=as_id_list,json=asIdList,proto3" json:"as_id_list,omitempty"`
-	MinAs                int64    `protobuf:"varint,3,opt,name=min_as,json=minAs,proto3" json:"min_as,omitempty"`
-	RequestParamsHash    string   `protobuf:"bytes,4,opt,name=request_params_hash,json=requestParamsHash,proto3" json:"request_params_hash,omitempty"`
-	AnsweredAsIdList     []string `protobuf:"bytes,5,rep,name=answered_as_id_list,json=answeredAsIdList,proto3" json:"answered_as_id_list,omitempty"`
-	ReceivedDataFromList []string `protobuf:"bytes,6,rep,name=received_data_from_list,json=receivedDataFromList,proto3" json:"received_data_from_list,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Request) GetAutoClose() bool {
+	if m != nil {
+		return m.AutoClose
+	}
+	return false
 }
 
-func (m *DataRequest) Reset()         { *m = DataRequest{} }
-func (m *DataRequest) String() string { return proto.CompactTextString(m) }
-func (*DataRequest) ProtoMessage()    {}
-func (*DataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{13}
+func (m *Request) GetTimeoutExtension() int64 {
+	if m != nil {
+		return m.TimeoutExtension
+	}
+	return 0
 }
 
-func (m *DataRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DataRequest.Unmarshal(m, b)
-}
-func (m *DataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DataRequest.Marshal(b, m, deterministic)
-}
-func (m *DataRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DataRequest.Merge(m, src)
-}
-func (m *DataRequest) XXX_Size() int {
-	return xxx_messageInfo_DataRequest.Size(m)
+func (m *Request) GetTimeoutExtensionBlockHeight() int64 {
+	if m != nil {
+		return m.TimeoutExtensionBlockHeight
+	}
+	return 0
 }
-func (m *DataRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DataRequest.DiscardUnknown(m)
+
+func (m *Request) GetEscrowAmount() float64 {
+	if m != nil {
+		return m.EscrowAmount
+	}
+	return 0
 }
 
-var xxx_messageInfo_DataRequest proto.InternalMessageInfo
+func (m *Request) GetEscrowIdpResponsePrice() float64 {
+	if m != nil {
+		return m.EscrowIdpResponsePrice
+	}
+	return 0
+}
 
-func (m *DataRequest) GetServiceId() string {
+func (m *Request) GetEscrowAsDataPrice() float64 {
 	if m != nil {
-		return m.ServiceId
+		return m.EscrowAsDataPrice
 	}
-	return ""
+	return 0
 }
 
-func (m *DataRequest) GetAsIdList() []string {
+func (m *Request) GetCreationBlockTime() int64 {
 	if m != nil {
-		return m.AsIdList
+		return m.CreationBlockTime
 	}
-	return nil
+	return 0
 }
 
-func (m *DataRequest) GetMinAs() int64 {
+func (m *Request) GetIdpResponseTimeout() int64 {
 	if m != nil {
-		return m.MinAs
+		return m.IdpResponseTimeout
 	}
 	return 0
 }
 
-func (m *DataRequest) GetRequestParamsHash() string {
+func (m *Request) GetPriorityClass() string {
 	if m != nil {
-		return m.RequestParamsHash
+		return m.PriorityClass
 	}
 	return ""
 }
 
-func (m *DataRequest) GetAnsweredAsIdList() []string {
+func (m *Request) GetSubRecordsSplit() bool {
 	if m != nil {
-		return m.AnsweredAsIdList
+		return m.SubRecordsSplit
 	}
-	return nil
+	return false
 }
 
-func (m *DataRequest) GetReceivedDataFromList() []string {
+func (m *Request) GetSummary() *RequestSummary {
 	if m != nil {
-		return m.ReceivedDataFromList
+		return m.Summary
 	}
 	return nil
 }
 
-type Response struct {
-	Ial                  float64  `protobuf:"fixed64,1,opt,name=ial,proto3" json:"ial,omitempty"`
-	Aal                  float64  `protobuf:"fixed64,2,opt,name=aal,proto3" json:"aal,omitempty"`
-	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	Signature            string   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
-	IdpId                string   `protobuf:"bytes,5,opt,name=idp_id,json=idpId,proto3" json:"idp_id,omitempty"`
-	ValidIal             string   `protobuf:"bytes,6,opt,name=valid_ial,json=validIal,proto3" json:"valid_ial,omitempty"`
-	ValidSignature       string   `protobuf:"bytes,7,opt,name=valid_signature,json=validSignature,proto3" json:"valid_signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Request) GetLastUpdateBlockHeight() int64 {
+	if m != nil {
+		return m.LastUpdateBlockHeight
+	}
+	return 0
 }
 
-func (m *Response) Reset()         { *m = Response{} }
-func (m *Response) String() string { return proto.CompactTextString(m) }
-func (*Response) ProtoMessage()    {}
-func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{14}
+func (m *Request) GetLastUpdateBlockTime() int64 {
+	if m != nil {
+		return m.LastUpdateBlockTime
+	}
+	return 0
 }
 
-func (m *Response) XXX_Unmarshal(b []byte) error {
+func (m *Request) GetRequestMessageSalt() string {
+	if m != nil {
+		return m.RequestMessageSalt
+	}
+	return ""
+}
+
+type RequestSummary struct {
+	AcceptCount           int64                 `protobuf:"varint,1,opt,name=accept_count,json=acceptCount,proto3" json:"accept_count,omitempty"`
+	RejectCount           int64                 `protobuf:"varint,2,opt,name=reject_count,json=rejectCount,proto3" json:"reject_count,omitempty"`
+	ErrorCount            int64                 `protobuf:"varint,3,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	SignedServiceList     []*ServiceSignedCount `protobuf:"bytes,4,rep,name=signed_service_list,json=signedServiceList,proto3" json:"signed_service_list,omitempty"`
+	Status                string                `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	LastUpdateBlockHeight int64                 `protobuf:"varint,6,opt,name=last_update_block_height,json=lastUpdateBlockHeight,proto3" json:"last_update_block_height,omitempty"`
+	LastUpdateBlockTime   int64                 `protobuf:"varint,7,opt,name=last_update_block_time,json=lastUpdateBlockTime,proto3" json:"last_update_block_time,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}              `json:"-"`
+	XXX_unrecognized      []byte                `json:"-"`
+	XXX_sizecache         int32                 `json:"-"`
+}
+
+func (m *RequestSummary) Reset()         { *m = RequestSummary{} }
+func (m *RequestSummary) String() string { return proto.CompactTextString(m) }
+func (*RequestSummary) ProtoMessage()    {}
+func (*RequestSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{18}
+}
+
+func (m *RequestSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestSummary.Unmarshal(m, b)
+}
+func (m *RequestSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestSummary.Marshal(b, m, deterministic)
+}
+func (m *RequestSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestSummary.Merge(m, src)
+}
+func (m *RequestSummary) XXX_Size() int {
+	return xxx_messageInfo_RequestSummary.Size(m)
+}
+func (m *RequestSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestSummary proto.InternalMessageInfo
+
+func (m *RequestSummary) GetAcceptCount() int64 {
+	if m != nil {
+		return m.AcceptCount
+	}
+	return 0
+}
+
+func (m *RequestSummary) GetRejectCount() int64 {
+	if m != nil {
+		return m.RejectCount
+	}
+	return 0
+}
+
+func (m *RequestSummary) GetErrorCount() int64 {
+	if m != nil {
+		return m.ErrorCount
+	}
+	return 0
+}
+
+func (m *RequestSummary) GetSignedServiceList() []*ServiceSignedCount {
+	if m != nil {
+		return m.SignedServiceList
+	}
+	return nil
+}
+
+func (m *RequestSummary) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *RequestSummary) GetLastUpdateBlockHeight() int64 {
+	if m != nil {
+		return m.LastUpdateBlockHeight
+	}
+	return 0
+}
+
+func (m *RequestSummary) GetLastUpdateBlockTime() int64 {
+	if m != nil {
+		return m.LastUpdateBlockTime
+	}
+	return 0
+}
+
+type ServiceSignedCount struct {
+	ServiceId            string   `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	SignedCount          int64    `protobuf:"varint,2,opt,name=signed_count,json=signedCount,proto3" json:"signed_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceSignedCount) Reset()         { *m = ServiceSignedCount{} }
+func (m *ServiceSignedCount) String() string { return proto.CompactTextString(m) }
+func (*ServiceSignedCount) ProtoMessage()    {}
+func (*ServiceSignedCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{19}
+}
+
+func (m *ServiceSignedCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceSignedCount.Unmarshal(m, b)
+}
+func (m *ServiceSignedCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceSignedCount.Marshal(b, m, deterministic)
+}
+func (m *ServiceSignedCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceSignedCount.Merge(m, src)
+}
+func (m *ServiceSignedCount) XXX_Size() int {
+	return xxx_messageInfo_ServiceSignedCount.Size(m)
+}
+func (m *ServiceSignedCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceSignedCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceSignedCount proto.InternalMessageInfo
+
+func (m *ServiceSignedCount) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *ServiceSignedCount) GetSignedCount() int64 {
+	if m != nil {
+		return m.SignedCount
+	}
+	return 0
+}
+
+type DataRequest struct {
+	ServiceId            string   `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	AsIdList             []string `protobuf:"bytes,2,rep,name=as_id_list,json=asIdList,proto3" json:"as_id_list,omitempty"`
+	MinAs                int64    `protobuf:"varint,3,opt,name=min_as,json=minAs,proto3" json:"min_as,omitempty"`
+	RequestParamsHash    string   `protobuf:"bytes,4,opt,name=request_params_hash,json=requestParamsHash,proto3" json:"request_params_hash,omitempty"`
+	AnsweredAsIdList     []string `protobuf:"bytes,5,rep,name=answered_as_id_list,json=answeredAsIdList,proto3" json:"answered_as_id_list,omitempty"`
+	ReceivedDataFromList []string `protobuf:"bytes,6,rep,name=received_data_from_list,json=receivedDataFromList,proto3" json:"received_data_from_list,omitempty"`
+	RequestParamsSalt    string   `protobuf:"bytes,7,opt,name=request_params_salt,json=requestParamsSalt,proto3" json:"request_params_salt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataRequest) Reset()         { *m = DataRequest{} }
+func (m *DataRequest) String() string { return proto.CompactTextString(m) }
+func (*DataRequest) ProtoMessage()    {}
+func (*DataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{20}
+}
+
+func (m *DataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataRequest.Unmarshal(m, b)
+}
+func (m *DataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataRequest.Marshal(b, m, deterministic)
+}
+func (m *DataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRequest.Merge(m, src)
+}
+func (m *DataRequest) XXX_Size() int {
+	return xxx_messageInfo_DataRequest.Size(m)
+}
+func (m *DataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataRequest proto.InternalMessageInfo
+
+func (m *DataRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *DataRequest) GetAsIdList() []string {
+	if m != nil {
+		return m.AsIdList
+	}
+	return nil
+}
+
+func (m *DataRequest) GetMinAs() int64 {
+	if m != nil {
+		return m.MinAs
+	}
+	return 0
+}
+
+func (m *DataRequest) GetRequestParamsHash() string {
+	if m != nil {
+		return m.RequestParamsHash
+	}
+	return ""
+}
+
+func (m *DataRequest) GetAnsweredAsIdList() []string {
+	if m != nil {
+		return m.AnsweredAsIdList
+	}
+	return nil
+}
+
+func (m *DataRequest) GetReceivedDataFromList() []string {
+	if m != nil {
+		return m.ReceivedDataFromList
+	}
+	return nil
+}
+
+func (m *DataRequest) GetRequestParamsSalt() string {
+	if m != nil {
+		return m.RequestParamsSalt
+	}
+	return ""
+}
+
+type Response struct {
+	Ial                  float64  `protobuf:"fixed64,1,opt,name=ial,proto3" json:"ial,omitempty"`
+	Aal                  float64  `protobuf:"fixed64,2,opt,name=aal,proto3" json:"aal,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Signature            string   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	IdpId                string   `protobuf:"bytes,5,opt,name=idp_id,json=idpId,proto3" json:"idp_id,omitempty"`
+	ValidIal             string   `protobuf:"bytes,6,opt,name=valid_ial,json=validIal,proto3" json:"valid_ial,omitempty"`
+	ValidSignature       string   `protobuf:"bytes,7,opt,name=valid_signature,json=validSignature,proto3" json:"valid_signature,omitempty"`
+	AgentId              string   `protobuf:"bytes,8,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AccessorId           string   `protobuf:"bytes,9,opt,name=accessor_id,json=accessorId,proto3" json:"accessor_id,omitempty"`
+	BlockHeight          int64    `protobuf:"varint,10,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockTime            int64    `protobuf:"varint,11,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Response) Reset()         { *m = Response{} }
+func (m *Response) String() string { return proto.CompactTextString(m) }
+func (*Response) ProtoMessage()    {}
+func (*Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{21}
+}
+
+func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
 }
 func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
@@ -981,6 +1538,34 @@ func (m *Response) GetValidSignature() string {
 	return ""
 }
 
+func (m *Response) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *Response) GetAccessorId() string {
+	if m != nil {
+		return m.AccessorId
+	}
+	return ""
+}
+
+func (m *Response) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *Response) GetBlockTime() int64 {
+	if m != nil {
+		return m.BlockTime
+	}
+	return 0
+}
+
 type ReportList struct {
 	Reports              []*Report `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -992,7 +1577,7 @@ func (m *ReportList) Reset()         { *m = ReportList{} }
 func (m *ReportList) String() string { return proto.CompactTextString(m) }
 func (*ReportList) ProtoMessage()    {}
 func (*ReportList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{15}
+	return fileDescriptor_492be2f0ffbab25c, []int{22}
 }
 
 func (m *ReportList) XXX_Unmarshal(b []byte) error {
@@ -1033,7 +1618,7 @@ func (m *Report) Reset()         { *m = Report{} }
 func (m *Report) String() string { return proto.CompactTextString(m) }
 func (*Report) ProtoMessage()    {}
 func (*Report) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{16}
+	return fileDescriptor_492be2f0ffbab25c, []int{23}
 }
 
 func (m *Report) XXX_Unmarshal(b []byte) error {
@@ -1081,6 +1666,8 @@ type Accessor struct {
 	AccessorPublicKey    string   `protobuf:"bytes,3,opt,name=accessor_public_key,json=accessorPublicKey,proto3" json:"accessor_public_key,omitempty"`
 	Active               bool     `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
 	Owner                string   `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	Revoked              bool     `protobuf:"varint,6,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	CreationBlockHeight  int64    `protobuf:"varint,7,opt,name=creation_block_height,json=creationBlockHeight,proto3" json:"creation_block_height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1090,7 +1677,7 @@ func (m *Accessor) Reset()         { *m = Accessor{} }
 func (m *Accessor) String() string { return proto.CompactTextString(m) }
 func (*Accessor) ProtoMessage()    {}
 func (*Accessor) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{17}
+	return fileDescriptor_492be2f0ffbab25c, []int{24}
 }
 
 func (m *Accessor) XXX_Unmarshal(b []byte) error {
@@ -1146,6 +1733,20 @@ func (m *Accessor) GetOwner() string {
 	return ""
 }
 
+func (m *Accessor) GetRevoked() bool {
+	if m != nil {
+		return m.Revoked
+	}
+	return false
+}
+
+func (m *Accessor) GetCreationBlockHeight() int64 {
+	if m != nil {
+		return m.CreationBlockHeight
+	}
+	return 0
+}
+
 type MsqDesList struct {
 	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1157,7 +1758,7 @@ func (m *MsqDesList) Reset()         { *m = MsqDesList{} }
 func (m *MsqDesList) String() string { return proto.CompactTextString(m) }
 func (*MsqDesList) ProtoMessage()    {}
 func (*MsqDesList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{18}
+	return fileDescriptor_492be2f0ffbab25c, []int{25}
 }
 
 func (m *MsqDesList) XXX_Unmarshal(b []byte) error {
@@ -1200,7 +1801,7 @@ func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{19}
+	return fileDescriptor_492be2f0ffbab25c, []int{26}
 }
 
 func (m *Node) XXX_Unmarshal(b []byte) error {
@@ -1267,7 +1868,7 @@ func (m *ServiceList) Reset()         { *m = ServiceList{} }
 func (m *ServiceList) String() string { return proto.CompactTextString(m) }
 func (*ServiceList) ProtoMessage()    {}
 func (*ServiceList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{20}
+	return fileDescriptor_492be2f0ffbab25c, []int{27}
 }
 
 func (m *ServiceList) XXX_Unmarshal(b []byte) error {
@@ -1296,22 +1897,23 @@ func (m *ServiceList) GetServices() []*Service {
 }
 
 type Service struct {
-	ServiceId              string   `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
-	MinIal                 float64  `protobuf:"fixed64,2,opt,name=min_ial,json=minIal,proto3" json:"min_ial,omitempty"`
-	MinAal                 float64  `protobuf:"fixed64,3,opt,name=min_aal,json=minAal,proto3" json:"min_aal,omitempty"`
-	Active                 bool     `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
-	Suspended              bool     `protobuf:"varint,5,opt,name=suspended,proto3" json:"suspended,omitempty"`
-	SupportedNamespaceList []string `protobuf:"bytes,6,rep,name=supported_namespace_list,json=supportedNamespaceList,proto3" json:"supported_namespace_list,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
-	XXX_unrecognized       []byte   `json:"-"`
-	XXX_sizecache          int32    `json:"-"`
+	ServiceId                string   `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	MinIal                   float64  `protobuf:"fixed64,2,opt,name=min_ial,json=minIal,proto3" json:"min_ial,omitempty"`
+	MinAal                   float64  `protobuf:"fixed64,3,opt,name=min_aal,json=minAal,proto3" json:"min_aal,omitempty"`
+	Active                   bool     `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	Suspended                bool     `protobuf:"varint,5,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	SupportedNamespaceList   []string `protobuf:"bytes,6,rep,name=supported_namespace_list,json=supportedNamespaceList,proto3" json:"supported_namespace_list,omitempty"`
+	SupportedDataUrlTypeList []string `protobuf:"bytes,7,rep,name=supported_data_url_type_list,json=supportedDataUrlTypeList,proto3" json:"supported_data_url_type_list,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
 }
 
 func (m *Service) Reset()         { *m = Service{} }
 func (m *Service) String() string { return proto.CompactTextString(m) }
 func (*Service) ProtoMessage()    {}
 func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{21}
+	return fileDescriptor_492be2f0ffbab25c, []int{28}
 }
 
 func (m *Service) XXX_Unmarshal(b []byte) error {
@@ -1374,6 +1976,13 @@ func (m *Service) GetSupportedNamespaceList() []string {
 	return nil
 }
 
+func (m *Service) GetSupportedDataUrlTypeList() []string {
+	if m != nil {
+		return m.SupportedDataUrlTypeList
+	}
+	return nil
+}
+
 type ServiceDesList struct {
 	Node                 []*ASNode `protobuf:"bytes,1,rep,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -1385,7 +1994,7 @@ func (m *ServiceDesList) Reset()         { *m = ServiceDesList{} }
 func (m *ServiceDesList) String() string { return proto.CompactTextString(m) }
 func (*ServiceDesList) ProtoMessage()    {}
 func (*ServiceDesList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{22}
+	return fileDescriptor_492be2f0ffbab25c, []int{29}
 }
 
 func (m *ServiceDesList) XXX_Unmarshal(b []byte) error {
@@ -1414,22 +2023,23 @@ func (m *ServiceDesList) GetNode() []*ASNode {
 }
 
 type ASNode struct {
-	NodeId                 string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	MinIal                 float64  `protobuf:"fixed64,2,opt,name=min_ial,json=minIal,proto3" json:"min_ial,omitempty"`
-	MinAal                 float64  `protobuf:"fixed64,3,opt,name=min_aal,json=minAal,proto3" json:"min_aal,omitempty"`
-	ServiceId              string   `protobuf:"bytes,4,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
-	SupportedNamespaceList []string `protobuf:"bytes,5,rep,name=supported_namespace_list,json=supportedNamespaceList,proto3" json:"supported_namespace_list,omitempty"`
-	Active                 bool     `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
-	XXX_unrecognized       []byte   `json:"-"`
-	XXX_sizecache          int32    `json:"-"`
+	NodeId                   string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	MinIal                   float64  `protobuf:"fixed64,2,opt,name=min_ial,json=minIal,proto3" json:"min_ial,omitempty"`
+	MinAal                   float64  `protobuf:"fixed64,3,opt,name=min_aal,json=minAal,proto3" json:"min_aal,omitempty"`
+	ServiceId                string   `protobuf:"bytes,4,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	SupportedNamespaceList   []string `protobuf:"bytes,5,rep,name=supported_namespace_list,json=supportedNamespaceList,proto3" json:"supported_namespace_list,omitempty"`
+	Active                   bool     `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
+	SupportedDataUrlTypeList []string `protobuf:"bytes,7,rep,name=supported_data_url_type_list,json=supportedDataUrlTypeList,proto3" json:"supported_data_url_type_list,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
 }
 
 func (m *ASNode) Reset()         { *m = ASNode{} }
 func (m *ASNode) String() string { return proto.CompactTextString(m) }
 func (*ASNode) ProtoMessage()    {}
 func (*ASNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{23}
+	return fileDescriptor_492be2f0ffbab25c, []int{30}
 }
 
 func (m *ASNode) XXX_Unmarshal(b []byte) error {
@@ -1492,6 +2102,13 @@ func (m *ASNode) GetActive() bool {
 	return false
 }
 
+func (m *ASNode) GetSupportedDataUrlTypeList() []string {
+	if m != nil {
+		return m.SupportedDataUrlTypeList
+	}
+	return nil
+}
+
 type RPList struct {
 	NodeId               []string `protobuf:"bytes,1,rep,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1503,7 +2120,7 @@ func (m *RPList) Reset()         { *m = RPList{} }
 func (m *RPList) String() string { return proto.CompactTextString(m) }
 func (*RPList) ProtoMessage()    {}
 func (*RPList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{24}
+	return fileDescriptor_492be2f0ffbab25c, []int{31}
 }
 
 func (m *RPList) XXX_Unmarshal(b []byte) error {
@@ -1542,7 +2159,7 @@ func (m *ASList) Reset()         { *m = ASList{} }
 func (m *ASList) String() string { return proto.CompactTextString(m) }
 func (*ASList) ProtoMessage()    {}
 func (*ASList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{25}
+	return fileDescriptor_492be2f0ffbab25c, []int{32}
 }
 
 func (m *ASList) XXX_Unmarshal(b []byte) error {
@@ -1581,7 +2198,7 @@ func (m *AllList) Reset()         { *m = AllList{} }
 func (m *AllList) String() string { return proto.CompactTextString(m) }
 func (*AllList) ProtoMessage()    {}
 func (*AllList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{26}
+	return fileDescriptor_492be2f0ffbab25c, []int{33}
 }
 
 func (m *AllList) XXX_Unmarshal(b []byte) error {
@@ -1620,7 +2237,7 @@ func (m *AccessorInGroup) Reset()         { *m = AccessorInGroup{} }
 func (m *AccessorInGroup) String() string { return proto.CompactTextString(m) }
 func (*AccessorInGroup) ProtoMessage()    {}
 func (*AccessorInGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{27}
+	return fileDescriptor_492be2f0ffbab25c, []int{34}
 }
 
 func (m *AccessorInGroup) XXX_Unmarshal(b []byte) error {
@@ -1650,6 +2267,7 @@ func (m *AccessorInGroup) GetAccessors() []string {
 
 type Token struct {
 	Amount               float64  `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	LedgerEntryCount     int64    `protobuf:"varint,2,opt,name=ledger_entry_count,json=ledgerEntryCount,proto3" json:"ledger_entry_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1659,7 +2277,7 @@ func (m *Token) Reset()         { *m = Token{} }
 func (m *Token) String() string { return proto.CompactTextString(m) }
 func (*Token) ProtoMessage()    {}
 func (*Token) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{28}
+	return fileDescriptor_492be2f0ffbab25c, []int{35}
 }
 
 func (m *Token) XXX_Unmarshal(b []byte) error {
@@ -1687,8 +2305,133 @@ func (m *Token) GetAmount() float64 {
 	return 0
 }
 
-type TokenPrice struct {
-	Price                float64  `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+func (m *Token) GetLedgerEntryCount() int64 {
+	if m != nil {
+		return m.LedgerEntryCount
+	}
+	return 0
+}
+
+type RequestEscrowPrice struct {
+	IdpResponsePrice     float64  `protobuf:"fixed64,1,opt,name=idp_response_price,json=idpResponsePrice,proto3" json:"idp_response_price,omitempty"`
+	AsDataPrice          float64  `protobuf:"fixed64,2,opt,name=as_data_price,json=asDataPrice,proto3" json:"as_data_price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestEscrowPrice) Reset()         { *m = RequestEscrowPrice{} }
+func (m *RequestEscrowPrice) String() string { return proto.CompactTextString(m) }
+func (*RequestEscrowPrice) ProtoMessage()    {}
+func (*RequestEscrowPrice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{36}
+}
+
+func (m *RequestEscrowPrice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestEscrowPrice.Unmarshal(m, b)
+}
+func (m *RequestEscrowPrice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestEscrowPrice.Marshal(b, m, deterministic)
+}
+func (m *RequestEscrowPrice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestEscrowPrice.Merge(m, src)
+}
+func (m *RequestEscrowPrice) XXX_Size() int {
+	return xxx_messageInfo_RequestEscrowPrice.Size(m)
+}
+func (m *RequestEscrowPrice) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestEscrowPrice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestEscrowPrice proto.InternalMessageInfo
+
+func (m *RequestEscrowPrice) GetIdpResponsePrice() float64 {
+	if m != nil {
+		return m.IdpResponsePrice
+	}
+	return 0
+}
+
+func (m *RequestEscrowPrice) GetAsDataPrice() float64 {
+	if m != nil {
+		return m.AsDataPrice
+	}
+	return 0
+}
+
+type TokenLedgerEntry struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Amount               float64  `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Balance              float64  `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	BlockHeight          int64    `protobuf:"varint,4,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Reason               string   `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenLedgerEntry) Reset()         { *m = TokenLedgerEntry{} }
+func (m *TokenLedgerEntry) String() string { return proto.CompactTextString(m) }
+func (*TokenLedgerEntry) ProtoMessage()    {}
+func (*TokenLedgerEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{37}
+}
+
+func (m *TokenLedgerEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenLedgerEntry.Unmarshal(m, b)
+}
+func (m *TokenLedgerEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenLedgerEntry.Marshal(b, m, deterministic)
+}
+func (m *TokenLedgerEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenLedgerEntry.Merge(m, src)
+}
+func (m *TokenLedgerEntry) XXX_Size() int {
+	return xxx_messageInfo_TokenLedgerEntry.Size(m)
+}
+func (m *TokenLedgerEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenLedgerEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenLedgerEntry proto.InternalMessageInfo
+
+func (m *TokenLedgerEntry) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenLedgerEntry) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *TokenLedgerEntry) GetBalance() float64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *TokenLedgerEntry) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *TokenLedgerEntry) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type TokenPrice struct {
+	Price                float64  `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1698,7 +2441,7 @@ func (m *TokenPrice) Reset()         { *m = TokenPrice{} }
 func (m *TokenPrice) String() string { return proto.CompactTextString(m) }
 func (*TokenPrice) ProtoMessage()    {}
 func (*TokenPrice) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{29}
+	return fileDescriptor_492be2f0ffbab25c, []int{38}
 }
 
 func (m *TokenPrice) XXX_Unmarshal(b []byte) error {
@@ -1726,6 +2469,45 @@ func (m *TokenPrice) GetPrice() float64 {
 	return 0
 }
 
+type LowTokenThreshold struct {
+	Threshold            float64  `protobuf:"fixed64,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LowTokenThreshold) Reset()         { *m = LowTokenThreshold{} }
+func (m *LowTokenThreshold) String() string { return proto.CompactTextString(m) }
+func (*LowTokenThreshold) ProtoMessage()    {}
+func (*LowTokenThreshold) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{39}
+}
+
+func (m *LowTokenThreshold) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LowTokenThreshold.Unmarshal(m, b)
+}
+func (m *LowTokenThreshold) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LowTokenThreshold.Marshal(b, m, deterministic)
+}
+func (m *LowTokenThreshold) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LowTokenThreshold.Merge(m, src)
+}
+func (m *LowTokenThreshold) XXX_Size() int {
+	return xxx_messageInfo_LowTokenThreshold.Size(m)
+}
+func (m *LowTokenThreshold) XXX_DiscardUnknown() {
+	xxx_messageInfo_LowTokenThreshold.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LowTokenThreshold proto.InternalMessageInfo
+
+func (m *LowTokenThreshold) GetThreshold() float64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
 type ReferenceGroup struct {
 	Identities           []*IdentityInRefGroup `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
 	Idps                 []*IdPInRefGroup      `protobuf:"bytes,2,rep,name=idps,proto3" json:"idps,omitempty"`
@@ -1738,7 +2520,7 @@ func (m *ReferenceGroup) Reset()         { *m = ReferenceGroup{} }
 func (m *ReferenceGroup) String() string { return proto.CompactTextString(m) }
 func (*ReferenceGroup) ProtoMessage()    {}
 func (*ReferenceGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{30}
+	return fileDescriptor_492be2f0ffbab25c, []int{40}
 }
 
 func (m *ReferenceGroup) XXX_Unmarshal(b []byte) error {
@@ -1788,7 +2570,7 @@ func (m *IdPInRefGroup) Reset()         { *m = IdPInRefGroup{} }
 func (m *IdPInRefGroup) String() string { return proto.CompactTextString(m) }
 func (*IdPInRefGroup) ProtoMessage()    {}
 func (*IdPInRefGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{31}
+	return fileDescriptor_492be2f0ffbab25c, []int{41}
 }
 
 func (m *IdPInRefGroup) XXX_Unmarshal(b []byte) error {
@@ -1857,7 +2639,7 @@ func (m *IdentityInRefGroup) Reset()         { *m = IdentityInRefGroup{} }
 func (m *IdentityInRefGroup) String() string { return proto.CompactTextString(m) }
 func (*IdentityInRefGroup) ProtoMessage()    {}
 func (*IdentityInRefGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{32}
+	return fileDescriptor_492be2f0ffbab25c, []int{42}
 }
 
 func (m *IdentityInRefGroup) XXX_Unmarshal(b []byte) error {
@@ -1910,7 +2692,7 @@ func (m *AllowedModeList) Reset()         { *m = AllowedModeList{} }
 func (m *AllowedModeList) String() string { return proto.CompactTextString(m) }
 func (*AllowedModeList) ProtoMessage()    {}
 func (*AllowedModeList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{33}
+	return fileDescriptor_492be2f0ffbab25c, []int{43}
 }
 
 func (m *AllowedModeList) XXX_Unmarshal(b []byte) error {
@@ -1953,7 +2735,7 @@ func (m *AllowedMinIalForRegisterIdentityAtFirstIdp) String() string {
 }
 func (*AllowedMinIalForRegisterIdentityAtFirstIdp) ProtoMessage() {}
 func (*AllowedMinIalForRegisterIdentityAtFirstIdp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492be2f0ffbab25c, []int{34}
+	return fileDescriptor_492be2f0ffbab25c, []int{44}
 }
 
 func (m *AllowedMinIalForRegisterIdentityAtFirstIdp) XXX_Unmarshal(b []byte) error {
@@ -1981,6 +2763,2110 @@ func (m *AllowedMinIalForRegisterIdentityAtFirstIdp) GetMinIal() float64 {
 	return 0
 }
 
+type NodeKey struct {
+	PublicKey            string   `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	MasterPublicKey      string   `protobuf:"bytes,2,opt,name=master_public_key,json=masterPublicKey,proto3" json:"master_public_key,omitempty"`
+	BlockHeight          int64    `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeKey) Reset()         { *m = NodeKey{} }
+func (m *NodeKey) String() string { return proto.CompactTextString(m) }
+func (*NodeKey) ProtoMessage()    {}
+func (*NodeKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{45}
+}
+
+func (m *NodeKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeKey.Unmarshal(m, b)
+}
+func (m *NodeKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeKey.Marshal(b, m, deterministic)
+}
+func (m *NodeKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeKey.Merge(m, src)
+}
+func (m *NodeKey) XXX_Size() int {
+	return xxx_messageInfo_NodeKey.Size(m)
+}
+func (m *NodeKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeKey proto.InternalMessageInfo
+
+func (m *NodeKey) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *NodeKey) GetMasterPublicKey() string {
+	if m != nil {
+		return m.MasterPublicKey
+	}
+	return ""
+}
+
+func (m *NodeKey) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+type DataSignature struct {
+	Signature            string   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	DataSchemaVersion    string   `protobuf:"bytes,2,opt,name=data_schema_version,json=dataSchemaVersion,proto3" json:"data_schema_version,omitempty"`
+	BlockHeight          int64    `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	DataHash             string   `protobuf:"bytes,4,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
+	DataContentType      string   `protobuf:"bytes,5,opt,name=data_content_type,json=dataContentType,proto3" json:"data_content_type,omitempty"`
+	BlockTime            int64    `protobuf:"varint,6,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataSignature) Reset()         { *m = DataSignature{} }
+func (m *DataSignature) String() string { return proto.CompactTextString(m) }
+func (*DataSignature) ProtoMessage()    {}
+func (*DataSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{46}
+}
+
+func (m *DataSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataSignature.Unmarshal(m, b)
+}
+func (m *DataSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataSignature.Marshal(b, m, deterministic)
+}
+func (m *DataSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataSignature.Merge(m, src)
+}
+func (m *DataSignature) XXX_Size() int {
+	return xxx_messageInfo_DataSignature.Size(m)
+}
+func (m *DataSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataSignature proto.InternalMessageInfo
+
+func (m *DataSignature) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+func (m *DataSignature) GetDataSchemaVersion() string {
+	if m != nil {
+		return m.DataSchemaVersion
+	}
+	return ""
+}
+
+func (m *DataSignature) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *DataSignature) GetDataHash() string {
+	if m != nil {
+		return m.DataHash
+	}
+	return ""
+}
+
+func (m *DataSignature) GetDataContentType() string {
+	if m != nil {
+		return m.DataContentType
+	}
+	return ""
+}
+
+func (m *DataSignature) GetBlockTime() int64 {
+	if m != nil {
+		return m.BlockTime
+	}
+	return 0
+}
+
+type ConsentReceiptList struct {
+	ConsentReceipts      []*ConsentReceipt `protobuf:"bytes,1,rep,name=consent_receipts,json=consentReceipts,proto3" json:"consent_receipts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConsentReceiptList) Reset()         { *m = ConsentReceiptList{} }
+func (m *ConsentReceiptList) String() string { return proto.CompactTextString(m) }
+func (*ConsentReceiptList) ProtoMessage()    {}
+func (*ConsentReceiptList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{47}
+}
+
+func (m *ConsentReceiptList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsentReceiptList.Unmarshal(m, b)
+}
+func (m *ConsentReceiptList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsentReceiptList.Marshal(b, m, deterministic)
+}
+func (m *ConsentReceiptList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsentReceiptList.Merge(m, src)
+}
+func (m *ConsentReceiptList) XXX_Size() int {
+	return xxx_messageInfo_ConsentReceiptList.Size(m)
+}
+func (m *ConsentReceiptList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsentReceiptList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsentReceiptList proto.InternalMessageInfo
+
+func (m *ConsentReceiptList) GetConsentReceipts() []*ConsentReceipt {
+	if m != nil {
+		return m.ConsentReceipts
+	}
+	return nil
+}
+
+type ConsentReceipt struct {
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ConsentReceiptHash   string   `protobuf:"bytes,2,opt,name=consent_receipt_hash,json=consentReceiptHash,proto3" json:"consent_receipt_hash,omitempty"`
+	BlockHeight          int64    `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConsentReceipt) Reset()         { *m = ConsentReceipt{} }
+func (m *ConsentReceipt) String() string { return proto.CompactTextString(m) }
+func (*ConsentReceipt) ProtoMessage()    {}
+func (*ConsentReceipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{48}
+}
+
+func (m *ConsentReceipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsentReceipt.Unmarshal(m, b)
+}
+func (m *ConsentReceipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsentReceipt.Marshal(b, m, deterministic)
+}
+func (m *ConsentReceipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsentReceipt.Merge(m, src)
+}
+func (m *ConsentReceipt) XXX_Size() int {
+	return xxx_messageInfo_ConsentReceipt.Size(m)
+}
+func (m *ConsentReceipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsentReceipt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsentReceipt proto.InternalMessageInfo
+
+func (m *ConsentReceipt) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ConsentReceipt) GetConsentReceiptHash() string {
+	if m != nil {
+		return m.ConsentReceiptHash
+	}
+	return ""
+}
+
+func (m *ConsentReceipt) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+type Statistics struct {
+	RequestCreatedCount   int64                `protobuf:"varint,1,opt,name=request_created_count,json=requestCreatedCount,proto3" json:"request_created_count,omitempty"`
+	RequestClosedCount    int64                `protobuf:"varint,2,opt,name=request_closed_count,json=requestClosedCount,proto3" json:"request_closed_count,omitempty"`
+	RequestTimedOutCount  int64                `protobuf:"varint,3,opt,name=request_timed_out_count,json=requestTimedOutCount,proto3" json:"request_timed_out_count,omitempty"`
+	ServiceStatisticsList []*ServiceStatistics `protobuf:"bytes,4,rep,name=service_statistics_list,json=serviceStatisticsList,proto3" json:"service_statistics_list,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}             `json:"-"`
+	XXX_unrecognized      []byte               `json:"-"`
+	XXX_sizecache         int32                `json:"-"`
+}
+
+func (m *Statistics) Reset()         { *m = Statistics{} }
+func (m *Statistics) String() string { return proto.CompactTextString(m) }
+func (*Statistics) ProtoMessage()    {}
+func (*Statistics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{49}
+}
+
+func (m *Statistics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Statistics.Unmarshal(m, b)
+}
+func (m *Statistics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Statistics.Marshal(b, m, deterministic)
+}
+func (m *Statistics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Statistics.Merge(m, src)
+}
+func (m *Statistics) XXX_Size() int {
+	return xxx_messageInfo_Statistics.Size(m)
+}
+func (m *Statistics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Statistics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Statistics proto.InternalMessageInfo
+
+func (m *Statistics) GetRequestCreatedCount() int64 {
+	if m != nil {
+		return m.RequestCreatedCount
+	}
+	return 0
+}
+
+func (m *Statistics) GetRequestClosedCount() int64 {
+	if m != nil {
+		return m.RequestClosedCount
+	}
+	return 0
+}
+
+func (m *Statistics) GetRequestTimedOutCount() int64 {
+	if m != nil {
+		return m.RequestTimedOutCount
+	}
+	return 0
+}
+
+func (m *Statistics) GetServiceStatisticsList() []*ServiceStatistics {
+	if m != nil {
+		return m.ServiceStatisticsList
+	}
+	return nil
+}
+
+type ServiceStatistics struct {
+	ServiceId            string   `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	SignDataCount        int64    `protobuf:"varint,2,opt,name=sign_data_count,json=signDataCount,proto3" json:"sign_data_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceStatistics) Reset()         { *m = ServiceStatistics{} }
+func (m *ServiceStatistics) String() string { return proto.CompactTextString(m) }
+func (*ServiceStatistics) ProtoMessage()    {}
+func (*ServiceStatistics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{50}
+}
+
+func (m *ServiceStatistics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceStatistics.Unmarshal(m, b)
+}
+func (m *ServiceStatistics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceStatistics.Marshal(b, m, deterministic)
+}
+func (m *ServiceStatistics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceStatistics.Merge(m, src)
+}
+func (m *ServiceStatistics) XXX_Size() int {
+	return xxx_messageInfo_ServiceStatistics.Size(m)
+}
+func (m *ServiceStatistics) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceStatistics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceStatistics proto.InternalMessageInfo
+
+func (m *ServiceStatistics) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *ServiceStatistics) GetSignDataCount() int64 {
+	if m != nil {
+		return m.SignDataCount
+	}
+	return 0
+}
+
+type NodeQuota struct {
+	DailyLimit           int64    `protobuf:"varint,1,opt,name=daily_limit,json=dailyLimit,proto3" json:"daily_limit,omitempty"`
+	MonthlyLimit         int64    `protobuf:"varint,2,opt,name=monthly_limit,json=monthlyLimit,proto3" json:"monthly_limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeQuota) Reset()         { *m = NodeQuota{} }
+func (m *NodeQuota) String() string { return proto.CompactTextString(m) }
+func (*NodeQuota) ProtoMessage()    {}
+func (*NodeQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{51}
+}
+
+func (m *NodeQuota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeQuota.Unmarshal(m, b)
+}
+func (m *NodeQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeQuota.Marshal(b, m, deterministic)
+}
+func (m *NodeQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeQuota.Merge(m, src)
+}
+func (m *NodeQuota) XXX_Size() int {
+	return xxx_messageInfo_NodeQuota.Size(m)
+}
+func (m *NodeQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeQuota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeQuota proto.InternalMessageInfo
+
+func (m *NodeQuota) GetDailyLimit() int64 {
+	if m != nil {
+		return m.DailyLimit
+	}
+	return 0
+}
+
+func (m *NodeQuota) GetMonthlyLimit() int64 {
+	if m != nil {
+		return m.MonthlyLimit
+	}
+	return 0
+}
+
+type ValidatorNode struct {
+	PublicKey                   string   `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	NodeId                      string   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	MissedBlockCount            int64    `protobuf:"varint,3,opt,name=missed_block_count,json=missedBlockCount,proto3" json:"missed_block_count,omitempty"`
+	ByzantineEvidenceCount      int64    `protobuf:"varint,4,opt,name=byzantine_evidence_count,json=byzantineEvidenceCount,proto3" json:"byzantine_evidence_count,omitempty"`
+	ConsecutiveMissedBlockCount int64    `protobuf:"varint,5,opt,name=consecutive_missed_block_count,json=consecutiveMissedBlockCount,proto3" json:"consecutive_missed_block_count,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{} `json:"-"`
+	XXX_unrecognized            []byte   `json:"-"`
+	XXX_sizecache               int32    `json:"-"`
+}
+
+func (m *ValidatorNode) Reset()         { *m = ValidatorNode{} }
+func (m *ValidatorNode) String() string { return proto.CompactTextString(m) }
+func (*ValidatorNode) ProtoMessage()    {}
+func (*ValidatorNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{52}
+}
+
+func (m *ValidatorNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatorNode.Unmarshal(m, b)
+}
+func (m *ValidatorNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatorNode.Marshal(b, m, deterministic)
+}
+func (m *ValidatorNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorNode.Merge(m, src)
+}
+func (m *ValidatorNode) XXX_Size() int {
+	return xxx_messageInfo_ValidatorNode.Size(m)
+}
+func (m *ValidatorNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorNode proto.InternalMessageInfo
+
+func (m *ValidatorNode) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *ValidatorNode) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ValidatorNode) GetMissedBlockCount() int64 {
+	if m != nil {
+		return m.MissedBlockCount
+	}
+	return 0
+}
+
+func (m *ValidatorNode) GetByzantineEvidenceCount() int64 {
+	if m != nil {
+		return m.ByzantineEvidenceCount
+	}
+	return 0
+}
+
+func (m *ValidatorNode) GetConsecutiveMissedBlockCount() int64 {
+	if m != nil {
+		return m.ConsecutiveMissedBlockCount
+	}
+	return 0
+}
+
+type ValidatorMissThreshold struct {
+	Threshold            int64    `protobuf:"varint,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatorMissThreshold) Reset()         { *m = ValidatorMissThreshold{} }
+func (m *ValidatorMissThreshold) String() string { return proto.CompactTextString(m) }
+func (*ValidatorMissThreshold) ProtoMessage()    {}
+func (*ValidatorMissThreshold) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{53}
+}
+
+func (m *ValidatorMissThreshold) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatorMissThreshold.Unmarshal(m, b)
+}
+func (m *ValidatorMissThreshold) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatorMissThreshold.Marshal(b, m, deterministic)
+}
+func (m *ValidatorMissThreshold) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorMissThreshold.Merge(m, src)
+}
+func (m *ValidatorMissThreshold) XXX_Size() int {
+	return xxx_messageInfo_ValidatorMissThreshold.Size(m)
+}
+func (m *ValidatorMissThreshold) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorMissThreshold.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorMissThreshold proto.InternalMessageInfo
+
+func (m *ValidatorMissThreshold) GetThreshold() int64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+type BlockWriteLimit struct {
+	MaxWriteCount        int64    `protobuf:"varint,1,opt,name=max_write_count,json=maxWriteCount,proto3" json:"max_write_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockWriteLimit) Reset()         { *m = BlockWriteLimit{} }
+func (m *BlockWriteLimit) String() string { return proto.CompactTextString(m) }
+func (*BlockWriteLimit) ProtoMessage()    {}
+func (*BlockWriteLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{54}
+}
+
+func (m *BlockWriteLimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockWriteLimit.Unmarshal(m, b)
+}
+func (m *BlockWriteLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockWriteLimit.Marshal(b, m, deterministic)
+}
+func (m *BlockWriteLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockWriteLimit.Merge(m, src)
+}
+func (m *BlockWriteLimit) XXX_Size() int {
+	return xxx_messageInfo_BlockWriteLimit.Size(m)
+}
+func (m *BlockWriteLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockWriteLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockWriteLimit proto.InternalMessageInfo
+
+func (m *BlockWriteLimit) GetMaxWriteCount() int64 {
+	if m != nil {
+		return m.MaxWriteCount
+	}
+	return 0
+}
+
+type AdminApprovalPolicy struct {
+	OperatorPublicKeyList []string `protobuf:"bytes,1,rep,name=operator_public_key_list,json=operatorPublicKeyList,proto3" json:"operator_public_key_list,omitempty"`
+	RequiredApprovalCount int64    `protobuf:"varint,2,opt,name=required_approval_count,json=requiredApprovalCount,proto3" json:"required_approval_count,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *AdminApprovalPolicy) Reset()         { *m = AdminApprovalPolicy{} }
+func (m *AdminApprovalPolicy) String() string { return proto.CompactTextString(m) }
+func (*AdminApprovalPolicy) ProtoMessage()    {}
+func (*AdminApprovalPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{55}
+}
+
+func (m *AdminApprovalPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdminApprovalPolicy.Unmarshal(m, b)
+}
+func (m *AdminApprovalPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdminApprovalPolicy.Marshal(b, m, deterministic)
+}
+func (m *AdminApprovalPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdminApprovalPolicy.Merge(m, src)
+}
+func (m *AdminApprovalPolicy) XXX_Size() int {
+	return xxx_messageInfo_AdminApprovalPolicy.Size(m)
+}
+func (m *AdminApprovalPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdminApprovalPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdminApprovalPolicy proto.InternalMessageInfo
+
+func (m *AdminApprovalPolicy) GetOperatorPublicKeyList() []string {
+	if m != nil {
+		return m.OperatorPublicKeyList
+	}
+	return nil
+}
+
+func (m *AdminApprovalPolicy) GetRequiredApprovalCount() int64 {
+	if m != nil {
+		return m.RequiredApprovalCount
+	}
+	return 0
+}
+
+type AdminProposal struct {
+	ProposalId                  string   `protobuf:"bytes,1,opt,name=proposal_id,json=proposalId,proto3" json:"proposal_id,omitempty"`
+	Method                      string   `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Param                       string   `protobuf:"bytes,3,opt,name=param,proto3" json:"param,omitempty"`
+	ApprovedOperatorKeyHashList []string `protobuf:"bytes,4,rep,name=approved_operator_key_hash_list,json=approvedOperatorKeyHashList,proto3" json:"approved_operator_key_hash_list,omitempty"`
+	CreationBlockHeight         int64    `protobuf:"varint,5,opt,name=creation_block_height,json=creationBlockHeight,proto3" json:"creation_block_height,omitempty"`
+	Executed                    bool     `protobuf:"varint,6,opt,name=executed,proto3" json:"executed,omitempty"`
+	ExecutionBlockHeight        int64    `protobuf:"varint,7,opt,name=execution_block_height,json=executionBlockHeight,proto3" json:"execution_block_height,omitempty"`
+	ResultCode                  uint32   `protobuf:"varint,8,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	ResultLog                   string   `protobuf:"bytes,9,opt,name=result_log,json=resultLog,proto3" json:"result_log,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{} `json:"-"`
+	XXX_unrecognized            []byte   `json:"-"`
+	XXX_sizecache               int32    `json:"-"`
+}
+
+func (m *AdminProposal) Reset()         { *m = AdminProposal{} }
+func (m *AdminProposal) String() string { return proto.CompactTextString(m) }
+func (*AdminProposal) ProtoMessage()    {}
+func (*AdminProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{56}
+}
+
+func (m *AdminProposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdminProposal.Unmarshal(m, b)
+}
+func (m *AdminProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdminProposal.Marshal(b, m, deterministic)
+}
+func (m *AdminProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdminProposal.Merge(m, src)
+}
+func (m *AdminProposal) XXX_Size() int {
+	return xxx_messageInfo_AdminProposal.Size(m)
+}
+func (m *AdminProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdminProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdminProposal proto.InternalMessageInfo
+
+func (m *AdminProposal) GetProposalId() string {
+	if m != nil {
+		return m.ProposalId
+	}
+	return ""
+}
+
+func (m *AdminProposal) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *AdminProposal) GetParam() string {
+	if m != nil {
+		return m.Param
+	}
+	return ""
+}
+
+func (m *AdminProposal) GetApprovedOperatorKeyHashList() []string {
+	if m != nil {
+		return m.ApprovedOperatorKeyHashList
+	}
+	return nil
+}
+
+func (m *AdminProposal) GetCreationBlockHeight() int64 {
+	if m != nil {
+		return m.CreationBlockHeight
+	}
+	return 0
+}
+
+func (m *AdminProposal) GetExecuted() bool {
+	if m != nil {
+		return m.Executed
+	}
+	return false
+}
+
+func (m *AdminProposal) GetExecutionBlockHeight() int64 {
+	if m != nil {
+		return m.ExecutionBlockHeight
+	}
+	return 0
+}
+
+func (m *AdminProposal) GetResultCode() uint32 {
+	if m != nil {
+		return m.ResultCode
+	}
+	return 0
+}
+
+func (m *AdminProposal) GetResultLog() string {
+	if m != nil {
+		return m.ResultLog
+	}
+	return ""
+}
+
+type GovernanceActionDelay struct {
+	DelayBlock           int64    `protobuf:"varint,1,opt,name=delay_block,json=delayBlock,proto3" json:"delay_block,omitempty"`
+	ActionCount          int64    `protobuf:"varint,2,opt,name=action_count,json=actionCount,proto3" json:"action_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GovernanceActionDelay) Reset()         { *m = GovernanceActionDelay{} }
+func (m *GovernanceActionDelay) String() string { return proto.CompactTextString(m) }
+func (*GovernanceActionDelay) ProtoMessage()    {}
+func (*GovernanceActionDelay) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{57}
+}
+
+func (m *GovernanceActionDelay) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GovernanceActionDelay.Unmarshal(m, b)
+}
+func (m *GovernanceActionDelay) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GovernanceActionDelay.Marshal(b, m, deterministic)
+}
+func (m *GovernanceActionDelay) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GovernanceActionDelay.Merge(m, src)
+}
+func (m *GovernanceActionDelay) XXX_Size() int {
+	return xxx_messageInfo_GovernanceActionDelay.Size(m)
+}
+func (m *GovernanceActionDelay) XXX_DiscardUnknown() {
+	xxx_messageInfo_GovernanceActionDelay.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GovernanceActionDelay proto.InternalMessageInfo
+
+func (m *GovernanceActionDelay) GetDelayBlock() int64 {
+	if m != nil {
+		return m.DelayBlock
+	}
+	return 0
+}
+
+func (m *GovernanceActionDelay) GetActionCount() int64 {
+	if m != nil {
+		return m.ActionCount
+	}
+	return 0
+}
+
+type GovernanceAction struct {
+	Method               string   `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Param                string   `protobuf:"bytes,2,opt,name=param,proto3" json:"param,omitempty"`
+	NodeId               string   `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ScheduledBlockHeight int64    `protobuf:"varint,4,opt,name=scheduled_block_height,json=scheduledBlockHeight,proto3" json:"scheduled_block_height,omitempty"`
+	EffectiveBlockHeight int64    `protobuf:"varint,5,opt,name=effective_block_height,json=effectiveBlockHeight,proto3" json:"effective_block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GovernanceAction) Reset()         { *m = GovernanceAction{} }
+func (m *GovernanceAction) String() string { return proto.CompactTextString(m) }
+func (*GovernanceAction) ProtoMessage()    {}
+func (*GovernanceAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{58}
+}
+
+func (m *GovernanceAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GovernanceAction.Unmarshal(m, b)
+}
+func (m *GovernanceAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GovernanceAction.Marshal(b, m, deterministic)
+}
+func (m *GovernanceAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GovernanceAction.Merge(m, src)
+}
+func (m *GovernanceAction) XXX_Size() int {
+	return xxx_messageInfo_GovernanceAction.Size(m)
+}
+func (m *GovernanceAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_GovernanceAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GovernanceAction proto.InternalMessageInfo
+
+func (m *GovernanceAction) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *GovernanceAction) GetParam() string {
+	if m != nil {
+		return m.Param
+	}
+	return ""
+}
+
+func (m *GovernanceAction) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *GovernanceAction) GetScheduledBlockHeight() int64 {
+	if m != nil {
+		return m.ScheduledBlockHeight
+	}
+	return 0
+}
+
+func (m *GovernanceAction) GetEffectiveBlockHeight() int64 {
+	if m != nil {
+		return m.EffectiveBlockHeight
+	}
+	return 0
+}
+
+type KeyChange struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Deleted              bool     `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	ValueHash            []byte   `protobuf:"bytes,3,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyChange) Reset()         { *m = KeyChange{} }
+func (m *KeyChange) String() string { return proto.CompactTextString(m) }
+func (*KeyChange) ProtoMessage()    {}
+func (*KeyChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{59}
+}
+
+func (m *KeyChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyChange.Unmarshal(m, b)
+}
+func (m *KeyChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyChange.Marshal(b, m, deterministic)
+}
+func (m *KeyChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyChange.Merge(m, src)
+}
+func (m *KeyChange) XXX_Size() int {
+	return xxx_messageInfo_KeyChange.Size(m)
+}
+func (m *KeyChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyChange proto.InternalMessageInfo
+
+func (m *KeyChange) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyChange) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func (m *KeyChange) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+type ChangeJournal struct {
+	Changes              []*KeyChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ChangeJournal) Reset()         { *m = ChangeJournal{} }
+func (m *ChangeJournal) String() string { return proto.CompactTextString(m) }
+func (*ChangeJournal) ProtoMessage()    {}
+func (*ChangeJournal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{60}
+}
+
+func (m *ChangeJournal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangeJournal.Unmarshal(m, b)
+}
+func (m *ChangeJournal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangeJournal.Marshal(b, m, deterministic)
+}
+func (m *ChangeJournal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangeJournal.Merge(m, src)
+}
+func (m *ChangeJournal) XXX_Size() int {
+	return xxx_messageInfo_ChangeJournal.Size(m)
+}
+func (m *ChangeJournal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangeJournal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangeJournal proto.InternalMessageInfo
+
+func (m *ChangeJournal) GetChanges() []*KeyChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type BlockActivity struct {
+	TxList               []*TxActivity `protobuf:"bytes,1,rep,name=tx_list,json=txList,proto3" json:"tx_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BlockActivity) Reset()         { *m = BlockActivity{} }
+func (m *BlockActivity) String() string { return proto.CompactTextString(m) }
+func (*BlockActivity) ProtoMessage()    {}
+func (*BlockActivity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{61}
+}
+
+func (m *BlockActivity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockActivity.Unmarshal(m, b)
+}
+func (m *BlockActivity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockActivity.Marshal(b, m, deterministic)
+}
+func (m *BlockActivity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockActivity.Merge(m, src)
+}
+func (m *BlockActivity) XXX_Size() int {
+	return xxx_messageInfo_BlockActivity.Size(m)
+}
+func (m *BlockActivity) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockActivity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockActivity proto.InternalMessageInfo
+
+func (m *BlockActivity) GetTxList() []*TxActivity {
+	if m != nil {
+		return m.TxList
+	}
+	return nil
+}
+
+type TxActivity struct {
+	Method               string   `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	NodeId               string   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Code                 uint32   `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	RequestId            string   `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	ParamHash            string   `protobuf:"bytes,5,opt,name=param_hash,json=paramHash,proto3" json:"param_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxActivity) Reset()         { *m = TxActivity{} }
+func (m *TxActivity) String() string { return proto.CompactTextString(m) }
+func (*TxActivity) ProtoMessage()    {}
+func (*TxActivity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{62}
+}
+
+func (m *TxActivity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxActivity.Unmarshal(m, b)
+}
+func (m *TxActivity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxActivity.Marshal(b, m, deterministic)
+}
+func (m *TxActivity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxActivity.Merge(m, src)
+}
+func (m *TxActivity) XXX_Size() int {
+	return xxx_messageInfo_TxActivity.Size(m)
+}
+func (m *TxActivity) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxActivity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxActivity proto.InternalMessageInfo
+
+func (m *TxActivity) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *TxActivity) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *TxActivity) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *TxActivity) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *TxActivity) GetParamHash() string {
+	if m != nil {
+		return m.ParamHash
+	}
+	return ""
+}
+
+type ValidatorPowerClass struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Power                int64    `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatorPowerClass) Reset()         { *m = ValidatorPowerClass{} }
+func (m *ValidatorPowerClass) String() string { return proto.CompactTextString(m) }
+func (*ValidatorPowerClass) ProtoMessage()    {}
+func (*ValidatorPowerClass) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{63}
+}
+
+func (m *ValidatorPowerClass) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatorPowerClass.Unmarshal(m, b)
+}
+func (m *ValidatorPowerClass) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatorPowerClass.Marshal(b, m, deterministic)
+}
+func (m *ValidatorPowerClass) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorPowerClass.Merge(m, src)
+}
+func (m *ValidatorPowerClass) XXX_Size() int {
+	return xxx_messageInfo_ValidatorPowerClass.Size(m)
+}
+func (m *ValidatorPowerClass) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorPowerClass.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorPowerClass proto.InternalMessageInfo
+
+func (m *ValidatorPowerClass) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ValidatorPowerClass) GetPower() int64 {
+	if m != nil {
+		return m.Power
+	}
+	return 0
+}
+
+type ValidatorPowerPolicy struct {
+	Mode                 string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	EqualPower           int64                  `protobuf:"varint,2,opt,name=equal_power,json=equalPower,proto3" json:"equal_power,omitempty"`
+	PowerClassList       []*ValidatorPowerClass `protobuf:"bytes,3,rep,name=power_class_list,json=powerClassList,proto3" json:"power_class_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ValidatorPowerPolicy) Reset()         { *m = ValidatorPowerPolicy{} }
+func (m *ValidatorPowerPolicy) String() string { return proto.CompactTextString(m) }
+func (*ValidatorPowerPolicy) ProtoMessage()    {}
+func (*ValidatorPowerPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{64}
+}
+
+func (m *ValidatorPowerPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatorPowerPolicy.Unmarshal(m, b)
+}
+func (m *ValidatorPowerPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatorPowerPolicy.Marshal(b, m, deterministic)
+}
+func (m *ValidatorPowerPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorPowerPolicy.Merge(m, src)
+}
+func (m *ValidatorPowerPolicy) XXX_Size() int {
+	return xxx_messageInfo_ValidatorPowerPolicy.Size(m)
+}
+func (m *ValidatorPowerPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorPowerPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorPowerPolicy proto.InternalMessageInfo
+
+func (m *ValidatorPowerPolicy) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *ValidatorPowerPolicy) GetEqualPower() int64 {
+	if m != nil {
+		return m.EqualPower
+	}
+	return 0
+}
+
+func (m *ValidatorPowerPolicy) GetPowerClassList() []*ValidatorPowerClass {
+	if m != nil {
+		return m.PowerClassList
+	}
+	return nil
+}
+
+type ValidatorMisbehavior struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	PublicKey            string   `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	EvidenceType         string   `protobuf:"bytes,3,opt,name=evidence_type,json=evidenceType,proto3" json:"evidence_type,omitempty"`
+	EvidenceHeight       int64    `protobuf:"varint,4,opt,name=evidence_height,json=evidenceHeight,proto3" json:"evidence_height,omitempty"`
+	RecordedHeight       int64    `protobuf:"varint,5,opt,name=recorded_height,json=recordedHeight,proto3" json:"recorded_height,omitempty"`
+	Action               string   `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatorMisbehavior) Reset()         { *m = ValidatorMisbehavior{} }
+func (m *ValidatorMisbehavior) String() string { return proto.CompactTextString(m) }
+func (*ValidatorMisbehavior) ProtoMessage()    {}
+func (*ValidatorMisbehavior) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{65}
+}
+
+func (m *ValidatorMisbehavior) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatorMisbehavior.Unmarshal(m, b)
+}
+func (m *ValidatorMisbehavior) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatorMisbehavior.Marshal(b, m, deterministic)
+}
+func (m *ValidatorMisbehavior) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorMisbehavior.Merge(m, src)
+}
+func (m *ValidatorMisbehavior) XXX_Size() int {
+	return xxx_messageInfo_ValidatorMisbehavior.Size(m)
+}
+func (m *ValidatorMisbehavior) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorMisbehavior.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorMisbehavior proto.InternalMessageInfo
+
+func (m *ValidatorMisbehavior) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ValidatorMisbehavior) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *ValidatorMisbehavior) GetEvidenceType() string {
+	if m != nil {
+		return m.EvidenceType
+	}
+	return ""
+}
+
+func (m *ValidatorMisbehavior) GetEvidenceHeight() int64 {
+	if m != nil {
+		return m.EvidenceHeight
+	}
+	return 0
+}
+
+func (m *ValidatorMisbehavior) GetRecordedHeight() int64 {
+	if m != nil {
+		return m.RecordedHeight
+	}
+	return 0
+}
+
+func (m *ValidatorMisbehavior) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+type ValidatorMisbehaviorList struct {
+	Misbehaviors         []*ValidatorMisbehavior `protobuf:"bytes,1,rep,name=misbehaviors,proto3" json:"misbehaviors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ValidatorMisbehaviorList) Reset()         { *m = ValidatorMisbehaviorList{} }
+func (m *ValidatorMisbehaviorList) String() string { return proto.CompactTextString(m) }
+func (*ValidatorMisbehaviorList) ProtoMessage()    {}
+func (*ValidatorMisbehaviorList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{66}
+}
+
+func (m *ValidatorMisbehaviorList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatorMisbehaviorList.Unmarshal(m, b)
+}
+func (m *ValidatorMisbehaviorList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatorMisbehaviorList.Marshal(b, m, deterministic)
+}
+func (m *ValidatorMisbehaviorList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorMisbehaviorList.Merge(m, src)
+}
+func (m *ValidatorMisbehaviorList) XXX_Size() int {
+	return xxx_messageInfo_ValidatorMisbehaviorList.Size(m)
+}
+func (m *ValidatorMisbehaviorList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorMisbehaviorList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorMisbehaviorList proto.InternalMessageInfo
+
+func (m *ValidatorMisbehaviorList) GetMisbehaviors() []*ValidatorMisbehavior {
+	if m != nil {
+		return m.Misbehaviors
+	}
+	return nil
+}
+
+type PendingValidatorUpdate struct {
+	PublicKey            string   `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Power                int64    `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PendingValidatorUpdate) Reset()         { *m = PendingValidatorUpdate{} }
+func (m *PendingValidatorUpdate) String() string { return proto.CompactTextString(m) }
+func (*PendingValidatorUpdate) ProtoMessage()    {}
+func (*PendingValidatorUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{67}
+}
+
+func (m *PendingValidatorUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingValidatorUpdate.Unmarshal(m, b)
+}
+func (m *PendingValidatorUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingValidatorUpdate.Marshal(b, m, deterministic)
+}
+func (m *PendingValidatorUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingValidatorUpdate.Merge(m, src)
+}
+func (m *PendingValidatorUpdate) XXX_Size() int {
+	return xxx_messageInfo_PendingValidatorUpdate.Size(m)
+}
+func (m *PendingValidatorUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingValidatorUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingValidatorUpdate proto.InternalMessageInfo
+
+func (m *PendingValidatorUpdate) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *PendingValidatorUpdate) GetPower() int64 {
+	if m != nil {
+		return m.Power
+	}
+	return 0
+}
+
+type PendingValidatorUpdateList struct {
+	ValidatorUpdates     []*PendingValidatorUpdate `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *PendingValidatorUpdateList) Reset()         { *m = PendingValidatorUpdateList{} }
+func (m *PendingValidatorUpdateList) String() string { return proto.CompactTextString(m) }
+func (*PendingValidatorUpdateList) ProtoMessage()    {}
+func (*PendingValidatorUpdateList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{68}
+}
+
+func (m *PendingValidatorUpdateList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingValidatorUpdateList.Unmarshal(m, b)
+}
+func (m *PendingValidatorUpdateList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingValidatorUpdateList.Marshal(b, m, deterministic)
+}
+func (m *PendingValidatorUpdateList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingValidatorUpdateList.Merge(m, src)
+}
+func (m *PendingValidatorUpdateList) XXX_Size() int {
+	return xxx_messageInfo_PendingValidatorUpdateList.Size(m)
+}
+func (m *PendingValidatorUpdateList) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingValidatorUpdateList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingValidatorUpdateList proto.InternalMessageInfo
+
+func (m *PendingValidatorUpdateList) GetValidatorUpdates() []*PendingValidatorUpdate {
+	if m != nil {
+		return m.ValidatorUpdates
+	}
+	return nil
+}
+
+type ServiceUsage struct {
+	RequestCount         int64    `protobuf:"varint,1,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`
+	SignDataCount        int64    `protobuf:"varint,2,opt,name=sign_data_count,json=signDataCount,proto3" json:"sign_data_count,omitempty"`
+	AsIdList             []string `protobuf:"bytes,3,rep,name=as_id_list,json=asIdList,proto3" json:"as_id_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceUsage) Reset()         { *m = ServiceUsage{} }
+func (m *ServiceUsage) String() string { return proto.CompactTextString(m) }
+func (*ServiceUsage) ProtoMessage()    {}
+func (*ServiceUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{69}
+}
+
+func (m *ServiceUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceUsage.Unmarshal(m, b)
+}
+func (m *ServiceUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceUsage.Marshal(b, m, deterministic)
+}
+func (m *ServiceUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceUsage.Merge(m, src)
+}
+func (m *ServiceUsage) XXX_Size() int {
+	return xxx_messageInfo_ServiceUsage.Size(m)
+}
+func (m *ServiceUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceUsage proto.InternalMessageInfo
+
+func (m *ServiceUsage) GetRequestCount() int64 {
+	if m != nil {
+		return m.RequestCount
+	}
+	return 0
+}
+
+func (m *ServiceUsage) GetSignDataCount() int64 {
+	if m != nil {
+		return m.SignDataCount
+	}
+	return 0
+}
+
+func (m *ServiceUsage) GetAsIdList() []string {
+	if m != nil {
+		return m.AsIdList
+	}
+	return nil
+}
+
+type RequestPriorityClass struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxOpenRequestCount  int64    `protobuf:"varint,2,opt,name=max_open_request_count,json=maxOpenRequestCount,proto3" json:"max_open_request_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestPriorityClass) Reset()         { *m = RequestPriorityClass{} }
+func (m *RequestPriorityClass) String() string { return proto.CompactTextString(m) }
+func (*RequestPriorityClass) ProtoMessage()    {}
+func (*RequestPriorityClass) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{70}
+}
+
+func (m *RequestPriorityClass) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestPriorityClass.Unmarshal(m, b)
+}
+func (m *RequestPriorityClass) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestPriorityClass.Marshal(b, m, deterministic)
+}
+func (m *RequestPriorityClass) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestPriorityClass.Merge(m, src)
+}
+func (m *RequestPriorityClass) XXX_Size() int {
+	return xxx_messageInfo_RequestPriorityClass.Size(m)
+}
+func (m *RequestPriorityClass) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestPriorityClass.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestPriorityClass proto.InternalMessageInfo
+
+func (m *RequestPriorityClass) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RequestPriorityClass) GetMaxOpenRequestCount() int64 {
+	if m != nil {
+		return m.MaxOpenRequestCount
+	}
+	return 0
+}
+
+type RequestPriorityClassList struct {
+	PriorityClasses      []*RequestPriorityClass `protobuf:"bytes,1,rep,name=priority_classes,json=priorityClasses,proto3" json:"priority_classes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *RequestPriorityClassList) Reset()         { *m = RequestPriorityClassList{} }
+func (m *RequestPriorityClassList) String() string { return proto.CompactTextString(m) }
+func (*RequestPriorityClassList) ProtoMessage()    {}
+func (*RequestPriorityClassList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{71}
+}
+
+func (m *RequestPriorityClassList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestPriorityClassList.Unmarshal(m, b)
+}
+func (m *RequestPriorityClassList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestPriorityClassList.Marshal(b, m, deterministic)
+}
+func (m *RequestPriorityClassList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestPriorityClassList.Merge(m, src)
+}
+func (m *RequestPriorityClassList) XXX_Size() int {
+	return xxx_messageInfo_RequestPriorityClassList.Size(m)
+}
+func (m *RequestPriorityClassList) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestPriorityClassList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestPriorityClassList proto.InternalMessageInfo
+
+func (m *RequestPriorityClassList) GetPriorityClasses() []*RequestPriorityClass {
+	if m != nil {
+		return m.PriorityClasses
+	}
+	return nil
+}
+
+type QueryVisibility struct {
+	AllowedRoleList      []string `protobuf:"bytes,1,rep,name=allowed_role_list,json=allowedRoleList,proto3" json:"allowed_role_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryVisibility) Reset()         { *m = QueryVisibility{} }
+func (m *QueryVisibility) String() string { return proto.CompactTextString(m) }
+func (*QueryVisibility) ProtoMessage()    {}
+func (*QueryVisibility) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{72}
+}
+
+func (m *QueryVisibility) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryVisibility.Unmarshal(m, b)
+}
+func (m *QueryVisibility) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryVisibility.Marshal(b, m, deterministic)
+}
+func (m *QueryVisibility) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryVisibility.Merge(m, src)
+}
+func (m *QueryVisibility) XXX_Size() int {
+	return xxx_messageInfo_QueryVisibility.Size(m)
+}
+func (m *QueryVisibility) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryVisibility.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryVisibility proto.InternalMessageInfo
+
+func (m *QueryVisibility) GetAllowedRoleList() []string {
+	if m != nil {
+		return m.AllowedRoleList
+	}
+	return nil
+}
+
+type DataRetentionPolicy struct {
+	RuleList             []*DataRetentionRule `protobuf:"bytes,1,rep,name=rule_list,json=ruleList,proto3" json:"rule_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DataRetentionPolicy) Reset()         { *m = DataRetentionPolicy{} }
+func (m *DataRetentionPolicy) String() string { return proto.CompactTextString(m) }
+func (*DataRetentionPolicy) ProtoMessage()    {}
+func (*DataRetentionPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{73}
+}
+
+func (m *DataRetentionPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataRetentionPolicy.Unmarshal(m, b)
+}
+func (m *DataRetentionPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataRetentionPolicy.Marshal(b, m, deterministic)
+}
+func (m *DataRetentionPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRetentionPolicy.Merge(m, src)
+}
+func (m *DataRetentionPolicy) XXX_Size() int {
+	return xxx_messageInfo_DataRetentionPolicy.Size(m)
+}
+func (m *DataRetentionPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRetentionPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataRetentionPolicy proto.InternalMessageInfo
+
+func (m *DataRetentionPolicy) GetRuleList() []*DataRetentionRule {
+	if m != nil {
+		return m.RuleList
+	}
+	return nil
+}
+
+type DataRetentionRule struct {
+	KeyPrefix            string   `protobuf:"bytes,1,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	RetentionBlock       int64    `protobuf:"varint,2,opt,name=retention_block,json=retentionBlock,proto3" json:"retention_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataRetentionRule) Reset()         { *m = DataRetentionRule{} }
+func (m *DataRetentionRule) String() string { return proto.CompactTextString(m) }
+func (*DataRetentionRule) ProtoMessage()    {}
+func (*DataRetentionRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{74}
+}
+
+func (m *DataRetentionRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataRetentionRule.Unmarshal(m, b)
+}
+func (m *DataRetentionRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataRetentionRule.Marshal(b, m, deterministic)
+}
+func (m *DataRetentionRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRetentionRule.Merge(m, src)
+}
+func (m *DataRetentionRule) XXX_Size() int {
+	return xxx_messageInfo_DataRetentionRule.Size(m)
+}
+func (m *DataRetentionRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRetentionRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataRetentionRule proto.InternalMessageInfo
+
+func (m *DataRetentionRule) GetKeyPrefix() string {
+	if m != nil {
+		return m.KeyPrefix
+	}
+	return ""
+}
+
+func (m *DataRetentionRule) GetRetentionBlock() int64 {
+	if m != nil {
+		return m.RetentionBlock
+	}
+	return 0
+}
+
+type DataRequestStatus struct {
+	AnsweredAsIdList     []string `protobuf:"bytes,1,rep,name=answered_as_id_list,json=answeredAsIdList,proto3" json:"answered_as_id_list,omitempty"`
+	ReceivedDataFromList []string `protobuf:"bytes,2,rep,name=received_data_from_list,json=receivedDataFromList,proto3" json:"received_data_from_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataRequestStatus) Reset()         { *m = DataRequestStatus{} }
+func (m *DataRequestStatus) String() string { return proto.CompactTextString(m) }
+func (*DataRequestStatus) ProtoMessage()    {}
+func (*DataRequestStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{75}
+}
+
+func (m *DataRequestStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataRequestStatus.Unmarshal(m, b)
+}
+func (m *DataRequestStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataRequestStatus.Marshal(b, m, deterministic)
+}
+func (m *DataRequestStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataRequestStatus.Merge(m, src)
+}
+func (m *DataRequestStatus) XXX_Size() int {
+	return xxx_messageInfo_DataRequestStatus.Size(m)
+}
+func (m *DataRequestStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataRequestStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataRequestStatus proto.InternalMessageInfo
+
+func (m *DataRequestStatus) GetAnsweredAsIdList() []string {
+	if m != nil {
+		return m.AnsweredAsIdList
+	}
+	return nil
+}
+
+func (m *DataRequestStatus) GetReceivedDataFromList() []string {
+	if m != nil {
+		return m.ReceivedDataFromList
+	}
+	return nil
+}
+
+type RequestSettlement struct {
+	Owner                string             `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	BlockHeight          int64              `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	EntryList            []*SettlementEntry `protobuf:"bytes,3,rep,name=entry_list,json=entryList,proto3" json:"entry_list,omitempty"`
+	Total                float64            `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *RequestSettlement) Reset()         { *m = RequestSettlement{} }
+func (m *RequestSettlement) String() string { return proto.CompactTextString(m) }
+func (*RequestSettlement) ProtoMessage()    {}
+func (*RequestSettlement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{76}
+}
+
+func (m *RequestSettlement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestSettlement.Unmarshal(m, b)
+}
+func (m *RequestSettlement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestSettlement.Marshal(b, m, deterministic)
+}
+func (m *RequestSettlement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestSettlement.Merge(m, src)
+}
+func (m *RequestSettlement) XXX_Size() int {
+	return xxx_messageInfo_RequestSettlement.Size(m)
+}
+func (m *RequestSettlement) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestSettlement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestSettlement proto.InternalMessageInfo
+
+func (m *RequestSettlement) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *RequestSettlement) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *RequestSettlement) GetEntryList() []*SettlementEntry {
+	if m != nil {
+		return m.EntryList
+	}
+	return nil
+}
+
+func (m *RequestSettlement) GetTotal() float64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type SettlementEntry struct {
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Role                 string   `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	ServiceId            string   `protobuf:"bytes,3,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Amount               float64  `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Valid                bool     `protobuf:"varint,5,opt,name=valid,proto3" json:"valid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SettlementEntry) Reset()         { *m = SettlementEntry{} }
+func (m *SettlementEntry) String() string { return proto.CompactTextString(m) }
+func (*SettlementEntry) ProtoMessage()    {}
+func (*SettlementEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{77}
+}
+
+func (m *SettlementEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SettlementEntry.Unmarshal(m, b)
+}
+func (m *SettlementEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SettlementEntry.Marshal(b, m, deterministic)
+}
+func (m *SettlementEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SettlementEntry.Merge(m, src)
+}
+func (m *SettlementEntry) XXX_Size() int {
+	return xxx_messageInfo_SettlementEntry.Size(m)
+}
+func (m *SettlementEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_SettlementEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SettlementEntry proto.InternalMessageInfo
+
+func (m *SettlementEntry) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *SettlementEntry) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *SettlementEntry) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *SettlementEntry) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *SettlementEntry) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+type AccessorResponse struct {
+	IdpId                string   `protobuf:"bytes,1,opt,name=idp_id,json=idpId,proto3" json:"idp_id,omitempty"`
+	BlockHeight          int64    `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccessorResponse) Reset()         { *m = AccessorResponse{} }
+func (m *AccessorResponse) String() string { return proto.CompactTextString(m) }
+func (*AccessorResponse) ProtoMessage()    {}
+func (*AccessorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{78}
+}
+
+func (m *AccessorResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessorResponse.Unmarshal(m, b)
+}
+func (m *AccessorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessorResponse.Marshal(b, m, deterministic)
+}
+func (m *AccessorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessorResponse.Merge(m, src)
+}
+func (m *AccessorResponse) XXX_Size() int {
+	return xxx_messageInfo_AccessorResponse.Size(m)
+}
+func (m *AccessorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessorResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessorResponse proto.InternalMessageInfo
+
+func (m *AccessorResponse) GetIdpId() string {
+	if m != nil {
+		return m.IdpId
+	}
+	return ""
+}
+
+func (m *AccessorResponse) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+type PreviousChainList struct {
+	ChainList            []*PreviousChain `protobuf:"bytes,1,rep,name=chain_list,json=chainList,proto3" json:"chain_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PreviousChainList) Reset()         { *m = PreviousChainList{} }
+func (m *PreviousChainList) String() string { return proto.CompactTextString(m) }
+func (*PreviousChainList) ProtoMessage()    {}
+func (*PreviousChainList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{79}
+}
+
+func (m *PreviousChainList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreviousChainList.Unmarshal(m, b)
+}
+func (m *PreviousChainList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreviousChainList.Marshal(b, m, deterministic)
+}
+func (m *PreviousChainList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreviousChainList.Merge(m, src)
+}
+func (m *PreviousChainList) XXX_Size() int {
+	return xxx_messageInfo_PreviousChainList.Size(m)
+}
+func (m *PreviousChainList) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreviousChainList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreviousChainList proto.InternalMessageInfo
+
+func (m *PreviousChainList) GetChainList() []*PreviousChain {
+	if m != nil {
+		return m.ChainList
+	}
+	return nil
+}
+
+type PreviousChain struct {
+	ChainId              string   `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	FinalBlockHeight     int64    `protobuf:"varint,2,opt,name=final_block_height,json=finalBlockHeight,proto3" json:"final_block_height,omitempty"`
+	FinalAppHash         string   `protobuf:"bytes,3,opt,name=final_app_hash,json=finalAppHash,proto3" json:"final_app_hash,omitempty"`
+	RecordedChainId      string   `protobuf:"bytes,4,opt,name=recorded_chain_id,json=recordedChainId,proto3" json:"recorded_chain_id,omitempty"`
+	RecordedBlockHeight  int64    `protobuf:"varint,5,opt,name=recorded_block_height,json=recordedBlockHeight,proto3" json:"recorded_block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PreviousChain) Reset()         { *m = PreviousChain{} }
+func (m *PreviousChain) String() string { return proto.CompactTextString(m) }
+func (*PreviousChain) ProtoMessage()    {}
+func (*PreviousChain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{80}
+}
+
+func (m *PreviousChain) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreviousChain.Unmarshal(m, b)
+}
+func (m *PreviousChain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreviousChain.Marshal(b, m, deterministic)
+}
+func (m *PreviousChain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreviousChain.Merge(m, src)
+}
+func (m *PreviousChain) XXX_Size() int {
+	return xxx_messageInfo_PreviousChain.Size(m)
+}
+func (m *PreviousChain) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreviousChain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreviousChain proto.InternalMessageInfo
+
+func (m *PreviousChain) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *PreviousChain) GetFinalBlockHeight() int64 {
+	if m != nil {
+		return m.FinalBlockHeight
+	}
+	return 0
+}
+
+func (m *PreviousChain) GetFinalAppHash() string {
+	if m != nil {
+		return m.FinalAppHash
+	}
+	return ""
+}
+
+func (m *PreviousChain) GetRecordedChainId() string {
+	if m != nil {
+		return m.RecordedChainId
+	}
+	return ""
+}
+
+func (m *PreviousChain) GetRecordedBlockHeight() int64 {
+	if m != nil {
+		return m.RecordedBlockHeight
+	}
+	return 0
+}
+
+type EndBlockHookFlagList struct {
+	FlagList             []*EndBlockHookFlag `protobuf:"bytes,1,rep,name=flag_list,json=flagList,proto3" json:"flag_list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *EndBlockHookFlagList) Reset()         { *m = EndBlockHookFlagList{} }
+func (m *EndBlockHookFlagList) String() string { return proto.CompactTextString(m) }
+func (*EndBlockHookFlagList) ProtoMessage()    {}
+func (*EndBlockHookFlagList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{81}
+}
+
+func (m *EndBlockHookFlagList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndBlockHookFlagList.Unmarshal(m, b)
+}
+func (m *EndBlockHookFlagList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndBlockHookFlagList.Marshal(b, m, deterministic)
+}
+func (m *EndBlockHookFlagList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndBlockHookFlagList.Merge(m, src)
+}
+func (m *EndBlockHookFlagList) XXX_Size() int {
+	return xxx_messageInfo_EndBlockHookFlagList.Size(m)
+}
+func (m *EndBlockHookFlagList) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndBlockHookFlagList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndBlockHookFlagList proto.InternalMessageInfo
+
+func (m *EndBlockHookFlagList) GetFlagList() []*EndBlockHookFlag {
+	if m != nil {
+		return m.FlagList
+	}
+	return nil
+}
+
+type EndBlockHookFlag struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled              bool     `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndBlockHookFlag) Reset()         { *m = EndBlockHookFlag{} }
+func (m *EndBlockHookFlag) String() string { return proto.CompactTextString(m) }
+func (*EndBlockHookFlag) ProtoMessage()    {}
+func (*EndBlockHookFlag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{82}
+}
+
+func (m *EndBlockHookFlag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndBlockHookFlag.Unmarshal(m, b)
+}
+func (m *EndBlockHookFlag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndBlockHookFlag.Marshal(b, m, deterministic)
+}
+func (m *EndBlockHookFlag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndBlockHookFlag.Merge(m, src)
+}
+func (m *EndBlockHookFlag) XXX_Size() int {
+	return xxx_messageInfo_EndBlockHookFlag.Size(m)
+}
+func (m *EndBlockHookFlag) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndBlockHookFlag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndBlockHookFlag proto.InternalMessageInfo
+
+func (m *EndBlockHookFlag) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EndBlockHookFlag) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type NodeContact struct {
+	Email                string   `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	WebhookUrlHash       string   `protobuf:"bytes,2,opt,name=webhook_url_hash,json=webhookUrlHash,proto3" json:"webhook_url_hash,omitempty"`
+	UpdatedBlockHeight   int64    `protobuf:"varint,3,opt,name=updated_block_height,json=updatedBlockHeight,proto3" json:"updated_block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeContact) Reset()         { *m = NodeContact{} }
+func (m *NodeContact) String() string { return proto.CompactTextString(m) }
+func (*NodeContact) ProtoMessage()    {}
+func (*NodeContact) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{83}
+}
+
+func (m *NodeContact) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeContact.Unmarshal(m, b)
+}
+func (m *NodeContact) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeContact.Marshal(b, m, deterministic)
+}
+func (m *NodeContact) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeContact.Merge(m, src)
+}
+func (m *NodeContact) XXX_Size() int {
+	return xxx_messageInfo_NodeContact.Size(m)
+}
+func (m *NodeContact) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeContact.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeContact proto.InternalMessageInfo
+
+func (m *NodeContact) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *NodeContact) GetWebhookUrlHash() string {
+	if m != nil {
+		return m.WebhookUrlHash
+	}
+	return ""
+}
+
+func (m *NodeContact) GetUpdatedBlockHeight() int64 {
+	if m != nil {
+		return m.UpdatedBlockHeight
+	}
+	return 0
+}
+
+type NodeIDAlias struct {
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	BlockHeight          int64    `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeIDAlias) Reset()         { *m = NodeIDAlias{} }
+func (m *NodeIDAlias) String() string { return proto.CompactTextString(m) }
+func (*NodeIDAlias) ProtoMessage()    {}
+func (*NodeIDAlias) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492be2f0ffbab25c, []int{84}
+}
+
+func (m *NodeIDAlias) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeIDAlias.Unmarshal(m, b)
+}
+func (m *NodeIDAlias) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeIDAlias.Marshal(b, m, deterministic)
+}
+func (m *NodeIDAlias) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeIDAlias.Merge(m, src)
+}
+func (m *NodeIDAlias) XXX_Size() int {
+	return xxx_messageInfo_NodeIDAlias.Size(m)
+}
+func (m *NodeIDAlias) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeIDAlias.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeIDAlias proto.InternalMessageInfo
+
+func (m *NodeIDAlias) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *NodeIDAlias) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*KeyVersions)(nil), "KeyVersions")
 	proto.RegisterType((*NodeDetail)(nil), "NodeDetail")
@@ -1992,9 +4878,16 @@ func init() {
 	proto.RegisterType((*ServiceDetail)(nil), "ServiceDetail")
 	proto.RegisterType((*ApproveService)(nil), "ApproveService")
 	proto.RegisterType((*TimeOutBlockRegisterIdentity)(nil), "TimeOutBlockRegisterIdentity")
+	proto.RegisterType((*MaxRequestTimeoutExtension)(nil), "MaxRequestTimeoutExtension")
+	proto.RegisterType((*RequestListSizeLimit)(nil), "RequestListSizeLimit")
+	proto.RegisterType((*RandomnessBeaconConfig)(nil), "RandomnessBeaconConfig")
+	proto.RegisterType((*AppHashSchemeConfig)(nil), "AppHashSchemeConfig")
+	proto.RegisterType((*AppHashSchemeActivation)(nil), "AppHashSchemeActivation")
 	proto.RegisterType((*Proxy)(nil), "Proxy")
 	proto.RegisterType((*BehindNodeList)(nil), "BehindNodeList")
 	proto.RegisterType((*Request)(nil), "Request")
+	proto.RegisterType((*RequestSummary)(nil), "RequestSummary")
+	proto.RegisterType((*ServiceSignedCount)(nil), "ServiceSignedCount")
 	proto.RegisterType((*DataRequest)(nil), "DataRequest")
 	proto.RegisterType((*Response)(nil), "Response")
 	proto.RegisterType((*ReportList)(nil), "ReportList")
@@ -2011,121 +4904,314 @@ func init() {
 	proto.RegisterType((*AllList)(nil), "AllList")
 	proto.RegisterType((*AccessorInGroup)(nil), "AccessorInGroup")
 	proto.RegisterType((*Token)(nil), "Token")
+	proto.RegisterType((*RequestEscrowPrice)(nil), "RequestEscrowPrice")
+	proto.RegisterType((*TokenLedgerEntry)(nil), "TokenLedgerEntry")
 	proto.RegisterType((*TokenPrice)(nil), "TokenPrice")
+	proto.RegisterType((*LowTokenThreshold)(nil), "LowTokenThreshold")
 	proto.RegisterType((*ReferenceGroup)(nil), "ReferenceGroup")
 	proto.RegisterType((*IdPInRefGroup)(nil), "IdPInRefGroup")
 	proto.RegisterType((*IdentityInRefGroup)(nil), "IdentityInRefGroup")
 	proto.RegisterType((*AllowedModeList)(nil), "AllowedModeList")
 	proto.RegisterType((*AllowedMinIalForRegisterIdentityAtFirstIdp)(nil), "AllowedMinIalForRegisterIdentityAtFirstIdp")
+	proto.RegisterType((*NodeKey)(nil), "NodeKey")
+	proto.RegisterType((*DataSignature)(nil), "DataSignature")
+	proto.RegisterType((*ConsentReceiptList)(nil), "ConsentReceiptList")
+	proto.RegisterType((*ConsentReceipt)(nil), "ConsentReceipt")
+	proto.RegisterType((*Statistics)(nil), "Statistics")
+	proto.RegisterType((*ServiceStatistics)(nil), "ServiceStatistics")
+	proto.RegisterType((*NodeQuota)(nil), "NodeQuota")
+	proto.RegisterType((*ValidatorNode)(nil), "ValidatorNode")
+	proto.RegisterType((*ValidatorMissThreshold)(nil), "ValidatorMissThreshold")
+	proto.RegisterType((*BlockWriteLimit)(nil), "BlockWriteLimit")
+	proto.RegisterType((*AdminApprovalPolicy)(nil), "AdminApprovalPolicy")
+	proto.RegisterType((*AdminProposal)(nil), "AdminProposal")
+	proto.RegisterType((*GovernanceActionDelay)(nil), "GovernanceActionDelay")
+	proto.RegisterType((*GovernanceAction)(nil), "GovernanceAction")
+	proto.RegisterType((*KeyChange)(nil), "KeyChange")
+	proto.RegisterType((*ChangeJournal)(nil), "ChangeJournal")
+	proto.RegisterType((*BlockActivity)(nil), "BlockActivity")
+	proto.RegisterType((*TxActivity)(nil), "TxActivity")
+	proto.RegisterType((*ValidatorPowerClass)(nil), "ValidatorPowerClass")
+	proto.RegisterType((*ValidatorPowerPolicy)(nil), "ValidatorPowerPolicy")
+	proto.RegisterType((*ValidatorMisbehavior)(nil), "ValidatorMisbehavior")
+	proto.RegisterType((*ValidatorMisbehaviorList)(nil), "ValidatorMisbehaviorList")
+	proto.RegisterType((*PendingValidatorUpdate)(nil), "PendingValidatorUpdate")
+	proto.RegisterType((*PendingValidatorUpdateList)(nil), "PendingValidatorUpdateList")
+	proto.RegisterType((*ServiceUsage)(nil), "ServiceUsage")
+	proto.RegisterType((*RequestPriorityClass)(nil), "RequestPriorityClass")
+	proto.RegisterType((*RequestPriorityClassList)(nil), "RequestPriorityClassList")
+	proto.RegisterType((*QueryVisibility)(nil), "QueryVisibility")
+	proto.RegisterType((*DataRetentionPolicy)(nil), "DataRetentionPolicy")
+	proto.RegisterType((*DataRetentionRule)(nil), "DataRetentionRule")
+	proto.RegisterType((*DataRequestStatus)(nil), "DataRequestStatus")
+	proto.RegisterType((*RequestSettlement)(nil), "RequestSettlement")
+	proto.RegisterType((*SettlementEntry)(nil), "SettlementEntry")
+	proto.RegisterType((*AccessorResponse)(nil), "AccessorResponse")
+	proto.RegisterType((*PreviousChainList)(nil), "PreviousChainList")
+	proto.RegisterType((*PreviousChain)(nil), "PreviousChain")
+	proto.RegisterType((*EndBlockHookFlagList)(nil), "EndBlockHookFlagList")
+	proto.RegisterType((*EndBlockHookFlag)(nil), "EndBlockHookFlag")
+	proto.RegisterType((*NodeContact)(nil), "NodeContact")
+	proto.RegisterType((*NodeIDAlias)(nil), "NodeIDAlias")
 }
 
 func init() { proto.RegisterFile("protos/data/data.proto", fileDescriptor_492be2f0ffbab25c) }
 
 var fileDescriptor_492be2f0ffbab25c = []byte{
-	// 1667 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0x4d, 0x6f, 0x1c, 0x45,
-	0x13, 0xd6, 0xec, 0xf7, 0xd6, 0xda, 0xeb, 0x78, 0xec, 0x38, 0xf3, 0xbe, 0x31, 0xc4, 0x1e, 0x42,
-	0xb2, 0x09, 0xc9, 0x06, 0x39, 0x42, 0x8a, 0xc4, 0x01, 0x6d, 0x62, 0x42, 0x96, 0xe0, 0xc4, 0x19,
-	0x1b, 0x2e, 0x20, 0x8d, 0x3a, 0x33, 0x6d, 0x6f, 0x2b, 0xf3, 0xe5, 0xee, 0x19, 0x27, 0xbe, 0x73,
-	0x44, 0xe2, 0x7f, 0x70, 0xe0, 0x07, 0x20, 0x71, 0x40, 0xe2, 0xc4, 0x1f, 0xe2, 0x8a, 0xba, 0xba,
-	0x7b, 0x66, 0xd6, 0x8e, 0x6d, 0xb8, 0xac, 0xa6, 0x9f, 0xaa, 0x9e, 0x9e, 0xae, 0xaa, 0xe7, 0xa9,
-	0x5a, 0x58, 0xcb, 0x78, 0x9a, 0xa7, 0xe2, 0x41, 0x48, 0x72, 0x82, 0x3f, 0x63, 0x04, 0xdc, 0x3b,
-	0x30, 0x78, 0x4e, 0x4f, 0xbe, 0xa3, 0x5c, 0xb0, 0x34, 0x11, 0xf6, 0xff, 0xa1, 0x77, 0xac, 0x9f,
-	0x1d, 0x6b, 0xa3, 0x39, 0x6a, 0x7a, 0xe5, 0xda, 0xfd, 0xa9, 0x09, 0xf0, 0x22, 0x0d, 0xe9, 0x36,
-	0xcd, 0x09, 0x8b, 0xec, 0x0f, 0x00, 0xb2, 0xe2, 0x75, 0xc4, 0x02, 0xff, 0x0d, 0x3d, 0x71, 0xac,
-	0x0d, 0x6b, 0xd4, 0xf7, 0xfa, 0x0a, 0x79, 0x4e, 0x4f, 0xec, 0xbb, 0xb0, 0x1c, 0x13, 0x91, 0x53,
-	0xee, 0xd7, 0xbc, 0x1a, 0xe8, 0xb5, 0xa4, 0x0c, 0xbb, 0xa5, 0xef, 0x75, 0xe8, 0x27, 0x69, 0x48,
-	0xfd, 0x84, 0xc4, 0xd4, 0x69, 0xa2, 0x4f, 0x4f, 0x02, 0x2f, 0x48, 0x4c, 0x6d, 0x1b, 0x5a, 0x3c,
-	0x8d, 0xa8, 0xd3, 0x42, 0x1c, 0x9f, 0xed, 0x6b, 0xd0, 0x8d, 0xc9, 0x3b, 0x9f, 0x91, 0xc8, 0x69,
-	0x6f, 0x58, 0x23, 0xcb, 0xeb, 0xc4, 0xe4, 0xdd, 0x94, 0x44, 0xc6, 0x40, 0x48, 0xe4, 0x74, 0x4a,
-	0xc3, 0x84, 0x44, 0xf6, 0x0a, 0x34, 0xe2, 0x23, 0xa7, 0xbb, 0xd1, 0x1c, 0x0d, 0xb6, 0x9a, 0xe3,
-	0x9d, 0x57, 0x5e, 0x23, 0x3e, 0xb2, 0xd7, 0xa0, 0x43, 0x82, 0x9c, 0x1d, 0x53, 0xa7, 0xb7, 0x61,
-	0x8d, 0x7a, 0x9e, 0x5e, 0xd9, 0x2e, 0x2c, 0x66, 0x3c, 0x7d, 0x77, 0xe2, 0xe3, 0x57, 0xb1, 0xd0,
-	0xe9, 0xe3, 0xd9, 0x03, 0x04, 0x65, 0x08, 0xa6, 0xa1, 0xbd, 0x09, 0x0b, 0xca, 0x27, 0x48, 0x93,
-	0x03, 0x76, 0xe8, 0x40, 0xcd, 0xe5, 0x09, 0x42, 0xf6, 0x0f, 0x70, 0x4f, 0x14, 0x59, 0x96, 0xf2,
-	0x9c, 0x86, 0x3e, 0xa7, 0x47, 0x05, 0x15, 0xb9, 0x1f, 0x53, 0x21, 0xc8, 0x21, 0xf5, 0x65, 0x0e,
-	0xfc, 0x82, 0x47, 0x7e, 0x7e, 0x92, 0x51, 0x3f, 0x62, 0x22, 0x77, 0x06, 0x1b, 0xcd, 0x51, 0xdf,
-	0xbb, 0x55, 0xee, 0xf1, 0xd4, 0x96, 0x1d, 0xb5, 0x63, 0x9b, 0xe4, 0xe4, 0x5b, 0x1e, 0xed, 0x9f,
-	0x64, 0xf4, 0x1b, 0x26, 0x72, 0x77, 0x04, 0x8d, 0x9d, 0x57, 0xf6, 0x10, 0x1a, 0x2c, 0xd3, 0xd1,
-	0x6f, 0xb0, 0x4c, 0x46, 0x4b, 0x6e, 0xc6, 0x48, 0x37, 0x3d, 0x7c, 0x76, 0x5d, 0xe8, 0x4e, 0xc3,
-	0x5d, 0xb9, 0x49, 0xc6, 0xc7, 0xdc, 0xc9, 0xc2, 0xd3, 0x3a, 0x09, 0x5e, 0xc7, 0xfd, 0x1c, 0x16,
-	0x65, 0xb4, 0x45, 0x46, 0x02, 0x7c, 0xbd, 0x7d, 0x17, 0x20, 0x31, 0x80, 0xaa, 0x85, 0xc1, 0x16,
-	0x8c, 0x4b, 0x1f, 0xaf, 0x66, 0x75, 0x7f, 0x69, 0x40, 0xbf, 0xb4, 0xd8, 0xeb, 0xd0, 0x2f, 0x6d,
-	0xa6, 0x2e, 0x4a, 0xc0, 0xde, 0x80, 0x41, 0x48, 0x45, 0xc0, 0x59, 0x96, 0xb3, 0x34, 0xd1, 0x15,
-	0x51, 0x87, 0x6a, 0x59, 0x69, 0xce, 0x65, 0xe5, 0x7b, 0xf8, 0x84, 0x44, 0x51, 0xfa, 0x96, 0x86,
-	0x3e, 0x0b, 0x69, 0x92, 0xb3, 0x03, 0x46, 0xb9, 0x1f, 0xa4, 0x45, 0x92, 0xfb, 0x2c, 0xf1, 0x39,
-	0x3d, 0xa0, 0x9c, 0x26, 0x01, 0xf5, 0x0f, 0x79, 0x5a, 0x64, 0x58, 0x2f, 0x6d, 0xef, 0x96, 0xde,
-	0x32, 0x2d, 0x77, 0x3c, 0x91, 0x1b, 0xa6, 0x89, 0x67, 0xdc, 0xbf, 0x92, 0xde, 0xf6, 0x0c, 0xb6,
-	0xcc, 0xcb, 0xd5, 0x71, 0xff, 0xea, 0x8c, 0x36, 0x9e, 0x71, 0x4f, 0xef, 0x9c, 0xe0, 0xc6, 0x4b,
-	0x4e, 0x72, 0xbf, 0x80, 0xe5, 0x3d, 0xca, 0x8f, 0x59, 0xa0, 0x89, 0xa4, 0xa3, 0xdd, 0x13, 0x0a,
-	0x34, 0xb1, 0x1e, 0x8e, 0xe7, 0xbc, 0xbc, 0xd2, 0xee, 0xfe, 0x66, 0xc1, 0xe2, 0x9c, 0x4d, 0x52,
-	0x51, 0x5b, 0x55, 0x62, 0x31, 0xe4, 0x1a, 0x51, 0xa5, 0x6a, 0xcc, 0xc8, 0x30, 0x1d, 0x73, 0x8d,
-	0x21, 0xc9, 0x6e, 0xc0, 0x00, 0x0b, 0x52, 0x04, 0x33, 0x1a, 0x13, 0xcd, 0x41, 0x90, 0xd0, 0x1e,
-	0x22, 0xf6, 0x18, 0x56, 0x6a, 0x0e, 0xbe, 0x16, 0x05, 0x4d, 0xca, 0xe5, 0xca, 0x51, 0x2b, 0x49,
-	0x2d, 0x89, 0xed, 0x7a, 0x12, 0xdd, 0x11, 0x0c, 0x27, 0x59, 0xc6, 0xd3, 0x63, 0xaa, 0xaf, 0x50,
-	0xf3, 0xb4, 0xe6, 0x3c, 0xb7, 0x61, 0x7d, 0x9f, 0xc5, 0xf4, 0x65, 0x91, 0x3f, 0x8e, 0xd2, 0xe0,
-	0x8d, 0x47, 0x0f, 0x99, 0x54, 0x0d, 0x15, 0xde, 0xfc, 0xc4, 0xbe, 0x09, 0xc3, 0x9c, 0xc5, 0xd4,
-	0x4f, 0x8b, 0xdc, 0x7f, 0x2d, 0x3d, 0x70, 0x7f, 0xd3, 0x5b, 0xc8, 0x6b, 0xbb, 0xdc, 0x27, 0xd0,
-	0xde, 0x95, 0x94, 0x3c, 0xcb, 0x69, 0xeb, 0x2c, 0xa7, 0xd7, 0xa0, 0xa3, 0xd9, 0xac, 0x42, 0xa4,
-	0x57, 0xee, 0x2d, 0x18, 0x3e, 0xa6, 0x33, 0x96, 0x84, 0xd2, 0x0f, 0xf3, 0xb5, 0x0a, 0x6d, 0xf9,
-	0x1e, 0xa1, 0x59, 0xa4, 0x16, 0xee, 0xef, 0x2d, 0xe8, 0x6a, 0xd2, 0xca, 0x9c, 0x18, 0xca, 0x57,
-	0x39, 0xd1, 0xc8, 0x34, 0x44, 0xa1, 0x62, 0x89, 0xcf, 0xc2, 0x4c, 0x53, 0xb5, 0x13, 0xb3, 0x64,
-	0x1a, 0x66, 0xc6, 0x20, 0x15, 0xac, 0xa9, 0x15, 0x8c, 0x25, 0x13, 0x2d, 0x6d, 0x72, 0x07, 0x89,
-	0x30, 0xea, 0xca, 0x20, 0x35, 0xef, 0x36, 0x2c, 0x99, 0x93, 0xe4, 0xd5, 0xd3, 0x22, 0xc7, 0x98,
-	0x37, 0xbd, 0xa1, 0x86, 0xf7, 0x15, 0x6a, 0x7f, 0x08, 0x03, 0x16, 0x66, 0x3e, 0x0b, 0x95, 0xdc,
-	0x74, 0xf0, 0xd3, 0xfb, 0x2c, 0xcc, 0xa6, 0x21, 0x5e, 0xea, 0x11, 0x60, 0x22, 0x4b, 0xa9, 0x42,
-	0x2f, 0x25, 0x99, 0x0b, 0x63, 0x29, 0x3f, 0xfa, 0x6e, 0xde, 0x52, 0x58, 0x2d, 0x70, 0xe7, 0xa7,
-	0xb0, 0x7a, 0x5a, 0xdf, 0x66, 0x44, 0xcc, 0x50, 0x56, 0xfb, 0x9e, 0xcd, 0xe7, 0x84, 0xec, 0x19,
-	0x11, 0x33, 0x7b, 0x0c, 0x8b, 0x9c, 0x8a, 0x2c, 0x4d, 0x84, 0x16, 0xbf, 0x3e, 0x9e, 0xd3, 0x1f,
-	0x7b, 0x1a, 0xf5, 0x16, 0x8c, 0x1d, 0x4f, 0x90, 0xa9, 0x89, 0x52, 0x41, 0x43, 0x14, 0xda, 0x9e,
-	0xa7, 0x57, 0xb2, 0x75, 0xc8, 0x4b, 0x87, 0xb2, 0x0c, 0x9c, 0x01, 0x9a, 0x7a, 0x08, 0xbc, 0x2c,
-	0x72, 0xdb, 0x81, 0x6e, 0x56, 0xf0, 0x2c, 0x15, 0xd4, 0x59, 0xc0, 0x2f, 0x31, 0x4b, 0x99, 0xbf,
-	0xf4, 0x6d, 0x42, 0xb9, 0xb3, 0x88, 0xb8, 0x5a, 0x48, 0xf1, 0x8c, 0xd3, 0x90, 0x3a, 0x43, 0xa4,
-	0x35, 0x3e, 0xcb, 0x03, 0x0a, 0x41, 0x95, 0x04, 0x38, 0x4b, 0x18, 0xd7, 0x5e, 0x21, 0x28, 0x72,
-	0xdb, 0xde, 0x82, 0xab, 0x01, 0xa7, 0x44, 0xca, 0x96, 0xaa, 0x41, 0x7f, 0x46, 0xd9, 0xe1, 0x2c,
-	0x77, 0xae, 0xa0, 0xe3, 0x8a, 0x31, 0x62, 0x2d, 0x3e, 0x43, 0x93, 0xfd, 0x3f, 0xe8, 0x05, 0x33,
-	0x82, 0xb9, 0x77, 0x96, 0xd5, 0x57, 0xe1, 0x7a, 0x1a, 0xba, 0x7f, 0x5b, 0x30, 0xa8, 0xc5, 0xf9,
-	0x32, 0x5e, 0xaf, 0x03, 0x10, 0x51, 0xa6, 0xb3, 0x81, 0xe9, 0xec, 0x11, 0xa1, 0xb3, 0x79, 0x15,
-	0x3a, 0x58, 0x48, 0x02, 0xeb, 0xa8, 0xe9, 0xb5, 0x65, 0x1d, 0x09, 0x49, 0x64, 0x93, 0xaa, 0x8c,
-	0x70, 0x12, 0x0b, 0x95, 0x29, 0x4d, 0x64, 0x6d, 0xda, 0x45, 0x0b, 0x26, 0xea, 0x3e, 0xac, 0x90,
-	0x44, 0xbc, 0xa5, 0x5c, 0x2a, 0x63, 0x75, 0x5a, 0x1b, 0x4f, 0xbb, 0x62, 0x4c, 0x13, 0x73, 0xea,
-	0x67, 0x70, 0x8d, 0xd3, 0x80, 0xb2, 0x63, 0x1a, 0xaa, 0x16, 0x77, 0xc0, 0xd3, 0xb8, 0x5e, 0x6f,
-	0xab, 0xc6, 0x2c, 0x2f, 0xfa, 0x94, 0xa7, 0x31, 0x36, 0xb3, 0x3f, 0x2c, 0xe8, 0x99, 0xcc, 0xdb,
-	0x57, 0xa0, 0x29, 0xab, 0xdc, 0xc2, 0x2a, 0x97, 0x8f, 0x12, 0x91, 0x84, 0x68, 0x28, 0x84, 0x90,
-	0x48, 0xd6, 0x83, 0xc8, 0x49, 0x5e, 0x08, 0xad, 0x55, 0x7a, 0x25, 0x9b, 0x8f, 0x60, 0x87, 0x09,
-	0xc9, 0x0b, 0x6e, 0x46, 0x86, 0x0a, 0x90, 0x31, 0x51, 0x0c, 0x40, 0x86, 0xf4, 0xbd, 0x36, 0x16,
-	0xbf, 0xcc, 0xf1, 0x31, 0x89, 0x58, 0x88, 0xe4, 0xea, 0xa8, 0xf9, 0x03, 0x01, 0x4d, 0x2f, 0x65,
-	0xac, 0xde, 0xdb, 0x45, 0x97, 0x21, 0xc2, 0x7b, 0x06, 0x75, 0x1f, 0x00, 0x78, 0x54, 0x36, 0x5c,
-	0x0c, 0xc4, 0x26, 0x74, 0x39, 0xae, 0x8c, 0xa0, 0x77, 0xc7, 0xca, 0xea, 0x19, 0xdc, 0xfd, 0x1a,
-	0x3a, 0x0a, 0x92, 0xb7, 0x89, 0x69, 0x3e, 0x4b, 0x4d, 0x92, 0xf5, 0x4a, 0x96, 0x69, 0xc6, 0x59,
-	0x40, 0xf5, 0xcd, 0xd5, 0x42, 0x96, 0xa9, 0x0c, 0xad, 0xbe, 0x39, 0x3e, 0xbb, 0xbf, 0x5a, 0xd0,
-	0x9b, 0x04, 0x01, 0x15, 0x22, 0xe5, 0x52, 0xcd, 0x89, 0x7e, 0xae, 0x0a, 0x07, 0x0c, 0x34, 0x0d,
-	0xed, 0x8f, 0x60, 0xb1, 0x74, 0x90, 0xf3, 0x87, 0xd6, 0xbb, 0x05, 0x03, 0xca, 0x21, 0x43, 0x56,
-	0x4a, 0xe9, 0x54, 0x9b, 0xe1, 0xd4, 0xa9, 0xcb, 0xc6, 0x54, 0x4d, 0x71, 0x95, 0x90, 0xb7, 0xe6,
-	0xfa, 0x76, 0xc9, 0xb5, 0x76, 0x8d, 0x6b, 0xee, 0x1d, 0x80, 0x1d, 0x71, 0xb4, 0x4d, 0x05, 0x46,
-	0xeb, 0x7a, 0x5d, 0x4f, 0x07, 0x5b, 0xed, 0xb1, 0x54, 0x5a, 0x23, 0xab, 0x3f, 0x5a, 0xd0, 0x92,
-	0xeb, 0xf7, 0x14, 0x46, 0x6d, 0x9e, 0xd1, 0x92, 0x9d, 0x94, 0x52, 0xfe, 0xde, 0x21, 0x62, 0x15,
-	0xda, 0x07, 0x8c, 0x8b, 0x5c, 0x7f, 0xa3, 0x5a, 0xc8, 0x78, 0x68, 0xe9, 0xd4, 0xad, 0xa4, 0x5d,
-	0xb5, 0x92, 0xd4, 0xb4, 0x92, 0x87, 0x30, 0xd0, 0x3d, 0x0b, 0x3f, 0xf9, 0xe6, 0x99, 0x96, 0xdd,
-	0x33, 0x2d, 0xbb, 0xd6, 0xac, 0xff, 0xb2, 0xa0, 0x6b, 0x3a, 0xdd, 0x25, 0x74, 0xae, 0x09, 0x7c,
-	0x63, 0x4e, 0xe0, 0xcf, 0x6d, 0x09, 0xe7, 0x45, 0x5c, 0x92, 0xa0, 0x10, 0x19, 0x4d, 0x42, 0x1a,
-	0xea, 0xfe, 0x5b, 0x01, 0xf6, 0x23, 0x70, 0xaa, 0xb1, 0xb4, 0x1c, 0xcc, 0xea, 0x1c, 0x5d, 0x2b,
-	0xed, 0x73, 0x33, 0xa1, 0x7b, 0x1f, 0x86, 0xe5, 0xe0, 0x61, 0xf2, 0xd6, 0x92, 0x01, 0x2f, 0x4b,
-	0x7c, 0xb2, 0x87, 0x89, 0x43, 0xd0, 0xfd, 0xd3, 0x82, 0x8e, 0x02, 0xe6, 0xe7, 0xce, 0x7a, 0x9e,
-	0xfe, 0xfb, 0xa5, 0xe7, 0xa3, 0xd8, 0x3a, 0x1d, 0xc5, 0x8b, 0x6e, 0xd7, 0xbe, 0xe8, 0x76, 0xb5,
-	0x68, 0x76, 0xe6, 0x06, 0x91, 0x4d, 0xe8, 0x78, 0x97, 0x4c, 0xcf, 0x9b, 0xf2, 0xa2, 0x17, 0xbb,
-	0xb8, 0xd0, 0x9d, 0x44, 0xd1, 0xc5, 0x3e, 0x0f, 0x60, 0xc9, 0x70, 0x78, 0x9a, 0xa8, 0xb9, 0x74,
-	0x1d, 0xfa, 0x86, 0x69, 0x66, 0xd8, 0xa8, 0x00, 0xf7, 0x06, 0xb4, 0xf7, 0xd3, 0x37, 0x54, 0x8d,
-	0x5b, 0x31, 0xb6, 0x28, 0x45, 0x0e, 0xbd, 0x72, 0x5d, 0x00, 0x74, 0xd8, 0x45, 0xe1, 0x28, 0xe5,
-	0xc4, 0xaa, 0xc9, 0x89, 0xcb, 0x60, 0x78, 0x6a, 0x18, 0x7e, 0x08, 0xa0, 0xa6, 0xdf, 0x9c, 0x95,
-	0xc5, 0xbd, 0x32, 0x36, 0x93, 0x17, 0x4e, 0xb4, 0xe8, 0xe8, 0xd5, 0xdc, 0x6c, 0x17, 0x5a, 0x2c,
-	0xcc, 0x04, 0xf6, 0x21, 0x39, 0xbe, 0x4e, 0xc3, 0xdd, 0x9a, 0x27, 0xda, 0xdc, 0x9f, 0x2d, 0x58,
-	0x9c, 0xc3, 0xcf, 0x2f, 0x0c, 0xd3, 0x8b, 0xe5, 0xeb, 0x4c, 0x2f, 0xbe, 0x5d, 0x0f, 0x46, 0x53,
-	0x0f, 0x0c, 0x26, 0x62, 0xb5, 0xb8, 0x18, 0xa1, 0x68, 0x55, 0x42, 0x71, 0xde, 0x3c, 0x2a, 0xc0,
-	0x3e, 0x7b, 0xaf, 0x4b, 0xfe, 0xc2, 0xdc, 0x86, 0xa5, 0xda, 0x9f, 0x03, 0x6c, 0x9f, 0x4a, 0x7c,
-	0x86, 0x15, 0x8c, 0xbd, 0xf3, 0x1c, 0x11, 0x72, 0x3f, 0x86, 0xa5, 0x89, 0xfa, 0xcb, 0xb0, 0x63,
-	0x06, 0x4a, 0x73, 0x5d, 0xab, 0xba, 0xae, 0xfb, 0x25, 0xdc, 0x35, 0x6e, 0xc8, 0x89, 0xa7, 0x29,
-	0x3f, 0x3d, 0x05, 0x4f, 0xf2, 0xa7, 0x52, 0xc0, 0x6a, 0x83, 0x63, 0x25, 0x90, 0x9a, 0x49, 0xaf,
-	0x3b, 0xf8, 0x4f, 0xff, 0xe1, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x7c, 0xcc, 0x14, 0xf1, 0x03,
-	0x10, 0x00, 0x00,
+	// 4074 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xad, 0x1a, 0xcb, 0x72, 0xdb, 0xd6,
+	0x75, 0x48, 0x8a, 0xa4, 0x78, 0x28, 0x51, 0x12, 0xf4, 0x30, 0x63, 0x3b, 0x0f, 0xa3, 0x89, 0xe3,
+	0x38, 0x09, 0x9d, 0xda, 0x4d, 0xda, 0xb4, 0xd3, 0x87, 0x2c, 0xd9, 0x89, 0x52, 0x2b, 0x91, 0x21,
+	0xdb, 0xed, 0x34, 0x99, 0x61, 0x20, 0x12, 0x92, 0x50, 0x83, 0x00, 0x0d, 0x80, 0x7a, 0x64, 0xd1,
+	0x76, 0x91, 0xe9, 0xa2, 0x5d, 0x74, 0xd1, 0x8f, 0xe8, 0x3f, 0x74, 0xd3, 0x99, 0xce, 0xf4, 0x17,
+	0xba, 0x6c, 0xb7, 0x9d, 0xae, 0xdb, 0x5d, 0x17, 0x3d, 0x8f, 0x7b, 0x81, 0x0b, 0x8a, 0x94, 0x9c,
+	0xb4, 0x1b, 0x0e, 0xef, 0x39, 0xe7, 0xbe, 0xce, 0xfb, 0x9c, 0x0b, 0x58, 0x1b, 0xc6, 0x51, 0x1a,
+	0x25, 0xb7, 0xfa, 0x6e, 0xea, 0xf2, 0x4f, 0x87, 0x01, 0xf6, 0x1b, 0xd0, 0xfc, 0xb1, 0x77, 0xfa,
+	0xc4, 0x8b, 0x13, 0x3f, 0x0a, 0x13, 0xeb, 0x32, 0xcc, 0x1e, 0xa9, 0xff, 0xed, 0xd2, 0x2b, 0x95,
+	0x1b, 0x15, 0x27, 0x1b, 0xdb, 0xbf, 0xaa, 0x01, 0x7c, 0x1c, 0xf5, 0xbd, 0x4d, 0x2f, 0x75, 0xfd,
+	0xc0, 0x7a, 0x11, 0x60, 0x38, 0xda, 0x0b, 0xfc, 0x5e, 0xf7, 0xa9, 0x77, 0x8a, 0xc4, 0xa5, 0x1b,
+	0x0d, 0xa7, 0x21, 0x10, 0x5c, 0xd1, 0xba, 0x09, 0x4b, 0x03, 0x37, 0x49, 0xbd, 0xb8, 0x6b, 0x50,
+	0x95, 0x99, 0x6a, 0x41, 0x10, 0x3b, 0x19, 0xed, 0x15, 0x68, 0x84, 0xb8, 0x70, 0x37, 0x74, 0x07,
+	0x5e, 0xbb, 0xc2, 0x34, 0xb3, 0x04, 0xf8, 0x18, 0xc7, 0x96, 0x05, 0x33, 0x71, 0x14, 0x78, 0xed,
+	0x19, 0x86, 0xf3, 0x7f, 0xeb, 0x12, 0xd4, 0x07, 0xee, 0x49, 0xd7, 0x77, 0x83, 0x76, 0x15, 0xc1,
+	0x25, 0xa7, 0x86, 0xc3, 0x2d, 0x37, 0xd0, 0x08, 0x17, 0x11, 0xb5, 0x0c, 0xb1, 0x8e, 0x88, 0x65,
+	0x28, 0x0f, 0x9e, 0xb5, 0xeb, 0x78, 0xa5, 0xe6, 0xed, 0x4a, 0x67, 0xfb, 0xa1, 0x83, 0x43, 0x6b,
+	0x0d, 0x6a, 0x6e, 0x2f, 0xf5, 0x8f, 0xbc, 0xf6, 0x2c, 0x12, 0xcf, 0x3a, 0x6a, 0x64, 0xd9, 0x30,
+	0x8f, 0xdc, 0x39, 0x39, 0xed, 0xf2, 0xa9, 0xfc, 0x7e, 0xbb, 0xc1, 0x7b, 0x37, 0x19, 0x48, 0x2c,
+	0xd8, 0xea, 0x5b, 0xd7, 0x60, 0x4e, 0x68, 0x7a, 0x51, 0xb8, 0xef, 0x1f, 0xb4, 0xc1, 0x20, 0xd9,
+	0x60, 0x90, 0xf5, 0x19, 0xbc, 0x95, 0x8c, 0x86, 0xc3, 0x28, 0x4e, 0xbd, 0x7e, 0x37, 0xf6, 0x9e,
+	0x8d, 0xbc, 0x24, 0xed, 0x0e, 0xbc, 0x24, 0x71, 0x0f, 0xbc, 0x2e, 0xc9, 0xa0, 0x3b, 0x8a, 0x83,
+	0x6e, 0x7a, 0x3a, 0xf4, 0xba, 0x81, 0x9f, 0xa4, 0xed, 0x26, 0x9e, 0xae, 0xe1, 0x5c, 0xcf, 0xe6,
+	0x38, 0x32, 0x65, 0x5b, 0x66, 0x6c, 0xe2, 0x84, 0xc7, 0x71, 0xf0, 0x08, 0xc9, 0x1f, 0x20, 0x35,
+	0x1f, 0xd2, 0x8d, 0xbd, 0x30, 0xc5, 0x03, 0x0e, 0xe9, 0x90, 0x73, 0xea, 0x04, 0x0c, 0xdc, 0xea,
+	0x0f, 0xf1, 0x90, 0xdf, 0x82, 0xb5, 0xfc, 0x04, 0xfb, 0x9e, 0x9b, 0x8e, 0x62, 0xb5, 0xd7, 0x3c,
+	0xef, 0xb5, 0x92, 0x61, 0xef, 0x0b, 0x92, 0x57, 0xbe, 0x0d, 0xab, 0xbd, 0x18, 0xc7, 0x28, 0xf5,
+	0xee, 0x5e, 0x10, 0xf5, 0x9e, 0x76, 0x0f, 0x3d, 0xff, 0xe0, 0x30, 0x6d, 0xb7, 0x70, 0x87, 0x8a,
+	0xb3, 0xac, 0x91, 0x77, 0x09, 0xf7, 0x21, 0xa3, 0xac, 0x0e, 0x2c, 0x8f, 0xcd, 0x49, 0x7d, 0x14,
+	0xe6, 0x02, 0xcf, 0x58, 0x2a, 0xcc, 0x78, 0x84, 0x08, 0xeb, 0xdb, 0xd0, 0x0e, 0x50, 0x0b, 0xba,
+	0xa3, 0x21, 0x32, 0xc2, 0x2b, 0x6e, 0xb3, 0xc8, 0x93, 0x56, 0x09, 0xff, 0x98, 0xd1, 0xe6, 0x46,
+	0x77, 0x60, 0xed, 0xec, 0x44, 0xde, 0x6b, 0x49, 0x4e, 0x37, 0x36, 0x8d, 0x77, 0x7b, 0x07, 0x56,
+	0xdc, 0x7e, 0xdf, 0xa7, 0x23, 0xb8, 0x41, 0x97, 0x54, 0x48, 0xb8, 0x60, 0x31, 0x17, 0xac, 0x1c,
+	0xe7, 0x20, 0x8a, 0x79, 0xb0, 0x02, 0x55, 0x37, 0xf0, 0xdd, 0xa4, 0xbd, 0xcc, 0x5c, 0x95, 0x81,
+	0xfd, 0x39, 0x94, 0xb7, 0x1f, 0x5a, 0x2d, 0x28, 0xfb, 0x43, 0xa5, 0xf1, 0xf8, 0x8f, 0x34, 0x94,
+	0x98, 0xc8, 0xda, 0x5d, 0x71, 0xf8, 0x3f, 0x19, 0xd2, 0x30, 0xf6, 0xa3, 0xd8, 0x4f, 0x4f, 0x59,
+	0xa3, 0xd1, 0x90, 0xf4, 0x98, 0x70, 0x7e, 0xa8, 0x14, 0x6f, 0x86, 0x15, 0x2f, 0x1b, 0xdb, 0x36,
+	0xd4, 0xb7, 0xfa, 0x3b, 0x7c, 0x04, 0xd4, 0x65, 0xad, 0x7f, 0x25, 0x3e, 0x67, 0x2d, 0x64, 0xd5,
+	0xb3, 0xbf, 0x07, 0xf3, 0x64, 0x19, 0xc9, 0xd0, 0xed, 0xc9, 0x61, 0x6f, 0x02, 0x84, 0x1a, 0x20,
+	0x76, 0xdb, 0xbc, 0x0d, 0x9d, 0x8c, 0xc6, 0x31, 0xb0, 0xf6, 0x5f, 0xcb, 0xd0, 0xc8, 0x30, 0xd6,
+	0x55, 0xb4, 0x3c, 0x3d, 0xd0, 0x36, 0x9c, 0x01, 0xac, 0x57, 0xa0, 0xd9, 0xf7, 0x92, 0x5e, 0xec,
+	0x0f, 0x89, 0x3b, 0xca, 0x7a, 0x4d, 0x90, 0x61, 0x41, 0x95, 0x82, 0x05, 0x7d, 0x0a, 0x6f, 0xba,
+	0x41, 0x10, 0x1d, 0xa3, 0xda, 0xf9, 0x7d, 0x54, 0x47, 0x7f, 0xdf, 0x47, 0x4f, 0xd0, 0x8b, 0x46,
+	0xa4, 0xae, 0x21, 0x1a, 0xc3, 0xbe, 0x87, 0x5a, 0xda, 0xf3, 0xba, 0x07, 0x71, 0x34, 0x1a, 0x32,
+	0x17, 0xaa, 0xce, 0x75, 0x35, 0x65, 0x2b, 0x9b, 0xb1, 0x41, 0x13, 0xb6, 0x42, 0x47, 0x93, 0x7f,
+	0x40, 0xd4, 0xd6, 0x21, 0xdc, 0xd6, 0x8b, 0xcb, 0x76, 0xcf, 0xb5, 0x47, 0x95, 0xf7, 0x78, 0x4b,
+	0xcd, 0x5c, 0xe7, 0x89, 0x17, 0xed, 0x84, 0x4e, 0x4c, 0xef, 0x34, 0x20, 0x51, 0xb0, 0xd2, 0xd4,
+	0x90, 0xbf, 0x55, 0x67, 0x41, 0x21, 0xb6, 0x11, 0x4e, 0x42, 0xb0, 0x7f, 0x08, 0x4b, 0xbb, 0x5e,
+	0x7c, 0xe4, 0xf7, 0x94, 0x83, 0x54, 0x92, 0x99, 0x4d, 0x04, 0xa8, 0xe5, 0xd2, 0xea, 0x14, 0xa8,
+	0x9c, 0x0c, 0x6f, 0xff, 0xb1, 0x04, 0xf3, 0x05, 0x1c, 0xb9, 0x58, 0x85, 0x15, 0x25, 0x60, 0xf1,
+	0x28, 0x88, 0xb8, 0x20, 0x8d, 0x66, 0xcf, 0xa9, 0xe4, 0xa3, 0x60, 0xec, 0x3c, 0x5f, 0x46, 0x09,
+	0x92, 0xa3, 0x49, 0x7a, 0x87, 0xde, 0xc0, 0x55, 0xbe, 0x15, 0x08, 0xb4, 0xcb, 0x10, 0xb2, 0x5b,
+	0x83, 0xa0, 0xab, 0x9c, 0xbd, 0x72, 0xb6, 0x4b, 0x39, 0xa1, 0x8a, 0x10, 0x86, 0xc0, 0xab, 0xa6,
+	0xc0, 0xed, 0x1b, 0xd0, 0x5a, 0x1f, 0xa2, 0xf3, 0x3b, 0xf2, 0xd4, 0x15, 0x0c, 0xca, 0x52, 0x81,
+	0x72, 0x13, 0xae, 0x92, 0x4d, 0x7e, 0x32, 0x4a, 0xd9, 0x3e, 0x1d, 0xef, 0xc0, 0xa7, 0x68, 0x20,
+	0xa2, 0x40, 0xeb, 0x78, 0x15, 0x5a, 0x64, 0xce, 0xdd, 0x68, 0x94, 0x8a, 0x75, 0xf3, 0xfc, 0x8a,
+	0x33, 0x97, 0x1a, 0xb3, 0xec, 0x75, 0xb8, 0xbc, 0xed, 0x9e, 0x28, 0x0f, 0x49, 0xeb, 0x21, 0xf9,
+	0xbd, 0x93, 0xd4, 0x0b, 0xf9, 0x94, 0xdf, 0x80, 0x79, 0x0a, 0x03, 0x9e, 0x06, 0xe8, 0x25, 0x10,
+	0x98, 0x11, 0xd9, 0x11, 0xac, 0xa8, 0xf9, 0x24, 0xaa, 0x5d, 0xff, 0x0b, 0x94, 0xe3, 0xc0, 0x67,
+	0x0f, 0x43, 0x93, 0x99, 0x2d, 0xda, 0x6b, 0xb3, 0x56, 0xa9, 0x55, 0x96, 0x11, 0x4b, 0xce, 0x58,
+	0x4d, 0x66, 0xcd, 0x21, 0x6f, 0xcc, 0x11, 0x09, 0x5d, 0xb1, 0xd0, 0x8a, 0x33, 0x68, 0x52, 0x5c,
+	0xea, 0x0f, 0x99, 0xc6, 0xbe, 0x0d, 0x6b, 0x8e, 0x1b, 0xf6, 0xa3, 0x41, 0x88, 0x1e, 0xfd, 0xae,
+	0xe7, 0x62, 0xe4, 0x50, 0x91, 0xa2, 0x0d, 0x75, 0x2f, 0x74, 0xf7, 0x02, 0xaf, 0xaf, 0x98, 0xa5,
+	0x87, 0xf6, 0x4f, 0x61, 0x19, 0xf9, 0xfa, 0xa1, 0x9b, 0x1c, 0xb2, 0x1c, 0x3c, 0x35, 0x61, 0x1d,
+	0x16, 0x98, 0x9d, 0xe2, 0x70, 0x59, 0x2d, 0x45, 0xbd, 0xda, 0x9d, 0x02, 0xf9, 0x7a, 0x46, 0xe4,
+	0xb4, 0xf2, 0x09, 0xac, 0xaf, 0x9f, 0xc3, 0xa5, 0x29, 0xa4, 0x74, 0x1c, 0xad, 0x08, 0x72, 0x65,
+	0x3d, 0xb4, 0xde, 0x44, 0x83, 0xc8, 0xf7, 0x55, 0xfe, 0x5a, 0xae, 0xba, 0x98, 0x23, 0xc4, 0x55,
+	0xdb, 0x1b, 0x50, 0xdd, 0xa1, 0x70, 0x78, 0x36, 0x9e, 0x96, 0xce, 0xc6, 0x53, 0x54, 0x17, 0x15,
+	0x49, 0x45, 0x8d, 0xd5, 0xc8, 0xbe, 0x0e, 0xad, 0xbb, 0xde, 0xa1, 0x1f, 0xf6, 0x3f, 0x56, 0x86,
+	0x46, 0xae, 0x99, 0xd6, 0x49, 0x94, 0x57, 0x94, 0x81, 0xfd, 0xf7, 0x06, 0xd4, 0x95, 0x44, 0xc8,
+	0x6e, 0xb4, 0xe0, 0x72, 0xbb, 0x51, 0x10, 0xdc, 0x8a, 0x92, 0x04, 0x74, 0x10, 0x28, 0x2b, 0x75,
+	0xf4, 0x1a, 0x0e, 0x51, 0x4a, 0x1a, 0x41, 0xd9, 0x43, 0x45, 0x65, 0x0f, 0x7e, 0xb8, 0xae, 0xd2,
+	0x0a, 0x9a, 0x81, 0x88, 0x99, 0x0c, 0x41, 0xf9, 0xc6, 0xeb, 0xb0, 0xa0, 0x77, 0x4a, 0x45, 0x09,
+	0xd9, 0x2e, 0x2a, 0x4e, 0x2b, 0x2e, 0xa8, 0xa6, 0xf5, 0x12, 0x34, 0x25, 0x4c, 0xe7, 0x3e, 0x04,
+	0xcf, 0xe4, 0x53, 0x94, 0xe6, 0x4b, 0x7d, 0x07, 0x96, 0x0a, 0x0a, 0xc7, 0x54, 0x92, 0xae, 0xcc,
+	0x75, 0x0c, 0x6d, 0x73, 0x16, 0xfa, 0xf9, 0x80, 0x67, 0x62, 0x6c, 0x1b, 0xcf, 0x2d, 0x0e, 0x51,
+	0xa8, 0x9c, 0xd2, 0x60, 0x6c, 0x8b, 0x0b, 0x49, 0x04, 0x89, 0x1b, 0x6d, 0x7e, 0x3e, 0x46, 0x0f,
+	0x8f, 0x39, 0x9d, 0xf2, 0x68, 0x0d, 0xde, 0xa7, 0xd1, 0x71, 0x14, 0xd4, 0x99, 0xd3, 0x78, 0xde,
+	0x81, 0x44, 0x13, 0x44, 0x09, 0x2a, 0x27, 0x88, 0x25, 0xcb, 0x88, 0xd2, 0x36, 0xba, 0x74, 0x9f,
+	0x4c, 0x15, 0x93, 0x17, 0x0e, 0x64, 0x0c, 0x40, 0x2b, 0x25, 0x1d, 0x1a, 0x8e, 0xe2, 0x21, 0x12,
+	0xaa, 0xc4, 0x44, 0x0f, 0x49, 0x7e, 0xd1, 0x71, 0xe8, 0xc5, 0x98, 0x83, 0x70, 0x68, 0xe5, 0x01,
+	0x05, 0x51, 0x72, 0xb1, 0x9c, 0x63, 0x54, 0x1d, 0xfe, 0x4f, 0x1b, 0x8c, 0xf0, 0x8c, 0x62, 0x50,
+	0x92, 0x4a, 0xcc, 0x22, 0x40, 0x2c, 0x6e, 0x6a, 0x96, 0xb2, 0x38, 0x3d, 0x4b, 0x79, 0x01, 0x66,
+	0x7b, 0x87, 0x2e, 0xcb, 0x9e, 0xd3, 0x05, 0x3c, 0x15, 0x8f, 0x51, 0x29, 0x50, 0x67, 0xdc, 0x51,
+	0x1a, 0x75, 0xf9, 0x6e, 0x98, 0x18, 0xd0, 0x6d, 0x1a, 0x04, 0xd9, 0x20, 0x00, 0x29, 0xbe, 0x12,
+	0xb0, 0xe1, 0x55, 0x96, 0x45, 0xf1, 0xd3, 0x71, 0xf7, 0xb3, 0x01, 0x2f, 0x9d, 0x21, 0x2e, 0x9e,
+	0x71, 0x85, 0x67, 0x5e, 0x19, 0x9f, 0x69, 0x9e, 0x15, 0x7d, 0x18, 0x05, 0xda, 0xe8, 0xb8, 0xeb,
+	0x0e, 0x98, 0x01, 0xab, 0xac, 0x79, 0x73, 0x02, 0x5c, 0x67, 0x98, 0xf5, 0x3e, 0xbc, 0xa0, 0x88,
+	0x48, 0xbb, 0x32, 0xa9, 0x62, 0xaa, 0x81, 0xf1, 0x7c, 0x8d, 0x27, 0xac, 0x09, 0x01, 0xea, 0xb7,
+	0x16, 0xef, 0x0e, 0x61, 0xad, 0x5b, 0xb0, 0xa2, 0xd7, 0x4f, 0xc4, 0xd9, 0xc9, 0xac, 0x4b, 0x3c,
+	0x6b, 0x49, 0x6d, 0x93, 0x90, 0xee, 0xc9, 0x84, 0x29, 0x29, 0x5e, 0x7b, 0x5a, 0x8a, 0x87, 0x8a,
+	0x59, 0x38, 0x94, 0x36, 0x90, 0x17, 0x78, 0x82, 0xe5, 0xe7, 0x07, 0xd2, 0x46, 0xf2, 0x1a, 0xb4,
+	0x74, 0x92, 0x84, 0x72, 0x70, 0x93, 0xa4, 0x7d, 0x99, 0x85, 0x34, 0xaf, 0xa1, 0x1b, 0x04, 0xa4,
+	0xa8, 0x9c, 0x8c, 0xf6, 0x70, 0xe1, 0x5e, 0x14, 0xf7, 0x93, 0x6e, 0x32, 0x0c, 0xfc, 0xb4, 0x7d,
+	0x85, 0x25, 0xb6, 0x80, 0x08, 0x47, 0xe0, 0xbb, 0x04, 0xb6, 0xde, 0x80, 0x7a, 0x32, 0x1a, 0x0c,
+	0xdc, 0xf8, 0xb4, 0x7d, 0x15, 0x29, 0x9a, 0xb7, 0x17, 0x3a, 0xca, 0x78, 0x76, 0x05, 0xec, 0x68,
+	0xfc, 0xb9, 0x29, 0xe9, 0x8b, 0x5f, 0x2f, 0x25, 0x7d, 0xe9, 0xdc, 0x94, 0x74, 0xdc, 0x6c, 0x13,
+	0x37, 0x48, 0xdb, 0x2f, 0x4f, 0x32, 0xdb, 0x5d, 0xc4, 0xd8, 0x7f, 0x2e, 0x43, 0xab, 0x78, 0x76,
+	0xca, 0x00, 0xdc, 0x5e, 0xcf, 0x1b, 0x16, 0x03, 0x54, 0x53, 0x60, 0x62, 0x26, 0x48, 0x12, 0x7b,
+	0x3f, 0xf7, 0x7a, 0x69, 0x31, 0x2e, 0x09, 0x4c, 0x48, 0x30, 0x49, 0xf0, 0xe2, 0x38, 0x52, 0xb9,
+	0x93, 0x4a, 0x57, 0x81, 0x41, 0x42, 0xb0, 0x01, 0xcb, 0x89, 0x7f, 0x10, 0xa2, 0xa5, 0xeb, 0x7c,
+	0x83, 0xdd, 0xc6, 0x0c, 0xbb, 0x8d, 0x65, 0x9d, 0xd0, 0xec, 0x32, 0x09, 0xcf, 0x70, 0x96, 0x84,
+	0x5e, 0x61, 0xb4, 0x17, 0x49, 0x52, 0x2c, 0x32, 0x12, 0xf6, 0x90, 0xe8, 0xe0, 0x65, 0x74, 0x2e,
+	0xdb, 0x6b, 0x5f, 0x8f, 0xed, 0xf5, 0xa9, 0x6c, 0xb7, 0x9f, 0x80, 0x75, 0xf6, 0xb8, 0xcf, 0x93,
+	0x68, 0xc9, 0xfd, 0x0b, 0x3c, 0x4c, 0xf2, 0x15, 0xec, 0x3f, 0x94, 0xa1, 0x69, 0xb8, 0xe9, 0x8b,
+	0x56, 0xbc, 0x8a, 0xde, 0x26, 0xc9, 0xa2, 0x41, 0x99, 0xa3, 0xc1, 0xac, 0x9b, 0xa8, 0x60, 0xb0,
+	0x0a, 0x35, 0x8e, 0x43, 0x89, 0x92, 0x45, 0x95, 0xc2, 0x50, 0x42, 0x06, 0xa8, 0x55, 0x06, 0x8b,
+	0x3c, 0x77, 0x90, 0x88, 0xa3, 0x57, 0xb9, 0x9a, 0x42, 0xed, 0x30, 0x86, 0xfd, 0xfc, 0xdb, 0xb0,
+	0xec, 0x86, 0xc9, 0x31, 0x26, 0xb4, 0xfd, 0xae, 0xb1, 0x5b, 0x95, 0x77, 0x5b, 0xd4, 0xa8, 0x75,
+	0xbd, 0xeb, 0xbb, 0x70, 0x09, 0x4d, 0xca, 0xc3, 0x1c, 0xad, 0x2f, 0xfe, 0x60, 0x3f, 0x8e, 0x06,
+	0x66, 0xb8, 0x5a, 0xd1, 0x68, 0xba, 0xe8, 0x7d, 0x44, 0xf2, 0xb4, 0xb3, 0xa7, 0x62, 0x3d, 0xae,
+	0x4f, 0x38, 0x15, 0xab, 0xf1, 0x9f, 0xca, 0x30, 0xab, 0x0d, 0xdf, 0x5a, 0x84, 0x0a, 0x05, 0xd5,
+	0x12, 0xfb, 0x1c, 0xfa, 0x4b, 0x10, 0x8a, 0xbf, 0x65, 0x81, 0xe0, 0x5f, 0x43, 0x71, 0x2a, 0x05,
+	0xc5, 0xc1, 0xda, 0x85, 0x24, 0xc0, 0x75, 0xab, 0x62, 0x42, 0x0e, 0x20, 0x1e, 0xaa, 0xba, 0x58,
+	0xd4, 0xad, 0xca, 0xb1, 0x96, 0x42, 0xca, 0x11, 0xd6, 0x72, 0x7d, 0x8e, 0xe5, 0x35, 0x69, 0x35,
+	0x30, 0x40, 0x45, 0x73, 0x41, 0xe6, 0xeb, 0xca, 0x35, 0x5a, 0x0c, 0xde, 0xcd, 0x16, 0xc7, 0x38,
+	0x82, 0x56, 0xc9, 0xa5, 0xb7, 0x8a, 0xb3, 0x75, 0x1e, 0xe3, 0x06, 0x68, 0x4c, 0x64, 0x7e, 0x49,
+	0x82, 0xf6, 0x94, 0x75, 0x0e, 0x40, 0x83, 0x44, 0x99, 0x0a, 0x3a, 0x0e, 0xa2, 0x4c, 0x7b, 0x86,
+	0x66, 0xa3, 0xf2, 0x18, 0xda, 0xdc, 0x64, 0x82, 0xc6, 0x5e, 0xa6, 0xc3, 0xb7, 0x00, 0x1c, 0x8f,
+	0xaa, 0x4c, 0xe6, 0xff, 0x35, 0xa8, 0xc7, 0x3c, 0xd2, 0x15, 0x46, 0xbd, 0x23, 0x58, 0x47, 0xc3,
+	0xed, 0x8f, 0xa0, 0x26, 0x20, 0xe2, 0xe5, 0xc0, 0x4b, 0x0f, 0x23, 0xad, 0x92, 0x6a, 0x44, 0x31,
+	0x59, 0xbc, 0xbf, 0xf0, 0x5d, 0x06, 0x14, 0x93, 0x49, 0x11, 0x14, 0xdf, 0xf9, 0xbf, 0xfd, 0x9f,
+	0x12, 0xcc, 0xae, 0xab, 0xdb, 0x8c, 0x5f, 0xb6, 0x74, 0xe6, 0xb2, 0x18, 0xc4, 0x32, 0x02, 0x6a,
+	0x74, 0xa8, 0xe4, 0x6e, 0x4e, 0x03, 0xa9, 0x9b, 0x41, 0x1a, 0x94, 0x11, 0x19, 0xcd, 0x22, 0xd9,
+	0x75, 0x49, 0xa3, 0xf2, 0x76, 0x51, 0x5e, 0x59, 0xcc, 0x14, 0x8a, 0xce, 0x2c, 0xb1, 0xa8, 0x9a,
+	0x89, 0x45, 0x9b, 0xf8, 0x73, 0x14, 0x3d, 0xc5, 0xf4, 0xa5, 0x26, 0xb9, 0xb5, 0x1a, 0x4e, 0xcf,
+	0x20, 0xea, 0x53, 0x33, 0x08, 0xfb, 0x0d, 0x80, 0xed, 0xe4, 0xd9, 0xa6, 0x97, 0x30, 0xef, 0xaf,
+	0x98, 0xa9, 0x68, 0xf3, 0x76, 0xb5, 0x43, 0x49, 0xaa, 0xce, 0x48, 0xbf, 0x2c, 0xc1, 0x0c, 0x8d,
+	0x27, 0x28, 0xb9, 0x51, 0xda, 0xab, 0x6c, 0x37, 0xcc, 0xb2, 0xe0, 0x89, 0xf5, 0x34, 0x5e, 0x6d,
+	0xdf, 0x8f, 0xd9, 0xe7, 0x12, 0x58, 0x06, 0xc4, 0x5d, 0x9d, 0x67, 0x48, 0xa5, 0x54, 0xcd, 0x2b,
+	0xa5, 0x48, 0x57, 0x4a, 0x77, 0xa0, 0x69, 0xba, 0xe1, 0x57, 0xcf, 0x54, 0xa4, 0xb3, 0xda, 0x81,
+	0x1b, 0xb5, 0xe8, 0x6f, 0xca, 0x50, 0xd7, 0x85, 0xdc, 0x05, 0xae, 0xcc, 0xc8, 0x8d, 0xcb, 0x85,
+	0xdc, 0x78, 0x6a, 0x36, 0x3d, 0x4d, 0x7e, 0x64, 0xd0, 0xa3, 0x64, 0xe8, 0x85, 0x7d, 0xaf, 0xaf,
+	0xca, 0xcb, 0x1c, 0x80, 0x19, 0x72, 0x3b, 0xef, 0x65, 0x65, 0x3d, 0x0a, 0xd3, 0x3f, 0xe5, 0xbd,
+	0xae, 0x62, 0x7b, 0xe4, 0x07, 0x70, 0x35, 0x9f, 0x39, 0xa1, 0xef, 0x56, 0xe7, 0xd9, 0xf9, 0xea,
+	0x63, 0x9d, 0x36, 0xfb, 0x6d, 0x68, 0x65, 0x75, 0xb9, 0x96, 0xfb, 0x0c, 0x09, 0x2c, 0x33, 0xb8,
+	0xf5, 0x5d, 0x16, 0x3c, 0x03, 0xed, 0x2f, 0xcb, 0x50, 0x13, 0x40, 0xb1, 0x85, 0x63, 0xca, 0xf9,
+	0xab, 0x33, 0xad, 0x28, 0x85, 0x99, 0x71, 0x29, 0x9c, 0xc7, 0x9d, 0xea, 0xb9, 0xdc, 0xc9, 0xa5,
+	0x51, 0x2b, 0x48, 0xe3, 0x7f, 0xe5, 0xda, 0x35, 0x74, 0x3a, 0x17, 0x34, 0xb2, 0xae, 0x11, 0xa3,
+	0xce, 0x27, 0xb1, 0xa1, 0xbe, 0x1e, 0x04, 0xe7, 0xd3, 0xdc, 0x82, 0x05, 0xed, 0x91, 0xb6, 0x42,
+	0x69, 0xdc, 0xa0, 0x2a, 0x69, 0xbf, 0xa1, 0xeb, 0xc4, 0x1c, 0x60, 0x6f, 0x43, 0xf5, 0x11, 0x7a,
+	0x00, 0xe9, 0x66, 0x0c, 0xb2, 0xcc, 0x09, 0x99, 0x2d, 0x23, 0xeb, 0x2d, 0xb0, 0xb0, 0xf8, 0x3e,
+	0xf0, 0xe2, 0x2e, 0x3a, 0xf5, 0xf8, 0xb4, 0x10, 0xf6, 0x17, 0x05, 0x73, 0x8f, 0x10, 0x12, 0xfb,
+	0xf7, 0xc1, 0x52, 0x61, 0xff, 0x1e, 0x27, 0xcd, 0x92, 0x2e, 0xe3, 0x1a, 0x13, 0x72, 0x72, 0xd9,
+	0x67, 0xd1, 0x1f, 0xcf, 0xc6, 0xb1, 0x44, 0x2e, 0xa6, 0xe1, 0xa2, 0x16, 0x4d, 0x37, 0x4f, 0xc0,
+	0xed, 0xdf, 0x97, 0x60, 0x91, 0xcf, 0xfd, 0x20, 0x3f, 0x01, 0xf9, 0x68, 0x76, 0xac, 0xa2, 0x5f,
+	0xfc, 0xdf, 0xb8, 0x56, 0xb9, 0x70, 0x2d, 0x74, 0x85, 0x7b, 0x6e, 0xe0, 0x86, 0x3d, 0x4f, 0x29,
+	0x97, 0x1e, 0x9e, 0x09, 0x4a, 0x33, 0x67, 0x83, 0x12, 0x2e, 0x8a, 0xfe, 0x30, 0xc1, 0xb2, 0x47,
+	0xe5, 0x6f, 0x32, 0x42, 0x09, 0x01, 0x1f, 0x4a, 0xee, 0x91, 0x05, 0x92, 0x92, 0x11, 0x48, 0xec,
+	0x6f, 0xc2, 0xd2, 0x83, 0xe8, 0x98, 0xc9, 0x1e, 0x1d, 0x22, 0x47, 0x0e, 0xa3, 0x80, 0x72, 0xa0,
+	0x46, 0xaa, 0x07, 0x8a, 0x3c, 0x07, 0xd8, 0x3e, 0x25, 0xbb, 0x85, 0x66, 0xdc, 0x1d, 0x00, 0xe9,
+	0xf3, 0xa5, 0x7e, 0xe6, 0xbb, 0x96, 0x3b, 0xba, 0x6f, 0xc4, 0xbd, 0x3b, 0x26, 0x74, 0x0c, 0x32,
+	0xe4, 0xeb, 0x0c, 0xf2, 0x3a, 0xe1, 0x14, 0x8b, 0x9a, 0x6f, 0x5b, 0xfd, 0x1d, 0x83, 0x92, 0x71,
+	0xf6, 0xef, 0x4a, 0x30, 0x5f, 0x80, 0x4f, 0xb7, 0x5b, 0x5d, 0xa5, 0x96, 0xb9, 0x07, 0x28, 0x55,
+	0xea, 0xeb, 0xa6, 0xae, 0x55, 0x54, 0x29, 0xad, 0x15, 0xd2, 0x50, 0x3b, 0x1d, 0x07, 0x66, 0xf2,
+	0x38, 0x30, 0xad, 0x9b, 0x96, 0x80, 0x75, 0xf6, 0x5e, 0x17, 0x34, 0x6b, 0x31, 0x79, 0x31, 0xda,
+	0xa0, 0x9c, 0x19, 0x4a, 0x6c, 0x69, 0xe5, 0x60, 0x4e, 0x0b, 0xa7, 0xc4, 0x18, 0xfb, 0x35, 0x34,
+	0xa3, 0x62, 0x4f, 0x33, 0xbb, 0x6e, 0x29, 0xbf, 0xae, 0x7d, 0x0f, 0x6e, 0x6a, 0x32, 0x76, 0x59,
+	0xf7, 0xf1, 0x92, 0x63, 0x3d, 0xbc, 0xf5, 0xf4, 0x3e, 0xc5, 0x27, 0xa3, 0xa5, 0x92, 0xc7, 0x3f,
+	0xe5, 0xe8, 0xec, 0x63, 0xa8, 0x93, 0x8b, 0xa4, 0x78, 0xfe, 0x7f, 0x7c, 0x49, 0x1a, 0xd7, 0xe3,
+	0xca, 0x19, 0x3d, 0xb6, 0xff, 0x81, 0xd2, 0x26, 0x9b, 0xca, 0xb3, 0xb9, 0x42, 0x22, 0x59, 0x1a,
+	0x4f, 0x24, 0xa7, 0x74, 0x48, 0xcb, 0xd3, 0x3a, 0xa4, 0x17, 0x1f, 0x81, 0x92, 0x50, 0x5e, 0xd2,
+	0x48, 0xdf, 0x67, 0x09, 0xc0, 0xe2, 0xb9, 0xa9, 0x3a, 0x41, 0xbd, 0x28, 0x4c, 0x29, 0xc5, 0x64,
+	0xeb, 0x16, 0x93, 0xe3, 0xde, 0xcf, 0x86, 0xc0, 0x39, 0x73, 0x2a, 0x26, 0x8a, 0xb5, 0xf1, 0x44,
+	0x71, 0x07, 0xac, 0x0d, 0x72, 0x31, 0x58, 0x90, 0x51, 0xe6, 0x3e, 0x94, 0x84, 0xf1, 0xbb, 0xb0,
+	0xd8, 0x13, 0x68, 0x37, 0x16, 0xb0, 0xb6, 0xa6, 0x85, 0x4e, 0x91, 0xdc, 0x59, 0xe8, 0x15, 0xc6,
+	0x89, 0xfd, 0x0b, 0x68, 0x15, 0x49, 0xa6, 0x9b, 0x0a, 0x16, 0xb8, 0x63, 0xdb, 0x98, 0x4a, 0x69,
+	0x15, 0x57, 0xe6, 0x9b, 0x3f, 0x87, 0xf0, 0xfe, 0x5d, 0x02, 0xd8, 0xc5, 0xf4, 0x1f, 0xef, 0xe1,
+	0xf7, 0x12, 0xca, 0xe0, 0xb2, 0x0e, 0x2d, 0x25, 0x6b, 0x59, 0x85, 0xa6, 0x3a, 0xb5, 0x0a, 0xb9,
+	0x21, 0x38, 0xa9, 0xf5, 0x8c, 0xc2, 0x5b, 0xfa, 0x58, 0x05, 0xef, 0xae, 0x0b, 0x6f, 0xee, 0xfa,
+	0xa8, 0x19, 0x5c, 0x18, 0xe5, 0x4d, 0x3e, 0xee, 0x77, 0x15, 0x6a, 0xe5, 0x15, 0xa3, 0xd9, 0x47,
+	0xcd, 0x2f, 0x99, 0xf6, 0x11, 0x5c, 0xd2, 0x11, 0x3b, 0xc9, 0x8e, 0x6c, 0x56, 0xce, 0x56, 0x56,
+	0x39, 0x67, 0x68, 0x67, 0x35, 0x19, 0x07, 0x71, 0x30, 0xfd, 0x59, 0xf6, 0xb8, 0x60, 0xdc, 0xfe,
+	0x82, 0xc4, 0xec, 0x3a, 0x2c, 0x90, 0x16, 0x77, 0x95, 0x36, 0xe5, 0x77, 0x9c, 0x27, 0xf0, 0x26,
+	0xab, 0x12, 0x85, 0xaf, 0x87, 0xd0, 0x20, 0x4b, 0x7c, 0x38, 0x8a, 0x52, 0x57, 0x1e, 0x0c, 0xfc,
+	0xe0, 0x14, 0xcf, 0x39, 0xf0, 0x35, 0x1f, 0x81, 0x41, 0xd2, 0x1d, 0xa7, 0xd6, 0x3a, 0x6a, 0xe0,
+	0x61, 0x46, 0x52, 0x56, 0xad, 0x75, 0x01, 0x32, 0x91, 0xfd, 0x2f, 0xb4, 0xb1, 0x27, 0x54, 0x32,
+	0xb9, 0x69, 0x14, 0x73, 0x26, 0x74, 0x81, 0x8d, 0x4f, 0x4d, 0x88, 0x31, 0x8a, 0x0e, 0xfc, 0x84,
+	0xa4, 0x24, 0xaa, 0x61, 0xb2, 0x7d, 0x51, 0x30, 0x9c, 0xe6, 0x0a, 0xcb, 0x31, 0x0b, 0xda, 0x3b,
+	0xfd, 0xc2, 0x45, 0x27, 0x14, 0x7a, 0x5d, 0xef, 0x88, 0x1c, 0x5f, 0x4f, 0xf7, 0x0f, 0x25, 0xa4,
+	0xad, 0x65, 0xf8, 0x7b, 0x0a, 0xad, 0x5b, 0x1c, 0x2f, 0xb1, 0x46, 0xf6, 0x46, 0xfc, 0xa0, 0x34,
+	0x61, 0x4f, 0xc9, 0xad, 0xaf, 0x18, 0x54, 0xdb, 0x63, 0xdb, 0xdb, 0xef, 0xc1, 0x5a, 0x76, 0x6b,
+	0x42, 0x9e, 0x13, 0xeb, 0x2a, 0x66, 0xac, 0x7b, 0x1f, 0x16, 0x78, 0x95, 0x9f, 0xc4, 0x7e, 0xaa,
+	0x1e, 0x21, 0x50, 0x78, 0xf4, 0x9e, 0x70, 0x4c, 0x90, 0x82, 0x4e, 0xd3, 0x33, 0x03, 0xd3, 0xc9,
+	0x96, 0xbf, 0x2e, 0xc1, 0xf2, 0x7a, 0x9f, 0x72, 0x44, 0x7e, 0x7d, 0x71, 0x83, 0x9d, 0x08, 0x59,
+	0xca, 0xcd, 0xac, 0x68, 0xe8, 0xc5, 0x74, 0x12, 0xc3, 0x6d, 0xe6, 0x2f, 0x05, 0x0d, 0x67, 0x55,
+	0xe3, 0x33, 0xef, 0xc9, 0xde, 0xe1, 0x3d, 0x51, 0x76, 0x9f, 0x9b, 0x06, 0x6a, 0xcd, 0x82, 0xf6,
+	0xac, 0x6a, 0xb4, 0xde, 0x51, 0x0e, 0xf2, 0xcf, 0x32, 0xcc, 0xf3, 0x41, 0x76, 0xe2, 0x68, 0x18,
+	0x25, 0x18, 0xdc, 0x50, 0x95, 0x86, 0xea, 0xbf, 0x51, 0x1c, 0x6a, 0x90, 0x14, 0x3b, 0xaa, 0x18,
+	0x2d, 0x9f, 0x29, 0x46, 0xa9, 0x5f, 0xa0, 0x2a, 0x40, 0x19, 0x58, 0x9b, 0xf0, 0xb2, 0x9c, 0x87,
+	0x0c, 0x50, 0x5f, 0x8d, 0xee, 0x44, 0x5e, 0x25, 0x37, 0xab, 0x86, 0x73, 0x45, 0x93, 0x7d, 0xa2,
+	0xa8, 0xf0, 0x6a, 0xe4, 0x5f, 0xce, 0x7f, 0xdb, 0xae, 0x4e, 0xef, 0x1a, 0x5f, 0x86, 0x59, 0xef,
+	0x84, 0x64, 0x9e, 0x95, 0x90, 0xd9, 0x98, 0x5e, 0xd8, 0xe5, 0xff, 0x94, 0x22, 0x72, 0x25, 0xc3,
+	0x9a, 0x2b, 0x22, 0x6b, 0x50, 0xf6, 0xa3, 0x80, 0xdc, 0x48, 0x5f, 0xbe, 0x3e, 0x98, 0x77, 0x40,
+	0x40, 0x1b, 0xca, 0x5c, 0x14, 0x41, 0x10, 0x1d, 0xa8, 0x26, 0x42, 0x43, 0x20, 0x0f, 0xa2, 0x03,
+	0xfb, 0x53, 0x58, 0xfd, 0x00, 0x6f, 0x18, 0x87, 0x94, 0xbc, 0xd1, 0xc3, 0x4d, 0x14, 0x6e, 0x7a,
+	0x81, 0x7b, 0xca, 0xe6, 0x4b, 0x7f, 0x0a, 0x2f, 0x67, 0xc0, 0x20, 0xde, 0x5f, 0x3a, 0x86, 0x7c,
+	0xd8, 0x42, 0x2b, 0x4b, 0x60, 0x22, 0xc9, 0xbf, 0x60, 0x9a, 0x39, 0xbe, 0xfa, 0xb9, 0x8d, 0x03,
+	0x96, 0x55, 0xd9, 0x94, 0x95, 0x61, 0xce, 0x95, 0x82, 0x39, 0xd3, 0x07, 0x09, 0x18, 0x2d, 0xfb,
+	0xa3, 0x20, 0xb3, 0xae, 0x42, 0xc6, 0xb9, 0x92, 0x61, 0x4d, 0x76, 0x11, 0x93, 0xf7, 0xf7, 0x3d,
+	0x79, 0xeb, 0x9d, 0x20, 0xb5, 0x95, 0x0c, 0x6b, 0x96, 0xea, 0x4f, 0xa0, 0x81, 0x92, 0xdf, 0x38,
+	0x74, 0xc3, 0x03, 0xae, 0xc1, 0x73, 0xc7, 0x43, 0x7f, 0x29, 0x19, 0x46, 0xbe, 0x78, 0x24, 0xd4,
+	0xb2, 0xf4, 0x05, 0xd4, 0x90, 0x98, 0x8f, 0x6a, 0x3d, 0x52, 0xef, 0x28, 0x74, 0x81, 0x39, 0xa7,
+	0xc1, 0x10, 0x52, 0x23, 0xfb, 0x5d, 0x98, 0x97, 0x45, 0x3f, 0x8a, 0x46, 0xc8, 0xa3, 0x00, 0x4b,
+	0x6a, 0x7a, 0x45, 0x40, 0x40, 0xfe, 0xf6, 0x9e, 0x6d, 0xec, 0x68, 0x14, 0x4d, 0xe3, 0xd3, 0xf1,
+	0x3b, 0x9b, 0x3c, 0x74, 0xd6, 0xd3, 0x13, 0xf3, 0xed, 0xae, 0xd9, 0x79, 0x74, 0xa2, 0xb1, 0x4e,
+	0x2d, 0x3d, 0x61, 0xcf, 0x8f, 0xc9, 0x29, 0xe4, 0xe0, 0xa9, 0x72, 0x98, 0xea, 0x40, 0x31, 0x85,
+	0x63, 0x1d, 0xab, 0xb0, 0x8e, 0xf1, 0xff, 0xb1, 0xf7, 0xb1, 0x99, 0xf1, 0xf7, 0x31, 0xf2, 0xd5,
+	0x24, 0x46, 0xb9, 0x7f, 0x55, 0xf9, 0x6a, 0x82, 0xf0, 0xfd, 0x7f, 0x08, 0xcb, 0x99, 0x97, 0xdb,
+	0xc1, 0x3c, 0x30, 0x96, 0xae, 0x3c, 0x6e, 0xc4, 0xaf, 0xd0, 0xaa, 0x10, 0xa1, 0xff, 0xac, 0x1d,
+	0x44, 0xa1, 0xd4, 0x4c, 0x06, 0xf6, 0x6f, 0x4b, 0xb0, 0x52, 0x5c, 0x41, 0x39, 0xad, 0x3c, 0xdd,
+	0xe4, 0x25, 0x38, 0xbb, 0xa6, 0xe6, 0xf4, 0xb3, 0x11, 0xba, 0x10, 0x73, 0x21, 0x60, 0x10, 0x4f,
+	0xc5, 0x3a, 0x75, 0x91, 0x51, 0xf2, 0x62, 0x20, 0xfc, 0x94, 0x2c, 0x7c, 0xa5, 0x33, 0xe1, 0x9c,
+	0x4e, 0x6b, 0x98, 0xfd, 0x67, 0x06, 0xff, 0xcd, 0x3c, 0x0d, 0x7a, 0xed, 0x3d, 0xef, 0xd0, 0x3d,
+	0xf2, 0x23, 0x6e, 0x1c, 0xb9, 0xfd, 0x3e, 0x1a, 0x5d, 0xa2, 0x0e, 0xa4, 0x87, 0x63, 0xc1, 0xac,
+	0x3c, 0x1e, 0xcc, 0xe8, 0xe5, 0x46, 0xc7, 0x1e, 0xce, 0xde, 0xc4, 0x06, 0xe6, 0x34, 0x90, 0x53,
+	0x37, 0x4c, 0xd7, 0x33, 0xa2, 0x82, 0x09, 0xb4, 0x34, 0x58, 0x29, 0x3f, 0x3f, 0x31, 0xd2, 0x8b,
+	0x06, 0x5a, 0x4c, 0x41, 0xeb, 0x5b, 0x1a, 0x9c, 0x17, 0x68, 0x62, 0xc6, 0xaa, 0xaf, 0xa9, 0x46,
+	0xf6, 0x63, 0x68, 0x4f, 0xba, 0x1f, 0xbb, 0xc3, 0xf7, 0x61, 0x6e, 0x90, 0x83, 0xb4, 0xfe, 0xae,
+	0x76, 0x26, 0x4d, 0x70, 0x0a, 0xa4, 0x58, 0x44, 0xaf, 0xed, 0x78, 0x61, 0xdf, 0x0f, 0x0f, 0x32,
+	0x62, 0x69, 0xb6, 0x5f, 0x14, 0xeb, 0x27, 0x2b, 0xc5, 0x1e, 0x5c, 0x9e, 0xbc, 0x1c, 0x9f, 0x73,
+	0x13, 0x96, 0x8e, 0x34, 0x58, 0x35, 0xfc, 0xf5, 0x61, 0x2f, 0x75, 0x26, 0xcf, 0x73, 0x16, 0x8f,
+	0x8a, 0x80, 0xc4, 0x3e, 0x85, 0x39, 0x95, 0x45, 0x3d, 0xa6, 0x57, 0x15, 0x12, 0xd4, 0xa4, 0x07,
+	0xfe, 0xb9, 0xd8, 0x7c, 0xd9, 0x7f, 0xce, 0x34, 0x6a, 0xac, 0xa5, 0x5f, 0x29, 0xb6, 0xf4, 0xed,
+	0x6e, 0xf6, 0xb1, 0xc1, 0x4e, 0xe1, 0x2d, 0x6b, 0x92, 0xd5, 0xa8, 0x0f, 0x10, 0x30, 0xc8, 0x85,
+	0x63, 0x1f, 0x20, 0x94, 0xb3, 0x0f, 0x10, 0x30, 0xb6, 0x85, 0xe6, 0x07, 0x08, 0xf6, 0x67, 0xd0,
+	0x9e, 0xb4, 0x01, 0x73, 0xef, 0x47, 0x68, 0x22, 0x85, 0x77, 0x35, 0x2f, 0x97, 0xf4, 0xa4, 0x49,
+	0xce, 0x42, 0xe1, 0xc1, 0x0d, 0x39, 0xf7, 0x7d, 0x58, 0x78, 0x38, 0xf2, 0xe2, 0xd3, 0x27, 0x7e,
+	0xe2, 0xef, 0xf9, 0x01, 0x79, 0x22, 0xe3, 0xdb, 0x98, 0xfc, 0x83, 0x2a, 0x49, 0x2d, 0xf4, 0xb7,
+	0x31, 0xfa, 0x6b, 0x2a, 0xfb, 0x3e, 0x2c, 0xcb, 0xe3, 0x08, 0x55, 0x2e, 0xa8, 0x93, 0xca, 0xde,
+	0x6f, 0x41, 0x23, 0x1e, 0x99, 0x53, 0x29, 0x27, 0x2e, 0x10, 0x3a, 0x88, 0x76, 0x66, 0x89, 0x88,
+	0xd7, 0xf9, 0x14, 0x96, 0xce, 0xa0, 0x49, 0xdd, 0x28, 0x0d, 0x18, 0xc6, 0xde, 0xbe, 0x7f, 0xa2,
+	0xd5, 0x0d, 0x21, 0x3b, 0x0c, 0x10, 0xfb, 0x51, 0xf4, 0x2a, 0x2c, 0x96, 0xb5, 0xfd, 0x28, 0xb0,
+	0x34, 0x4a, 0x4f, 0xf5, 0xe2, 0xf2, 0xc4, 0x26, 0x8f, 0x0c, 0x53, 0xde, 0x50, 0x4a, 0x5f, 0xfd,
+	0x0d, 0xa5, 0x3c, 0xfd, 0x0d, 0x85, 0x3a, 0x3b, 0x4b, 0x7a, 0x5f, 0x2f, 0x4d, 0x03, 0x6f, 0x80,
+	0x07, 0xcb, 0xfb, 0xd9, 0x25, 0xb3, 0x9f, 0x3d, 0x5e, 0x25, 0x95, 0xcf, 0xd6, 0x97, 0xb7, 0x00,
+	0xa4, 0x6f, 0x65, 0x38, 0xc3, 0xc5, 0x4e, 0xbe, 0x32, 0x77, 0x8e, 0x9c, 0x06, 0xd3, 0xe8, 0x4f,
+	0x2a, 0x52, 0xcc, 0xfe, 0x75, 0x6f, 0x42, 0x06, 0xe4, 0xa7, 0x17, 0xc6, 0x26, 0x9d, 0xdb, 0x19,
+	0xe1, 0xcf, 0x34, 0xcb, 0xc6, 0x67, 0x9a, 0xc5, 0x02, 0xa5, 0x32, 0x5e, 0xa0, 0xe4, 0x6d, 0xaa,
+	0x99, 0x42, 0x9b, 0x0a, 0x4f, 0xc3, 0xa6, 0xab, 0x9a, 0x22, 0x32, 0xb0, 0x1f, 0xc0, 0x62, 0xd6,
+	0x54, 0xd1, 0xcf, 0x47, 0xf9, 0x23, 0x4f, 0xc9, 0x7c, 0xe4, 0xb9, 0x98, 0x45, 0xf6, 0x5d, 0x58,
+	0x42, 0xfd, 0x40, 0x4f, 0x36, 0x4a, 0x36, 0xe8, 0x0b, 0x00, 0x66, 0xc3, 0xdb, 0x00, 0xf2, 0x79,
+	0x80, 0xa1, 0x90, 0xad, 0x4e, 0x81, 0xce, 0x69, 0xf4, 0x34, 0x39, 0x45, 0x8e, 0xf9, 0x02, 0xb2,
+	0xf0, 0x7d, 0x41, 0xa9, 0xf8, 0x7d, 0x01, 0x16, 0x32, 0xfb, 0x3e, 0x7d, 0x7d, 0x38, 0xe1, 0x64,
+	0x8b, 0x8c, 0x31, 0x33, 0x9e, 0x57, 0xa1, 0x25, 0xd4, 0x98, 0xcb, 0xe6, 0x69, 0x08, 0xc6, 0x10,
+	0x86, 0xaa, 0xef, 0x76, 0xc8, 0x04, 0xb3, 0xd0, 0x90, 0xed, 0x2b, 0xe1, 0x3c, 0x8b, 0x19, 0x1b,
+	0x6a, 0x7f, 0x2e, 0x95, 0x15, 0xed, 0xa4, 0xc4, 0x57, 0x23, 0xcd, 0x0c, 0xea, 0x3e, 0xac, 0xdc,
+	0x0b, 0x15, 0x24, 0x8a, 0x9e, 0xde, 0x0f, 0xdc, 0x03, 0xf5, 0xe4, 0xd7, 0xd8, 0xc7, 0xff, 0x26,
+	0x9b, 0x96, 0x3a, 0xe3, 0x94, 0xce, 0xec, 0xbe, 0xa2, 0xb7, 0xd1, 0xff, 0x8c, 0x63, 0x27, 0x3a,
+	0x3e, 0xe3, 0x33, 0xa8, 0x72, 0xf1, 0x33, 0xa8, 0x5f, 0x42, 0x93, 0xca, 0x48, 0xea, 0x7d, 0x60,
+	0x54, 0x23, 0x0d, 0xf1, 0x06, 0x58, 0x93, 0x6a, 0xb1, 0xf3, 0xc0, 0xba, 0x01, 0x8b, 0xc7, 0xde,
+	0xde, 0x21, 0xee, 0xc0, 0xad, 0x6a, 0xb3, 0x05, 0xa6, 0xe0, 0x8f, 0xe3, 0x80, 0x19, 0xf7, 0x0e,
+	0xac, 0x48, 0x10, 0x19, 0xe3, 0x85, 0xd4, 0x95, 0x96, 0xc2, 0x99, 0xac, 0xd8, 0x92, 0x03, 0x6c,
+	0x6d, 0xae, 0xd3, 0x87, 0xa0, 0xd3, 0xcd, 0xe0, 0x62, 0xd5, 0xdb, 0xab, 0xf1, 0x97, 0xd7, 0x77,
+	0xfe, 0x0b, 0x19, 0xdd, 0x68, 0x94, 0x93, 0x2d, 0x00, 0x00,
 }
@@ -68,6 +68,14 @@ func TestRegisterServiceDestination(t *testing.T, caseID int64, expected string)
 		param.SupportedNamespaceList = append(param.SupportedNamespaceList, data.UserNamespace1)
 		nodeID = data.AS2
 		privK = data.AsPrivK2
+	case 3:
+		param.ServiceID = data.ServiceID2
+		param.MinAal = 1.1
+		param.MinIal = 1.2
+		param.SupportedNamespaceList = append(param.SupportedNamespaceList, data.UserNamespace1)
+		param.SupportedDataURLTypeList = []string{"application/json", "application/pdf"}
+		nodeID = data.AS2
+		privK = data.AsPrivK2
 	}
 	RegisterServiceDestination(t, nodeID, privK, param, expected)
 }
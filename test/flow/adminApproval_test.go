@@ -0,0 +1,80 @@
+package flow
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+// approveAdminProposalParam returns approval of operator key with signature by signer key
+func approveAdminProposalParam(proposalID, method string, params []byte, operatorKey, signer *rsa.PrivateKey) appV1.ApproveAdminProposalParam {
+	var param appV1.ApproveAdminProposalParam
+	param.ProposalID = proposalID
+	param.OperatorPublicKey = PublicKeyPEM(operatorKey)
+	param.Signature = utils.CreateSignature("ApproveAdminProposal", []byte(method+string(params)), proposalID, signer)
+	return param
+}
+
+// TestAdminApproval checks 2-of-3 approval of NDID operators to set node token
+func TestAdminApproval(t *testing.T) {
+	operatorKeys := make([]*rsa.PrivateKey, 4)
+	for i := range operatorKeys {
+		privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		operatorKeys[i] = privKey
+	}
+	op1, op2, op3, notOperator := operatorKeys[0], operatorKeys[1], operatorKeys[2], operatorKeys[3]
+	var policy appV1.AdminApprovalPolicyParam
+	policy.OperatorPublicKeyList = []string{PublicKeyPEM(op1), PublicKeyPEM(op2), PublicKeyPEM(op3)}
+	policy.RequiredApprovalCount = 2
+
+	setNodeToken := appV1.SetNodeTokenParam{NodeID: RP.NodeID, Amount: 500}
+	params, err := json.Marshal(setNodeToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposalID := "proposal_1"
+	approve := func(operatorKey, signer *rsa.PrivateKey) Step {
+		return Step{"ApproveAdminProposal", approveAdminProposalParam(proposalID, "SetNodeToken", params, operatorKey, signer), NDID}
+	}
+
+	app := newChain(t)
+	runCases(t, app, []txCase{
+		{"set policy", Step{"SetAdminApprovalPolicy", policy, NDID}, code.OK},
+		{"call method directly", Step{"SetNodeToken", setNodeToken, NDID}, code.MethodRequiresAdminApproval},
+		{"create proposal", Step{"CreateAdminProposal", appV1.CreateAdminProposalParam{ProposalID: proposalID, Method: "SetNodeToken", Params: params}, NDID}, code.OK},
+		{"approve with non-operator key", approve(notOperator, notOperator), code.NotAdminOperator},
+		{"approve with signature of other key", approve(op1, op2), code.VerifySignatureError},
+		{"first approval", approve(op1, op1), code.OK},
+	})
+
+	var pending appV1.GetPendingAdminProposalListResult
+	query(t, app, "GetPendingAdminProposalList", nil, &pending)
+	if len(pending.ProposalList) != 1 || pending.ProposalList[0].ApprovalCount != 1 {
+		t.Fatalf("got pending proposals %+v, want proposal with 1 approval", pending.ProposalList)
+	}
+	if token := nodeToken(t, app, RP.NodeID); token != 100 {
+		t.Errorf("got RP token %v before required approvals, want 100", token)
+	}
+
+	runCases(t, app, []txCase{
+		{"approve twice", approve(op1, op1), code.OperatorAlreadyApprovedAdminProposal},
+		{"second approval", approve(op2, op2), code.OK},
+		{"approve executed proposal", approve(op3, op3), code.AdminProposalIsAlreadyExecuted},
+	})
+
+	query(t, app, "GetPendingAdminProposalList", nil, &pending)
+	if len(pending.ProposalList) != 0 {
+		t.Errorf("got pending proposals %+v, want none", pending.ProposalList)
+	}
+	if token := nodeToken(t, app, RP.NodeID); token != 500 {
+		t.Errorf("got RP token %v after required approvals, want 500", token)
+	}
+}
@@ -0,0 +1,91 @@
+package flow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+func batchTx(method string, param interface{}) appV1.BatchTx {
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		panic(err)
+	}
+	return appV1.BatchTx{Method: method, Params: paramJSON}
+}
+
+func eventAttribute(result types.ResponseDeliverTx, key string) string {
+	for _, event := range result.Events {
+		for _, attribute := range event.Attributes {
+			if string(attribute.Key) == key {
+				return string(attribute.Value)
+			}
+		}
+	}
+	return ""
+}
+
+// TestBatch checks that sub-Txs of batch are applied together and
+// every sub-Tx is reverted when one of them fails
+func TestBatch(t *testing.T) {
+	requestID1 := NewRequestID()
+	requestID2 := NewRequestID()
+	tests := []struct {
+		name           string
+		txList         []appV1.BatchTx
+		wantCode       uint32
+		wantBatchIndex string
+		wantRequests   []string
+		wantToken      float64
+	}{
+		{
+			"every sub-Tx succeeds",
+			[]appV1.BatchTx{batchTx("CreateRequest", CreateRequestParam(requestID1)), batchTx("CreateRequest", CreateRequestParam(requestID2))},
+			code.OK, "", []string{requestID1, requestID2}, 98,
+		},
+		{
+			"second sub-Tx fails",
+			[]appV1.BatchTx{batchTx("CreateRequest", CreateRequestParam(requestID1)), batchTx("CreateRequest", CreateRequestParam(requestID1))},
+			code.DuplicateRequestID, "1", nil, 100,
+		},
+		{
+			"sub-Tx method is not allowed",
+			[]appV1.BatchTx{batchTx("CreateRequest", CreateRequestParam(requestID1)), batchTx("Batch", appV1.BatchParam{})},
+			code.MethodIsNotAllowedInBatch, "", nil, 100,
+		},
+		{
+			"empty Tx list",
+			[]appV1.BatchTx{},
+			code.BatchTxListCannotBeEmpty, "", nil, 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newChain(t)
+			result := app.DeliverTx("Batch", appV1.BatchParam{TxList: tt.txList}, RP.PrivKey, RP.NodeID)
+			if result.Code != tt.wantCode {
+				t.Fatalf("got code %d (%s), want %d", result.Code, result.Log, tt.wantCode)
+			}
+			if batchIndex := eventAttribute(result, "batch_index"); batchIndex != tt.wantBatchIndex {
+				t.Errorf("got batch index %q, want %q", batchIndex, tt.wantBatchIndex)
+			}
+			for _, requestID := range []string{requestID1, requestID2} {
+				retCode := query(t, app, "GetRequestDetail", appV1.GetRequestParam{RequestID: requestID}, nil)
+				wantRequest := false
+				for _, id := range tt.wantRequests {
+					wantRequest = wantRequest || id == requestID
+				}
+				if exist := retCode == code.OK; exist != wantRequest {
+					t.Errorf("got request %s exist %t, want %t", requestID, exist, wantRequest)
+				}
+			}
+			if token := nodeToken(t, app, RP.NodeID); token != tt.wantToken {
+				t.Errorf("got RP token %v, want %v", token, tt.wantToken)
+			}
+		})
+	}
+}
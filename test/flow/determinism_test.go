@@ -0,0 +1,88 @@
+package flow
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/client"
+	"github.com/ndidplatform/smart-contract/v4/test/harness"
+)
+
+// TestDeterminism checks that the same sequence of blocks gives the same DeliverTx results,
+// validator updates and app hash on two separate apps
+func TestDeterminism(t *testing.T) {
+	app := harness.NewApp()
+	replica := harness.NewApp()
+	nextBlock := func(steps ...Step) {
+		t.Helper()
+		txs := make([][]byte, 0, len(steps))
+		for _, s := range steps {
+			txs = append(txs, app.CreateTx(s.Method, s.Param, s.Signer.PrivKey, s.Signer.NodeID))
+		}
+		results := app.NextBlock(txs...)
+		replicaResults := replica.NextBlock(txs...)
+		if !reflect.DeepEqual(results, replicaResults) {
+			t.Fatalf("DeliverTx results at height %d differ:\n%v\n%v", app.Height, results, replicaResults)
+		}
+		if !reflect.DeepEqual(app.ValidatorUpdates, replica.ValidatorUpdates) {
+			t.Fatalf("validator updates at height %d differ:\n%v\n%v", app.Height, app.ValidatorUpdates, replica.ValidatorUpdates)
+		}
+		if !bytes.Equal(app.AppHash, replica.AppHash) {
+			t.Fatalf("app hash at height %d differs: %X / %X", app.Height, app.AppHash, replica.AppHash)
+		}
+	}
+
+	for _, s := range chainSetupSteps() {
+		nextBlock(s)
+	}
+	const validatorPublicKey = "AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0eHyA="
+	requestID1 := NewRequestID()
+	requestID2 := NewRequestID()
+	requestID3 := NewRequestID()
+	dataSignature, err := client.SignData([]byte("data_of_service"), AS.PrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	responseValidList := []appV1.ResponseValid{{IdpID: IdP.NodeID, ValidIal: BoolPtr(true), ValidSignature: BoolPtr(true)}}
+
+	nextBlock(
+		Step{"SetValidator", appV1.SetValidatorParam{PublicKey: validatorPublicKey, Power: 10}, NDID},
+		Step{"CreateRequest", CreateRequestParam(requestID1), RP},
+		Step{"CreateRequest", CreateRequestParam(requestID2), RP},
+		// Duplicate request ID fails
+		Step{"CreateRequest", CreateRequestParam(requestID1), RP},
+	)
+	nextBlock(
+		Step{"CreateIdpResponse", appV1.CreateIdpResponseParam{RequestID: requestID1, Ial: 2.3, Aal: 3, Status: "accept", Signature: "signature_of_request_message"}, IdP},
+		Step{"SetValidator", appV1.SetValidatorParam{PublicKey: validatorPublicKey, Power: 20, ActivationHeight: app.Height + 4}, NDID},
+	)
+	nextBlock(
+		Step{"SignData", appV1.SignDataParam{RequestID: requestID1, ServiceID: ServiceID, Signature: dataSignature}, AS},
+		Step{"Batch", appV1.BatchParam{TxList: []appV1.BatchTx{
+			batchTx("CreateRequest", CreateRequestParam(requestID3)),
+			batchTx("CreateRequest", CreateRequestParam(requestID3)),
+		}}, RP},
+	)
+	nextBlock(
+		Step{"CloseRequest", appV1.CloseRequestParam{RequestID: requestID1, ResponseValidList: responseValidList}, RP},
+		Step{"TimeOutRequest", appV1.TimeOutRequestParam{RequestID: requestID2}, RP},
+	)
+	// Staged validator update is applied at its activation height
+	nextBlock()
+	nextBlock(
+		Step{"SetValidator", appV1.SetValidatorParam{PublicKey: validatorPublicKey, Power: 0}, NDID},
+	)
+
+	if len(app.ValidatorUpdates) != 1 || app.ValidatorUpdates[0].Power != 0 {
+		t.Errorf("got validator updates %v of last block, want validator removed", app.ValidatorUpdates)
+	}
+	if detail := requestDetail(t, replica, requestID1); !detail.IsClosed {
+		t.Errorf("request %s is not closed on replica", requestID1)
+	}
+	if retCode := query(t, replica, "GetRequestDetail", appV1.GetRequestParam{RequestID: requestID3}, nil); retCode != code.ResultNotFound {
+		t.Errorf("got GetRequestDetail code %d of request of failed batch, want %d", retCode, code.ResultNotFound)
+	}
+}
@@ -0,0 +1,128 @@
+package flow
+
+import (
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/client"
+	"github.com/ndidplatform/smart-contract/v4/test/data"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+const (
+	escrowIdPResponsePrice = 5
+	escrowASDataPrice      = 3
+)
+
+// TestRequestEscrow checks that escrow of request is held at creation and
+// price of responses and data not given is refunded when request is closed or timed out.
+// Every Tx costs 1 token.
+func TestRequestEscrow(t *testing.T) {
+	idp2 := Signer{"idp2", utils.GetPrivateKeyFromString(data.IdpPrivK2)}
+	tests := []struct {
+		name       string
+		method     string
+		minIdp     int
+		idpAnswer  bool
+		asAnswer   bool
+		wantRefund float64
+	}{
+		{"close without answer", "CloseRequest", 1, false, false, escrowIdPResponsePrice + escrowASDataPrice},
+		{"close after IdP answered", "CloseRequest", 1, true, false, escrowASDataPrice},
+		{"close after IdP and AS answered", "CloseRequest", 1, true, true, 0},
+		{"close with fewer than min_idp responses", "CloseRequest", 2, true, true, escrowIdPResponsePrice},
+		{"time out without answer", "TimeOutRequest", 1, false, false, escrowIdPResponsePrice + escrowASDataPrice},
+		{"time out after IdP answered", "TimeOutRequest", 1, true, false, escrowASDataPrice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newChain(t)
+			requestID := NewRequestID()
+			request := CreateRequestParam(requestID)
+			request.MinIdp = tt.minIdp
+			request.IdPIDList = []string{IdP.NodeID, idp2.NodeID}
+			escrow := float64(tt.minIdp*escrowIdPResponsePrice + escrowASDataPrice)
+			runCases(t, app, []txCase{
+				{"register second IdP", Step{"RegisterNode", registerNode(idp2, "IdP"), NDID}, code.OK},
+				{"set escrow price", Step{"SetRequestEscrowPrice", appV1.RequestEscrowPriceParam{IdPResponsePrice: escrowIdPResponsePrice, ASDataPrice: escrowASDataPrice}, NDID}, code.OK},
+				{"create request", Step{"CreateRequest", request, RP}, code.OK},
+			})
+			if token := nodeToken(t, app, RP.NodeID); token != 100-1-escrow {
+				t.Fatalf("got RP token %v after request creation, want fee and escrow %v taken", token, escrow)
+			}
+
+			var responseValidList []appV1.ResponseValid
+			if tt.idpAnswer {
+				runCases(t, app, []txCase{
+					{"IdP response", Step{"CreateIdpResponse", appV1.CreateIdpResponseParam{RequestID: requestID, Ial: 2.3, Aal: 3, Status: "accept", Signature: "signature_of_request_message"}, IdP}, code.OK},
+				})
+				responseValidList = []appV1.ResponseValid{{IdpID: IdP.NodeID, ValidIal: BoolPtr(true), ValidSignature: BoolPtr(true)}}
+			}
+			if tt.asAnswer {
+				dataSignature, err := client.SignData([]byte("data_of_service"), AS.PrivKey)
+				if err != nil {
+					t.Fatal(err)
+				}
+				runCases(t, app, []txCase{
+					{"sign data", Step{"SignData", appV1.SignDataParam{RequestID: requestID, ServiceID: ServiceID, Signature: dataSignature}, AS}, code.OK},
+				})
+			}
+			var finishParam interface{} = appV1.CloseRequestParam{RequestID: requestID, ResponseValidList: responseValidList}
+			if tt.method == "TimeOutRequest" {
+				finishParam = appV1.TimeOutRequestParam{RequestID: requestID, ResponseValidList: responseValidList}
+			}
+			runCases(t, app, []txCase{
+				{tt.name, Step{tt.method, finishParam, RP}, code.OK},
+			})
+			want := 100 - 2 - escrow + tt.wantRefund
+			if token := nodeToken(t, app, RP.NodeID); token != want {
+				t.Errorf("got RP token %v after %s, want %v", token, tt.method, want)
+			}
+		})
+	}
+}
+
+func TestRequestEscrowNotEnoughToken(t *testing.T) {
+	app := newChain(t)
+	requestID := NewRequestID()
+	runCases(t, app, []txCase{
+		{"set escrow price", Step{"SetRequestEscrowPrice", appV1.RequestEscrowPriceParam{IdPResponsePrice: escrowIdPResponsePrice, ASDataPrice: escrowASDataPrice}, NDID}, code.OK},
+		// Enough for fee but not for fee and escrow
+		{"set RP token", Step{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: RP.NodeID, Amount: 5}, NDID}, code.OK},
+		{"create request", Step{"CreateRequest", CreateRequestParam(requestID), RP}, code.TokenNotEnough},
+	})
+	if token := nodeToken(t, app, RP.NodeID); token != 5 {
+		t.Errorf("got RP token %v, want 5", token)
+	}
+	if retCode := query(t, app, "GetRequestDetail", appV1.GetRequestParam{RequestID: requestID}, nil); retCode != code.ResultNotFound {
+		t.Errorf("got GetRequestDetail code %d, want %d", retCode, code.ResultNotFound)
+	}
+}
+
+// TestRequestEscrowFeeNotEnoughAfterBatch checks that requests of batch are discarded
+// when escrow held by the batch leaves too little token for its fee
+func TestRequestEscrowFeeNotEnoughAfterBatch(t *testing.T) {
+	app := newChain(t)
+	requestID1 := NewRequestID()
+	requestID2 := NewRequestID()
+	// Enough for fee and escrow of each request alone, 1 short for both with fee of batch
+	const rpToken = 2*(escrowIdPResponsePrice+escrowASDataPrice) + 1
+	batch := appV1.BatchParam{TxList: []appV1.BatchTx{
+		batchTx("CreateRequest", CreateRequestParam(requestID1)),
+		batchTx("CreateRequest", CreateRequestParam(requestID2)),
+	}}
+	runCases(t, app, []txCase{
+		{"set escrow price", Step{"SetRequestEscrowPrice", appV1.RequestEscrowPriceParam{IdPResponsePrice: escrowIdPResponsePrice, ASDataPrice: escrowASDataPrice}, NDID}, code.OK},
+		{"set RP token", Step{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: RP.NodeID, Amount: rpToken}, NDID}, code.OK},
+		{"create requests in batch", Step{"Batch", batch, RP}, code.TokenNotEnough},
+	})
+	if token := nodeToken(t, app, RP.NodeID); token != rpToken {
+		t.Errorf("got RP token %v, want %v", token, rpToken)
+	}
+	for _, requestID := range []string{requestID1, requestID2} {
+		if retCode := query(t, app, "GetRequestDetail", appV1.GetRequestParam{RequestID: requestID}, nil); retCode != code.ResultNotFound {
+			t.Errorf("got GetRequestDetail code %d of request %s, want %d", retCode, requestID, code.ResultNotFound)
+		}
+	}
+}
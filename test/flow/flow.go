@@ -0,0 +1,173 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Package flow tests transaction flows end-to-end on in-process ABCI app (see test/harness).
+// NewChain bootstraps a chain with NDID, RP, IdP and AS nodes, namespace and service
+// which tests of this package start from.
+package flow
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/test/data"
+	"github.com/ndidplatform/smart-contract/v4/test/harness"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+const (
+	Namespace = "citizen_id"
+	ServiceID = "bank_statement"
+)
+
+// Signer is node ID and private key which signs Tx of node
+type Signer struct {
+	NodeID  string
+	PrivKey *rsa.PrivateKey
+}
+
+var (
+	NDID      = Signer{"ndid1", utils.GetPrivateKeyFromString(data.NdidPrivK)}
+	RP        = Signer{"rp1", utils.GetPrivateKeyFromString(data.AsPrivK2)}
+	IdP       = Signer{"idp1", utils.GetPrivateKeyFromString(data.IdpPrivK1)}
+	AS        = Signer{"as1", utils.GetPrivateKeyFromString(data.AsPrivK1)}
+	MasterKey = utils.GetPrivateKeyFromString(data.AllMasterKey)
+)
+
+// Step is Tx of method signed by signer
+type Step struct {
+	Method string
+	Param  interface{}
+	Signer Signer
+}
+
+// NewRequestID returns new request ID in UUID version 4 format
+func NewRequestID() string {
+	return uuid.NewV4().String()
+}
+
+// CreateRequestParam returns param of mode 1 request to IdP for data of service from AS
+func CreateRequestParam(requestID string) appV1.CreateRequestParam {
+	return appV1.CreateRequestParam{
+		RequestID:   requestID,
+		MinIdp:      1,
+		MinAal:      1,
+		MinIal:      1.1,
+		Timeout:     3600,
+		IdPIDList:   []string{IdP.NodeID},
+		MessageHash: "hash_of_request_message",
+		Mode:        1,
+		DataRequestList: []appV1.DataRequest{
+			{ServiceID: ServiceID, As: []string{AS.NodeID}, Count: 1, RequestParamsHash: "hash_of_request_params"},
+		},
+	}
+}
+
+// BoolPtr returns pointer to value
+func BoolPtr(value bool) *bool {
+	return &value
+}
+
+// PublicKeyPEM returns PEM encoded public key of private key
+func PublicKeyPEM(privKey *rsa.PrivateKey) string {
+	publicKey, err := utils.GeneratePublicKey(&privKey.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+	return string(publicKey)
+}
+
+func registerNode(s Signer, role string) appV1.RegisterNode {
+	var param appV1.RegisterNode
+	param.NodeID = s.NodeID
+	param.PublicKey = PublicKeyPEM(s.PrivKey)
+	param.MasterPublicKey = PublicKeyPEM(MasterKey)
+	param.NodeName = s.NodeID
+	param.Role = role
+	if role == "IdP" {
+		param.MaxIal = 3
+		param.MaxAal = 3
+	}
+	return param
+}
+
+// NewChain returns app on which NDID is initialized, RP, IdP and AS are registered with
+// 100 tokens each and message queue address, namespace is added and AS serves service
+func NewChain() (*harness.App, error) {
+	app := harness.NewApp()
+	err := Run(app, chainSetupSteps())
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// chainSetupSteps returns steps which NewChain runs
+func chainSetupSteps() []Step {
+	return []Step{
+		{"InitNDID", appV1.InitNDIDParam{NodeID: NDID.NodeID, PublicKey: PublicKeyPEM(NDID.PrivKey), MasterPublicKey: PublicKeyPEM(MasterKey)}, NDID},
+		{"SetAllowedMinIalForRegisterIdentityAtFirstIdp", appV1.SetAllowedMinIalForRegisterIdentityAtFirstIdpParam{MinIal: 2.3}, NDID},
+		{"SetTimeOutBlockRegisterIdentity", appV1.TimeOutBlockRegisterIdentity{TimeOutBlock: 100}, NDID},
+		{"EndInit", appV1.EndInitParam{}, NDID},
+		{"RegisterNode", registerNode(RP, "RP"), NDID},
+		{"RegisterNode", registerNode(IdP, "IdP"), NDID},
+		{"RegisterNode", registerNode(AS, "AS"), NDID},
+		{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: RP.NodeID, Amount: 100}, NDID},
+		{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: IdP.NodeID, Amount: 100}, NDID},
+		{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: AS.NodeID, Amount: 100}, NDID},
+		{"AddNamespace", appV1.Namespace{Namespace: Namespace, Description: "Citizen ID"}, NDID},
+		{"AddService", appV1.AddServiceParam{ServiceID: ServiceID, ServiceName: "Bank statement", DataSchema: "n/a", DataSchemaVersion: "n/a"}, NDID},
+		{"RegisterServiceDestinationByNDID", appV1.RegisterServiceDestinationByNDIDParam{ServiceID: ServiceID, NodeID: AS.NodeID}, NDID},
+		{"RegisterServiceDestination", appV1.RegisterServiceDestinationParam{ServiceID: ServiceID, MinIal: 1.1, MinAal: 1, SupportedNamespaceList: []string{Namespace}}, AS},
+		{"SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "192.0.2.1", Port: 8000}}}, RP},
+		{"SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "192.0.2.2", Port: 8000}}}, IdP},
+		{"SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "192.0.2.3", Port: 8000}}}, AS},
+	}
+}
+
+// Run delivers steps, one Tx per block. Every Tx must succeed.
+func Run(app *harness.App, steps []Step) error {
+	for i, s := range steps {
+		result := app.DeliverTx(s.Method, s.Param, s.Signer.PrivKey, s.Signer.NodeID)
+		if result.Code != 0 {
+			return fmt.Errorf("Step %d (%s) failed with code %d: %s", i+1, s.Method, result.Code, result.Log)
+		}
+	}
+	return nil
+}
+
+// Query queries method at latest height and unmarshals result into v.
+// It returns code of query.
+func Query(app *harness.App, method string, param interface{}, v interface{}) (uint32, error) {
+	res := app.Query(method, param)
+	if v != nil && len(res.Value) > 0 {
+		err := json.Unmarshal(res.Value, v)
+		if err != nil {
+			return res.Code, err
+		}
+	}
+	return res.Code, nil
+}
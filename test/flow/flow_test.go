@@ -0,0 +1,82 @@
+package flow
+
+import (
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/test/harness"
+)
+
+// txCase is Tx delivered in its own block and code it must return
+type txCase struct {
+	name     string
+	step     Step
+	wantCode uint32
+}
+
+// runCases delivers cases in order and stops at first unexpected code
+// since later cases depend on state left by earlier ones
+func runCases(t *testing.T, app *harness.App, cases []txCase) {
+	t.Helper()
+	for _, tc := range cases {
+		result := app.DeliverTx(tc.step.Method, tc.step.Param, tc.step.Signer.PrivKey, tc.step.Signer.NodeID)
+		if result.Code != tc.wantCode {
+			t.Fatalf("%s: %s returned code %d (%s), want %d", tc.name, tc.step.Method, result.Code, result.Log, tc.wantCode)
+		}
+	}
+}
+
+func newChain(t *testing.T) *harness.App {
+	t.Helper()
+	app, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return app
+}
+
+func query(t *testing.T, app *harness.App, method string, param interface{}, v interface{}) uint32 {
+	t.Helper()
+	retCode, err := Query(app, method, param, v)
+	if err != nil {
+		t.Fatalf("%s: %s", method, err)
+	}
+	return retCode
+}
+
+func nodeToken(t *testing.T, app *harness.App, nodeID string) float64 {
+	t.Helper()
+	var res appV1.GetNodeTokenResult
+	if retCode := query(t, app, "GetNodeToken", appV1.GetNodeTokenParam{NodeID: nodeID}, &res); retCode != 0 {
+		t.Fatalf("GetNodeToken of %s returned code %d", nodeID, retCode)
+	}
+	return res.Amount
+}
+
+func nodeActive(t *testing.T, app *harness.App, nodeID string) bool {
+	t.Helper()
+	var res appV1.GetNodeInfoResult
+	if retCode := query(t, app, "GetNodeInfo", appV1.GetNodeInfoParam{NodeID: nodeID}, &res); retCode != 0 {
+		t.Fatalf("GetNodeInfo of %s returned code %d", nodeID, retCode)
+	}
+	return res.Active
+}
+
+func requestDetail(t *testing.T, app *harness.App, requestID string) appV1.GetRequestDetailResult {
+	t.Helper()
+	var res appV1.GetRequestDetailResult
+	if retCode := query(t, app, "GetRequestDetail", appV1.GetRequestParam{RequestID: requestID}, &res); retCode != 0 {
+		t.Fatalf("GetRequestDetail of %s returned code %d", requestID, retCode)
+	}
+	return res
+}
+
+func identityExists(t *testing.T, app *harness.App, identifierHash string) bool {
+	t.Helper()
+	var res appV1.CheckExistingIdentityResult
+	param := appV1.CheckExistingIdentityParam{IdentityNamespace: Namespace, IdentityIdentifierHash: identifierHash}
+	if retCode := query(t, app, "CheckExistingIdentity", param, &res); retCode != 0 {
+		t.Fatalf("CheckExistingIdentity returned code %d", retCode)
+	}
+	return res.Exist
+}
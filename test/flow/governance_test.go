@@ -0,0 +1,91 @@
+package flow
+
+import (
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/test/harness"
+)
+
+const governanceActionDelay = 2
+
+func pendingGovernanceActions(t *testing.T, app *harness.App) []appV1.GovernanceAction {
+	t.Helper()
+	var res appV1.GetPendingGovernanceActionListResult
+	if retCode := query(t, app, "GetPendingGovernanceActionList", nil, &res); retCode != code.OK {
+		t.Fatalf("GetPendingGovernanceActionList returned code %d", retCode)
+	}
+	return res.ActionList
+}
+
+// TestGovernanceTimeLock checks that time locked method is applied only after delay
+// and is not applied when it is cancelled
+func TestGovernanceTimeLock(t *testing.T) {
+	tests := []struct {
+		name       string
+		cancel     bool
+		wantActive bool
+	}{
+		{"applied after delay", false, false},
+		{"cancelled", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newChain(t)
+			runCases(t, app, []txCase{
+				{"set delay", Step{"SetGovernanceActionDelay", appV1.GovernanceActionDelayParam{DelayBlock: governanceActionDelay}, NDID}, code.OK},
+				{"disable node", Step{"DisableNode", appV1.DisableNodeParam{NodeID: AS.NodeID}, NDID}, code.OK},
+			})
+			scheduledHeight := app.Height
+			actions := pendingGovernanceActions(t, app)
+			if len(actions) != 1 || actions[0].Method != "DisableNode" || actions[0].EffectiveBlockHeight != scheduledHeight+governanceActionDelay {
+				t.Fatalf("got pending actions %+v, want DisableNode effective at %d", actions, scheduledHeight+governanceActionDelay)
+			}
+			if !nodeActive(t, app, AS.NodeID) {
+				t.Fatal("node is disabled before delay")
+			}
+			if tt.cancel {
+				runCases(t, app, []txCase{
+					{"cancel action", Step{"CancelGovernanceAction", appV1.GovernanceActionIDParam{ActionID: actions[0].ActionID}, NDID}, code.OK},
+					{"cancel cancelled action", Step{"CancelGovernanceAction", appV1.GovernanceActionIDParam{ActionID: actions[0].ActionID}, NDID}, code.GovernanceActionNotFound},
+				})
+			}
+			app.AdvanceBlocks(governanceActionDelay)
+			if active := nodeActive(t, app, AS.NodeID); active != tt.wantActive {
+				t.Errorf("got node active %t after delay, want %t", active, tt.wantActive)
+			}
+			if actions := pendingGovernanceActions(t, app); len(actions) != 0 {
+				t.Errorf("got pending actions %+v after delay, want none", actions)
+			}
+		})
+	}
+}
+
+// TestGovernanceTimeLockInBatch checks that time locked method cannot skip delay as sub-Tx of batch
+func TestGovernanceTimeLockInBatch(t *testing.T) {
+	app := newChain(t)
+	batch := appV1.BatchParam{TxList: []appV1.BatchTx{batchTx("DisableService", appV1.DisableServiceParam{ServiceID: ServiceID})}}
+	runCases(t, app, []txCase{
+		{"set delay", Step{"SetGovernanceActionDelay", appV1.GovernanceActionDelayParam{DelayBlock: governanceActionDelay}, NDID}, code.OK},
+		{"disable service in batch", Step{"Batch", batch, NDID}, code.OK},
+	})
+	actions := pendingGovernanceActions(t, app)
+	if len(actions) != 1 || actions[0].Method != "DisableService" {
+		t.Fatalf("got pending actions %+v, want DisableService", actions)
+	}
+	serviceActive := func() bool {
+		var res appV1.ServiceDetail
+		if retCode := query(t, app, "GetServiceDetail", appV1.GetServiceDetailParam{ServiceID: ServiceID}, &res); retCode != code.OK {
+			t.Fatalf("GetServiceDetail returned code %d", retCode)
+		}
+		return res.Active
+	}
+	if !serviceActive() {
+		t.Fatal("service is disabled before delay")
+	}
+	app.AdvanceBlocks(governanceActionDelay)
+	if serviceActive() {
+		t.Error("service is active after delay")
+	}
+}
@@ -0,0 +1,65 @@
+package flow
+
+import (
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/test/data"
+)
+
+func registerIdentityAndCreateIdpResponseParam(requestID, referenceGroupCode, identifierHash string, responseIal float64) appV1.RegisterIdentityAndCreateIdpResponseParam {
+	var param appV1.RegisterIdentityAndCreateIdpResponseParam
+	param.RegisterIdentity = appV1.RegisterIdentityParam{
+		ReferenceGroupCode: referenceGroupCode,
+		NewIdentityList:    []appV1.Identity{{IdentityNamespace: Namespace, IdentityIdentifierHash: identifierHash}},
+		Ial:                2.3,
+		ModeList:           []int32{2, 3},
+		AccessorID:         NewRequestID(),
+		AccessorPublicKey:  data.AccessorPubKey1,
+		AccessorType:       "RSA2048",
+		RequestID:          requestID,
+	}
+	param.CreateIdpResponse = appV1.CreateIdpResponseParam{
+		RequestID: requestID,
+		Ial:       responseIal,
+		Aal:       3,
+		Status:    "accept",
+		Signature: "signature_of_request_message",
+	}
+	return param
+}
+
+// TestRegisterIdentityAndCreateIdpResponse checks that identity is registered together with
+// IdP response and neither is kept when one of them fails
+func TestRegisterIdentityAndCreateIdpResponse(t *testing.T) {
+	tests := []struct {
+		name               string
+		referenceGroupCode string
+		responseIal        float64
+		wantCode           uint32
+		wantIdentity       bool
+		wantResponseCount  int
+	}{
+		{"registration and response", "ref_group_1", 2.3, code.OK, true, 1},
+		{"registration fails", "", 2.3, code.RefGroupCodeCannotBeEmpty, false, 0},
+		{"response fails after registration", "ref_group_1", 4, code.IALError, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newChain(t)
+			requestID := NewRequestID()
+			identifierHash := "hash_of_identifier_of_" + requestID
+			runCases(t, app, []txCase{
+				{"create request", Step{"CreateRequest", CreateRequestParam(requestID), RP}, code.OK},
+				{tt.name, Step{"RegisterIdentityAndCreateIdpResponse", registerIdentityAndCreateIdpResponseParam(requestID, tt.referenceGroupCode, identifierHash, tt.responseIal), IdP}, tt.wantCode},
+			})
+			if exist := identityExists(t, app, identifierHash); exist != tt.wantIdentity {
+				t.Errorf("got identity exist %t, want %t", exist, tt.wantIdentity)
+			}
+			if responses := requestDetail(t, app, requestID).Responses; len(responses) != tt.wantResponseCount {
+				t.Errorf("got %d responses, want %d", len(responses), tt.wantResponseCount)
+			}
+		})
+	}
+}
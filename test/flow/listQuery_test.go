@@ -0,0 +1,54 @@
+package flow
+
+import (
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/test/data"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+// TestListQueryEmptyResult checks that list queries return empty list with success code
+// when service or node exists but every item is filtered out, and not found only
+// when service or node does not exist
+func TestListQueryEmptyResult(t *testing.T) {
+	proxy := Signer{"proxy1", utils.GetPrivateKeyFromString(data.IdpPrivK2)}
+	app := newChain(t)
+	runCases(t, app, []txCase{
+		{"disable service destination", Step{"DisableServiceDestination", appV1.DisableServiceDestinationParam{ServiceID: ServiceID}, AS}, code.OK},
+		{"register proxy node", Step{"RegisterNode", registerNode(proxy, "Proxy"), NDID}, code.OK},
+		{"add RP to proxy node", Step{"AddNodeToProxyNode", appV1.AddNodeToProxyNodeParam{NodeID: RP.NodeID, ProxyNodeID: proxy.NodeID, Config: "KEY_ON_PROXY"}, NDID}, code.OK},
+		{"remove RP from proxy node", Step{"RemoveNodeFromProxyNode", appV1.RemoveNodeFromProxyNode{NodeID: RP.NodeID}, NDID}, code.OK},
+		{"disable AS", Step{"DisableNode", appV1.DisableNodeParam{NodeID: AS.NodeID}, NDID}, code.OK},
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		param    interface{}
+		wantCode uint32
+	}{
+		{"AS of service filtered out", "GetAsNodesByServiceId", appV1.GetAsNodesByServiceIdParam{ServiceID: ServiceID}, code.OK},
+		{"AS of unknown service", "GetAsNodesByServiceId", appV1.GetAsNodesByServiceIdParam{ServiceID: "unknown_service"}, code.ResultNotFound},
+		{"services of disabled AS", "GetServicesByAsID", appV1.GetServicesByAsIDParam{AsID: AS.NodeID}, code.OK},
+		{"services of unknown AS", "GetServicesByAsID", appV1.GetServicesByAsIDParam{AsID: "unknown_as"}, code.ResultNotFound},
+		{"nodes behind proxy node after removal", "GetNodesBehindProxyNode", appV1.GetNodesBehindProxyNodeParam{ProxyNodeID: proxy.NodeID}, code.OK},
+		{"nodes behind unknown proxy node", "GetNodesBehindProxyNode", appV1.GetNodesBehindProxyNodeParam{ProxyNodeID: "unknown_proxy"}, code.ResultNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res struct {
+				Node     []interface{} `json:"node"`
+				Services []interface{} `json:"services"`
+				Nodes    []interface{} `json:"nodes"`
+			}
+			if retCode := query(t, app, tt.method, tt.param, &res); retCode != tt.wantCode {
+				t.Fatalf("got code %d, want %d", retCode, tt.wantCode)
+			}
+			if count := len(res.Node) + len(res.Services) + len(res.Nodes); count != 0 {
+				t.Errorf("got %d items, want empty list", count)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package flow
+
+import (
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/keys"
+	"github.com/ndidplatform/smart-contract/v4/test/data"
+	"github.com/ndidplatform/smart-contract/v4/test/harness"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+// deleteNodeKeyHistory removes key history of node from committed state
+// as if node was registered before key history was introduced
+func deleteNodeKeyHistory(app *harness.App, nodeID string) {
+	// "}" follows "|" so range covers every key with prefix "NodeKey|<node ID>|"
+	start := []byte(keys.NodeKeyPrefix + "|" + nodeID + "|")
+	end := []byte(keys.NodeKeyPrefix + "|" + nodeID + "}")
+	var historyKeys [][]byte
+	itr := app.DB.Iterator(start, end)
+	for ; itr.Valid(); itr.Next() {
+		historyKeys = append(historyKeys, append([]byte(nil), itr.Key()...))
+	}
+	itr.Close()
+	for _, key := range historyKeys {
+		app.DB.Delete(key)
+	}
+}
+
+// testRotateNodeKey updates public key of IdP and checks its key before, at and after rotation
+func testRotateNodeKey(t *testing.T, app *harness.App) {
+	oldPublicKey := PublicKeyPEM(IdP.PrivKey)
+	newPublicKey := PublicKeyPEM(utils.GetPrivateKeyFromString(data.IdpPrivK2))
+	heightBeforeRotation := app.Height
+	result := app.DeliverTx("UpdateNode", appV1.UpdateNodeParam{PublicKey: newPublicKey}, MasterKey, IdP.NodeID)
+	if result.Code != 0 {
+		t.Fatalf("UpdateNode failed with code %d: %s", result.Code, result.Log)
+	}
+	rotationHeight := app.Height
+
+	tests := []struct {
+		name   string
+		height int64
+		want   string
+	}{
+		{"before rotation", heightBeforeRotation, oldPublicKey},
+		{"at rotation", rotationHeight, newPublicKey},
+		{"latest", 0, newPublicKey},
+	}
+	for _, tt := range tests {
+		var res appV1.GetNodePublicKeyResult
+		retCode, err := Query(app, "GetNodePublicKey", appV1.GetNodePublicKeyParam{NodeID: IdP.NodeID, Height: tt.height}, &res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if retCode != 0 || res.PublicKey != tt.want {
+			t.Errorf("%s: got code %d and public key %q, want %q", tt.name, retCode, res.PublicKey, tt.want)
+		}
+	}
+}
+
+func TestNodeKeyAtHeight(t *testing.T) {
+	app, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testRotateNodeKey(t, app)
+}
+
+func TestNodeKeyAtHeightBeforeFirstRotationOfNodeWithoutHistory(t *testing.T) {
+	app, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleteNodeKeyHistory(app, IdP.NodeID)
+	app.AdvanceBlocks(2)
+
+	testRotateNodeKey(t, app)
+}
@@ -0,0 +1,48 @@
+package flow
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+// TestQueryPrunedHeight checks that query at height deleted by pruning worker
+// returns HeightPruned instead of empty result
+func TestQueryPrunedHeight(t *testing.T) {
+	os.Setenv("ABCI_PRUNE_KEEP_BLOCKS", "10")
+	defer os.Unsetenv("ABCI_PRUNE_KEEP_BLOCKS")
+	app := newChain(t)
+	initHeight := app.Height
+	// Pruning worker runs once committed height is at least 100 blocks past last pruned height
+	app.AdvanceBlocks(120)
+
+	// Pruning runs in background after commit
+	deadline := time.Now().Add(10 * time.Second)
+	for query(t, app, "GetChangesAtHeight", appV1.GetChangesAtHeightParam{Height: initHeight}, nil) != code.HeightPruned {
+		if time.Now().After(deadline) {
+			t.Fatalf("GetChangesAtHeight at height %d is not pruned", initHeight)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		param    interface{}
+		wantCode uint32
+	}{
+		{"block activity of pruned height", "GetBlockActivity", appV1.GetBlockActivityParam{Height: initHeight}, code.HeightPruned},
+		{"block activity of kept height", "GetBlockActivity", appV1.GetBlockActivityParam{Height: app.Height}, code.OK},
+		{"changes of kept height", "GetChangesAtHeight", appV1.GetChangesAtHeightParam{Height: app.Height}, code.OK},
+		{"node key at pruned height", "GetNodePublicKey", appV1.GetNodePublicKeyParam{NodeID: RP.NodeID, Height: initHeight}, code.HeightPruned},
+		{"node key at latest height", "GetNodePublicKey", appV1.GetNodePublicKeyParam{NodeID: RP.NodeID}, code.OK},
+	}
+	for _, tt := range tests {
+		if retCode := query(t, app, tt.method, tt.param, nil); retCode != tt.wantCode {
+			t.Errorf("%s: got code %d, want %d", tt.name, retCode, tt.wantCode)
+		}
+	}
+}
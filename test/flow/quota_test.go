@@ -0,0 +1,73 @@
+package flow
+
+import (
+	"testing"
+	"time"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+// TestNodeQuotaWindow checks that daily and monthly quota usage rolls over at
+// day and month boundary of block time in UTC, not in local time of block time
+func TestNodeQuotaWindow(t *testing.T) {
+	// Block time is given in UTC+7 so that local midnight differs from UTC midnight
+	ict := time.FixedZone("ICT", 7*60*60)
+	tests := []struct {
+		name         string
+		dailyLimit   int64
+		monthlyLimit int64
+		// startTime is time of block before first CreateRequest, every block is 1 second later
+		startTime time.Time
+		wantCodes []uint32
+	}{
+		{
+			"daily usage rolls over at UTC midnight",
+			1, 0,
+			time.Date(2019, time.January, 1, 23, 59, 57, 0, time.UTC).In(ict),
+			[]uint32{code.OK, code.NodeQuotaExceeded, code.OK},
+		},
+		{
+			"daily usage does not roll over at local midnight",
+			1, 0,
+			time.Date(2019, time.January, 1, 16, 59, 58, 0, time.UTC).In(ict),
+			[]uint32{code.OK, code.NodeQuotaExceeded},
+		},
+		{
+			"monthly usage rolls over at UTC month boundary",
+			0, 2,
+			time.Date(2019, time.January, 31, 23, 59, 56, 0, time.UTC).In(ict),
+			[]uint32{code.OK, code.OK, code.NodeQuotaExceeded, code.OK},
+		},
+		{
+			"monthly usage does not roll over at new day",
+			0, 2,
+			time.Date(2019, time.January, 1, 23, 59, 57, 0, time.UTC).In(ict),
+			[]uint32{code.OK, code.OK, code.NodeQuotaExceeded},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newChain(t)
+			quota := appV1.SetNodeQuotaParam{NodeID: RP.NodeID, Method: "CreateRequest", DailyLimit: tt.dailyLimit, MonthlyLimit: tt.monthlyLimit}
+			runCases(t, app, []txCase{
+				{"set quota", Step{"SetNodeQuota", quota, NDID}, code.OK},
+			})
+			app.Time = tt.startTime
+			for i, wantCode := range tt.wantCodes {
+				result := app.DeliverTx("CreateRequest", CreateRequestParam(NewRequestID()), RP.PrivKey, RP.NodeID)
+				if result.Code != wantCode {
+					t.Fatalf("CreateRequest %d at %s returned code %d (%s), want %d", i, app.Time.UTC(), result.Code, result.Log, wantCode)
+				}
+			}
+			// Usage is counted only for Txs within quota
+			var res appV1.GetNodeQuotaResult
+			if retCode := query(t, app, "GetNodeQuota", appV1.GetNodeQuotaParam{NodeID: RP.NodeID, Method: "CreateRequest"}, &res); retCode != code.OK {
+				t.Fatalf("GetNodeQuota returned code %d", retCode)
+			}
+			if last := tt.wantCodes[len(tt.wantCodes)-1]; last == code.OK && res.DailyUsage != 1 {
+				t.Errorf("got daily usage %d after rollover, want 1", res.DailyUsage)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package flow
+
+import (
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+// TestRequestMessageHashCheckHeight checks that request message hash is required in IdP response
+// only for requests created from activation height
+func TestRequestMessageHashCheckHeight(t *testing.T) {
+	app := newChain(t)
+	legacyRequestID := NewRequestID()
+	requestID := NewRequestID()
+	response := func(requestID, messageHash string) appV1.CreateIdpResponseParam {
+		return appV1.CreateIdpResponseParam{RequestID: requestID, Ial: 2.3, Aal: 3, Status: "accept", Signature: "signature_of_request_message", RequestMessageHash: messageHash}
+	}
+	// Every Tx is delivered in its own block so request is created at activation height
+	activationHeight := app.Height + 4
+	runCases(t, app, []txCase{
+		{"activation height is not in future", Step{"SetRequestMessageHashCheckHeight", appV1.RequestMessageHashCheckHeightParam{ActivationHeight: app.Height + 1}, NDID}, code.InvalidActivationHeight},
+		{"set activation height", Step{"SetRequestMessageHashCheckHeight", appV1.RequestMessageHashCheckHeightParam{ActivationHeight: activationHeight}, NDID}, code.OK},
+		{"create request before activation", Step{"CreateRequest", CreateRequestParam(legacyRequestID), RP}, code.OK},
+		{"create request at activation", Step{"CreateRequest", CreateRequestParam(requestID), RP}, code.OK},
+		{"response without hash to request before activation", Step{"CreateIdpResponse", response(legacyRequestID, ""), IdP}, code.OK},
+		{"response without hash", Step{"CreateIdpResponse", response(requestID, ""), IdP}, code.RequestMessageHashCannotBeEmpty},
+		{"response with other hash", Step{"CreateIdpResponse", response(requestID, "hash_of_other_message"), IdP}, code.RequestMessageHashMismatch},
+		{"response with hash", Step{"CreateIdpResponse", response(requestID, "hash_of_request_message"), IdP}, code.OK},
+		{"change activation height after it is reached", Step{"SetRequestMessageHashCheckHeight", appV1.RequestMessageHashCheckHeightParam{ActivationHeight: app.Height + 100}, NDID}, code.InvalidActivationHeight},
+	})
+
+	var res appV1.RequestMessageHashCheckHeightParam
+	if retCode := query(t, app, "GetRequestMessageHashCheckHeight", nil, &res); retCode != code.OK {
+		t.Fatalf("GetRequestMessageHashCheckHeight returned code %d", retCode)
+	}
+	if res.ActivationHeight != activationHeight {
+		t.Errorf("got activation height %d, want %d", res.ActivationHeight, activationHeight)
+	}
+}
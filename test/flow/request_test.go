@@ -0,0 +1,47 @@
+package flow
+
+import (
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/client"
+)
+
+func TestRequestFlow(t *testing.T) {
+	app := newChain(t)
+	requestID := NewRequestID()
+	dataSignature, err := client.SignData([]byte("data_of_service"), AS.PrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idpResponse := appV1.CreateIdpResponseParam{RequestID: requestID, Ial: 2.3, Aal: 3, Status: "accept", Signature: "signature_of_request_message"}
+	closeRequest := appV1.CloseRequestParam{
+		RequestID:         requestID,
+		ResponseValidList: []appV1.ResponseValid{{IdpID: IdP.NodeID, ValidIal: BoolPtr(true), ValidSignature: BoolPtr(true)}},
+	}
+
+	runCases(t, app, []txCase{
+		{"create request", Step{"CreateRequest", CreateRequestParam(requestID), RP}, code.OK},
+		{"duplicate request ID", Step{"CreateRequest", CreateRequestParam(requestID), RP}, code.DuplicateRequestID},
+		{"response to unknown request", Step{"CreateIdpResponse", appV1.CreateIdpResponseParam{RequestID: NewRequestID(), Ial: 2.3, Aal: 3, Status: "accept"}, IdP}, code.RequestIDNotFound},
+		{"IdP response", Step{"CreateIdpResponse", idpResponse, IdP}, code.OK},
+		{"response to request with min_idp responses", Step{"CreateIdpResponse", idpResponse, IdP}, code.RequestIsCompleted},
+		{"sign data", Step{"SignData", appV1.SignDataParam{RequestID: requestID, ServiceID: ServiceID, Signature: dataSignature}, AS}, code.OK},
+		{"set data received", Step{"SetDataReceived", appV1.SetDataReceivedParam{RequestID: requestID, ServiceID: ServiceID, AsID: AS.NodeID}, RP}, code.OK},
+		{"close request", Step{"CloseRequest", closeRequest, RP}, code.OK},
+		{"close closed request", Step{"CloseRequest", closeRequest, RP}, code.RequestIsClosed},
+	})
+
+	request := requestDetail(t, app, requestID)
+	if !request.IsClosed {
+		t.Error("request is not closed")
+	}
+	if len(request.Responses) != 1 || request.Responses[0].IdpID != IdP.NodeID {
+		t.Errorf("got response list %+v, want response of %s", request.Responses, IdP.NodeID)
+	}
+	dataRequest := request.DataRequestList[0]
+	if len(dataRequest.AnsweredAsIdList) != 1 || len(dataRequest.ReceivedDataFromList) != 1 {
+		t.Errorf("got answered AS %v and received data from %v, want %s in both", dataRequest.AnsweredAsIdList, dataRequest.ReceivedDataFromList, AS.NodeID)
+	}
+}
@@ -0,0 +1,56 @@
+package flow
+
+import (
+	"encoding/json"
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/client"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+// TestSignedQueryReplay checks that the same signed query can't be used twice
+// even though result of the same query is still valid
+func TestSignedQueryReplay(t *testing.T) {
+	app := newChain(t)
+	runCases(t, app, []txCase{
+		{"restrict query", Step{"SetQueryVisibility", appV1.SetQueryVisibilityParam{Method: "GetNodeToken", AllowedRoleList: []string{"NDID"}}, NDID}, code.OK},
+	})
+	params, err := json.Marshal(appV1.GetNodeTokenParam{NodeID: RP.NodeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce, err := client.GenerateNonce()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedQuery := appV1.SignedQueryParam{
+		NodeID:    NDID.NodeID,
+		Method:    "GetNodeToken",
+		Params:    string(params),
+		Nonce:     nonce,
+		Signature: utils.CreateSignature("GetNodeToken", params, nonce, NDID.PrivKey),
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		param    interface{}
+		wantCode uint32
+	}{
+		{"unsigned query", "GetNodeToken", appV1.GetNodeTokenParam{NodeID: RP.NodeID}, code.QueryIsNotAllowed},
+		{"signed query", "SignedQuery", signedQuery, code.OK},
+		{"replayed signed query", "SignedQuery", signedQuery, code.DuplicateNonce},
+	}
+	for _, tt := range tests {
+		var res appV1.GetNodeTokenResult
+		retCode := query(t, app, tt.method, tt.param, &res)
+		if retCode != tt.wantCode {
+			t.Fatalf("%s: got code %d, want %d", tt.name, retCode, tt.wantCode)
+		}
+		if retCode == code.OK && res.Amount != 100 {
+			t.Errorf("%s: got token %v, want 100", tt.name, res.Amount)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package flow
+
+import (
+	"encoding/json"
+	"testing"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+)
+
+func simulateTxParam(signer Signer, method string, param interface{}) appV1.SimulateTxParam {
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		panic(err)
+	}
+	return appV1.SimulateTxParam{NodeID: signer.NodeID, Method: method, Params: paramJSON}
+}
+
+// TestSimulateTx checks that simulated Tx returns the same code as the Tx delivered
+// in DeliverTx and leaves no change in state
+func TestSimulateTx(t *testing.T) {
+	batch := appV1.BatchParam{TxList: []appV1.BatchTx{
+		batchTx("CreateRequest", CreateRequestParam(NewRequestID())),
+		batchTx("CreateRequest", CreateRequestParam(NewRequestID())),
+	}}
+	tests := []struct {
+		name     string
+		rpToken  float64
+		step     Step
+		wantCode uint32
+	}{
+		{"request within token", 100, Step{"CreateRequest", CreateRequestParam(NewRequestID()), RP}, code.OK},
+		// Enough for fee but not for fee and escrow
+		{"request escrow over token", 5, Step{"CreateRequest", CreateRequestParam(NewRequestID()), RP}, code.TokenNotEnough},
+		// Enough for fee and escrow of each request alone, 1 short for both with fee of batch
+		{"batch fee over token left by escrow", 2*(escrowIdPResponsePrice+escrowASDataPrice) + 1, Step{"Batch", batch, RP}, code.TokenNotEnough},
+		{"time locked method", 100, Step{"DisableService", appV1.DisableServiceParam{ServiceID: ServiceID}, NDID}, code.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newChain(t)
+			runCases(t, app, []txCase{
+				{"set delay", Step{"SetGovernanceActionDelay", appV1.GovernanceActionDelayParam{DelayBlock: governanceActionDelay}, NDID}, code.OK},
+				{"set escrow price", Step{"SetRequestEscrowPrice", appV1.RequestEscrowPriceParam{IdPResponsePrice: escrowIdPResponsePrice, ASDataPrice: escrowASDataPrice}, NDID}, code.OK},
+				{"set RP token", Step{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: RP.NodeID, Amount: tt.rpToken}, NDID}, code.OK},
+			})
+			var res appV1.SimulateTxResult
+			if retCode := query(t, app, "SimulateTx", simulateTxParam(tt.step.Signer, tt.step.Method, tt.step.Param), &res); retCode != code.OK {
+				t.Fatalf("SimulateTx returned code %d", retCode)
+			}
+			if res.Code != tt.wantCode {
+				t.Errorf("got simulated code %d (%s), want %d", res.Code, res.Log, tt.wantCode)
+			}
+			if token := nodeToken(t, app, RP.NodeID); token != tt.rpToken {
+				t.Errorf("got RP token %v after simulation, want %v", token, tt.rpToken)
+			}
+			if actions := pendingGovernanceActions(t, app); len(actions) != 0 {
+				t.Errorf("got pending actions %+v after simulation, want none", actions)
+			}
+			result := app.DeliverTx(tt.step.Method, tt.step.Param, tt.step.Signer.PrivKey, tt.step.Signer.NodeID)
+			if result.Code != res.Code {
+				t.Errorf("got code %d from DeliverTx, want simulated code %d", result.Code, res.Code)
+			}
+		})
+	}
+}
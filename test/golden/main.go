@@ -0,0 +1,246 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Command golden executes a fixed scenario (bootstrap, node registration and full request
+// lifecycle) on a new chain and writes exported state (keys in app hash) and app hash as
+// golden file of the release. Test of this package executes the same scenario and compares
+// the result with golden file of current release to catch unintended changes of state
+// written by handlers.
+//
+// Usage:
+//
+//	go run ./test/golden -out test/golden/testdata/golden-4.0.0.json
+package main
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/keys"
+	"github.com/ndidplatform/smart-contract/v4/abci/version"
+	"github.com/ndidplatform/smart-contract/v4/client"
+	"github.com/ndidplatform/smart-contract/v4/test/data"
+	"github.com/ndidplatform/smart-contract/v4/test/harness"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+const (
+	ndidID    = "ndid1"
+	rpID      = "rp1"
+	idpID     = "idp1"
+	asID      = "as1"
+	serviceID = "bank_statement"
+	namespace = "citizen_id"
+	requestID = "0c4d2f3a-5b6e-4c7d-8e9f-a0b1c2d3e4f5"
+)
+
+// excludedKeyPrefixes are prefixes of keys which are not part of app hash
+var excludedKeyPrefixes = []string{
+	keys.StateMetadataKey,
+	keys.ChangeJournalPrefix,
+	keys.BlockActivityPrefix,
+}
+
+type signer struct {
+	nodeID  string
+	privKey *rsa.PrivateKey
+}
+
+type step struct {
+	method string
+	param  interface{}
+	signer signer
+}
+
+// Golden is exported state and app hash after scenario is executed
+type Golden struct {
+	Version string       `json:"version"`
+	Height  int64        `json:"height"`
+	AppHash string       `json:"app_hash"`
+	State   []StateEntry `json:"state"`
+}
+
+// StateEntry is key and hex encoded value in state
+type StateEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func main() {
+	out := flag.String("out", "", "output file (default stdout)")
+	flag.Parse()
+
+	golden, err := generate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	goldenJSON, err := json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	goldenJSON = append(goldenJSON, '\n')
+	if *out == "" {
+		os.Stdout.Write(goldenJSON)
+		return
+	}
+	err = ioutil.WriteFile(*out, goldenJSON, 0644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func publicKeyPEM(privKey *rsa.PrivateKey) string {
+	publicKey, err := utils.GeneratePublicKey(&privKey.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+	return string(publicKey)
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+// run executes scenario, one Tx per block, on a new app. Every Tx must succeed.
+func run() (*harness.App, error) {
+	ndid := signer{ndidID, utils.GetPrivateKeyFromString(data.NdidPrivK)}
+	rp := signer{rpID, utils.GetPrivateKeyFromString(data.AsPrivK2)}
+	idp := signer{idpID, utils.GetPrivateKeyFromString(data.IdpPrivK1)}
+	as := signer{asID, utils.GetPrivateKeyFromString(data.AsPrivK1)}
+	masterKey := publicKeyPEM(utils.GetPrivateKeyFromString(data.AllMasterKey))
+
+	registerNode := func(s signer, role string) appV1.RegisterNode {
+		var param appV1.RegisterNode
+		param.NodeID = s.nodeID
+		param.PublicKey = publicKeyPEM(s.privKey)
+		param.MasterPublicKey = masterKey
+		param.NodeName = s.nodeID
+		param.Role = role
+		if role == "IdP" {
+			param.MaxIal = 3
+			param.MaxAal = 3
+		}
+		return param
+	}
+	dataSignature, err := client.SignData([]byte("data_of_service"), as.privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []step{
+		{"InitNDID", appV1.InitNDIDParam{NodeID: ndidID, PublicKey: publicKeyPEM(ndid.privKey), MasterPublicKey: masterKey}, ndid},
+		{"SetAllowedMinIalForRegisterIdentityAtFirstIdp", appV1.SetAllowedMinIalForRegisterIdentityAtFirstIdpParam{MinIal: 2.3}, ndid},
+		{"SetTimeOutBlockRegisterIdentity", appV1.TimeOutBlockRegisterIdentity{TimeOutBlock: 100}, ndid},
+		{"EndInit", appV1.EndInitParam{}, ndid},
+		{"RegisterNode", registerNode(rp, "RP"), ndid},
+		{"RegisterNode", registerNode(idp, "IdP"), ndid},
+		{"RegisterNode", registerNode(as, "AS"), ndid},
+		{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: rpID, Amount: 100}, ndid},
+		{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: idpID, Amount: 100}, ndid},
+		{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: asID, Amount: 100}, ndid},
+		{"SetPriceFunc", appV1.SetPriceFuncParam{Func: "CreateRequest", Price: 1}, ndid},
+		{"AddNamespace", appV1.Namespace{Namespace: namespace, Description: "Citizen ID"}, ndid},
+		{"AddService", appV1.AddServiceParam{ServiceID: serviceID, ServiceName: "Bank statement", DataSchema: "n/a", DataSchemaVersion: "n/a"}, ndid},
+		{"RegisterServiceDestinationByNDID", appV1.RegisterServiceDestinationByNDIDParam{ServiceID: serviceID, NodeID: asID}, ndid},
+		{"RegisterServiceDestination", appV1.RegisterServiceDestinationParam{ServiceID: serviceID, MinIal: 1.1, MinAal: 1, SupportedNamespaceList: []string{namespace}}, as},
+		{"SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "192.0.2.1", Port: 8000}}}, rp},
+		{"SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "192.0.2.2", Port: 8000}}}, idp},
+		{"SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "192.0.2.3", Port: 8000}}}, as},
+		{"CreateRequest", appV1.CreateRequestParam{
+			RequestID:   requestID,
+			MinIdp:      1,
+			MinAal:      1,
+			MinIal:      1.1,
+			Timeout:     3600,
+			IdPIDList:   []string{idpID},
+			MessageHash: "hash_of_request_message",
+			Mode:        1,
+			DataRequestList: []appV1.DataRequest{
+				{ServiceID: serviceID, As: []string{asID}, Count: 1, RequestParamsHash: "hash_of_request_params"},
+			},
+		}, rp},
+		{"CreateIdpResponse", appV1.CreateIdpResponseParam{RequestID: requestID, Ial: 2.3, Aal: 3, Status: "accept", Signature: "signature_of_request_message"}, idp},
+		{"SignData", appV1.SignDataParam{RequestID: requestID, ServiceID: serviceID, Signature: dataSignature}, as},
+		{"SetDataReceived", appV1.SetDataReceivedParam{RequestID: requestID, ServiceID: serviceID, AsID: asID}, rp},
+		{"CloseRequest", appV1.CloseRequestParam{RequestID: requestID, ResponseValidList: []appV1.ResponseValid{{IdpID: idpID, ValidIal: boolPtr(true), ValidSignature: boolPtr(true)}}}, rp},
+	}
+
+	app := harness.NewApp()
+	for i, s := range steps {
+		nonce := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("golden-%d", i+1)))
+		tx := app.CreateTxWithNonce(s.method, s.param, nonce, s.signer.privKey, s.signer.nodeID)
+		result := app.NextBlock(tx)[0]
+		if result.Code != 0 {
+			return nil, fmt.Errorf("Step %d (%s) failed with code %d: %s", i+1, s.method, result.Code, result.Log)
+		}
+	}
+	return app, nil
+}
+
+// isExcludedKey returns true when key is not part of app hash
+func isExcludedKey(key []byte) bool {
+	for _, prefix := range excludedKeyPrefixes {
+		if strings.HasPrefix(string(key), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// export returns state of app which is part of app hash in key order
+func export(app *harness.App) Golden {
+	var golden Golden
+	golden.Version = version.ABCIAppSemVer
+	golden.Height = app.Height
+	golden.AppHash = hex.EncodeToString(app.AppHash)
+	golden.State = make([]StateEntry, 0)
+	itr := app.DB.Iterator(nil, nil)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		if isExcludedKey(itr.Key()) {
+			continue
+		}
+		golden.State = append(golden.State, StateEntry{
+			Key:   string(itr.Key()),
+			Value: hex.EncodeToString(itr.Value()),
+		})
+	}
+	return golden
+}
+
+func generate() (Golden, error) {
+	app, err := run()
+	if err != nil {
+		return Golden{}, err
+	}
+	return export(app), nil
+}
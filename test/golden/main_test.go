@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/ndidplatform/smart-contract/v4/abci/storage"
+	"github.com/ndidplatform/smart-contract/v4/abci/version"
+)
+
+// maxReportedDiffs is number of differing keys reported when state differs from golden file
+const maxReportedDiffs = 20
+
+// goldenFilePath returns path of golden file of current release
+func goldenFilePath() string {
+	return filepath.Join("testdata", "golden-"+version.ABCIAppSemVer+".json")
+}
+
+// loadDB writes exported state into in-memory DB
+func loadDB(golden Golden) (dbm.DB, error) {
+	db := dbm.NewMemDB()
+	for _, entry := range golden.State {
+		value, err := hex.DecodeString(entry.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value of key %s: %v", entry.Key, err)
+		}
+		db.Set([]byte(entry.Key), value)
+	}
+	return db, nil
+}
+
+func TestGoldenState(t *testing.T) {
+	path := goldenFilePath()
+	goldenJSON, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		// Golden file must be committed with every release, otherwise state changes are not caught
+		t.Fatalf("No golden file of release %s, generate it with: go run ./test/golden -out test/golden/%s", version.ABCIAppSemVer, path)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected Golden
+	err = json.Unmarshal(goldenJSON, &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedDB, err := loadDB(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app, err := run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := export(app)
+	actualDB, err := loadDB(actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diffCount := 0
+	storage.CompareDB(expectedDB, actualDB, func(diff storage.KeyDiff) bool {
+		diffCount++
+		switch {
+		case diff.OtherValue == nil:
+			t.Errorf("Key %s is missing", diff.Key)
+		case diff.Value == nil:
+			t.Errorf("Unexpected key %s: %x", diff.Key, diff.OtherValue)
+		default:
+			t.Errorf("Key %s has value %x, expected %x", diff.Key, diff.OtherValue, diff.Value)
+		}
+		return diffCount < maxReportedDiffs
+	})
+	if actual.Height != expected.Height {
+		t.Errorf("Height is %d, expected %d", actual.Height, expected.Height)
+	}
+	if actual.AppHash != expected.AppHash {
+		t.Errorf("App hash is %s, expected %s", actual.AppHash, expected.AppHash)
+	}
+}
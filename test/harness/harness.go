@@ -0,0 +1,139 @@
+// Package harness runs ABCI app in process on top of in-memory DB
+// for writing end-to-end tests of transaction flows without running Tendermint node.
+//
+//	app := harness.NewApp()
+//	tx := app.CreateTx("InitNDID", param, ndidPrivKey, "NDID")
+//	results := app.NextBlock(tx)
+//	res := app.Query("GetNodeInfo", getNodeInfoParam)
+package harness
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/client"
+	protoTm "github.com/ndidplatform/smart-contract/v4/protos/tendermint"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+// ChainID is chain ID of blocks created by App
+const ChainID = "test-chain"
+
+// BlockInterval is time between blocks created by App
+const BlockInterval = time.Second
+
+// App is ABCI app with in-memory DB and its current block height and time,
+// app hash and validator updates of last committed block
+type App struct {
+	*appV1.ABCIApplication
+	DB               dbm.DB
+	Height           int64
+	Time             time.Time
+	AppHash          []byte
+	ValidatorUpdates []types.ValidatorUpdate
+}
+
+func NewApp() *App {
+	return NewAppWithDB(dbm.NewMemDB())
+}
+
+// NewAppWithDB returns App with state in db, e.g. in-memory DB wrapped with
+// abci/storage/faultdb for testing handling of storage faults
+func NewAppWithDB(db dbm.DB) *App {
+	logger := logrus.WithFields(logrus.Fields{"module": "abci-app-test"})
+	return &App{
+		ABCIApplication: appV1.NewABCIApplication(logger, db),
+		DB:              db,
+		Height:          0,
+		Time:            time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// CreateTx creates Tx of method signed by node private key
+func (app *App) CreateTx(method string, param interface{}, privKey *rsa.PrivateKey, nodeID string) []byte {
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		panic(err)
+	}
+	nonce, signature := utils.CreateSignatureAndNonce(method, paramJSON, privKey)
+	return newTx(method, paramJSON, nonce, signature, nodeID)
+}
+
+// CreateTxWithNonce creates Tx of method with given nonce signed by node private key.
+// Tx bytes are deterministic for the same arguments.
+func (app *App) CreateTxWithNonce(method string, param interface{}, nonce string, privKey *rsa.PrivateKey, nodeID string) []byte {
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		panic(err)
+	}
+	signature := utils.CreateSignature(method, paramJSON, nonce, privKey)
+	return newTx(method, paramJSON, nonce, signature, nodeID)
+}
+
+func newTx(method string, paramJSON []byte, nonce string, signature []byte, nodeID string) []byte {
+	txBytes, err := client.NewTx(method, paramJSON, nonce, signature, nodeID)
+	if err != nil {
+		panic(err)
+	}
+	return txBytes
+}
+
+// CheckTx checks Tx as new Tx to mempool
+func (app *App) CheckTx(tx []byte) types.ResponseCheckTx {
+	return app.ABCIApplication.CheckTx(types.RequestCheckTx{Tx: tx, Type: types.CheckTxType_New})
+}
+
+// NextBlock creates block of given Txs at next height and commits it.
+// Txs are delivered in order without being checked by CheckTx.
+func (app *App) NextBlock(txs ...[]byte) []types.ResponseDeliverTx {
+	app.Height++
+	app.Time = app.Time.Add(BlockInterval)
+	var header types.Header
+	header.ChainID = ChainID
+	header.Height = app.Height
+	header.Time = app.Time
+	app.BeginBlock(types.RequestBeginBlock{Header: header})
+	results := make([]types.ResponseDeliverTx, 0, len(txs))
+	for _, tx := range txs {
+		results = append(results, app.ABCIApplication.DeliverTx(types.RequestDeliverTx{Tx: tx}))
+	}
+	endBlockResult := app.EndBlock(types.RequestEndBlock{Height: app.Height})
+	app.ValidatorUpdates = endBlockResult.ValidatorUpdates
+	app.AppHash = app.Commit().Data
+	return results
+}
+
+// AdvanceBlocks creates and commits given number of empty blocks
+func (app *App) AdvanceBlocks(count int) {
+	for i := 0; i < count; i++ {
+		app.NextBlock()
+	}
+}
+
+// DeliverTx creates Tx of method signed by node private key and commits it in its own block
+func (app *App) DeliverTx(method string, param interface{}, privKey *rsa.PrivateKey, nodeID string) types.ResponseDeliverTx {
+	return app.NextBlock(app.CreateTx(method, param, privKey, nodeID))[0]
+}
+
+// Query queries method at latest height
+func (app *App) Query(method string, param interface{}) types.ResponseQuery {
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		panic(err)
+	}
+	var query protoTm.Query
+	query.Method = method
+	query.Params = string(paramJSON)
+	queryBytes, err := proto.Marshal(&query)
+	if err != nil {
+		panic(err)
+	}
+	return app.ABCIApplication.Query(types.RequestQuery{Data: queryBytes})
+}
@@ -345,6 +345,10 @@ func TestAddService(t *testing.T, serviceID string) {
 		param.ServiceName = "Bank statement"
 		param.DataSchema = "DataSchema"
 		param.DataSchemaVersion = "DataSchemaVersion"
+	case data.ServiceID2:
+		param.ServiceName = "Bank statement PDF"
+		param.DataSchema = "DataSchema"
+		param.DataSchemaVersion = "DataSchemaVersion"
 	}
 	AddService(t, ndidNodeID, data.NdidPrivK, param)
 }
@@ -381,6 +385,9 @@ func TestRegisterServiceDestinationByNDID(t *testing.T, caseID int64, expected s
 	case 4:
 		param.ServiceID = data.ServiceID1
 		param.NodeID = data.AS2
+	case 5:
+		param.ServiceID = data.ServiceID2
+		param.NodeID = data.AS2
 	}
 	RegisterServiceDestinationByNDID(t, ndidNodeID, data.NdidPrivK, param, expected)
 }
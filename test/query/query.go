@@ -313,6 +313,8 @@ func TestGetAsNodesByServiceId(t *testing.T, caseID int64, expected string) {
 	switch caseID {
 	case 1:
 		param.ServiceID = data.ServiceID1
+	case 2:
+		param.ServiceID = data.ServiceID2
 	}
 	GetAsNodesByServiceId(t, param, expected)
 }
@@ -374,6 +376,8 @@ func TestGetServicesByAsID(t *testing.T, caseID int64, expected string) {
 	switch caseID {
 	case 1:
 		param.AsID = data.AS1
+	case 2:
+		param.AsID = data.AS2
 	}
 	GetServicesByAsID(t, param, expected)
 }
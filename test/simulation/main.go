@@ -0,0 +1,455 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Command simulation runs randomized sequences of transactions from RP, IdP and AS
+// nodes against in-process ABCI app and checks state invariants after each block.
+// Every block is also executed on replica app to check that DeliverTx results,
+// validator updates and app hash are deterministic.
+//
+// Usage:
+//
+//	go run ./test/simulation -blocks 500 -txs 10 -seed 1
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"flag"
+	"fmt"
+	mathRand "math/rand"
+	"os"
+	"reflect"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/abci/code"
+	"github.com/ndidplatform/smart-contract/v4/client"
+	"github.com/ndidplatform/smart-contract/v4/test/harness"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+const (
+	ndidNodeID      = "NDID"
+	serviceID       = "sim_service"
+	initialToken    = 10000.0
+	defaultTokenFee = 1.0
+)
+
+type node struct {
+	id      string
+	role    string
+	privKey *rsa.PrivateKey
+}
+
+type simRequest struct {
+	id             string
+	owner          *node
+	idpIDList      []string
+	asIDList       []string
+	closedSnapshot *appV1.GetRequestDetailResult
+}
+
+type simulation struct {
+	app      *harness.App
+	replica  *harness.App
+	rand     *mathRand.Rand
+	ndid     *node
+	rps      []*node
+	idps     []*node
+	ases     []*node
+	requests []*simRequest
+	tokens   map[string]float64
+}
+
+func main() {
+	blocks := flag.Int("blocks", 200, "number of blocks to simulate")
+	txsPerBlock := flag.Int("txs", 10, "maximum number of Txs per block")
+	seed := flag.Int64("seed", 1, "random seed")
+	nodeCount := flag.Int("nodes", 3, "number of nodes of each role")
+	determinism := flag.Bool("determinism", true, "execute every block twice on separate apps and compare results")
+	flag.Parse()
+
+	fmt.Printf("Simulating %d blocks with seed %d\n", *blocks, *seed)
+	sim := newSimulation(*seed, *nodeCount)
+	if *determinism {
+		sim.replica = harness.NewApp()
+	}
+	sim.setup()
+	for i := 0; i < *blocks; i++ {
+		txCount := sim.rand.Intn(*txsPerBlock + 1)
+		txs := make([][]byte, 0, txCount)
+		for j := 0; j < txCount; j++ {
+			txs = append(txs, sim.randomTx())
+		}
+		if _, err := sim.nextBlock(txs...); err != nil {
+			fmt.Fprintf(os.Stderr, "Non-deterministic block at height %d (seed %d): %s\n", sim.app.Height, *seed, err.Error())
+			os.Exit(1)
+		}
+		if err := sim.checkInvariants(len(txs)); err != nil {
+			fmt.Fprintf(os.Stderr, "Invariant violated at height %d (seed %d): %s\n", sim.app.Height, *seed, err.Error())
+			os.Exit(1)
+		}
+	}
+	fmt.Printf("OK: %d blocks, %d requests\n", *blocks, len(sim.requests))
+}
+
+func newSimulation(seed int64, nodeCount int) *simulation {
+	sim := &simulation{
+		app:    harness.NewApp(),
+		rand:   mathRand.New(mathRand.NewSource(seed)),
+		ndid:   newNode(ndidNodeID, "NDID"),
+		tokens: make(map[string]float64),
+	}
+	for i := 0; i < nodeCount; i++ {
+		sim.rps = append(sim.rps, newNode(fmt.Sprintf("sim_rp%d", i+1), "RP"))
+		sim.idps = append(sim.idps, newNode(fmt.Sprintf("sim_idp%d", i+1), "IdP"))
+		sim.ases = append(sim.ases, newNode(fmt.Sprintf("sim_as%d", i+1), "AS"))
+	}
+	return sim
+}
+
+func newNode(nodeID, role string) *node {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return &node{id: nodeID, role: role, privKey: privKey}
+}
+
+func publicKeyPEM(n *node) string {
+	publicKey, err := utils.GeneratePublicKey(&n.privKey.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+	return string(publicKey)
+}
+
+func (sim *simulation) allNodes() []*node {
+	var nodes []*node
+	nodes = append(nodes, sim.rps...)
+	nodes = append(nodes, sim.idps...)
+	nodes = append(nodes, sim.ases...)
+	return nodes
+}
+
+// nextBlock commits block of Txs on app and replica (if any) and checks that
+// both produce the same DeliverTx results, validator updates and app hash
+func (sim *simulation) nextBlock(txs ...[]byte) ([]types.ResponseDeliverTx, error) {
+	results := sim.app.NextBlock(txs...)
+	if sim.replica == nil {
+		return results, nil
+	}
+	replicaResults := sim.replica.NextBlock(txs...)
+	for index := range results {
+		if !reflect.DeepEqual(results[index], replicaResults[index]) {
+			return nil, fmt.Errorf("result of Tx %d differs: %v / %v", index, results[index], replicaResults[index])
+		}
+	}
+	if !reflect.DeepEqual(sim.app.ValidatorUpdates, sim.replica.ValidatorUpdates) {
+		return nil, fmt.Errorf("validator updates differ: %v / %v", sim.app.ValidatorUpdates, sim.replica.ValidatorUpdates)
+	}
+	if !bytes.Equal(sim.app.AppHash, sim.replica.AppHash) {
+		return nil, fmt.Errorf("app hash differs: %X / %X", sim.app.AppHash, sim.replica.AppHash)
+	}
+	return results, nil
+}
+
+func (sim *simulation) mustDeliver(method string, param interface{}, n *node) {
+	results, err := sim.nextBlock(sim.app.CreateTx(method, param, n.privKey, n.id))
+	if err != nil {
+		panic(fmt.Sprintf("setup %s is not deterministic: %s", method, err.Error()))
+	}
+	result := results[0]
+	if result.Code != code.OK {
+		panic(fmt.Sprintf("setup %s failed: %d %s", method, result.Code, result.Log))
+	}
+}
+
+func (sim *simulation) setup() {
+	var initNDIDParam appV1.InitNDIDParam
+	initNDIDParam.NodeID = sim.ndid.id
+	initNDIDParam.PublicKey = publicKeyPEM(sim.ndid)
+	initNDIDParam.MasterPublicKey = publicKeyPEM(sim.ndid)
+	sim.mustDeliver("InitNDID", initNDIDParam, sim.ndid)
+	sim.mustDeliver("EndInit", appV1.EndInitParam{}, sim.ndid)
+
+	for _, n := range sim.allNodes() {
+		var registerNodeParam appV1.RegisterNode
+		registerNodeParam.NodeID = n.id
+		registerNodeParam.PublicKey = publicKeyPEM(n)
+		registerNodeParam.MasterPublicKey = publicKeyPEM(n)
+		registerNodeParam.NodeName = n.id
+		registerNodeParam.Role = n.role
+		if n.role == "IdP" {
+			registerNodeParam.MaxIal = 3
+			registerNodeParam.MaxAal = 3
+		}
+		sim.mustDeliver("RegisterNode", registerNodeParam, sim.ndid)
+		sim.mustDeliver("SetNodeToken", appV1.SetNodeTokenParam{NodeID: n.id, Amount: initialToken}, sim.ndid)
+	}
+
+	var addServiceParam appV1.AddServiceParam
+	addServiceParam.ServiceID = serviceID
+	addServiceParam.ServiceName = "Simulation service"
+	addServiceParam.DataSchema = "n/a"
+	addServiceParam.DataSchemaVersion = "n/a"
+	sim.mustDeliver("AddService", addServiceParam, sim.ndid)
+	for _, as := range sim.ases {
+		sim.mustDeliver("RegisterServiceDestinationByNDID", appV1.RegisterServiceDestinationByNDIDParam{ServiceID: serviceID, NodeID: as.id}, sim.ndid)
+		var registerServiceDestinationParam appV1.RegisterServiceDestinationParam
+		registerServiceDestinationParam.ServiceID = serviceID
+		registerServiceDestinationParam.MinIal = 1.1
+		registerServiceDestinationParam.MinAal = 1
+		sim.mustDeliver("RegisterServiceDestination", registerServiceDestinationParam, as)
+	}
+
+	for _, n := range sim.allNodes() {
+		sim.tokens[n.id] = sim.getToken(n.id)
+	}
+}
+
+func (sim *simulation) pickNode(nodes []*node) *node {
+	return nodes[sim.rand.Intn(len(nodes))]
+}
+
+func (sim *simulation) pickSubset(nodes []*node) []string {
+	var ids []string
+	for _, n := range nodes {
+		if sim.rand.Intn(2) == 0 {
+			ids = append(ids, n.id)
+		}
+	}
+	if len(ids) == 0 {
+		ids = append(ids, sim.pickNode(nodes).id)
+	}
+	return ids
+}
+
+func (sim *simulation) pickRequest() *simRequest {
+	if len(sim.requests) == 0 {
+		return nil
+	}
+	return sim.requests[sim.rand.Intn(len(sim.requests))]
+}
+
+func (sim *simulation) randomHex() string {
+	b := make([]byte, 16)
+	sim.rand.Read(b)
+	return fmt.Sprintf("%x", b)
+}
+
+// randomUUID creates random UUID version 4 for request ID
+func (sim *simulation) randomUUID() string {
+	b := make([]byte, 16)
+	sim.rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// randomTx creates random Tx. Txs are schema-valid but may be rejected by app
+// (e.g. responding to closed request) to exercise error paths.
+func (sim *simulation) randomTx() []byte {
+	request := sim.pickRequest()
+	choice := sim.rand.Intn(10)
+	if request == nil || choice < 3 {
+		return sim.createRequestTx()
+	}
+	switch choice {
+	case 3, 4:
+		var param appV1.CreateIdpResponseParam
+		param.RequestID = request.id
+		param.Ial = []float64{1.1, 1.2, 2.1, 2.2, 2.3, 3}[sim.rand.Intn(6)]
+		param.Aal = []float64{1, 2.1, 2.2, 3}[sim.rand.Intn(4)]
+		param.Status = []string{"accept", "reject"}[sim.rand.Intn(2)]
+		param.Signature = sim.randomHex()
+		idp := sim.pickNode(sim.idps)
+		return sim.app.CreateTx("CreateIdpResponse", param, idp.privKey, idp.id)
+	case 5, 6:
+		var param appV1.SignDataParam
+		param.RequestID = request.id
+		param.ServiceID = serviceID
+		as := sim.pickNode(sim.ases)
+		signature, err := client.SignData([]byte(sim.randomHex()), as.privKey)
+		if err != nil {
+			panic(err)
+		}
+		param.Signature = signature
+		return sim.app.CreateTx("SignData", param, as.privKey, as.id)
+	case 7:
+		var param appV1.SetDataReceivedParam
+		param.RequestID = request.id
+		param.ServiceID = serviceID
+		param.AsID = sim.pickNode(sim.ases).id
+		return sim.app.CreateTx("SetDataReceived", param, request.owner.privKey, request.owner.id)
+	case 8:
+		var param appV1.CloseRequestParam
+		param.RequestID = request.id
+		return sim.app.CreateTx("CloseRequest", param, request.owner.privKey, request.owner.id)
+	default:
+		var param appV1.TimeOutRequestParam
+		param.RequestID = request.id
+		return sim.app.CreateTx("TimeOutRequest", param, request.owner.privKey, request.owner.id)
+	}
+}
+
+func (sim *simulation) createRequestTx() []byte {
+	rp := sim.pickNode(sim.rps)
+	var param appV1.CreateRequestParam
+	param.RequestID = sim.randomUUID()
+	param.IdPIDList = sim.pickSubset(sim.idps)
+	param.MinIdp = 1 + sim.rand.Intn(len(param.IdPIDList))
+	param.MinIal = 1.1
+	param.MinAal = 1
+	param.Timeout = 3600
+	param.MessageHash = sim.randomHex()
+	param.Mode = 1
+	asIDList := sim.pickSubset(sim.ases)
+	if sim.rand.Intn(3) > 0 {
+		var dataRequest appV1.DataRequest
+		dataRequest.ServiceID = serviceID
+		dataRequest.As = asIDList
+		dataRequest.Count = 1 + sim.rand.Intn(len(asIDList))
+		dataRequest.RequestParamsHash = sim.randomHex()
+		param.DataRequestList = append(param.DataRequestList, dataRequest)
+	}
+	sim.requests = append(sim.requests, &simRequest{
+		id:        param.RequestID,
+		owner:     rp,
+		idpIDList: param.IdPIDList,
+		asIDList:  asIDList,
+	})
+	return sim.app.CreateTx("CreateRequest", param, rp.privKey, rp.id)
+}
+
+func (sim *simulation) getToken(nodeID string) float64 {
+	result := sim.app.Query("GetNodeToken", appV1.GetNodeTokenParam{NodeID: nodeID})
+	if result.Log != "success" {
+		panic(fmt.Sprintf("GetNodeToken %s: %s", nodeID, result.Log))
+	}
+	var token appV1.GetNodeTokenResult
+	if err := json.Unmarshal(result.Value, &token); err != nil {
+		panic(err)
+	}
+	return token.Amount
+}
+
+func (sim *simulation) getRequestDetail(requestID string) (*appV1.GetRequestDetailResult, bool) {
+	result := sim.app.Query("GetRequestDetail", appV1.GetRequestParam{RequestID: requestID})
+	if result.Log != "success" {
+		return nil, false
+	}
+	var detail appV1.GetRequestDetailResult
+	if err := json.Unmarshal(result.Value, &detail); err != nil {
+		panic(err)
+	}
+	return &detail, true
+}
+
+func (sim *simulation) checkInvariants(txCount int) error {
+	if err := sim.checkTokens(txCount); err != nil {
+		return err
+	}
+	for _, request := range sim.requests {
+		detail, found := sim.getRequestDetail(request.id)
+		if !found {
+			continue
+		}
+		if err := checkRequest(request, detail); err != nil {
+			return fmt.Errorf("request %s: %s", request.id, err.Error())
+		}
+	}
+	return nil
+}
+
+// checkTokens checks that tokens are only burned, never minted,
+// and that block burns at most fee of each Tx in it
+func (sim *simulation) checkTokens(txCount int) error {
+	var burned float64
+	for nodeID, before := range sim.tokens {
+		after := sim.getToken(nodeID)
+		if after < 0 {
+			return fmt.Errorf("token of %s is negative: %f", nodeID, after)
+		}
+		if after > before {
+			return fmt.Errorf("token of %s increased from %f to %f", nodeID, before, after)
+		}
+		burned += before - after
+		sim.tokens[nodeID] = after
+	}
+	if burned > float64(txCount)*defaultTokenFee {
+		return fmt.Errorf("burned %f tokens in block of %d Txs", burned, txCount)
+	}
+	return nil
+}
+
+func checkRequest(request *simRequest, detail *appV1.GetRequestDetailResult) error {
+	if len(detail.Responses) > detail.MinIdp {
+		return fmt.Errorf("%d responses exceed min_idp %d", len(detail.Responses), detail.MinIdp)
+	}
+	for _, response := range detail.Responses {
+		if !contains(detail.IdPIDList, response.IdpID) {
+			return fmt.Errorf("response from %s which is not in idp_id_list", response.IdpID)
+		}
+	}
+	for _, dataRequest := range detail.DataRequestList {
+		if len(dataRequest.AnsweredAsIdList) > dataRequest.Count {
+			return fmt.Errorf("service %s: %d answered AS exceed min_as %d", dataRequest.ServiceID, len(dataRequest.AnsweredAsIdList), dataRequest.Count)
+		}
+		for _, asID := range dataRequest.ReceivedDataFromList {
+			if !contains(dataRequest.AnsweredAsIdList, asID) {
+				return fmt.Errorf("service %s: data received from %s which has not answered", dataRequest.ServiceID, asID)
+			}
+		}
+	}
+	if detail.IsClosed && detail.IsTimedOut {
+		return fmt.Errorf("request is both closed and timed out")
+	}
+	if !detail.IsClosed && !detail.IsTimedOut {
+		return nil
+	}
+	if request.closedSnapshot == nil {
+		request.closedSnapshot = detail
+		return nil
+	}
+	if len(detail.Responses) != len(request.closedSnapshot.Responses) {
+		return fmt.Errorf("response list changed after request was finished")
+	}
+	for i, dataRequest := range detail.DataRequestList {
+		if len(dataRequest.AnsweredAsIdList) != len(request.closedSnapshot.DataRequestList[i].AnsweredAsIdList) {
+			return fmt.Errorf("service %s: answered AS list changed after request was finished", dataRequest.ServiceID)
+		}
+	}
+	return nil
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,8 @@
 package utils
 
 import (
-	"crypto"
-	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
-	"encoding/base64"
-	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
@@ -18,8 +14,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/gogo/protobuf/proto"
-	protoTm "github.com/ndidplatform/smart-contract/v4/protos/tendermint"
+	"github.com/ndidplatform/smart-contract/v4/client"
 	"github.com/tendermint/tendermint/libs/common"
 )
 
@@ -50,33 +45,32 @@ func GeneratePublicKey(publicKey *rsa.PublicKey) ([]byte, error) {
 }
 
 func CreateSignatureAndNonce(fnName string, paramJSON []byte, privKey *rsa.PrivateKey) (nonce string, signature []byte) {
-	nonce = base64.StdEncoding.EncodeToString([]byte(common.RandStr(12)))
-	tempPSSmessage := append([]byte(fnName), paramJSON...)
-	tempPSSmessage = append(tempPSSmessage, []byte(nonce)...)
-	PSSmessage := []byte(base64.StdEncoding.EncodeToString(tempPSSmessage))
-	newhash := crypto.SHA256
-	pssh := newhash.New()
-	pssh.Write(PSSmessage)
-	hashed := pssh.Sum(nil)
-	signature, err := rsa.SignPKCS1v15(rand.Reader, privKey, newhash, hashed)
+	nonce, err := client.GenerateNonce()
 	if err != nil {
 		fmt.Println(err.Error())
 	}
-	return nonce, signature
+	return nonce, CreateSignature(fnName, paramJSON, nonce, privKey)
+}
+
+// CreateSignature signs Tx of method, params and nonce with node private key
+func CreateSignature(fnName string, paramJSON []byte, nonce string, privKey *rsa.PrivateKey) []byte {
+	signature, err := client.Sign(fnName, paramJSON, nonce, privKey)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+	return signature
+}
+
+// SigningPayload returns message which is hashed and signed for Tx of method, params and nonce
+func SigningPayload(fnName string, paramJSON []byte, nonce string) []byte {
+	return client.SigningPayload(fnName, paramJSON, nonce)
 }
 
 func CreateTxn(fnName []byte, param []byte, nonce []byte, signature []byte, nodeID []byte) (interface{}, error) {
-	var tx protoTm.Tx
-	tx.Method = string(fnName)
-	tx.Params = string(param)
-	tx.Nonce = nonce
-	tx.Signature = signature
-	tx.NodeId = string(nodeID)
-	txByte, err := proto.Marshal(&tx)
+	txByte, err := client.NewTx(string(fnName), param, string(nonce), signature, string(nodeID))
 	if err != nil {
 		log.Printf("err: %s", err.Error())
 	}
-	txEncoded := hex.EncodeToString(txByte)
 	var URL *url.URL
 	URL, err = url.Parse(tendermintAddr)
 	if err != nil {
@@ -84,7 +78,7 @@ func CreateTxn(fnName []byte, param []byte, nonce []byte, signature []byte, node
 	}
 	URL.Path += "/broadcast_tx_commit"
 	parameters := url.Values{}
-	parameters.Add("tx", `0x`+txEncoded)
+	parameters.Add("tx", client.EncodeRPCBytes(txByte))
 	URL.RawQuery = parameters.Encode()
 	encodedURL := URL.String()
 	req, err := http.NewRequest("GET", encodedURL, nil)
@@ -109,14 +103,10 @@ func CreateTxn(fnName []byte, param []byte, nonce []byte, signature []byte, node
 }
 
 func Query(fnName []byte, param []byte) (interface{}, error) {
-	var data protoTm.Query
-	data.Method = string(fnName)
-	data.Params = string(param)
-	dataByte, err := proto.Marshal(&data)
+	dataByte, err := client.NewQuery(string(fnName), param)
 	if err != nil {
 		log.Printf("err: %s", err.Error())
 	}
-	dataEncoded := hex.EncodeToString(dataByte)
 	var URL *url.URL
 	URL, err = url.Parse(tendermintAddr)
 	if err != nil {
@@ -124,7 +114,7 @@ func Query(fnName []byte, param []byte) (interface{}, error) {
 	}
 	URL.Path += "/abci_query"
 	parameters := url.Values{}
-	parameters.Add("data", `0x`+dataEncoded)
+	parameters.Add("data", client.EncodeRPCBytes(dataByte))
 	URL.RawQuery = parameters.Encode()
 	encodedURL := URL.String()
 	req, err := http.NewRequest("GET", encodedURL, nil)
@@ -0,0 +1,248 @@
+/**
+ * Copyright (c) 2018, 2019 National Digital ID COMPANY LIMITED
+ *
+ * This file is part of NDID software.
+ *
+ * NDID is the free software: you can redistribute it and/or modify it under
+ * the terms of the Affero GNU General Public License as published by the
+ * Free Software Foundation, either version 3 of the License, or any later
+ * version.
+ *
+ * NDID is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the Affero GNU General Public License for more details.
+ *
+ * You should have received a copy of the Affero GNU General Public License
+ * along with the NDID source code. If not, see https://www.gnu.org/licenses/agpl.txt.
+ *
+ * Please contact info@ndid.co.th for any further questions
+ *
+ */
+
+// Command vectors generates deterministic test vectors of signed Txs for client implementations.
+// Each vector contains params, signing payload, signature, encoded Tx envelope
+// and expected DeliverTx result when Txs are delivered in order, one Tx per block, to a new chain.
+//
+// Usage:
+//
+//	go run ./test/vectors -out vectors.json
+package main
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	appV1 "github.com/ndidplatform/smart-contract/v4/abci/app/v1"
+	"github.com/ndidplatform/smart-contract/v4/client"
+	"github.com/ndidplatform/smart-contract/v4/test/data"
+	"github.com/ndidplatform/smart-contract/v4/test/harness"
+	"github.com/ndidplatform/smart-contract/v4/test/utils"
+)
+
+const (
+	ndidID     = "ndid1"
+	rpID       = "rp1"
+	idpID      = "idp1"
+	asID       = "as1"
+	serviceID  = "bank_statement"
+	namespace  = "citizen_id"
+	requestID1 = "ef6f4c9c-818b-42b8-8904-3d97c4c520f6"
+	requestID2 = "9f3ab8e1-4a8c-4d53-b0a7-7cf2c04a1c62"
+)
+
+type signer struct {
+	nodeID  string
+	privKey *rsa.PrivateKey
+}
+
+type step struct {
+	method string
+	param  interface{}
+	signer signer
+}
+
+// Vector is a signed Tx and its expected DeliverTx result
+type Vector struct {
+	Height         int64           `json:"height"`
+	Method         string          `json:"method"`
+	NodeID         string          `json:"node_id"`
+	Params         json.RawMessage `json:"params"`
+	Nonce          string          `json:"nonce"`
+	SigningPayload string          `json:"signing_payload"`
+	Signature      string          `json:"signature"`
+	Tx             string          `json:"tx"`
+	ExpectedResult ExpectedResult  `json:"expected_result"`
+}
+
+// ExpectedResult is expected DeliverTx result of Vector
+type ExpectedResult struct {
+	Code uint32 `json:"code"`
+	Log  string `json:"log"`
+	Data string `json:"data"`
+}
+
+func main() {
+	out := flag.String("out", "", "output file (default stdout)")
+	flag.Parse()
+
+	vectors := generate()
+	vectorsJSON, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	vectorsJSON = append(vectorsJSON, '\n')
+	if *out == "" {
+		os.Stdout.Write(vectorsJSON)
+		return
+	}
+	err = ioutil.WriteFile(*out, vectorsJSON, 0644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func publicKeyPEM(privKey *rsa.PrivateKey) string {
+	publicKey, err := utils.GeneratePublicKey(&privKey.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+	return string(publicKey)
+}
+
+// signData returns signature of data by signer. RSA PKCS #1 v1.5 signature is deterministic.
+func signData(data string, s signer) string {
+	signature, err := client.SignData([]byte(data), s.privKey)
+	if err != nil {
+		panic(err)
+	}
+	return signature
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func generate() []Vector {
+	ndid := signer{ndidID, utils.GetPrivateKeyFromString(data.NdidPrivK)}
+	rp := signer{rpID, utils.GetPrivateKeyFromString(data.AsPrivK2)}
+	idp := signer{idpID, utils.GetPrivateKeyFromString(data.IdpPrivK1)}
+	as := signer{asID, utils.GetPrivateKeyFromString(data.AsPrivK1)}
+	masterKey := publicKeyPEM(utils.GetPrivateKeyFromString(data.AllMasterKey))
+
+	registerNode := func(s signer, role string) appV1.RegisterNode {
+		var param appV1.RegisterNode
+		param.NodeID = s.nodeID
+		param.PublicKey = publicKeyPEM(s.privKey)
+		param.MasterPublicKey = masterKey
+		param.NodeName = s.nodeID
+		param.Role = role
+		if role == "IdP" {
+			param.MaxIal = 3
+			param.MaxAal = 3
+		}
+		return param
+	}
+
+	steps := []step{
+		{"InitNDID", appV1.InitNDIDParam{NodeID: ndidID, PublicKey: publicKeyPEM(ndid.privKey), MasterPublicKey: masterKey}, ndid},
+		{"SetAllowedMinIalForRegisterIdentityAtFirstIdp", appV1.SetAllowedMinIalForRegisterIdentityAtFirstIdpParam{MinIal: 2.3}, ndid},
+		{"SetTimeOutBlockRegisterIdentity", appV1.TimeOutBlockRegisterIdentity{TimeOutBlock: 100}, ndid},
+		{"EndInit", appV1.EndInitParam{}, ndid},
+		{"RegisterNode", registerNode(rp, "RP"), ndid},
+		{"RegisterNode", registerNode(idp, "IdP"), ndid},
+		{"RegisterNode", registerNode(as, "AS"), ndid},
+		{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: rpID, Amount: 100}, ndid},
+		{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: idpID, Amount: 100}, ndid},
+		{"SetNodeToken", appV1.SetNodeTokenParam{NodeID: asID, Amount: 100}, ndid},
+		{"AddNodeToken", appV1.AddNodeTokenParam{NodeID: rpID, Amount: 10}, ndid},
+		{"ReduceNodeToken", appV1.ReduceNodeTokenParam{NodeID: rpID, Amount: 10}, ndid},
+		{"SetPriceFunc", appV1.SetPriceFuncParam{Func: "CreateRequest", Price: 1}, ndid},
+		{"SetAllowedModeList", appV1.SetAllowedModeListParam{Purpose: "RegisterIdentity", AllowedModeList: []int32{2, 3}}, ndid},
+		{"AddNamespace", appV1.Namespace{Namespace: namespace, Description: "Citizen ID"}, ndid},
+		{"DisableNamespace", appV1.DisableNamespaceParam{Namespace: namespace}, ndid},
+		{"EnableNamespace", appV1.DisableNamespaceParam{Namespace: namespace}, ndid},
+		{"AddService", appV1.AddServiceParam{ServiceID: serviceID, ServiceName: "Bank statement", DataSchema: "n/a", DataSchemaVersion: "n/a"}, ndid},
+		{"UpdateService", appV1.UpdateServiceParam{ServiceID: serviceID, ServiceName: "Bank statement (6 months)"}, ndid},
+		{"DisableService", appV1.DisableServiceParam{ServiceID: serviceID}, ndid},
+		{"EnableService", appV1.DisableServiceParam{ServiceID: serviceID}, ndid},
+		{"RegisterServiceDestinationByNDID", appV1.RegisterServiceDestinationByNDIDParam{ServiceID: serviceID, NodeID: asID}, ndid},
+		{"DisableServiceDestinationByNDID", appV1.DisableServiceDestinationByNDIDParam{ServiceID: serviceID, NodeID: asID}, ndid},
+		{"EnableServiceDestinationByNDID", appV1.DisableServiceDestinationByNDIDParam{ServiceID: serviceID, NodeID: asID}, ndid},
+		{"UpdateNodeByNDID", appV1.UpdateNodeByNDIDParam{NodeID: idpID, MaxIal: 3, MaxAal: 3, NodeName: "IdP 1"}, ndid},
+		{"SetNodeQuota", appV1.SetNodeQuotaParam{NodeID: rpID, Method: "CreateRequest", DailyLimit: 100, MonthlyLimit: 1000}, ndid},
+		{"RegisterServiceDestination", appV1.RegisterServiceDestinationParam{ServiceID: serviceID, MinIal: 1.1, MinAal: 1, SupportedNamespaceList: []string{namespace}}, as},
+		{"UpdateServiceDestination", appV1.UpdateServiceDestinationParam{ServiceID: serviceID, MinIal: 1.1, MinAal: 1}, as},
+		{"DisableServiceDestination", appV1.DisableServiceDestinationParam{ServiceID: serviceID}, as},
+		{"EnableServiceDestination", appV1.DisableServiceDestinationParam{ServiceID: serviceID}, as},
+		{"SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "192.0.2.1", Port: 8000}}}, rp},
+		{"SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "192.0.2.2", Port: 8000}}}, idp},
+		{"SetMqAddresses", appV1.SetMqAddressesParam{Addresses: []appV1.MsqAddress{{IP: "192.0.2.3", Port: 8000}}}, as},
+		{"UpdateNode", appV1.UpdateNodeParam{SupportedRequestMessageDataUrlTypeList: []string{"text/plain"}}, idp},
+		{"CreateRequest", appV1.CreateRequestParam{
+			RequestID:   requestID1,
+			MinIdp:      1,
+			MinAal:      1,
+			MinIal:      1.1,
+			Timeout:     3600,
+			IdPIDList:   []string{idpID},
+			MessageHash: "hash_of_request_message",
+			Mode:        1,
+			DataRequestList: []appV1.DataRequest{
+				{ServiceID: serviceID, As: []string{asID}, Count: 1, RequestParamsHash: "hash_of_request_params"},
+			},
+		}, rp},
+		{"CreateIdpResponse", appV1.CreateIdpResponseParam{RequestID: requestID1, Ial: 2.3, Aal: 3, Status: "accept", Signature: "signature_of_request_message"}, idp},
+		{"SignData", appV1.SignDataParam{RequestID: requestID1, ServiceID: serviceID, Signature: signData("data_of_service", as)}, as},
+		{"SetDataReceived", appV1.SetDataReceivedParam{RequestID: requestID1, ServiceID: serviceID, AsID: asID}, rp},
+		{"CloseRequest", appV1.CloseRequestParam{RequestID: requestID1, ResponseValidList: []appV1.ResponseValid{{IdpID: idpID, ValidIal: boolPtr(true), ValidSignature: boolPtr(true)}}}, rp},
+		{"AnchorConsentReceipt", appV1.AnchorConsentReceiptParam{RequestID: requestID1, ConsentReceiptHash: "hash_of_consent_receipt"}, rp},
+		{"CreateRequest", appV1.CreateRequestParam{
+			RequestID:   requestID2,
+			MinIdp:      1,
+			MinAal:      1,
+			MinIal:      1.1,
+			Timeout:     3600,
+			IdPIDList:   []string{idpID},
+			MessageHash: "hash_of_request_message",
+			Mode:        1,
+		}, rp},
+		{"TimeOutRequest", appV1.TimeOutRequestParam{RequestID: requestID2}, rp},
+		{"CreateIdpResponse", appV1.CreateIdpResponseParam{RequestID: requestID2, Ial: 2.3, Aal: 3, Status: "accept", Signature: "signature_of_request_message"}, idp},
+		{"DisableNode", appV1.DisableNodeParam{NodeID: asID}, ndid},
+		{"EnableNode", appV1.DisableNodeParam{NodeID: asID}, ndid},
+	}
+
+	app := harness.NewApp()
+	vectors := make([]Vector, 0, len(steps))
+	for i, s := range steps {
+		paramJSON, err := json.Marshal(s.param)
+		if err != nil {
+			panic(err)
+		}
+		nonce := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("test-vector-%d", i+1)))
+		tx := app.CreateTxWithNonce(s.method, s.param, nonce, s.signer.privKey, s.signer.nodeID)
+		result := app.NextBlock(tx)[0]
+		var vector Vector
+		vector.Height = app.Height
+		vector.Method = s.method
+		vector.NodeID = s.signer.nodeID
+		vector.Params = paramJSON
+		vector.Nonce = nonce
+		vector.SigningPayload = string(utils.SigningPayload(s.method, paramJSON, nonce))
+		vector.Signature = base64.StdEncoding.EncodeToString(utils.CreateSignature(s.method, paramJSON, nonce, s.signer.privKey))
+		vector.Tx = hex.EncodeToString(tx)
+		vector.ExpectedResult.Code = result.Code
+		vector.ExpectedResult.Log = result.Log
+		vector.ExpectedResult.Data = string(result.Data)
+		vectors = append(vectors, vector)
+	}
+	return vectors
+}